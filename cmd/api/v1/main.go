@@ -4,9 +4,14 @@ import (
 	"cinema_service/config"
 	"cinema_service/internal/api/handlers"
 	"cinema_service/internal/api/middleware"
+	"cinema_service/internal/cache"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/events"
+	"cinema_service/internal/payment"
 	"cinema_service/internal/repository"
 	"cinema_service/internal/usecase"
 	"context"
+	"expvar"
 	"fmt"
 	"log"
 	"net"
@@ -42,46 +47,157 @@ func main() {
 		log.Println("failed to read config:", err.Error())
 		return
 	}
-	dbPool, err := repository.Connect(c)
-	if err != nil {
-		fmt.Println(err.Error())
-	}
+	var repos repositories
+	switch c.Storage.Backend {
+	case config.StorageMemory:
+		log.Println("Using in-memory storage, data is lost on shutdown")
+		repos = newMemoryRepositories()
+	case config.StoragePostgres:
+		dbPool, err := repository.Connect(c)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+
+		defer func() {
+			if dbPool != nil {
+				dbPool.Close()
+			}
+		}()
 
-	defer func() {
 		if dbPool != nil {
-			dbPool.Close()
+			err = repository.MigrateOnStartup(context.Background(), dbPool, c.Postgres.MigrateOnStartup)
+			if err != nil {
+				log.Println("failed to migrate database:", err.Error())
+				return
+			}
 		}
-	}()
-
-	storageActor := repository.NewStorageActor(dbPool)
-	storageMovie := repository.NewStorageMovie(dbPool)
-	storageUser := repository.NewUserStorage(dbPool)
+		repos = newPostgresRepositories(dbPool, c.Postgres.TxMaxRetries)
+	}
 
-	serviceActor := usecase.NewActorsService(&storageActor)
-	serviceMovie := usecase.NewMovieService(&storageMovie)
-	serviceUser := usecase.NewUserService(&storageUser)
+	if c.Payment.Provider != "fake" {
+		log.Println("unknown payment provider:", c.Payment.Provider)
+		return
+	}
+	paymentProvider := payment.NewFakeProvider(c.Payment.WebhookSecret)
+
+	serviceAudit := usecase.NewAuditService(repos.audit)
+	serviceVersion := usecase.NewVersionService(repos.version)
+	serviceWebhook := usecase.NewWebhookService(repos.webhook,
+		events.NewHTTPSender(&http.Client{Timeout: c.Webhooks.Timeout}), c.Webhooks.MaxAttempts)
+	// Webhook subscriptions and the live feed always receive events; the
+	// configured sinks come on top.
+	feed := events.NewFeed(c.Feed.LogSize)
+	sinks := []usecase.Sink{serviceWebhook, feed}
+	for _, sink := range c.Events.Sinks {
+		switch sink {
+		case config.SinkLog:
+			sinks = append(sinks, events.Log{})
+		case config.SinkWebhook:
+			sinks = append(sinks, events.NewWebhook(c.Events.WebhookURL, nil))
+		}
+	}
+	serviceEvent := usecase.NewEventService(repos.event, sinks, c.Events.BatchSize, c.Events.Retention)
+	var catalogueCache usecase.Cache = cache.Disabled{}
+	if c.Cache.Size > 0 {
+		catalogueCache = cache.NewLRU(c.Cache.Size, c.Cache.TTL)
+	}
+	serviceActor := usecase.NewActorsService(repos.actor, repos.transactor, serviceAudit, serviceVersion, catalogueCache, serviceEvent)
+	serviceMovie := usecase.NewMovieService(repos.movie, repos.transactor, serviceAudit, serviceVersion, catalogueCache, serviceEvent)
+	serviceKey := usecase.NewKeyService(repos.signingKey)
+	serviceUser := usecase.NewUserService(repos.user, serviceKey, repos.transactor, serviceAudit)
+	servicePayment := usecase.NewPaymentService(repos.payment, repos.transactor, paymentProvider)
+	serviceRating := usecase.NewRatingService(repos.rating, repos.transactor, catalogueCache, c.Rating.BayesianMinVotes)
+	serviceWatchlist := usecase.NewWatchlistService(repos.watchlist)
+	serviceGenre := usecase.NewGenreService(repos.genre, catalogueCache)
+	serviceCredit := usecase.NewCreditService(repos.credit, repos.transactor, catalogueCache, serviceEvent)
+	serviceTranslation := usecase.NewTranslationService(repos.translation, catalogueCache)
+	serviceImport := usecase.NewImportService(repos.importer, catalogueCache, c.Import.BatchSize)
+	serviceExport := usecase.NewExportService(repos.exporter)
+	serviceTrash := usecase.NewTrashService(repos.trash, repos.transactor, serviceAudit, catalogueCache, serviceEvent, c.Trash.Retention)
+	serviceIdempotency := usecase.NewIdempotencyService(repos.idempotency, c.Idempotency.TTL)
+
+	if c.Storage.Backend == config.StorageMemory && c.Storage.AdminLogin != "" {
+		_, err = serviceUser.CreateUser(context.Background(), c.Storage.AdminLogin, c.Storage.AdminPassword, domain.ADMIN)
+		if err != nil {
+			log.Println("failed to create admin:", err.Error())
+			return
+		}
+	}
 
 	handlerActor := handlers.NewActorHandler(serviceActor)
 	handlerMovie := handlers.NewMovieHandler(serviceMovie)
 	handlerUser := handlers.NewUserHandler(serviceUser)
+	handlerPayment := handlers.NewPaymentHandler(servicePayment)
+	handlerRating := handlers.NewRatingHandler(serviceRating)
+	handlerWatchlist := handlers.NewWatchlistHandler(serviceWatchlist)
+	handlerGenre := handlers.NewGenreHandler(serviceGenre)
+	handlerCredit := handlers.NewCreditHandler(serviceCredit)
+	handlerTranslation := handlers.NewTranslationHandler(serviceTranslation)
+	handlerImport := handlers.NewImportHandler(serviceImport)
+	handlerExport := handlers.NewExportHandler(serviceExport)
+	handlerTrash := handlers.NewTrashHandler(serviceTrash)
+	handlerAudit := handlers.NewAuditHandler(serviceAudit)
+	handlerWebhook := handlers.NewWebhookHandler(serviceWebhook)
+	handlerEvent := handlers.NewEventHandler(feed, c.Feed.Heartbeat)
 
 	middlewareUser := middleware.NewUserMiddleware(serviceUser)
 	//authentication := middlewareUser.Authenticate()
 
 	mux := http.NewServeMux()
 
-	mux = handlerActor.RegisterActor(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
-	mux = handlerMovie.RegisterMovie(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	ifMatch := middleware.RequireIfMatch(c.API.RequireIfMatch)
+	idempotent := middleware.Idempotency(serviceIdempotency)
+	cacheControl := middleware.CacheControl(c.Cache.MaxAge)
+
+	mux = handlerActor.RegisterActor(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware, ifMatch, idempotent, cacheControl)
+	mux = handlerMovie.RegisterMovie(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware, ifMatch, idempotent, cacheControl)
 	mux = handlerUser.RegisterUser(mux, middlewareUser.LoggingMiddleware)
+	mux = handlerPayment.RegisterPayment(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	mux = handlerRating.RegisterRating(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	mux = handlerWatchlist.RegisterWatchlist(mux, middlewareUser.Authenticate, middlewareUser.LoggingMiddleware)
+	mux = handlerGenre.RegisterGenre(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	mux = handlerCredit.RegisterCredit(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	mux = handlerTranslation.RegisterTranslation(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	mux = handlerImport.RegisterImport(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	mux = handlerExport.RegisterExport(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	mux = handlerTrash.RegisterTrash(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	mux = handlerAudit.RegisterAudit(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	mux = handlerWebhook.RegisterWebhook(mux, middlewareUser.Authenticate, middlewareUser.RequireAdmin, middlewareUser.LoggingMiddleware)
+	mux = handlerEvent.RegisterEvent(mux, middlewareUser.Authenticate, middlewareUser.LoggingMiddleware)
 	mux.Handle("/swagger/", httpSwagger.WrapHandler)
+	// Cache hits and misses and the runtime memory statistics.
+	mux.HandleFunc("GET /debug/vars", middlewareUser.LoggingMiddleware(middlewareUser.Authenticate(middlewareUser.RequireAdmin(expvar.Handler().ServeHTTP))))
 	server := &http.Server{
 		Addr:    net.JoinHostPort(c.Host, c.Port),
-		Handler: mux,
+		Handler: middleware.RequestID(middleware.Localize(mux)),
 	}
+	// Event streams never end on their own and would hold up the shutdown.
+	server.RegisterOnShutdown(feed.Close)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
+	jobs, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if c.Trash.PurgeInterval > 0 {
+		go serviceTrash.RunPurge(jobs, c.Trash.PurgeInterval)
+	}
+	if c.Idempotency.PurgeInterval > 0 {
+		go serviceIdempotency.RunPurge(jobs, c.Idempotency.PurgeInterval)
+	}
+	if c.Rating.BayesianMinVotes > 0 && c.Rating.RecomputeInterval > 0 {
+		go serviceRating.RunRecompute(jobs, c.Rating.RecomputeInterval)
+	}
+	if c.Events.DispatchInterval > 0 {
+		go serviceEvent.RunDispatch(jobs, c.Events.DispatchInterval)
+	}
+	if c.Events.PurgeInterval > 0 {
+		go serviceEvent.RunPurge(jobs, c.Events.PurgeInterval)
+	}
+	if c.Webhooks.DispatchInterval > 0 {
+		go serviceWebhook.RunDispatch(jobs, c.Webhooks.DispatchInterval)
+	}
+
 	go func() {
 		log.Printf("Starting server on port %v...\n", c.Port)
 		err = server.ListenAndServe()
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"cinema_service/internal/repository"
+	"cinema_service/internal/repository/memory"
+	"cinema_service/internal/usecase"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// repositories are the storage implementations the services run on.
+type repositories struct {
+	actor       usecase.ActorsRepo
+	movie       usecase.MovieRepo
+	user        usecase.UserRepo
+	payment     usecase.PaymentRepo
+	rating      usecase.RatingRepo
+	watchlist   usecase.WatchlistRepo
+	genre       usecase.GenreRepo
+	credit      usecase.CreditRepo
+	translation usecase.TranslationRepo
+	importer    usecase.ImportRepo
+	exporter    usecase.ExportRepo
+	signingKey  usecase.SigningKeyRepo
+	trash       usecase.TrashRepo
+	audit       usecase.AuditRepo
+	version     usecase.VersionRepo
+	idempotency usecase.IdempotencyRepo
+	event       usecase.EventRepo
+	webhook     usecase.WebhookRepo
+	transactor  usecase.Transactor
+}
+
+func newPostgresRepositories(dbPool *pgxpool.Pool, txMaxRetries int) repositories {
+	storageActor := repository.NewStorageActor(dbPool)
+	storageMovie := repository.NewStorageMovie(dbPool)
+	storageUser := repository.NewUserStorage(dbPool)
+	storagePayment := repository.NewStoragePayment(dbPool)
+	storageRating := repository.NewStorageRating(dbPool)
+	storageWatchlist := repository.NewStorageWatchlist(dbPool)
+	storageGenre := repository.NewStorageGenre(dbPool)
+	storageCredit := repository.NewStorageCredit(dbPool)
+	storageTranslation := repository.NewStorageTranslation(dbPool)
+	storageImport := repository.NewStorageImport(dbPool)
+	storageExport := repository.NewStorageExport(dbPool)
+	storageSigningKey := repository.NewStorageSigningKey(dbPool)
+	storageTrash := repository.NewStorageTrash(dbPool)
+	storageAudit := repository.NewStorageAudit(dbPool)
+	storageVersion := repository.NewStorageVersion(dbPool)
+	storageIdempotency := repository.NewStorageIdempotency(dbPool)
+	storageEvent := repository.NewStorageEvent(dbPool)
+	storageWebhook := repository.NewStorageWebhook(dbPool)
+	transactor := repository.NewTransactor(dbPool, txMaxRetries)
+
+	return repositories{
+		actor:       &storageActor,
+		movie:       &storageMovie,
+		user:        &storageUser,
+		payment:     &storagePayment,
+		rating:      &storageRating,
+		watchlist:   &storageWatchlist,
+		genre:       &storageGenre,
+		credit:      &storageCredit,
+		translation: &storageTranslation,
+		importer:    &storageImport,
+		exporter:    &storageExport,
+		signingKey:  &storageSigningKey,
+		trash:       &storageTrash,
+		audit:       &storageAudit,
+		version:     &storageVersion,
+		idempotency: &storageIdempotency,
+		event:       &storageEvent,
+		webhook:     &storageWebhook,
+		transactor:  &transactor,
+	}
+}
+
+// newMemoryRepositories backs every repository with one in-memory storage.
+func newMemoryRepositories() repositories {
+	storage := memory.NewStorage()
+	return repositories{
+		actor:       storage,
+		movie:       storage,
+		user:        storage,
+		payment:     storage,
+		rating:      storage,
+		watchlist:   storage,
+		genre:       storage,
+		credit:      storage,
+		translation: storage,
+		importer:    storage,
+		exporter:    storage,
+		signingKey:  storage,
+		trash:       storage,
+		audit:       storage,
+		version:     storage,
+		idempotency: storage,
+		event:       storage,
+		webhook:     storage,
+		transactor:  storage,
+	}
+}
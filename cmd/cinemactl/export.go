@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"cinema_service/config"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/repository"
+	"cinema_service/internal/usecase"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func runExport(ctx context.Context, c *config.Config, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	kind := flags.String("kind", "", "record kind: movies, actors or credits")
+	format := flags.String("format", "", "file format: csv, ndjson or json, defaults to the file extension or json")
+	output := flags.String("o", "", "output file, defaults to standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return errors.New("unexpected arguments")
+	}
+
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(*output)) {
+		case ".csv":
+			*format = domain.FormatCSV
+		case ".ndjson", ".jsonl":
+			*format = domain.FormatNDJSON
+		default:
+			*format = domain.FormatJSON
+		}
+	}
+
+	dbPool, err := repository.Connect(c)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	buffered := bufio.NewWriter(out)
+
+	storageExport := repository.NewStorageExport(dbPool)
+	serviceExport := usecase.NewExportService(&storageExport)
+
+	if err = serviceExport.Export(ctx, *kind, *format, buffered); err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"cinema_service/config"
+	"cinema_service/internal/cache"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/repository"
+	"cinema_service/internal/usecase"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var errRowsRejected = errors.New("some rows were rejected")
+
+func runImport(ctx context.Context, c *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	kind := flags.String("kind", "", "record kind: movies, actors or credits")
+	format := flags.String("format", "", "file format: csv or ndjson, defaults to the file extension")
+	dryRun := flags.Bool("dry-run", false, "only validate the rows")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("expected exactly one file")
+	}
+	path := flags.Arg(0)
+
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			*format = domain.FormatCSV
+		case ".ndjson", ".jsonl":
+			*format = domain.FormatNDJSON
+		default:
+			return fmt.Errorf("cannot infer format of %s, use -format", path)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	dbPool, err := repository.Connect(c)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	storageImport := repository.NewStorageImport(dbPool)
+	// The API caches the catalogue in its own memory, so entries it holds
+	// stay stale until they expire.
+	serviceImport := usecase.NewImportService(&storageImport, cache.Disabled{}, c.Import.BatchSize)
+
+	report, err := serviceImport.Import(ctx, *kind, *format, file, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "imported"
+	if report.DryRun {
+		verb = "valid"
+	}
+	fmt.Printf("%s: %d rows, %d %s, %d rejected\n",
+		report.Kind, report.Rows, report.Imported, verb, len(report.Errors))
+	for _, rowErr := range report.Errors {
+		fmt.Printf("line %d: %s\n", rowErr.Line, rowErr.Message)
+	}
+
+	if len(report.Errors) > 0 {
+		return errRowsRejected
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"cinema_service/config"
+	"cinema_service/internal/repository"
+	"cinema_service/internal/usecase"
+	"context"
+	"flag"
+	"fmt"
+)
+
+func runRotateKeys(ctx context.Context, c *config.Config, args []string) error {
+	flags := flag.NewFlagSet("rotate-keys", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dbPool, err := repository.Connect(c)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	storageSigningKey := repository.NewStorageSigningKey(dbPool)
+	serviceKey := usecase.NewKeyService(&storageSigningKey)
+
+	key, err := serviceKey.Rotate(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("token signing key %s is now current\n", key.ID)
+	return nil
+}
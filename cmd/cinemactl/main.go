@@ -0,0 +1,77 @@
+// Command cinemactl runs maintenance tasks against the cinema service
+// database.
+package main
+
+import (
+	"cinema_service/config"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/joho/godotenv"
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, c *config.Config, args []string) error
+}
+
+var commands = map[string]command{
+	"import":       {usage: "import -kind movies|actors|credits [-format csv|ndjson] [-dry-run] FILE", run: runImport},
+	"export":       {usage: "export -kind movies|actors|credits [-format csv|ndjson|json] [-o FILE]", run: runExport},
+	"migrate":      {usage: "migrate up|down|status", run: runMigrate},
+	"create-admin": {usage: "create-admin -login LOGIN < password", run: runCreateAdmin},
+	"seed":         {usage: "seed", run: runSeed},
+	"rotate-keys":  {usage: "rotate-keys", run: runRotateKeys},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	// The environment may come from the caller instead of a .env file.
+	_ = godotenv.Load()
+	c, err := config.Read()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to read config:", err)
+		os.Exit(1)
+	}
+	if c.Storage.Backend != config.StoragePostgres {
+		fmt.Fprintf(os.Stderr, "cinemactl needs the %s storage backend, not %q\n", config.StoragePostgres, c.Storage.Backend)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err = cmd.run(ctx, c, os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+		stop()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: cinemactl COMMAND [ARGS]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, "  "+commands[name].usage)
+	}
+}
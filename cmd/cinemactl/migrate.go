@@ -0,0 +1,68 @@
+package main
+
+import (
+	"cinema_service/config"
+	"cinema_service/internal/repository"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+)
+
+func runMigrate(ctx context.Context, c *config.Config, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("expected one of up, down or status")
+	}
+
+	dbPool, err := repository.Connect(c)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	migrator, err := repository.NewMigrator(dbPool)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch flags.Arg(0) {
+	case "up":
+		results, err := migrator.Up(ctx)
+		for _, result := range results {
+			fmt.Printf("applied %s (%s)\n", result.Source.Path, result.Duration.Round(time.Millisecond))
+		}
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		result, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rolled back %s (%s)\n", result.Source.Path, result.Duration.Round(time.Millisecond))
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if !status.AppliedAt.IsZero() {
+				appliedAt = status.AppliedAt.Format(time.DateTime)
+			}
+			fmt.Printf("%-20s %s\n", appliedAt, status.Source.Path)
+		}
+	default:
+		return fmt.Errorf("unknown migrate action %q", flags.Arg(0))
+	}
+	return nil
+}
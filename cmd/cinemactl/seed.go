@@ -0,0 +1,58 @@
+package main
+
+import (
+	"cinema_service/config"
+	"cinema_service/internal/cache"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/repository"
+	"cinema_service/internal/usecase"
+	"context"
+	"embed"
+	"flag"
+	"fmt"
+)
+
+// seedFiles is a small demo catalogue. Its IDs are fixed, so seeding twice
+// updates the same rows.
+//
+//go:embed seed/*.csv
+var seedFiles embed.FS
+
+func runSeed(ctx context.Context, c *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dbPool, err := repository.Connect(c)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	storageImport := repository.NewStorageImport(dbPool)
+	// The API caches the catalogue in its own memory, so entries it holds
+	// stay stale until they expire.
+	serviceImport := usecase.NewImportService(&storageImport, cache.Disabled{}, c.Import.BatchSize)
+
+	// Credits reference actors and movies, so they go last.
+	for _, kind := range []string{domain.ImportActors, domain.ImportMovies, domain.ImportCredits} {
+		file, err := seedFiles.Open("seed/" + kind + ".csv")
+		if err != nil {
+			return err
+		}
+		report, err := serviceImport.Import(ctx, kind, domain.FormatCSV, file, false)
+		file.Close()
+		if err != nil {
+			return err
+		}
+		for _, rowErr := range report.Errors {
+			fmt.Printf("%s line %d: %s\n", kind, rowErr.Line, rowErr.Message)
+		}
+		if len(report.Errors) > 0 {
+			return errRowsRejected
+		}
+		fmt.Printf("%s: %d seeded\n", kind, report.Imported)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"cinema_service/config"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/repository"
+	"cinema_service/internal/usecase"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func runCreateAdmin(ctx context.Context, c *config.Config, args []string) error {
+	flags := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	login := flags.String("login", "", "login of the new admin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *login == "" {
+		return errors.New("-login is required")
+	}
+
+	// The password is read from standard input so it does not show up in
+	// the process list or shell history.
+	password, err := readPassword()
+	if err != nil {
+		return err
+	}
+
+	dbPool, err := repository.Connect(c)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	storageUser := repository.NewUserStorage(dbPool)
+	storageAudit := repository.NewStorageAudit(dbPool)
+	transactor := repository.NewTransactor(dbPool, c.Postgres.TxMaxRetries)
+	serviceUser := usecase.NewUserService(&storageUser, nil, &transactor, usecase.NewAuditService(&storageAudit))
+
+	user, err := serviceUser.CreateUser(ctx, *login, password, domain.ADMIN)
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return fmt.Errorf("login %q is taken", *login)
+		}
+		return err
+	}
+	fmt.Printf("created admin %s with ID %s\n", user.Login, user.ID)
+	return nil
+}
+
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("password is empty")
+	}
+	return password, nil
+}
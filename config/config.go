@@ -1,18 +1,137 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"github.com/caarlos0/env/v9"
 	"net"
+	"time"
+)
+
+// Storage backends.
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+// Event sinks.
+const (
+	SinkLog     = "log"
+	SinkWebhook = "webhook"
 )
 
 type Config struct {
+	Storage struct {
+		// Backend is StoragePostgres or StorageMemory. The memory backend
+		// starts empty and loses everything on exit.
+		Backend string `env:"STORAGE_BACKEND" envDefault:"postgres"`
+		// AdminLogin and AdminPassword create an administrator on startup
+		// when the memory backend is used.
+		AdminLogin    string `env:"STORAGE_ADMIN_LOGIN"`
+		AdminPassword string `env:"STORAGE_ADMIN_PASSWORD"`
+	}
+	// Postgres is required by the postgres storage backend only.
 	Postgres struct {
-		Host     string `env:"POSTGRES_HOST,notEmpty"`
-		Port     string `env:"POSTGRES_PORT,notEmpty"`
-		User     string `env:"POSTGRES_USER,notEmpty"`
-		Password string `env:"POSTGRES_PASSWORD,notEmpty"`
-		Database string `env:"POSTGRES_DB,notEmpty"`
+		Host     string `env:"POSTGRES_HOST"`
+		Port     string `env:"POSTGRES_PORT"`
+		User     string `env:"POSTGRES_USER"`
+		Password string `env:"POSTGRES_PASSWORD"`
+		Database string `env:"POSTGRES_DB"`
+		// MigrateOnStartup applies pending embedded migrations before the
+		// server starts listening.
+		MigrateOnStartup bool `env:"MIGRATE_ON_STARTUP" envDefault:"false"`
+		// TxMaxRetries is how many times a transaction is run again after a
+		// serialization failure or a deadlock.
+		TxMaxRetries int `env:"POSTGRES_TX_MAX_RETRIES" envDefault:"3"`
+	}
+	Payment struct {
+		Provider      string `env:"PAYMENT_PROVIDER" envDefault:"fake"`
+		WebhookSecret string `env:"PAYMENT_WEBHOOK_SECRET" envDefault:"fake-webhook-secret"`
+	}
+	Rating struct {
+		// BayesianMinVotes is the prior weight of the Bayesian movie rating,
+		// zero disables weighting.
+		BayesianMinVotes int `env:"RATING_BAYESIAN_MIN_VOTES" envDefault:"0"`
+		// RecomputeInterval is how often the Bayesian ratings of all movies
+		// are brought in line with the mean of all votes, zero disables
+		// recomputing. A vote only recomputes the rating of its movie.
+		RecomputeInterval time.Duration `env:"RATING_RECOMPUTE_INTERVAL" envDefault:"10m"`
+	}
+	Import struct {
+		// BatchSize is the number of rows upserted per COPY.
+		BatchSize int `env:"IMPORT_BATCH_SIZE" envDefault:"1000"`
+	}
+	Trash struct {
+		// Retention is how long deleted movies and actors can be restored
+		// before they are purged.
+		Retention time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
+		// PurgeInterval is how often the trash is purged, zero disables
+		// purging.
+		PurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL" envDefault:"1h"`
+	}
+	Idempotency struct {
+		// TTL is how long the response to a request with an Idempotency-Key
+		// header is replayed for retries.
+		TTL time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+		// PurgeInterval is how often expired keys are removed, zero
+		// disables purging.
+		PurgeInterval time.Duration `env:"IDEMPOTENCY_PURGE_INTERVAL" envDefault:"1h"`
+	}
+	Cache struct {
+		// Size is the number of movie and actor reads kept in process, zero
+		// disables the cache.
+		Size int `env:"CACHE_SIZE" envDefault:"1000"`
+		// TTL bounds how long cached reads stay stale after writes the API
+		// does not see, such as cinemactl imports, or whose invalidation
+		// failed.
+		TTL time.Duration `env:"CACHE_TTL" envDefault:"30s"`
+		// MaxAge is the max-age of the Cache-Control header of movie and
+		// actor reads; zero makes clients revalidate every time.
+		MaxAge time.Duration `env:"CACHE_MAX_AGE" envDefault:"0s"`
+	}
+	Events struct {
+		// DispatchInterval is how often the outbox is checked for events to
+		// deliver, zero disables delivery. Instances claim the events
+		// they deliver, so any number of them may dispatch.
+		DispatchInterval time.Duration `env:"EVENTS_DISPATCH_INTERVAL" envDefault:"1s"`
+		// BatchSize is the number of events delivered at once.
+		BatchSize int `env:"EVENTS_BATCH_SIZE" envDefault:"100"`
+		// Sinks are the sinks events are delivered to, SinkLog and
+		// SinkWebhook.
+		Sinks []string `env:"EVENTS_SINKS" envDefault:"log" envSeparator:","`
+		// WebhookURL is required by the webhook sink.
+		WebhookURL string `env:"EVENTS_WEBHOOK_URL"`
+		// Retention is how long delivered events are kept in the outbox.
+		Retention time.Duration `env:"EVENTS_RETENTION" envDefault:"168h"`
+		// PurgeInterval is how often delivered events are purged, zero
+		// disables purging.
+		PurgeInterval time.Duration `env:"EVENTS_PURGE_INTERVAL" envDefault:"1h"`
+	}
+	Webhooks struct {
+		// DispatchInterval is how often due webhook deliveries are sent,
+		// zero disables sending. Only one instance should send them.
+		DispatchInterval time.Duration `env:"WEBHOOKS_DISPATCH_INTERVAL" envDefault:"1s"`
+		// MaxAttempts is the number of attempts before a delivery fails
+		// and waits for a manual redelivery.
+		MaxAttempts int `env:"WEBHOOKS_MAX_ATTEMPTS" envDefault:"10"`
+		// Timeout bounds a single delivery attempt.
+		Timeout time.Duration `env:"WEBHOOKS_TIMEOUT" envDefault:"10s"`
+	}
+	Feed struct {
+		// LogSize is the number of recent events kept for clients that
+		// resume the event stream with Last-Event-ID. The feed is filled
+		// by event dispatch, so an instance only streams the events it
+		// dispatched.
+		LogSize int `env:"FEED_LOG_SIZE" envDefault:"1000"`
+		// Heartbeat is how often a comment is sent on idle event streams,
+		// so that proxies keep them open.
+		Heartbeat time.Duration `env:"FEED_HEARTBEAT" envDefault:"15s"`
+	}
+	API struct {
+		// RequireIfMatch rejects updates and deletes of movies and actors
+		// without an If-Match header, so that concurrent edits cannot
+		// silently overwrite each other.
+		RequireIfMatch bool `env:"API_REQUIRE_IF_MATCH" envDefault:"false"`
 	}
 	Host string `env:"HOST"`
 	Port string `env:"PORT"`
@@ -33,5 +152,37 @@ func Read() (*Config, error) {
 	if err := env.Parse(&config); err != nil {
 		return nil, fmt.Errorf("parse config: %w", err)
 	}
+
+	switch config.Storage.Backend {
+	case StoragePostgres:
+		p := config.Postgres
+		if p.Host == "" || p.Port == "" || p.User == "" || p.Password == "" || p.Database == "" {
+			return nil, errors.New("parse config: POSTGRES_HOST, POSTGRES_PORT, POSTGRES_USER, POSTGRES_PASSWORD and POSTGRES_DB are required")
+		}
+	case StorageMemory:
+	default:
+		return nil, fmt.Errorf("parse config: unknown storage backend %q", config.Storage.Backend)
+	}
+
+	for _, sink := range config.Events.Sinks {
+		switch sink {
+		case SinkLog:
+		case SinkWebhook:
+			if config.Events.WebhookURL == "" {
+				return nil, errors.New("parse config: EVENTS_WEBHOOK_URL is required by the webhook sink")
+			}
+		default:
+			return nil, fmt.Errorf("parse config: unknown event sink %q", sink)
+		}
+	}
+	if config.Events.BatchSize < 1 {
+		return nil, errors.New("parse config: EVENTS_BATCH_SIZE must be positive")
+	}
+	if config.Webhooks.MaxAttempts < 1 {
+		return nil, errors.New("parse config: WEBHOOKS_MAX_ATTEMPTS must be positive")
+	}
+	if config.Feed.LogSize < 1 || config.Feed.Heartbeat <= 0 {
+		return nil, errors.New("parse config: FEED_LOG_SIZE and FEED_HEARTBEAT must be positive")
+	}
 	return &config, nil
 }
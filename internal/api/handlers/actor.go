@@ -5,6 +5,7 @@ import (
 	"cinema_service/internal/domain"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -14,10 +15,16 @@ import (
 //go:generate mockgen -source=actor.go -destination=mocks/actorServiceMock.go
 
 type ActorService interface {
+	GetActor(ctx context.Context, actorID uuid.UUID) (*domain.Actor, error)
 	CreateActor(ctx context.Context, act *domain.Actor) error
 	UpdateActor(ctx context.Context, act *domain.Actor) error
-	DeleteActor(ctx context.Context, actorID uuid.UUID) error
+	PatchActor(ctx context.Context, actorID uuid.UUID, version int,
+		apply func(act *domain.Actor) error) (*domain.Actor, error)
+	DeleteActor(ctx context.Context, actorID uuid.UUID, version int) error
 	GetActors(ctx context.Context) (map[*domain.Actor][]*domain.Movie, error)
+	GetActorVersions(ctx context.Context, actorID uuid.UUID) ([]*domain.EntityVersion, error)
+	DiffActorVersions(ctx context.Context, actorID uuid.UUID, from int, to int) (map[string]domain.AuditChange, error)
+	RestoreActorVersion(ctx context.Context, actorID uuid.UUID, number int) error
 }
 
 type ActorHandler struct {
@@ -37,33 +44,36 @@ func NewActorHandler(service ActorService) *ActorHandler {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param actor body models.Actor true "Actor object"
-// @Success 201 {object} statusResponse "Actor created successfully"
+// @Param Idempotency-Key header string false "Key that makes retries of the request replay its response"
+// @Param actor body models.NewActor true "Actor object"
+// @Success 201 {object} domain.Actor "Created actor"
+// @Header 201 {string} Location "URL of the actor"
+// @Header 201 {string} ETag "Version of the actor"
 // @Failure 400 {object} errorResponse "Invalid request payload"
+// @Failure 409 {object} errorResponse "Actor with this ID already exists"
+// @Failure 422 {object} errorResponse "Idempotency-Key was used for a different request"
 // @Failure 500 {object} errorResponse "Failed to create actor"
 // @Router /actors [post]
 func (h *ActorHandler) CreateActorHandler(w http.ResponseWriter, r *http.Request) {
-	var input models.Actor
+	var input models.NewActor
 	err := json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
 		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
 		return
 	}
 
-	actor := &domain.Actor{
-		Name:    input.Name,
-		Surname: input.Surname,
-		Sex:     input.Sex,
-	}
+	actor := actorFromInput(input.ID, input.Actor)
 	err = h.service.CreateActor(r.Context(), actor)
 	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			NewErrorResponse(w, http.StatusConflict, "Actor with this ID already exists")
+			return
+		}
 		NewErrorResponse(w, http.StatusInternalServerError, "Failed to create actor")
 		return
 	}
 
-	sendJSONResponse(w, http.StatusCreated, statusResponse{
-		Status: "Actor created successfully",
-	})
+	sendCreated(w, "/api/v1/actors?id="+actor.ID.String(), versionETag(actor.Version), actor)
 }
 
 // UpdateActorHandler updates actor information.
@@ -74,9 +84,12 @@ func (h *ActorHandler) CreateActorHandler(w http.ResponseWriter, r *http.Request
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id query string true "Actor ID"
+// @Param If-Match header string false "ETag of the version to update"
 // @Param actor body models.Actor true "Updated actor information"
 // @Success 200 {object} statusResponse
 // @Failure 400 {object} errorResponse
+// @Failure 412 {object} errorResponse
+// @Failure 428 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Router /actors [put]
 func (h *ActorHandler) UpdateActorHandler(w http.ResponseWriter, r *http.Request) {
@@ -94,6 +107,11 @@ func (h *ActorHandler) UpdateActorHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	version, ok := parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	var input models.Actor
 	err = json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
@@ -101,32 +119,129 @@ func (h *ActorHandler) UpdateActorHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	actor := &domain.Actor{
-		ID:      id,
-		Name:    input.Name,
-		Surname: input.Surname,
-		Sex:     input.Sex,
-	}
+	actor := actorFromInput(id, input)
+	actor.Version = version
 
 	err = h.service.UpdateActor(r.Context(), actor)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeMissingTarget(w, r, "Actor not found")
+			return
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			NewErrorResponse(w, http.StatusPreconditionFailed, "Actor was changed since the If-Match version")
+			return
+		}
 		NewErrorResponse(w, http.StatusInternalServerError, "Failed to update actor")
 		return
 	}
+	if actor.Version != 0 {
+		w.Header().Set("ETag", versionETag(actor.Version))
+	}
 
 	sendJSONResponse(w, http.StatusOK, statusResponse{
 		Status: "Actor updated successfully",
 	})
 }
 
+// PatchActorHandler partially updates an actor.
+// @Summary Patch Actor
+// @Description Applies a JSON Merge Patch (application/merge-patch+json) or a JSON Patch (application/json-patch+json) to the actor object and stores the fields that changed
+// @Tags Actors
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Actor ID"
+// @Param If-Match header string false "ETag of the version to update"
+// @Param patch body object true "Patch of a models.Actor"
+// @Success 200 {object} models.Actor
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 412 {object} errorResponse
+// @Failure 415 {object} errorResponse
+// @Failure 428 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /actors/{id} [patch]
+func (h *ActorHandler) PatchActorHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid actor ID")
+		return
+	}
+
+	version, ok := parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	patch, ok := readPatch(w, r)
+	if !ok {
+		return
+	}
+
+	actor, err := h.service.PatchActor(r.Context(), id, version, func(act *domain.Actor) error {
+		var input models.Actor
+		if err := patch(actorToInput(act), &input); err != nil {
+			return err
+		}
+		patched := actorFromInput(act.ID, input)
+		patched.Version = act.Version
+		*act = *patched
+		return nil
+	})
+	if err != nil {
+		if writePatchError(w, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			NewErrorResponse(w, http.StatusNotFound, "Actor not found")
+		case errors.Is(err, domain.ErrVersionConflict):
+			NewErrorResponse(w, http.StatusPreconditionFailed, "Actor was changed since the If-Match version")
+		default:
+			NewErrorResponse(w, http.StatusInternalServerError, "Failed to patch actor")
+		}
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(actor.Version))
+	sendJSONResponse(w, http.StatusOK, actor)
+}
+
+func actorFromInput(id uuid.UUID, input models.Actor) *domain.Actor {
+	return &domain.Actor{
+		ID:        id,
+		Name:      input.Name,
+		Surname:   input.Surname,
+		Sex:       input.Sex,
+		Birthdate: input.Birthdate,
+	}
+}
+
+// actorToInput returns the actor object of an actor, which PATCH requests
+// patch.
+func actorToInput(act *domain.Actor) models.Actor {
+	return models.Actor{
+		Name:      act.Name,
+		Surname:   act.Surname,
+		Sex:       act.Sex,
+		Birthdate: act.Birthdate,
+	}
+}
+
 // DeleteActorHandler deletes an actor.
 // @Summary Delete an actor
-// @Description Deletes an actor based on the provided actor ID.
+// @Description Moves an actor to the trash based on the provided actor ID. The actor can be restored until it is purged.
 // @Tags Actors
 // @Security ApiKeyAuth
 // @Param id query string true "Actor ID"
+// @Param If-Match header string false "ETag of the version to delete"
 // @Success 200 {object} statusResponse
 // @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 412 {object} errorResponse
+// @Failure 428 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Router /actors [delete]
 func (h *ActorHandler) DeleteActorHandler(w http.ResponseWriter, r *http.Request) {
@@ -143,9 +258,21 @@ func (h *ActorHandler) DeleteActorHandler(w http.ResponseWriter, r *http.Request
 	}
 	fmt.Printf(" %+v", actorID)
 
-	err = h.service.DeleteActor(r.Context(), actorID)
+	version, ok := parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	err = h.service.DeleteActor(r.Context(), actorID, version)
 	if err != nil {
-		NewErrorResponse(w, http.StatusInternalServerError, "Failed to delete actor")
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			NewErrorResponse(w, http.StatusNotFound, "Actor not found")
+		case errors.Is(err, domain.ErrVersionConflict):
+			NewErrorResponse(w, http.StatusPreconditionFailed, "Actor was changed since the If-Match version")
+		default:
+			NewErrorResponse(w, http.StatusInternalServerError, "Failed to delete actor")
+		}
 		return
 	}
 
@@ -154,16 +281,27 @@ func (h *ActorHandler) DeleteActorHandler(w http.ResponseWriter, r *http.Request
 	})
 }
 
-// GetActorsHandler retrieves a list of actors.
+// GetActorsHandler retrieves a list of actors, or a single actor if an ID
+// is given.
 // @Summary Get Actors
-// @Description Retrieves a list of actors
+// @Description Retrieves a list of actors, or the actor with the given ID. The ETag of a single actor holds their version and a hash of the response, which also changes with the language and translations; send it in If-Match to update or delete the actor only if nobody changed them since.
 // @Tags Actors
 // @Accept json
 // @Security ApiKeyAuth
+// @Param id query string false "Actor ID"
+// @Param If-None-Match header string false "ETag of a cached copy"
 // @Success 200 {object} models.ActorMovies "Actors retrieved successfully"
+// @Success 304 "Not modified"
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Router /actors [get]
 func (h *ActorHandler) GetActorsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("id") {
+		h.getActor(w, r)
+		return
+	}
+
 	actors, err := h.service.GetActors(r.Context())
 	if err != nil {
 		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get actors")
@@ -180,15 +318,146 @@ func (h *ActorHandler) GetActorsHandler(w http.ResponseWriter, r *http.Request)
 		actorMoviesList = append(actorMoviesList, actorMovies)
 	}
 
-	sendJSONResponse(w, http.StatusOK, actorMoviesList)
+	sendJSONWithETag(w, r, 0, actorMoviesList)
+}
+
+func (h *ActorHandler) getActor(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+
+	actor, err := h.service.GetActor(r.Context(), actorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Actor not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get actor")
+		return
+	}
+
+	sendJSONWithETag(w, r, actor.Version, actor)
+}
+
+// GetActorVersionsHandler lists the versions of an actor.
+// @Summary Get Actor Versions
+// @Description Lists the versions of an actor kept on every update, newest first
+// @Tags Actors
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Actor ID"
+// @Success 200 {array} models.EntityVersion
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /actors/versions [get]
+func (h *ActorHandler) GetActorVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+
+	versions, err := h.service.GetActorVersions(r.Context(), actorID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get actor versions")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, toVersionModels(versions))
+}
+
+// DiffActorVersionsHandler compares two versions of an actor.
+// @Summary Diff Actor Versions
+// @Description Returns the fields that differ between two versions of an actor
+// @Tags Actors
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Actor ID"
+// @Param from query int true "Version to compare from"
+// @Param to query int true "Version to compare to"
+// @Success 200 {object} models.VersionDiff
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /actors/versions/diff [get]
+func (h *ActorHandler) DiffActorVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+	from, ok := parseVersionParam(w, r, "from")
+	if !ok {
+		return
+	}
+	to, ok := parseVersionParam(w, r, "to")
+	if !ok {
+		return
+	}
+
+	changes, err := h.service.DiffActorVersions(r.Context(), actorID, from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Actor version not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to diff actor versions")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, models.VersionDiff{From: from, To: to, Changes: toChangeModels(changes)})
+}
+
+// RestoreActorVersionHandler reverts an actor to one of their versions.
+// @Summary Restore Actor Version
+// @Description Updates an actor to the state of one of their versions
+// @Tags Actors
+// @Security ApiKeyAuth
+// @Param id query string true "Actor ID"
+// @Param version query int true "Version to restore"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /actors/versions/restore [post]
+func (h *ActorHandler) RestoreActorVersionHandler(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+	number, ok := parseVersionParam(w, r, "version")
+	if !ok {
+		return
+	}
+
+	err := h.service.RestoreActorVersion(r.Context(), actorID, number)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Actor version not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to restore actor version")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Actor version restored successfully",
+	})
 }
 
+// RegisterActor adds the actor routes to mux. ifMatch guards the updates
+// and deletes, see middleware.RequireIfMatch; cacheControl marks the reads
+// that are the same for every user, see middleware.CacheControl.
 func (h *ActorHandler) RegisterActor(mux *http.ServeMux,
-	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
-	mux.HandleFunc("GET /api/v1/actors", logging(authentication(h.GetActorsHandler)))
-	mux.HandleFunc("POST /api/v1/actors", logging(authentication(authorization(h.CreateActorHandler))))
-	mux.HandleFunc("PUT /api/v1/actors", logging(authentication(authorization(h.UpdateActorHandler))))
-	mux.HandleFunc("DELETE /api/v1/actors", logging(authentication(authorization(h.DeleteActorHandler))))
+	authentication Middleware, authorization Middleware, logging Middleware, ifMatch Middleware,
+	idempotent Middleware, cacheControl Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/actors", logging(authentication(cacheControl(h.GetActorsHandler))))
+	mux.HandleFunc("POST /api/v1/actors", logging(authentication(authorization(idempotent(h.CreateActorHandler)))))
+	mux.HandleFunc("PUT /api/v1/actors", logging(authentication(authorization(ifMatch(h.UpdateActorHandler)))))
+	mux.HandleFunc("PATCH /api/v1/actors/{id}", logging(authentication(authorization(ifMatch(h.PatchActorHandler)))))
+	mux.HandleFunc("DELETE /api/v1/actors", logging(authentication(authorization(ifMatch(h.DeleteActorHandler)))))
+	mux.HandleFunc("GET /api/v1/actors/versions", logging(authentication(authorization(h.GetActorVersionsHandler))))
+	mux.HandleFunc("GET /api/v1/actors/versions/diff", logging(authentication(authorization(h.DiffActorVersionsHandler))))
+	mux.HandleFunc("POST /api/v1/actors/versions/restore", logging(authentication(authorization(h.RestoreActorVersionHandler))))
 	return mux
 }
 
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=audit.go -destination=mocks/auditServiceMock.go
+
+type AuditService interface {
+	GetAuditRecords(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditRecord, error)
+}
+
+type AuditHandler struct {
+	service AuditService
+}
+
+func NewAuditHandler(service AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// GetAuditLogHandler lists audit records.
+// @Summary Get Audit Log
+// @Description Lists changes of movies, actors and users, newest first
+// @Tags Audit
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string false "ID of the user who made the change"
+// @Param entity query string false "Entity type" Enums(movie, actor, user)
+// @Param entity_id query string false "Entity ID"
+// @Param from query string false "Earliest change time, inclusive (RFC 3339)"
+// @Param to query string false "Latest change time, exclusive (RFC 3339)"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {array} models.AuditRecord
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /audit [get]
+func (h *AuditHandler) GetAuditLogHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.AuditFilter{Entity: query.Get("entity")}
+
+	switch filter.Entity {
+	case "", domain.AuditEntityMovie, domain.AuditEntityActor, domain.AuditEntityUser:
+	default:
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid entity parameter")
+		return
+	}
+
+	var ok bool
+	if filter.UserID, ok = parseOptionalUUID(w, r, "user_id"); !ok {
+		return
+	}
+	if filter.EntityID, ok = parseOptionalUUID(w, r, "entity_id"); !ok {
+		return
+	}
+	if filter.From, ok = parseOptionalTime(w, r, "from"); !ok {
+		return
+	}
+	if filter.To, ok = parseOptionalTime(w, r, "to"); !ok {
+		return
+	}
+	if filter.Limit, filter.Offset, ok = parsePagination(w, r); !ok {
+		return
+	}
+
+	records, err := h.service.GetAuditRecords(r.Context(), filter)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get audit log")
+		return
+	}
+
+	result := make([]models.AuditRecord, 0, len(records))
+	for _, record := range records {
+		result = append(result, toAuditRecordModel(record))
+	}
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+func toAuditRecordModel(record *domain.AuditRecord) models.AuditRecord {
+	result := models.AuditRecord{
+		ID:        record.ID,
+		Action:    record.Action,
+		Entity:    record.Entity,
+		EntityID:  record.EntityID,
+		Changes:   toChangeModels(record.Changes),
+		RequestID: record.RequestID,
+		CreatedAt: record.CreatedAt,
+	}
+	if record.UserID != uuid.Nil {
+		userID := record.UserID
+		result.UserID = &userID
+	}
+	return result
+}
+
+func (h *AuditHandler) RegisterAudit(mux *http.ServeMux,
+	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/audit", logging(authentication(authorization(h.GetAuditLogHandler))))
+	return mux
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=credit.go -destination=mocks/creditServiceMock.go
+
+type CreditService interface {
+	CreateCredit(ctx context.Context, credit *domain.Credit) error
+	DeleteCredit(ctx context.Context, creditID uuid.UUID) error
+	GetCredit(ctx context.Context, creditID uuid.UUID) (*domain.Credit, error)
+	GetMovieCredits(ctx context.Context, movieID uuid.UUID) (map[string][]*domain.Credit, error)
+	GetFilmography(ctx context.Context, personID uuid.UUID) (map[string][]*domain.Credit, error)
+}
+
+type CreditHandler struct {
+	service CreditService
+}
+
+func NewCreditHandler(service CreditService) *CreditHandler {
+	return &CreditHandler{service: service}
+}
+
+// CreateCreditHandler credits a person in a movie.
+// @Summary Create Credit
+// @Description Credits a person in a movie as actor, director, writer or producer
+// @Tags Credits
+// @Accept json
+// @Security ApiKeyAuth
+// @Param credit body models.CreditInput true "Credit object"
+// @Success 201 {object} models.CreditRecord
+// @Header 201 {string} Location "URL of the credit"
+// @Failure 400 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /credits [post]
+func (h *CreditHandler) CreateCreditHandler(w http.ResponseWriter, r *http.Request) {
+	var input models.CreditInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	credit := &domain.Credit{
+		MovieID:      input.MovieID,
+		PersonID:     input.PersonID,
+		Role:         input.Role,
+		Character:    input.Character,
+		BillingOrder: input.BillingOrder,
+	}
+	err = h.service.CreateCredit(r.Context(), credit)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidRole):
+			NewErrorResponse(w, http.StatusBadRequest, "Invalid role")
+		case errors.Is(err, domain.ErrNotFound):
+			NewErrorResponse(w, http.StatusBadRequest, "Unknown movie or person")
+		case errors.Is(err, domain.ErrAlreadyExists):
+			NewErrorResponse(w, http.StatusConflict, "Credit already exists")
+		default:
+			NewErrorResponse(w, http.StatusInternalServerError, "Failed to create credit")
+		}
+		return
+	}
+
+	sendCreated(w, "/api/v1/credits?id="+credit.ID.String(), "", toCreditRecord(credit))
+}
+
+// GetCreditHandler returns a single credit.
+// @Summary Get Credit
+// @Description Returns a credit by its ID
+// @Tags Credits
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Credit ID"
+// @Success 200 {object} models.CreditRecord
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /credits [get]
+func (h *CreditHandler) GetCreditHandler(w http.ResponseWriter, r *http.Request) {
+	creditID, ok := parseUUIDParam(w, r, "id", "Credit")
+	if !ok {
+		return
+	}
+
+	credit, err := h.service.GetCredit(r.Context(), creditID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Credit not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get credit")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, toCreditRecord(credit))
+}
+
+func toCreditRecord(credit *domain.Credit) models.CreditRecord {
+	return models.CreditRecord{
+		ID: credit.ID,
+		CreditInput: models.CreditInput{
+			MovieID:      credit.MovieID,
+			PersonID:     credit.PersonID,
+			Role:         credit.Role,
+			Character:    credit.Character,
+			BillingOrder: credit.BillingOrder,
+		},
+	}
+}
+
+// DeleteCreditHandler removes a credit.
+// @Summary Delete Credit
+// @Description Removes a credit
+// @Tags Credits
+// @Security ApiKeyAuth
+// @Param id query string true "Credit ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /credits [delete]
+func (h *CreditHandler) DeleteCreditHandler(w http.ResponseWriter, r *http.Request) {
+	creditID, ok := parseUUIDParam(w, r, "id", "Credit")
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteCredit(r.Context(), creditID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Credit not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to delete credit")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Credit deleted successfully",
+	})
+}
+
+// GetMovieCreditsHandler returns the full credits of a movie.
+// @Summary Get Movie Credits
+// @Description Returns the credits of a movie grouped by role
+// @Tags Credits
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Success 200 {array} models.CreditGroup
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/credits [get]
+func (h *CreditHandler) GetMovieCreditsHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	grouped, err := h.service.GetMovieCredits(r.Context(), movieID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get movie credits")
+		return
+	}
+
+	result := make([]models.CreditGroup, 0, len(grouped))
+	for _, role := range domain.Roles {
+		credits, ok := grouped[role]
+		if !ok {
+			continue
+		}
+		group := models.CreditGroup{Role: role, Credits: make([]models.Credit, 0, len(credits))}
+		for _, credit := range credits {
+			group.Credits = append(group.Credits, models.Credit{
+				ID:           credit.ID,
+				PersonID:     credit.PersonID,
+				Name:         credit.Person.Name,
+				Surname:      credit.Person.Surname,
+				Character:    credit.Character,
+				BillingOrder: credit.BillingOrder,
+			})
+		}
+		result = append(result, group)
+	}
+
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+// GetFilmographyHandler returns the filmography of a person.
+// @Summary Get Filmography
+// @Description Returns the movies of a person grouped by role
+// @Tags Credits
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Actor ID"
+// @Success 200 {array} models.FilmographyGroup
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /actors/filmography [get]
+func (h *CreditHandler) GetFilmographyHandler(w http.ResponseWriter, r *http.Request) {
+	personID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+
+	grouped, err := h.service.GetFilmography(r.Context(), personID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get filmography")
+		return
+	}
+
+	result := make([]models.FilmographyGroup, 0, len(grouped))
+	for _, role := range domain.Roles {
+		credits, ok := grouped[role]
+		if !ok {
+			continue
+		}
+		group := models.FilmographyGroup{Role: role, Movies: make([]models.FilmographyEntry, 0, len(credits))}
+		for _, credit := range credits {
+			group.Movies = append(group.Movies, models.FilmographyEntry{
+				ID:        credit.ID,
+				MovieID:   credit.MovieID,
+				Title:     credit.Movie.Title,
+				Date:      credit.Movie.Date,
+				Rating:    credit.Movie.Rating,
+				Character: credit.Character,
+			})
+		}
+		result = append(result, group)
+	}
+
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+func (h *CreditHandler) RegisterCredit(mux *http.ServeMux,
+	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/movies/credits", logging(authentication(h.GetMovieCreditsHandler)))
+	mux.HandleFunc("GET /api/v1/actors/filmography", logging(authentication(h.GetFilmographyHandler)))
+	mux.HandleFunc("GET /api/v1/credits", logging(authentication(h.GetCreditHandler)))
+	mux.HandleFunc("POST /api/v1/credits", logging(authentication(authorization(h.CreateCreditHandler))))
+	mux.HandleFunc("DELETE /api/v1/credits", logging(authentication(authorization(h.DeleteCreditHandler))))
+	return mux
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// versionETag returns the strong ETag of a movie or actor version, as sent
+// by writes.
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// representationETag returns the strong ETag of a movie or actor as read.
+// The body also depends on the language of the request, translations,
+// genres and credits, none of which change the version, so a hash of the
+// body follows the version.
+func representationETag(version int, body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + strconv.Itoa(version) + "-" + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// bodyETag returns a weak ETag of a response body. Listings have no
+// version of their own, so their ETag changes with any of the entities.
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// sendJSONWithETag sends data with an ETag header, or an empty 304 response
+// if the If-None-Match header of the request matches it. The ETag is that of
+// the given version of a movie or actor, or for a zero version, as for
+// listings, is derived from the response body alone.
+func sendJSONWithETag(w http.ResponseWriter, r *http.Request, version int, data interface{}) {
+	jsonResponse, err := json.Marshal(data)
+	if err != nil {
+		slog.Error("Failed to marshal JSON response", "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	etag := bodyETag(jsonResponse)
+	if version != 0 {
+		etag = representationETag(version, jsonResponse)
+	}
+
+	w.Header().Set("ETag", etag)
+	if noneMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(jsonResponse); err != nil {
+		slog.Error("Failed to write response", "err", err)
+	}
+}
+
+// noneMatch reports whether an If-None-Match header matches etag. Entity
+// tags are compared weakly, as RFC 9110 requires for If-None-Match.
+func noneMatch(header string, etag string) bool {
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIfMatch returns the version required by the If-Match header of a
+// write, or zero if the header is absent or "*", which any existing record
+// matches. The ETags of writes and reads both carry the version; writes
+// only change what it covers, so the body hash of a read ETag is not
+// checked. Writes check a single version, so it writes a 400 response for
+// a list of entity tags, and a 412 response for a tag that is not a
+// version, which can never match.
+func parseIfMatch(w http.ResponseWriter, r *http.Request) (int, bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	if strings.Contains(header, ",") {
+		NewErrorResponse(w, http.StatusBadRequest, "If-Match must be a single entity tag or *")
+		return 0, false
+	}
+
+	version, ok := etagVersion(header)
+	if !ok {
+		NewErrorResponse(w, http.StatusPreconditionFailed, "If-Match does not match the current version")
+		return 0, false
+	}
+	return version, true
+}
+
+// etagVersion returns the version in the ETag of a movie or actor, which
+// is "<version>" or "<version>-<hash>".
+func etagVersion(etag string) (int, bool) {
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, false
+	}
+	tag, hash, hashed := strings.Cut(etag[1:len(etag)-1], "-")
+	if hashed {
+		if _, err := hex.DecodeString(hash); err != nil || hash == "" {
+			return 0, false
+		}
+	}
+	version, err := strconv.Atoi(tag)
+	if err != nil || version <= 0 || strconv.Itoa(version) != tag {
+		return 0, false
+	}
+	return version, true
+}
+
+// writeMissingTarget answers a write to a record that does not exist: with
+// 412 if the request has an If-Match header, which nothing missing can
+// match, or with 404.
+func writeMissingTarget(w http.ResponseWriter, r *http.Request, message string) {
+	if r.Header.Get("If-Match") != "" {
+		NewErrorResponse(w, http.StatusPreconditionFailed, "If-Match does not match the current version")
+		return
+	}
+	NewErrorResponse(w, http.StatusNotFound, message)
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"cinema_service/internal/domain"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=event.go -destination=mocks/eventFeedMock.go
+
+// lastEventIDHeader is sent by SSE clients when they reconnect.
+const lastEventIDHeader = "Last-Event-ID"
+
+// resetEvent tells a client that events were missed while it was away, so
+// it must reload what it shows.
+const resetEvent = "reset"
+
+type EventFeed interface {
+	Subscribe(lastID *domain.FeedID) (backlog []domain.FeedEntry, complete bool, updates <-chan domain.FeedEntry, cancel func())
+}
+
+type EventHandler struct {
+	feed EventFeed
+	// heartbeat is how often a comment is sent on an idle stream.
+	heartbeat time.Duration
+}
+
+func NewEventHandler(feed EventFeed, heartbeat time.Duration) *EventHandler {
+	return &EventHandler{feed: feed, heartbeat: heartbeat}
+}
+
+// eventFilter selects the events sent to a client.
+type eventFilter struct {
+	types   []string
+	movieID uuid.UUID
+}
+
+// matches reports whether event passes the filter. Movie events and cast
+// changes belong to their movie; actor events to no movie.
+func (f eventFilter) matches(event *domain.Event) bool {
+	if len(f.types) > 0 && !slices.Contains(f.types, event.Type) {
+		return false
+	}
+	if f.movieID == uuid.Nil {
+		return true
+	}
+	switch event.Type {
+	case domain.EventMovieCreated, domain.EventMovieUpdated, domain.EventMovieDeleted, domain.EventCastChanged:
+		return event.EntityID == f.movieID
+	}
+	return false
+}
+
+// StreamEventsHandler streams catalogue changes as Server-Sent Events.
+// @Summary Stream Events
+// @Description Streams catalogue events as Server-Sent Events. The event name is the event type, the data its JSON message and the id its position in the feed, "<epoch>-<number>", where the epoch changes when the server restarts. Clients reconnecting with a Last-Event-ID header first receive the events they missed; a "reset" event means some of them are no longer available. Comments are sent as heartbeats on idle streams.
+// @Tags Events
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param type query string false "Comma-separated event types"
+// @Param movie_id query string false "Movie ID"
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} errorResponse
+// @Router /events [get]
+func (h *EventHandler) StreamEventsHandler(w http.ResponseWriter, r *http.Request) {
+	var filter eventFilter
+	if value := r.URL.Query().Get("type"); value != "" {
+		filter.types = strings.Split(value, ",")
+		for _, eventType := range filter.types {
+			if !slices.Contains(domain.EventTypes, eventType) {
+				NewErrorResponse(w, http.StatusBadRequest, "Invalid type parameter")
+				return
+			}
+		}
+	}
+	var ok bool
+	if filter.movieID, ok = parseOptionalUUID(w, r, "movie_id"); !ok {
+		return
+	}
+
+	var lastID *domain.FeedID
+	if value := r.Header.Get(lastEventIDHeader); value != "" {
+		// An ID this feed cannot have issued, like one without an epoch,
+		// is treated as an ID of another run, so the client is reset.
+		if lastID, ok = parseFeedID(value); !ok {
+			lastID = &domain.FeedID{}
+		}
+	}
+
+	backlog, complete, updates, cancel := h.feed.Subscribe(lastID)
+	defer cancel()
+
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Proxies like nginx would otherwise buffer the stream.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if !complete {
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: {}\n\n", resetEvent); err != nil {
+			return
+		}
+	}
+	for _, entry := range backlog {
+		if err := writeEntry(w, filter, entry); err != nil {
+			return
+		}
+	}
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case entry, ok := <-updates:
+			if !ok {
+				// The client fell behind or the server is shutting down;
+				// it resumes from Last-Event-ID when it reconnects.
+				return
+			}
+			if err := writeEntry(w, filter, entry); err != nil {
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// parseFeedID parses an entry ID sent back by a client, "<epoch>-<number>".
+func parseFeedID(value string) (*domain.FeedID, bool) {
+	epoch, seq, found := strings.Cut(value, "-")
+	if !found {
+		return nil, false
+	}
+	var id domain.FeedID
+	var err error
+	if id.Epoch, err = strconv.ParseInt(epoch, 10, 64); err != nil || id.Epoch < 0 {
+		return nil, false
+	}
+	if id.Seq, err = strconv.ParseInt(seq, 10, 64); err != nil || id.Seq < 0 {
+		return nil, false
+	}
+	return &id, true
+}
+
+// writeEntry writes entry as an event if it passes the filter.
+func writeEntry(w io.Writer, filter eventFilter, entry domain.FeedEntry) error {
+	if !filter.matches(entry.Event) {
+		return nil
+	}
+	data, err := json.Marshal(entry.Event.Message())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", entry.ID, entry.Event.Type, data)
+	return err
+}
+
+func (h *EventHandler) RegisterEvent(mux *http.ServeMux,
+	authentication Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/events", logging(authentication(h.StreamEventsHandler)))
+	return mux
+}
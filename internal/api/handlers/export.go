@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+//go:generate mockgen -source=export.go -destination=mocks/exportServiceMock.go
+
+type ExportService interface {
+	Export(ctx context.Context, kind string, format string, w io.Writer) error
+}
+
+type ExportHandler struct {
+	service ExportService
+}
+
+func NewExportHandler(service ExportService) *ExportHandler {
+	return &ExportHandler{service: service}
+}
+
+var exportContentTypes = map[string]string{
+	domain.FormatCSV:    "text/csv; charset=utf-8",
+	domain.FormatNDJSON: "application/x-ndjson",
+	domain.FormatJSON:   "application/json",
+}
+
+// ExportHandler streams catalogue records.
+// @Summary Export Catalogue
+// @Description Streams all movies, actors or credits as CSV, NDJSON or a JSON array.
+// @Description The format is taken from the format parameter or negotiated from the Accept header and defaults to JSON.
+// @Tags Catalogue
+// @Produce json
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Security ApiKeyAuth
+// @Param kind query string true "Record kind" Enums(movies, actors, credits)
+// @Param format query string false "File format" Enums(csv, ndjson, json)
+// @Success 200 {file} file
+// @Failure 400 {object} errorResponse
+// @Failure 406 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /export [get]
+func (h *ExportHandler) ExportHandler(w http.ResponseWriter, r *http.Request) {
+	kind := r.URL.Query().Get("kind")
+	if !slices.Contains(domain.CatalogueKinds, kind) {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid kind parameter")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" {
+		if _, ok := exportContentTypes[format]; !ok {
+			NewErrorResponse(w, http.StatusBadRequest, "Invalid format parameter")
+			return
+		}
+	} else {
+		format = negotiateExportFormat(r.Header.Get("Accept"))
+		if format == "" {
+			NewErrorResponse(w, http.StatusNotAcceptable, "Unsupported export format")
+			return
+		}
+	}
+
+	w.Header().Add("Vary", "Accept")
+	w.Header().Set("Content-Type", exportContentTypes[format])
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": kind + "." + format,
+	}))
+
+	out := &countingWriter{w: w}
+	err := h.service.Export(r.Context(), kind, format, out)
+	if err == nil {
+		return
+	}
+	if out.written > 0 {
+		// The status line is already sent, so the truncated body is the
+		// only signal left to the client.
+		slog.Error("Failed to export catalogue", "kind", kind, "err", err)
+		return
+	}
+	w.Header().Del("Content-Disposition")
+	if errors.Is(err, domain.ErrInvalidExport) {
+		NewErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	NewErrorResponse(w, http.StatusInternalServerError, "Failed to export catalogue")
+}
+
+// negotiateExportFormat picks the first export format the Accept header
+// allows, in the order the client listed them. Quality values are not
+// weighed.
+func negotiateExportFormat(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return domain.FormatJSON
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return domain.FormatCSV
+		case "application/x-ndjson", "application/ndjson", "application/jsonl":
+			return domain.FormatNDJSON
+		case "application/json", "application/*", "*/*":
+			return domain.FormatJSON
+		}
+	}
+	return ""
+}
+
+type countingWriter struct {
+	w       io.Writer
+	written int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.written += n
+	return n, err
+}
+
+func (h *ExportHandler) RegisterExport(mux *http.ServeMux,
+	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/export", logging(authentication(authorization(h.ExportHandler))))
+	return mux
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=genre.go -destination=mocks/genreServiceMock.go
+
+const maxGenreNameLength = 100
+
+type GenreService interface {
+	CreateGenre(ctx context.Context, genre *domain.Genre) error
+	UpdateGenre(ctx context.Context, genre *domain.Genre) error
+	DeleteGenre(ctx context.Context, genreID uuid.UUID) error
+	GetGenre(ctx context.Context, genreID uuid.UUID) (*domain.Genre, error)
+	GetGenres(ctx context.Context) ([]*domain.Genre, error)
+}
+
+type GenreHandler struct {
+	service GenreService
+}
+
+func NewGenreHandler(service GenreService) *GenreHandler {
+	return &GenreHandler{service: service}
+}
+
+// GetGenresHandler lists all genres, or returns a single genre if an ID is
+// given.
+// @Summary Get Genres
+// @Description Lists the genre dictionary ordered by name, or returns the genre with the given ID
+// @Tags Genres
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string false "Genre ID"
+// @Success 200 {array} models.Genre
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /genres [get]
+func (h *GenreHandler) GetGenresHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("id") {
+		h.getGenre(w, r)
+		return
+	}
+
+	genres, err := h.service.GetGenres(r.Context())
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get genres")
+		return
+	}
+
+	result := make([]models.Genre, 0, len(genres))
+	for _, genre := range genres {
+		result = append(result, models.Genre{ID: genre.ID, Name: genre.Name})
+	}
+
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+func (h *GenreHandler) getGenre(w http.ResponseWriter, r *http.Request) {
+	genreID, ok := parseUUIDParam(w, r, "id", "Genre")
+	if !ok {
+		return
+	}
+
+	genre, err := h.service.GetGenre(r.Context(), genreID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Genre not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get genre")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, models.Genre{ID: genre.ID, Name: genre.Name})
+}
+
+// CreateGenreHandler adds a genre to the dictionary.
+// @Summary Create Genre
+// @Description Adds a genre to the dictionary
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param genre body models.GenreInput true "Genre object"
+// @Success 201 {object} models.Genre
+// @Header 201 {string} Location "URL of the genre"
+// @Failure 400 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /genres [post]
+func (h *GenreHandler) CreateGenreHandler(w http.ResponseWriter, r *http.Request) {
+	name, ok := decodeGenreName(w, r)
+	if !ok {
+		return
+	}
+
+	genre := &domain.Genre{Name: name}
+	err := h.service.CreateGenre(r.Context(), genre)
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			NewErrorResponse(w, http.StatusConflict, "Genre already exists")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to create genre")
+		return
+	}
+
+	sendCreated(w, "/api/v1/genres?id="+genre.ID.String(), "", models.Genre{ID: genre.ID, Name: genre.Name})
+}
+
+// UpdateGenreHandler renames a genre.
+// @Summary Update Genre
+// @Description Renames a genre
+// @Tags Genres
+// @Accept json
+// @Security ApiKeyAuth
+// @Param id query string true "Genre ID"
+// @Param genre body models.GenreInput true "Genre object"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /genres [put]
+func (h *GenreHandler) UpdateGenreHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseUUIDParam(w, r, "id", "Genre")
+	if !ok {
+		return
+	}
+	name, ok := decodeGenreName(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.service.UpdateGenre(r.Context(), &domain.Genre{ID: id, Name: name})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			NewErrorResponse(w, http.StatusNotFound, "Genre not found")
+		case errors.Is(err, domain.ErrAlreadyExists):
+			NewErrorResponse(w, http.StatusConflict, "Genre already exists")
+		default:
+			NewErrorResponse(w, http.StatusInternalServerError, "Failed to update genre")
+		}
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Genre updated successfully",
+	})
+}
+
+// DeleteGenreHandler removes a genre from the dictionary and from all movies.
+// @Summary Delete Genre
+// @Description Removes a genre from the dictionary and from all movies
+// @Tags Genres
+// @Security ApiKeyAuth
+// @Param id query string true "Genre ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /genres [delete]
+func (h *GenreHandler) DeleteGenreHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseUUIDParam(w, r, "id", "Genre")
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteGenre(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Genre not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to delete genre")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Genre deleted successfully",
+	})
+}
+
+func decodeGenreName(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var input models.GenreInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return "", false
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > maxGenreNameLength {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid genre name")
+		return "", false
+	}
+	return name, true
+}
+
+func (h *GenreHandler) RegisterGenre(mux *http.ServeMux,
+	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/genres", logging(authentication(h.GetGenresHandler)))
+	mux.HandleFunc("POST /api/v1/genres", logging(authentication(authorization(h.CreateGenreHandler))))
+	mux.HandleFunc("PUT /api/v1/genres", logging(authentication(authorization(h.UpdateGenreHandler))))
+	mux.HandleFunc("DELETE /api/v1/genres", logging(authentication(authorization(h.DeleteGenreHandler))))
+	return mux
+}
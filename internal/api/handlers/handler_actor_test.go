@@ -8,6 +8,7 @@ import (
 	"cinema_service/internal/domain"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -41,7 +42,7 @@ func TestCreateActorHandler(t *testing.T) {
 				r.EXPECT().CreateActor(gomock.Any(), actor).Return(nil)
 			},
 			expectedStatusCode:   201,
-			expectedResponseBody: "Actor created successfully",
+			expectedResponseBody: "",
 		},
 		{
 			name: "Internal Server Error",
@@ -57,6 +58,17 @@ func TestCreateActorHandler(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: "Failed to create actor",
 		},
+		{
+			name: "Duplicate ID",
+			inputActor: &domain.Actor{
+				Name: "Name",
+			},
+			mockBehavior: func(r *mock_service.MockActorService, actor *domain.Actor) {
+				r.EXPECT().CreateActor(gomock.Any(), actor).Return(fmt.Errorf("create actor: %w", domain.ErrAlreadyExists))
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: "Actor with this ID already exists",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -112,7 +124,7 @@ func TestDeleteActorHandler(t *testing.T) {
 				Birthdate: time.Time{},
 			},
 			mockBehavior: func(r *mock_service.MockActorService, actor *domain.Actor) {
-				r.EXPECT().DeleteActor(gomock.Any(), actor.ID).Return(nil)
+				r.EXPECT().DeleteActor(gomock.Any(), actor.ID, 0).Return(nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: "Actor deleted successfully",
@@ -126,11 +138,25 @@ func TestDeleteActorHandler(t *testing.T) {
 				Birthdate: time.Time{},
 			},
 			mockBehavior: func(r *mock_service.MockActorService, actor *domain.Actor) {
-				r.EXPECT().DeleteActor(gomock.Any(), actor.ID).Return(dummyError)
+				r.EXPECT().DeleteActor(gomock.Any(), actor.ID, 0).Return(dummyError)
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: "Failed to delete actor",
 		},
+		{
+			name: "Not Found",
+			inputActor: &domain.Actor{
+				Name:      "Name",
+				Surname:   "Surname",
+				Sex:       "Sex",
+				Birthdate: time.Time{},
+			},
+			mockBehavior: func(r *mock_service.MockActorService, actor *domain.Actor) {
+				r.EXPECT().DeleteActor(gomock.Any(), actor.ID, 0).Return(fmt.Errorf("delete actor: %w", domain.ErrNotFound))
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "Actor not found",
+		},
 	}
 
 	for _, tc := range testCases {
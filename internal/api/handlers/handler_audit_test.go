@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAuditLogHandler(t *testing.T) {
+	recordID := uuid.MustParse("6f1c2a3e-8b5f-4d43-9f7e-0c1d2e3f4a5b")
+	userID := uuid.MustParse("0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	movieID := uuid.MustParse("1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e")
+	createdAt := time.Date(2024, 4, 24, 10, 0, 0, 0, time.UTC)
+	type mockBehavior func(r *mock_service.MockAuditService)
+
+	testCases := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			query: "?user_id=" + userID.String() + "&entity=movie&from=2024-04-24T00:00:00Z&to=2024-04-25T00:00:00%2B03:00",
+			mockBehavior: func(r *mock_service.MockAuditService) {
+				r.EXPECT().GetAuditRecords(gomock.Any(), domain.AuditFilter{
+					UserID: userID,
+					Entity: domain.AuditEntityMovie,
+					From:   time.Date(2024, 4, 24, 0, 0, 0, 0, time.UTC),
+					To:     time.Date(2024, 4, 24, 21, 0, 0, 0, time.UTC),
+					Limit:  20,
+				}).Return([]*domain.AuditRecord{{
+					ID:        recordID,
+					UserID:    userID,
+					Action:    domain.AuditUpdate,
+					Entity:    domain.AuditEntityMovie,
+					EntityID:  movieID,
+					Changes:   map[string]domain.AuditChange{"Title": {Before: "Heat", After: "Ronin"}},
+					RequestID: "req-1",
+					CreatedAt: createdAt,
+				}}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `[{"id":"` + recordID.String() + `","user_id":"` + userID.String() + `",` +
+				`"action":"update","entity":"movie","entity_id":"` + movieID.String() + `",` +
+				`"changes":{"Title":{"before":"Heat","after":"Ronin"}},"request_id":"req-1",` +
+				`"created_at":"2024-04-24T10:00:00Z"}]`,
+		},
+		{
+			name:  "Change without user",
+			query: "?entity_id=" + movieID.String() + "&page=2&page_size=5",
+			mockBehavior: func(r *mock_service.MockAuditService) {
+				r.EXPECT().GetAuditRecords(gomock.Any(), domain.AuditFilter{EntityID: movieID, Limit: 5, Offset: 5}).
+					Return([]*domain.AuditRecord{{
+						ID:        recordID,
+						Action:    domain.AuditCreate,
+						Entity:    domain.AuditEntityUser,
+						EntityID:  movieID,
+						CreatedAt: createdAt,
+					}}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `[{"id":"` + recordID.String() + `","user_id":null,"action":"create","entity":"user",` +
+				`"entity_id":"` + movieID.String() + `","changes":{},"request_id":"","created_at":"2024-04-24T10:00:00Z"}]`,
+		},
+		{
+			name:                 "Invalid entity",
+			query:                "?entity=genre",
+			mockBehavior:         func(r *mock_service.MockAuditService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid entity parameter"}`,
+		},
+		{
+			name:                 "Invalid user ID",
+			query:                "?user_id=123",
+			mockBehavior:         func(r *mock_service.MockAuditService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid user_id parameter"}`,
+		},
+		{
+			name:                 "Invalid time",
+			query:                "?from=2024-04-24",
+			mockBehavior:         func(r *mock_service.MockAuditService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid from parameter"}`,
+		},
+		{
+			name:  "Internal Server Error",
+			query: "",
+			mockBehavior: func(r *mock_service.MockAuditService) {
+				r.EXPECT().GetAuditRecords(gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to get audit log"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockAuditService(c)
+			tc.mockBehavior(service)
+
+			handler := NewAuditHandler(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/audit"+tc.query, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.GetAuditLogHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
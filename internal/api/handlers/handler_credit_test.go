@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"bytes"
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCreditHandler(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockCreditService)
+	creditID, movieID, personID := uuid.New(), uuid.New(), uuid.New()
+	testCases := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedLocation     string
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(r *mock_service.MockCreditService) {
+				r.EXPECT().CreateCredit(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, credit *domain.Credit) error {
+						credit.ID = creditID
+						return nil
+					})
+			},
+			expectedStatusCode: 201,
+			expectedLocation:   "/api/v1/credits?id=" + creditID.String(),
+			expectedResponseBody: `{"id":"` + creditID.String() + `","movie_id":"` + movieID.String() +
+				`","person_id":"` + personID.String() + `","role":"ACTOR","character":"Neo"}`,
+		},
+		{
+			name: "Invalid role",
+			mockBehavior: func(r *mock_service.MockCreditService) {
+				r.EXPECT().CreateCredit(gomock.Any(), gomock.Any()).Return(usecase.ErrInvalidRole)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid role"}`,
+		},
+		{
+			name: "Unknown person",
+			mockBehavior: func(r *mock_service.MockCreditService) {
+				r.EXPECT().CreateCredit(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Unknown movie or person"}`,
+		},
+		{
+			name: "Internal Server Error",
+			mockBehavior: func(r *mock_service.MockCreditService) {
+				r.EXPECT().CreateCredit(gomock.Any(), gomock.Any()).Return(errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to create credit"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockCreditService(c)
+			tc.mockBehavior(service)
+
+			handler := NewCreditHandler(service)
+
+			body := `{"movie_id":"` + movieID.String() + `","person_id":"` + personID.String() + `","role":"ACTOR","character":"Neo"}`
+			req := httptest.NewRequest(http.MethodPost, "/credits", bytes.NewBufferString(body))
+			recorder := httptest.NewRecorder()
+
+			handler.CreateCreditHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedLocation, recorder.Header().Get("Location"))
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestGetMovieCreditsHandler(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	movieID := uuid.New()
+	actor := &domain.Credit{ID: uuid.New(), PersonID: uuid.New(), Role: domain.RoleActor, Character: "Neo", BillingOrder: 1,
+		Person: &domain.Actor{Name: "Keanu", Surname: "Reeves"}}
+	director := &domain.Credit{ID: uuid.New(), PersonID: uuid.New(), Role: domain.RoleDirector,
+		Person: &domain.Actor{Name: "Lana", Surname: "Wachowski"}}
+
+	service := mock_service.NewMockCreditService(c)
+	service.EXPECT().GetMovieCredits(gomock.Any(), movieID).Return(map[string][]*domain.Credit{
+		domain.RoleActor:    {actor},
+		domain.RoleDirector: {director},
+	}, nil)
+
+	handler := NewCreditHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/movies/credits?id="+movieID.String(), nil)
+	recorder := httptest.NewRecorder()
+
+	handler.GetMovieCreditsHandler(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `[
+		{"role":"DIRECTOR","credits":[{"id":"`+director.ID.String()+`","person_id":"`+director.PersonID.String()+`","name":"Lana","surname":"Wachowski"}]},
+		{"role":"ACTOR","credits":[{"id":"`+actor.ID.String()+`","person_id":"`+actor.PersonID.String()+`","name":"Keanu","surname":"Reeves","character":"Neo","billing_order":1}]}
+	]`, recorder.Body.String())
+}
+
+func TestGetCreditHandler(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	creditID := uuid.MustParse("4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b")
+	movieID := uuid.MustParse("5f6a7b8c-9d0e-4f1a-8b2c-3d4e5f6a7b8c")
+	personID := uuid.MustParse("6a7b8c9d-0e1f-4a2b-9c3d-4e5f6a7b8c9d")
+	service := mock_service.NewMockCreditService(c)
+	service.EXPECT().GetCredit(gomock.Any(), creditID).Return(&domain.Credit{
+		ID: creditID, MovieID: movieID, PersonID: personID, Role: domain.RoleActor, Character: "Neo",
+	}, nil)
+	service.EXPECT().GetCredit(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("get credit: %w", domain.ErrNotFound))
+	handler := NewCreditHandler(service)
+
+	// The Location of a created credit.
+	recorder := httptest.NewRecorder()
+	handler.GetCreditHandler(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/credits?id="+creditID.String(), nil))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, `{"id":"`+creditID.String()+`","movie_id":"`+movieID.String()+`","person_id":"`+personID.String()+
+		`","role":"ACTOR","character":"Neo"}`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	handler.GetCreditHandler(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/credits?id="+uuid.NewString(), nil))
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, `{"error":"Credit not found"}`, recorder.Body.String())
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetMovieHandlerETag(t *testing.T) {
+	movieID := uuid.New()
+	c := gomock.NewController(t)
+	defer c.Finish()
+	service := mock_service.NewMockMovieService(c)
+	service.EXPECT().GetMovie(gomock.Any(), movieID).Return(&domain.Movie{ID: movieID, Title: "Heat", Version: 3}, nil).Times(2)
+	handler := NewMovieHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/movies?id="+movieID.String(), nil)
+	recorder := httptest.NewRecorder()
+	handler.GetMovieHandler(recorder, req)
+	assert.Equal(t, 200, recorder.Code)
+	etag := recorder.Header().Get("ETag")
+	assert.True(t, strings.HasPrefix(etag, `"3-`), etag)
+	assert.Contains(t, recorder.Body.String(), `"Title":"Heat"`)
+
+	req.Header.Set("If-None-Match", `W/"2", `+etag)
+	recorder = httptest.NewRecorder()
+	handler.GetMovieHandler(recorder, req)
+	assert.Equal(t, 304, recorder.Code)
+	assert.Equal(t, etag, recorder.Header().Get("ETag"))
+	assert.Empty(t, recorder.Body.String())
+
+	// A translation changes the body but not the version.
+	service.EXPECT().GetMovie(gomock.Any(), movieID).Return(&domain.Movie{ID: movieID, Title: "Схватка", Version: 3}, nil)
+	recorder = httptest.NewRecorder()
+	handler.GetMovieHandler(recorder, req)
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotEqual(t, etag, recorder.Header().Get("ETag"))
+	assert.True(t, strings.HasPrefix(recorder.Header().Get("ETag"), `"3-`))
+
+	service.EXPECT().GetMovie(gomock.Any(), movieID).Return(nil, fmt.Errorf("get movie: %w", domain.ErrNotFound))
+	recorder = httptest.NewRecorder()
+	handler.GetMovieHandler(recorder, httptest.NewRequest(http.MethodGet, "/movies?id="+movieID.String(), nil))
+	assert.Equal(t, 404, recorder.Code)
+}
+
+func TestGetMoviesFilterHandlerETag(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	service := mock_service.NewMockMovieService(c)
+	service.EXPECT().GetMoviesFilter(gomock.Any(), gomock.Any()).Return([]*domain.Movie{{Title: "Heat"}}, nil).Times(2)
+	handler := NewMovieHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/movies/filter?filter=title", nil)
+	recorder := httptest.NewRecorder()
+	handler.GetMoviesFilterHandler(recorder, req)
+	assert.Equal(t, 200, recorder.Code)
+	etag := recorder.Header().Get("ETag")
+	assert.True(t, strings.HasPrefix(etag, `W/"`), etag)
+
+	req.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	handler.GetMoviesFilterHandler(recorder, req)
+	assert.Equal(t, 304, recorder.Code)
+}
+
+func TestUpdateMovieHandlerIfMatch(t *testing.T) {
+	movieID := uuid.New()
+	type mockBehavior func(r *mock_service.MockMovieService)
+
+	testCases := []struct {
+		name               string
+		ifMatch            string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+		expectedETag       string
+	}{
+		{
+			name:    "Matching version",
+			ifMatch: `"3"`,
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, movie *domain.Movie) error {
+					assert.Equal(t, 3, movie.Version)
+					movie.Version = 4
+					return nil
+				})
+			},
+			expectedStatusCode: 200,
+			expectedETag:       `"4"`,
+		},
+		{
+			name:    "Stale version",
+			ifMatch: `"2"`,
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).Return(fmt.Errorf("update movie: %w", domain.ErrVersionConflict))
+			},
+			expectedStatusCode: 412,
+		},
+		{
+			name:    "Any version",
+			ifMatch: "*",
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, movie *domain.Movie) error {
+					assert.Zero(t, movie.Version)
+					movie.Version = 4
+					return nil
+				})
+			},
+			expectedStatusCode: 200,
+			expectedETag:       `"4"`,
+		},
+		{
+			name:    "ETag of a read",
+			ifMatch: `"3-5f1a2b3c4d5e6f70"`,
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, movie *domain.Movie) error {
+					assert.Equal(t, 3, movie.Version)
+					movie.Version = 4
+					return nil
+				})
+			},
+			expectedStatusCode: 200,
+			expectedETag:       `"4"`,
+		},
+		{
+			name:               "Malformed hash",
+			ifMatch:            `"3-heat"`,
+			mockBehavior:       func(r *mock_service.MockMovieService) {},
+			expectedStatusCode: 412,
+		},
+		{
+			name:               "Weak ETag",
+			ifMatch:            `W/"3"`,
+			mockBehavior:       func(r *mock_service.MockMovieService) {},
+			expectedStatusCode: 412,
+		},
+		{
+			name:               "Several ETags",
+			ifMatch:            `"3", "4"`,
+			mockBehavior:       func(r *mock_service.MockMovieService) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name:    "Missing movie",
+			ifMatch: "",
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).Return(fmt.Errorf("update movie: %w", domain.ErrNotFound))
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name:    "Missing movie with any version",
+			ifMatch: "*",
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).Return(fmt.Errorf("update movie: %w", domain.ErrNotFound))
+			},
+			expectedStatusCode: 412,
+		},
+		{
+			name:    "Unknown genre",
+			ifMatch: `"3"`,
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).Return(fmt.Errorf("update movie: %w", usecase.ErrUnknownGenre))
+			},
+			expectedStatusCode: 400,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			service := mock_service.NewMockMovieService(c)
+			tc.mockBehavior(service)
+
+			req := httptest.NewRequest(http.MethodPut, "/movies?id="+movieID.String(), strings.NewReader(`{"title":"Heat"}`))
+			req.Header.Set("If-Match", tc.ifMatch)
+			recorder := httptest.NewRecorder()
+			NewMovieHandler(service).UpdateMovieHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedETag, recorder.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestUpdateActorHandlerMissingActor(t *testing.T) {
+	actorID := uuid.New()
+	c := gomock.NewController(t)
+	defer c.Finish()
+	service := mock_service.NewMockActorService(c)
+	service.EXPECT().UpdateActor(gomock.Any(), gomock.Any()).Return(fmt.Errorf("update actor: %w", domain.ErrNotFound)).Times(2)
+
+	send := func(ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/actors?id="+actorID.String(), strings.NewReader(`{"name":"Al"}`))
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		recorder := httptest.NewRecorder()
+		NewActorHandler(service).UpdateActorHandler(recorder, req)
+		return recorder
+	}
+
+	recorder := send("")
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, `{"error":"Actor not found"}`, recorder.Body.String())
+	assert.Equal(t, 412, send(`"2"`).Code)
+}
+
+func TestDeleteActorHandlerIfMatch(t *testing.T) {
+	actorID := uuid.New()
+	c := gomock.NewController(t)
+	defer c.Finish()
+	service := mock_service.NewMockActorService(c)
+	service.EXPECT().DeleteActor(gomock.Any(), actorID, 2).Return(fmt.Errorf("delete actor: %w", domain.ErrVersionConflict))
+
+	req := httptest.NewRequest(http.MethodDelete, "/actors?id="+actorID.String(), nil)
+	req.Header.Set("If-Match", `"2"`)
+	recorder := httptest.NewRecorder()
+	NewActorHandler(service).DeleteActorHandler(recorder, req)
+
+	assert.Equal(t, 412, recorder.Code)
+	assert.Equal(t, `{"error":"Actor was changed since the If-Match version"}`, recorder.Body.String())
+}
+
+func TestGetActorsHandlerByID(t *testing.T) {
+	actorID := uuid.New()
+	c := gomock.NewController(t)
+	defer c.Finish()
+	service := mock_service.NewMockActorService(c)
+	service.EXPECT().GetActor(gomock.Any(), actorID).Return(&domain.Actor{ID: actorID, Name: "Al", Version: 5}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/actors?id="+actorID.String(), nil)
+	recorder := httptest.NewRecorder()
+	NewActorHandler(service).GetActorsHandler(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+	etag := recorder.Header().Get("ETag")
+	assert.True(t, strings.HasPrefix(etag, `"5-`), etag)
+	version, ok := etagVersion(etag)
+	assert.True(t, ok)
+	assert.Equal(t, 5, version)
+
+	recorder = httptest.NewRecorder()
+	NewActorHandler(service).GetActorsHandler(recorder, httptest.NewRequest(http.MethodGet, "/actors?id=nope", nil))
+	assert.Equal(t, 400, recorder.Code)
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStreamEventsHandler(t *testing.T) {
+	movieID := uuid.MustParse("1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e")
+	eventID := uuid.MustParse("6f1c2a3e-8b5f-4d43-9f7e-0c1d2e3f4a5b")
+	createdAt := time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC)
+	movieCreated := domain.FeedEntry{ID: domain.FeedID{Epoch: 100, Seq: 5}, Event: &domain.Event{ID: eventID, Sequence: 9, Type: domain.EventMovieCreated,
+		EntityID: movieID, Payload: []byte(`{"Title":"Heat"}`), CreatedAt: createdAt}}
+	actorCreated := domain.FeedEntry{ID: domain.FeedID{Epoch: 100, Seq: 6}, Event: &domain.Event{ID: uuid.New(), Type: domain.EventActorCreated,
+		EntityID: uuid.New(), Payload: []byte(`{}`), CreatedAt: createdAt}}
+	otherMovie := domain.FeedEntry{ID: domain.FeedID{Epoch: 100, Seq: 7}, Event: &domain.Event{ID: uuid.New(), Type: domain.EventMovieUpdated,
+		EntityID: uuid.New(), Payload: []byte(`{}`), CreatedAt: createdAt}}
+	movieCreatedEvent := "id: 100-5\nevent: movie.created\ndata: {\"id\":\"" + eventID.String() + "\",\"sequence\":9," +
+		"\"type\":\"movie.created\",\"entity_id\":\"" + movieID.String() + "\",\"payload\":{\"Title\":\"Heat\"}," +
+		"\"created_at\":\"2024-05-06T10:00:00Z\"}\n\n"
+	type mockBehavior func(r *mock_service.MockEventFeed)
+
+	testCases := []struct {
+		name                 string
+		query                string
+		lastEventID          string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Resume",
+			query:       "?movie_id=" + movieID.String(),
+			lastEventID: "100-4",
+			mockBehavior: func(r *mock_service.MockEventFeed) {
+				updates := make(chan domain.FeedEntry, 1)
+				updates <- otherMovie
+				close(updates)
+				r.EXPECT().Subscribe(&domain.FeedID{Epoch: 100, Seq: 4}).Return([]domain.FeedEntry{movieCreated, actorCreated}, true, updates, func() {})
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: movieCreatedEvent,
+		},
+		{
+			name:  "Missed events",
+			query: "?type=movie.created,cast.changed",
+			mockBehavior: func(r *mock_service.MockEventFeed) {
+				updates := make(chan domain.FeedEntry, 2)
+				updates <- actorCreated
+				updates <- movieCreated
+				close(updates)
+				r.EXPECT().Subscribe(gomock.Nil()).Return(nil, false, updates, func() {})
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "event: reset\ndata: {}\n\n" + movieCreatedEvent,
+		},
+		{
+			name:        "ID without epoch",
+			lastEventID: "4",
+			mockBehavior: func(r *mock_service.MockEventFeed) {
+				updates := make(chan domain.FeedEntry)
+				close(updates)
+				r.EXPECT().Subscribe(&domain.FeedID{}).Return([]domain.FeedEntry{movieCreated}, false, updates, func() {})
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "event: reset\ndata: {}\n\n" + movieCreatedEvent,
+		},
+		{
+			name:                 "Invalid type",
+			query:                "?type=movie.created,movie.watched",
+			mockBehavior:         func(r *mock_service.MockEventFeed) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid type parameter"}`,
+		},
+		{
+			name:        "Invalid Last-Event-ID",
+			lastEventID: "100-abc",
+			mockBehavior: func(r *mock_service.MockEventFeed) {
+				updates := make(chan domain.FeedEntry)
+				close(updates)
+				r.EXPECT().Subscribe(&domain.FeedID{}).Return(nil, false, updates, func() {})
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "event: reset\ndata: {}\n\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			feed := mock_service.NewMockEventFeed(c)
+			tc.mockBehavior(feed)
+
+			handler := NewEventHandler(feed, time.Hour)
+
+			req := httptest.NewRequest(http.MethodGet, "/events"+tc.query, nil)
+			if tc.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tc.lastEventID)
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.StreamEventsHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestStreamEventsHeartbeat(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	canceled := false
+	feed := mock_service.NewMockEventFeed(c)
+	feed.EXPECT().Subscribe(gomock.Nil()).Return(nil, true, make(chan domain.FeedEntry), func() { canceled = true })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+	recorder := httptest.NewRecorder()
+
+	NewEventHandler(feed, time.Millisecond).StreamEventsHandler(recorder, req)
+
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), ": heartbeat\n\n")
+	assert.True(t, recorder.Flushed)
+	assert.True(t, canceled)
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/usecase"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportHandler(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockExportService)
+	writeBody := func(body string) func(context.Context, string, string, io.Writer) error {
+		return func(_ context.Context, _ string, _ string, w io.Writer) error {
+			_, err := io.WriteString(w, body)
+			return err
+		}
+	}
+	testCases := []struct {
+		name                string
+		query               string
+		accept              string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:   "Format parameter",
+			query:  "?kind=movies&format=csv",
+			accept: "application/json",
+			mockBehavior: func(r *mock_service.MockExportService) {
+				r.EXPECT().Export(gomock.Any(), "movies", "csv", gomock.Any()).DoAndReturn(writeBody("id,title\n"))
+			},
+			expectedStatusCode:  200,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "id,title\n",
+		},
+		{
+			name:   "Accept header",
+			query:  "?kind=actors",
+			accept: "application/x-ndjson, application/json;q=0.5",
+			mockBehavior: func(r *mock_service.MockExportService) {
+				r.EXPECT().Export(gomock.Any(), "actors", "ndjson", gomock.Any()).DoAndReturn(writeBody("{}\n"))
+			},
+			expectedStatusCode:  200,
+			expectedContentType: "application/x-ndjson",
+			expectedBody:        "{}\n",
+		},
+		{
+			name:  "JSON by default",
+			query: "?kind=credits",
+			mockBehavior: func(r *mock_service.MockExportService) {
+				r.EXPECT().Export(gomock.Any(), "credits", "json", gomock.Any()).DoAndReturn(writeBody("[]\n"))
+			},
+			expectedStatusCode:  200,
+			expectedContentType: "application/json",
+			expectedBody:        "[]\n",
+		},
+		{
+			name:                "Invalid kind",
+			query:               "?kind=genres",
+			mockBehavior:        func(r *mock_service.MockExportService) {},
+			expectedStatusCode:  400,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"Invalid kind parameter"}`,
+		},
+		{
+			name:                "Not acceptable",
+			query:               "?kind=movies",
+			accept:              "application/xml",
+			mockBehavior:        func(r *mock_service.MockExportService) {},
+			expectedStatusCode:  406,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"Unsupported export format"}`,
+		},
+		{
+			name:  "Failure before streaming",
+			query: "?kind=movies&format=json",
+			mockBehavior: func(r *mock_service.MockExportService) {
+				r.EXPECT().Export(gomock.Any(), "movies", "json", gomock.Any()).Return(errors.New("dummy error"))
+			},
+			expectedStatusCode:  500,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":"Failed to export catalogue"}`,
+		},
+		{
+			name:  "Failure while streaming",
+			query: "?kind=movies&format=json",
+			mockBehavior: func(r *mock_service.MockExportService) {
+				r.EXPECT().Export(gomock.Any(), "movies", "json", gomock.Any()).DoAndReturn(
+					func(ctx context.Context, kind string, format string, w io.Writer) error {
+						_, _ = io.WriteString(w, "[{")
+						return errors.New("dummy error")
+					})
+			},
+			expectedStatusCode:  200,
+			expectedContentType: "application/json",
+			expectedBody:        "[{",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockExportService(c)
+			tc.mockBehavior(service)
+
+			handler := NewExportHandler(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/export"+tc.query, nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.ExportHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tc.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestExportHandlerRepositoryFailure(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repo.NewMockExportRepo(c)
+	repo.EXPECT().ExportMovies(gomock.Any(), gomock.Any()).Return(errors.New("dummy error"))
+	handler := NewExportHandler(usecase.NewExportService(repo))
+
+	req := httptest.NewRequest(http.MethodGet, "/export?kind=movies&format=json", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.ExportHandler(recorder, req)
+
+	// Nothing is written before the first record, so the failure gets an
+	// error status instead of a truncated array.
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, `{"error":"Failed to export catalogue"}`, recorder.Body.String())
+
+	// An empty catalogue is still a valid array.
+	repo.EXPECT().ExportMovies(gomock.Any(), gomock.Any()).Return(nil)
+	recorder = httptest.NewRecorder()
+
+	handler.ExportHandler(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "[]\n", recorder.Body.String())
+}
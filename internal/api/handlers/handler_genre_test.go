@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"bytes"
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateGenreHandler(t *testing.T) {
+	genreID := uuid.New()
+	type mockBehavior func(r *mock_service.MockGenreService)
+	testCases := []struct {
+		name                 string
+		body                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedLocation     string
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			body: `{"name":" Drama "}`,
+			mockBehavior: func(r *mock_service.MockGenreService) {
+				r.EXPECT().CreateGenre(gomock.Any(), &domain.Genre{Name: "Drama"}).DoAndReturn(
+					func(_ any, genre *domain.Genre) error {
+						genre.ID = genreID
+						return nil
+					})
+			},
+			expectedStatusCode:   201,
+			expectedLocation:     "/api/v1/genres?id=" + genreID.String(),
+			expectedResponseBody: `{"id":"` + genreID.String() + `","name":"Drama"}`,
+		},
+		{
+			name:                 "Empty name",
+			body:                 `{"name":"  "}`,
+			mockBehavior:         func(r *mock_service.MockGenreService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid genre name"}`,
+		},
+		{
+			name: "Duplicate",
+			body: `{"name":"Drama"}`,
+			mockBehavior: func(r *mock_service.MockGenreService) {
+				r.EXPECT().CreateGenre(gomock.Any(), gomock.Any()).Return(domain.ErrAlreadyExists)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"Genre already exists"}`,
+		},
+		{
+			name: "Internal Server Error",
+			body: `{"name":"Drama"}`,
+			mockBehavior: func(r *mock_service.MockGenreService) {
+				r.EXPECT().CreateGenre(gomock.Any(), gomock.Any()).Return(errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to create genre"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockGenreService(c)
+			tc.mockBehavior(service)
+
+			handler := NewGenreHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/genres", bytes.NewBufferString(tc.body))
+			recorder := httptest.NewRecorder()
+
+			handler.CreateGenreHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedLocation, recorder.Header().Get("Location"))
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestGetGenreFacetsHandler(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	drama := &domain.Genre{ID: uuid.New(), Name: "Drama"}
+	service := mock_service.NewMockMovieService(c)
+	service.EXPECT().GetGenreFacets(gomock.Any(), domain.MovieFilter{GenreIDs: []uuid.UUID{drama.ID}}).
+		Return([]*domain.GenreFacet{{Genre: drama, Count: 3}}, nil)
+
+	handler := NewMovieHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/movies/facets?genre="+drama.ID.String(), nil)
+	recorder := httptest.NewRecorder()
+
+	handler.GetGenreFacetsHandler(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, `[{"genre_id":"`+drama.ID.String()+`","name":"Drama","count":3}]`, recorder.Body.String())
+}
+
+func TestGetGenreHandlerByID(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	genreID := uuid.MustParse("3d4e5f6a-7b8c-4d9e-8f0a-1b2c3d4e5f6a")
+	service := mock_service.NewMockGenreService(c)
+	service.EXPECT().GetGenre(gomock.Any(), genreID).Return(&domain.Genre{ID: genreID, Name: "Drama"}, nil)
+	service.EXPECT().GetGenre(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("get genre: %w", domain.ErrNotFound))
+	handler := NewGenreHandler(service)
+
+	// The Location of a created genre.
+	recorder := httptest.NewRecorder()
+	handler.GetGenresHandler(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/genres?id="+genreID.String(), nil))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, `{"id":"`+genreID.String()+`","name":"Drama"}`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	handler.GetGenresHandler(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/genres?id="+uuid.NewString(), nil))
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, `{"error":"Genre not found"}`, recorder.Body.String())
+}
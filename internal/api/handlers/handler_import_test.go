@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportHandler(t *testing.T) {
+	type mockBehavior func(r *mock_service.MockImportService)
+	testCases := []struct {
+		name                 string
+		query                string
+		contentType          string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "OK",
+			query:       "?kind=movies&dry_run=true",
+			contentType: "text/csv; charset=utf-8",
+			mockBehavior: func(r *mock_service.MockImportService) {
+				r.EXPECT().Import(gomock.Any(), "movies", "csv", gomock.Any(), true).Return(&domain.ImportReport{
+					Kind:     "movies",
+					DryRun:   true,
+					Rows:     2,
+					Imported: 1,
+					Errors:   []domain.ImportRowError{{Line: 3, Message: "title must be 1 to 150 characters"}},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"kind":"movies","dry_run":true,"rows":2,"imported":1,"errors":[{"line":3,"error":"title must be 1 to 150 characters"}]}`,
+		},
+		{
+			name:        "Format parameter",
+			query:       "?kind=credits&format=ndjson",
+			contentType: "application/octet-stream",
+			mockBehavior: func(r *mock_service.MockImportService) {
+				r.EXPECT().Import(gomock.Any(), "credits", "ndjson", gomock.Any(), false).
+					Return(&domain.ImportReport{Kind: "credits", Rows: 1, Imported: 1}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"kind":"credits","dry_run":false,"rows":1,"imported":1,"errors":[]}`,
+		},
+		{
+			name:                 "Missing kind",
+			contentType:          "text/csv",
+			mockBehavior:         func(r *mock_service.MockImportService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"kind parameter is required"}`,
+		},
+		{
+			name:                 "Unknown content type",
+			query:                "?kind=movies",
+			contentType:          "application/xml",
+			mockBehavior:         func(r *mock_service.MockImportService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Unsupported import format"}`,
+		},
+		{
+			name:        "Invalid import",
+			query:       "?kind=genres",
+			contentType: "application/x-ndjson",
+			mockBehavior: func(r *mock_service.MockImportService) {
+				r.EXPECT().Import(gomock.Any(), "genres", "ndjson", gomock.Any(), false).
+					Return(nil, fmt.Errorf(`%w: unknown kind "genres"`, domain.ErrInvalidImport))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid import: unknown kind \"genres\""}`,
+		},
+		{
+			name:        "Internal Server Error",
+			query:       "?kind=movies",
+			contentType: "text/csv",
+			mockBehavior: func(r *mock_service.MockImportService) {
+				r.EXPECT().Import(gomock.Any(), "movies", "csv", gomock.Any(), false).Return(nil, errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to import catalogue"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockImportService(c)
+			tc.mockBehavior(service)
+
+			handler := NewImportHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/import"+tc.query, strings.NewReader("title\nBrother\n"))
+			req.Header.Set("Content-Type", tc.contentType)
+			recorder := httptest.NewRecorder()
+
+			handler.ImportHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.JSONEq(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
@@ -4,13 +4,17 @@ import (
 	"bytes"
 	mock_service "cinema_service/internal/api/handlers/mocks"
 	"cinema_service/internal/domain"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -32,7 +36,6 @@ func TestCreateMovieHandler(t *testing.T) {
 			inputMovie: &domain.Movie{
 				Title:       "Test Movie",
 				Description: "Test Description",
-				Rating:      4.5,
 			},
 			mockBehavior: func(r *mock_service.MockMovieService, movie *domain.Movie) {
 				r.EXPECT().CreateMovie(gomock.Any(), movie).Return(nil)
@@ -45,7 +48,6 @@ func TestCreateMovieHandler(t *testing.T) {
 			inputMovie: &domain.Movie{
 				Title:       "Test Movie",
 				Description: "Test Description",
-				Rating:      4.5,
 			},
 			mockBehavior: func(r *mock_service.MockMovieService, movie *domain.Movie) {
 				r.EXPECT().CreateMovie(gomock.Any(), movie).Return(errors.New(dummyError.Error()))
@@ -53,6 +55,28 @@ func TestCreateMovieHandler(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: "Failed to create movie",
 		},
+		{
+			name: "Invalid metadata",
+			inputMovie: &domain.Movie{
+				Title:  "Test Movie",
+			},
+			mockBehavior: func(r *mock_service.MockMovieService, movie *domain.Movie) {
+				r.EXPECT().CreateMovie(gomock.Any(), movie).Return(fmt.Errorf("%w: unknown age rating", domain.ErrInvalidMovie))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "invalid movie: unknown age rating",
+		},
+		{
+			name: "Duplicate external ID",
+			inputMovie: &domain.Movie{
+				Title:  "Test Movie",
+			},
+			mockBehavior: func(r *mock_service.MockMovieService, movie *domain.Movie) {
+				r.EXPECT().CreateMovie(gomock.Any(), movie).Return(fmt.Errorf("create movie: %w", domain.ErrAlreadyExists))
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: "Movie with this ID or external ID already exists",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -100,7 +124,6 @@ func TestUpdateMovieHandler(t *testing.T) {
 			inputMovie: &domain.Movie{
 				Title:       "Test Movie",
 				Description: "Test Description",
-				Rating:      4.5,
 			},
 			mockBehavior: func(r *mock_service.MockMovieService, movie *domain.Movie) {
 				r.EXPECT().UpdateMovie(gomock.Any(), movie).Return(nil)
@@ -113,7 +136,6 @@ func TestUpdateMovieHandler(t *testing.T) {
 			inputMovie: &domain.Movie{
 				Title:       "Test Movie",
 				Description: "Test Description",
-				Rating:      4.5,
 			},
 			mockBehavior: func(r *mock_service.MockMovieService, movie *domain.Movie) {
 				r.EXPECT().UpdateMovie(gomock.Any(), movie).Return(errors.New(dummyError.Error()))
@@ -177,7 +199,7 @@ func TestDeleteMovieHandler(t *testing.T) {
 				Rating:      4.5,
 			},
 			mockBehavior: func(r *mock_service.MockMovieService, movie *domain.Movie) {
-				r.EXPECT().DeleteMovie(gomock.Any(), movie.ID).Return(nil)
+				r.EXPECT().DeleteMovie(gomock.Any(), movie.ID, 0).Return(nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: "Movie deleted successfully",
@@ -190,11 +212,22 @@ func TestDeleteMovieHandler(t *testing.T) {
 				Rating:      4.5,
 			},
 			mockBehavior: func(r *mock_service.MockMovieService, movie *domain.Movie) {
-				r.EXPECT().DeleteMovie(gomock.Any(), movie.ID).Return(errors.New(dummyError.Error()))
+				r.EXPECT().DeleteMovie(gomock.Any(), movie.ID, 0).Return(errors.New(dummyError.Error()))
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: "Failed to delete movie",
 		},
+		{
+			name: "Not Found",
+			inputMovie: &domain.Movie{
+				Title: "Test Movie",
+			},
+			mockBehavior: func(r *mock_service.MockMovieService, movie *domain.Movie) {
+				r.EXPECT().DeleteMovie(gomock.Any(), movie.ID, 0).Return(fmt.Errorf("delete movie: %w", domain.ErrNotFound))
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: "Movie not found",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -253,7 +286,7 @@ func TestGetMoviesFilterHandler(t *testing.T) {
 				},
 			},
 			mockBehavior: func(r *mock_service.MockMovieService, movies []*domain.Movie) {
-				r.EXPECT().GetMoviesFilter(gomock.Any(), "rating").Return(movies, nil)
+				r.EXPECT().GetMoviesFilter(gomock.Any(), domain.MovieFilter{Sort: "rating"}).Return(movies, nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: "",
@@ -268,7 +301,7 @@ func TestGetMoviesFilterHandler(t *testing.T) {
 				},
 			},
 			mockBehavior: func(r *mock_service.MockMovieService, movies []*domain.Movie) {
-				r.EXPECT().GetMoviesFilter(gomock.Any(), "rating").Return(nil, dummyError)
+				r.EXPECT().GetMoviesFilter(gomock.Any(), domain.MovieFilter{Sort: "rating"}).Return(nil, dummyError)
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: "Failed to get movies",
@@ -389,3 +422,27 @@ func TestGetMoviesBySnippetHandler(t *testing.T) {
 	}
 }
 
+func TestCreateMovieHandlerReturnsMovie(t *testing.T) {
+	movieID := uuid.New()
+	c := gomock.NewController(t)
+	defer c.Finish()
+	service := mock_service.NewMockMovieService(c)
+	service.EXPECT().CreateMovie(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movie *domain.Movie) error {
+		assert.Equal(t, movieID, movie.ID)
+		movie.Version = 1
+		return nil
+	})
+
+	body := `{"id":"` + movieID.String() + `","title":"Heat","date":"1995-12-15T00:00:00Z"}`
+	req := httptest.NewRequest(http.MethodPost, "/movies", strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	NewMovieHandler(service).CreateMovieHandler(recorder, req)
+
+	assert.Equal(t, 201, recorder.Code)
+	assert.Equal(t, "/api/v1/movies?id="+movieID.String(), recorder.Header().Get("Location"))
+	assert.Equal(t, `"1"`, recorder.Header().Get("ETag"))
+	var movie domain.Movie
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &movie))
+	assert.Equal(t, movieID, movie.ID)
+	assert.Equal(t, "Heat", movie.Title)
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPatchMovieHandler(t *testing.T) {
+	movieID := uuid.New()
+	genreID := uuid.New()
+	stored := domain.Movie{
+		ID:          movieID,
+		Title:       "Heat",
+		Description: "Heist",
+		Date:        time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC),
+		Rating:      8,
+		Countries:   []string{"US"},
+		Genres:      []*domain.Genre{{ID: genreID, Name: "Crime"}},
+		Version:     3,
+	}
+
+	// patchWith runs the apply function of the handler on a copy of the
+	// stored movie, the way the service does.
+	patchWith := func(check func(movie *domain.Movie)) func(context.Context, uuid.UUID, int, func(*domain.Movie) error) (*domain.Movie, error) {
+		return func(_ context.Context, _ uuid.UUID, _ int, apply func(*domain.Movie) error) (*domain.Movie, error) {
+			movie := stored
+			if err := apply(&movie); err != nil {
+				return nil, fmt.Errorf("patch movie: %w", err)
+			}
+			check(&movie)
+			movie.Version++
+			return &movie, nil
+		}
+	}
+
+	type mockBehavior func(r *mock_service.MockMovieService)
+
+	testCases := []struct {
+		name               string
+		id                 string
+		contentType        string
+		body               string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+		expectedBody       string
+		expectedETag       string
+	}{
+		{
+			name:        "Merge patch",
+			id:          movieID.String(),
+			contentType: "application/merge-patch+json",
+			body:        `{"description":"Bank heist","countries":null}`,
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().PatchMovie(gomock.Any(), movieID, 0, gomock.Any()).DoAndReturn(patchWith(func(movie *domain.Movie) {
+					assert.Equal(t, "Heat", movie.Title)
+					assert.Equal(t, "Bank heist", movie.Description)
+					assert.Equal(t, stored.Date, movie.Date)
+					assert.Empty(t, movie.Countries)
+					assert.Equal(t, []*domain.Genre{{ID: genreID}}, movie.Genres)
+				}))
+			},
+			expectedStatusCode: 200,
+			expectedETag:       `"4"`,
+		},
+		{
+			name:        "JSON patch",
+			id:          movieID.String(),
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"test","path":"/title","value":"Heat"},{"op":"replace","path":"/duration_minutes","value":170},{"op":"add","path":"/countries/-","value":"GB"}]`,
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().PatchMovie(gomock.Any(), movieID, 0, gomock.Any()).DoAndReturn(patchWith(func(movie *domain.Movie) {
+					assert.Equal(t, 170, movie.DurationMinutes)
+					// The rating is not part of the patched document.
+					assert.Equal(t, float32(8), movie.Rating)
+					assert.Equal(t, []string{"US", "GB"}, movie.Countries)
+				}))
+			},
+			expectedStatusCode: 200,
+			expectedETag:       `"4"`,
+		},
+		{
+			name:        "Failed test operation",
+			id:          movieID.String(),
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"test","path":"/title","value":"Ronin"}]`,
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().PatchMovie(gomock.Any(), movieID, 0, gomock.Any()).DoAndReturn(patchWith(func(*domain.Movie) {}))
+			},
+			expectedStatusCode: 409,
+			expectedBody:       `{"error":"Patch test failed"}`,
+		},
+		{
+			name:        "Unknown member",
+			id:          movieID.String(),
+			contentType: "application/merge-patch+json",
+			body:        `{"director":"Michael Mann"}`,
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().PatchMovie(gomock.Any(), movieID, 0, gomock.Any()).DoAndReturn(patchWith(func(*domain.Movie) {}))
+			},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"Invalid patch document"}`,
+		},
+		{
+			name:        "Invalid movie",
+			id:          movieID.String(),
+			contentType: "application/merge-patch+json",
+			body:        `{"age_rating":"21+"}`,
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().PatchMovie(gomock.Any(), movieID, 0, gomock.Any()).
+					Return(nil, fmt.Errorf("patch movie: %w", domain.ErrInvalidMovie))
+			},
+			expectedStatusCode: 400,
+		},
+		{
+			name:        "Movie not found",
+			id:          movieID.String(),
+			contentType: "application/merge-patch+json",
+			body:        `{}`,
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().PatchMovie(gomock.Any(), movieID, 0, gomock.Any()).
+					Return(nil, fmt.Errorf("patch movie: %w", domain.ErrNotFound))
+			},
+			expectedStatusCode: 404,
+			expectedBody:       `{"error":"Movie not found"}`,
+		},
+		{
+			name:               "Unsupported content type",
+			id:                 movieID.String(),
+			contentType:        "application/json",
+			body:               `{"title":"Heat"}`,
+			mockBehavior:       func(r *mock_service.MockMovieService) {},
+			expectedStatusCode: 415,
+			expectedBody:       `{"error":"Unsupported patch format"}`,
+		},
+		{
+			name:               "Invalid ID",
+			id:                 "nope",
+			contentType:        "application/merge-patch+json",
+			body:               `{}`,
+			mockBehavior:       func(r *mock_service.MockMovieService) {},
+			expectedStatusCode: 400,
+			expectedBody:       `{"error":"Invalid movie ID"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			service := mock_service.NewMockMovieService(c)
+			tc.mockBehavior(service)
+
+			req := httptest.NewRequest(http.MethodPatch, "/movies/"+tc.id, strings.NewReader(tc.body))
+			req.SetPathValue("id", tc.id)
+			req.Header.Set("Content-Type", tc.contentType)
+			recorder := httptest.NewRecorder()
+			NewMovieHandler(service).PatchMovieHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, recorder.Body.String())
+			}
+			assert.Equal(t, tc.expectedETag, recorder.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestPatchActorHandler(t *testing.T) {
+	actorID := uuid.New()
+	birthdate := time.Date(1943, 8, 17, 0, 0, 0, 0, time.UTC)
+	c := gomock.NewController(t)
+	defer c.Finish()
+	service := mock_service.NewMockActorService(c)
+	service.EXPECT().PatchActor(gomock.Any(), actorID, 2, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ uuid.UUID, _ int, apply func(*domain.Actor) error) (*domain.Actor, error) {
+			act := &domain.Actor{ID: actorID, Name: "Robert", Surname: "De Niro", Sex: "male", Version: 2}
+			if err := apply(act); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, &domain.Actor{ID: actorID, Name: "Robert", Surname: "De Niro", Sex: "male", Birthdate: birthdate, Version: 2}, act)
+			act.Version = 3
+			return act, nil
+		})
+
+	req := httptest.NewRequest(http.MethodPatch, "/actors/"+actorID.String(), strings.NewReader(`{"birthdate":"1943-08-17T00:00:00Z"}`))
+	req.SetPathValue("id", actorID.String())
+	req.Header.Set("Content-Type", "application/merge-patch+json; charset=utf-8")
+	req.Header.Set("If-Match", `"2"`)
+	recorder := httptest.NewRecorder()
+	NewActorHandler(service).PatchActorHandler(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, `"3"`, recorder.Header().Get("ETag"))
+}
+
+func TestUpdateActorHandlerKeepsBirthdate(t *testing.T) {
+	actorID := uuid.New()
+	c := gomock.NewController(t)
+	defer c.Finish()
+	service := mock_service.NewMockActorService(c)
+	service.EXPECT().UpdateActor(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, act *domain.Actor) error {
+		assert.Equal(t, time.Date(1943, 8, 17, 0, 0, 0, 0, time.UTC), act.Birthdate)
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/actors?id="+actorID.String(),
+		strings.NewReader(`{"name":"Robert","surname":"De Niro","sex":"male","birthdate":"1943-08-17T00:00:00Z"}`))
+	recorder := httptest.NewRecorder()
+	NewActorHandler(service).UpdateActorHandler(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"bytes"
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreatePaymentHandler(t *testing.T) {
+	bookingID := uuid.New()
+	type mockBehavior func(r *mock_service.MockPaymentService, input models.PaymentInput)
+	testCases := []struct {
+		name                 string
+		input                models.PaymentInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			input: models.PaymentInput{BookingID: bookingID, Amount: 500, Currency: "RUB"},
+			mockBehavior: func(r *mock_service.MockPaymentService, input models.PaymentInput) {
+				r.EXPECT().CreatePayment(gomock.Any(), input.BookingID, input.Amount, input.Currency).Return(
+					&domain.Payment{BookingID: input.BookingID, IntentID: "pi_1", Amount: 500, Currency: "RUB", Status: domain.PaymentPending},
+					&domain.PaymentIntent{ID: "pi_1", ClientSecret: "secret"},
+					nil,
+				)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: "",
+		},
+		{
+			name:                 "Invalid amount",
+			input:                models.PaymentInput{BookingID: bookingID, Amount: 0, Currency: "RUB"},
+			mockBehavior:         func(r *mock_service.MockPaymentService, input models.PaymentInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "Invalid request payload",
+		},
+		{
+			name:  "Booking already paid",
+			input: models.PaymentInput{BookingID: bookingID, Amount: 500, Currency: "RUB"},
+			mockBehavior: func(r *mock_service.MockPaymentService, input models.PaymentInput) {
+				r.EXPECT().CreatePayment(gomock.Any(), input.BookingID, input.Amount, input.Currency).
+					Return(nil, nil, fmt.Errorf("create payment: %w", domain.ErrAlreadyExists))
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: "Booking already has a payment",
+		},
+		{
+			name:  "Internal Server Error",
+			input: models.PaymentInput{BookingID: bookingID, Amount: 500, Currency: "RUB"},
+			mockBehavior: func(r *mock_service.MockPaymentService, input models.PaymentInput) {
+				r.EXPECT().CreatePayment(gomock.Any(), input.BookingID, input.Amount, input.Currency).
+					Return(nil, nil, errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "Failed to create payment",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockPaymentService(c)
+			tc.mockBehavior(service, tc.input)
+
+			handler := NewPaymentHandler(service)
+
+			jsonData, err := json.Marshal(tc.input)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("POST", "/payments", bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+
+			handler.CreatePaymentHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			if tc.expectedResponseBody != "" {
+				expectedResponse := `{"error":"` + tc.expectedResponseBody + `"}`
+				assert.Equal(t, expectedResponse, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestPaymentWebhookHandler(t *testing.T) {
+	payload := []byte(`{"id":"evt_1"}`)
+	type mockBehavior func(r *mock_service.MockPaymentService)
+	testCases := []struct {
+		name               string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(r *mock_service.MockPaymentService) {
+				r.EXPECT().HandleWebhook(gomock.Any(), payload, "sig").Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Rejected",
+			mockBehavior: func(r *mock_service.MockPaymentService) {
+				r.EXPECT().HandleWebhook(gomock.Any(), payload, "sig").Return(errors.New("invalid signature"))
+			},
+			expectedStatusCode: 400,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockPaymentService(c)
+			tc.mockBehavior(service)
+
+			handler := NewPaymentHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/payments/webhook", bytes.NewBuffer(payload))
+			req.Header.Set(paymentSignatureHeader, "sig")
+			recorder := httptest.NewRecorder()
+
+			handler.PaymentWebhookHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+		})
+	}
+}
+
+func TestCancelPaymentHandler(t *testing.T) {
+	bookingID := uuid.New()
+	type mockBehavior func(r *mock_service.MockPaymentService)
+	testCases := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(r *mock_service.MockPaymentService) {
+				r.EXPECT().CancelPayment(gomock.Any(), bookingID).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"Payment cancelled successfully"}`,
+		},
+		{
+			name: "Not found",
+			mockBehavior: func(r *mock_service.MockPaymentService) {
+				r.EXPECT().CancelPayment(gomock.Any(), bookingID).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"Payment not found"}`,
+		},
+		{
+			name: "Payment of another user",
+			mockBehavior: func(r *mock_service.MockPaymentService) {
+				r.EXPECT().CancelPayment(gomock.Any(), bookingID).Return(domain.ErrForbidden)
+			},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"Payment belongs to another user"}`,
+		},
+		{
+			name: "Already refunded",
+			mockBehavior: func(r *mock_service.MockPaymentService) {
+				r.EXPECT().CancelPayment(gomock.Any(), bookingID).Return(domain.ErrPaymentState)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"Payment is in invalid state"}`,
+		},
+		{
+			name: "Internal Server Error",
+			mockBehavior: func(r *mock_service.MockPaymentService) {
+				r.EXPECT().CancelPayment(gomock.Any(), bookingID).Return(errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to cancel payment"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockPaymentService(c)
+			tc.mockBehavior(service)
+
+			handler := NewPaymentHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/payments/cancel?booking_id="+bookingID.String(), nil)
+			recorder := httptest.NewRecorder()
+
+			handler.CancelPaymentHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"bytes"
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRateMovieHandler(t *testing.T) {
+	movieID := uuid.New()
+	user := &usecase.UserInfo{UserID: uuid.New(), Role: domain.USER}
+	type mockBehavior func(r *mock_service.MockRatingService)
+	testCases := []struct {
+		name                 string
+		ctx                  context.Context
+		movieID              string
+		body                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "OK",
+			ctx:     context.WithValue(context.Background(), usecase.UserCtx, user),
+			movieID: movieID.String(),
+			body:    `{"score":8}`,
+			mockBehavior: func(r *mock_service.MockRatingService) {
+				r.EXPECT().RateMovie(gomock.Any(), &domain.Rating{UserID: user.UserID, MovieID: movieID, Score: 8}).
+					Return(&domain.RatingSummary{MovieID: movieID, Rating: 8, Votes: 1}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"movie_id":"` + movieID.String() + `","rating":8,"votes":1}`,
+		},
+		{
+			name:    "Invalid score",
+			ctx:     context.WithValue(context.Background(), usecase.UserCtx, user),
+			movieID: movieID.String(),
+			body:    `{"score":42}`,
+			mockBehavior: func(r *mock_service.MockRatingService) {
+				r.EXPECT().RateMovie(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidScore)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Score must be between 0 and 10"}`,
+		},
+		{
+			name:                 "Missing user",
+			ctx:                  context.Background(),
+			movieID:              movieID.String(),
+			body:                 `{"score":8}`,
+			mockBehavior:         func(r *mock_service.MockRatingService) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"User context not found"}`,
+		},
+		{
+			name:                 "Invalid movie ID",
+			ctx:                  context.WithValue(context.Background(), usecase.UserCtx, user),
+			movieID:              "invalid",
+			body:                 `{"score":8}`,
+			mockBehavior:         func(r *mock_service.MockRatingService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid movie ID"}`,
+		},
+		{
+			name:    "Internal Server Error",
+			ctx:     context.WithValue(context.Background(), usecase.UserCtx, user),
+			movieID: movieID.String(),
+			body:    `{"score":8}`,
+			mockBehavior: func(r *mock_service.MockRatingService) {
+				r.EXPECT().RateMovie(gomock.Any(), gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to rate movie"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockRatingService(c)
+			tc.mockBehavior(service)
+
+			handler := NewRatingHandler(service)
+
+			req := httptest.NewRequest(http.MethodPut, "/movies/rating?id="+url.QueryEscape(tc.movieID), bytes.NewBufferString(tc.body))
+			req = req.WithContext(tc.ctx)
+			recorder := httptest.NewRecorder()
+
+			handler.RateMovieHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestGetMovieReviewsHandler(t *testing.T) {
+	movieID := uuid.New()
+	type mockBehavior func(r *mock_service.MockRatingService)
+	testCases := []struct {
+		name               string
+		query              string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:  "Default pagination",
+			query: "?id=" + movieID.String(),
+			mockBehavior: func(r *mock_service.MockRatingService) {
+				r.EXPECT().GetMovieReviews(gomock.Any(), movieID, defaultPageSize, 0).Return(nil, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:  "Second page",
+			query: "?id=" + movieID.String() + "&page=2&page_size=10",
+			mockBehavior: func(r *mock_service.MockRatingService) {
+				r.EXPECT().GetMovieReviews(gomock.Any(), movieID, 10, 10).Return([]*domain.Review{{MovieID: movieID}}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Page size too large",
+			query:              "?id=" + movieID.String() + "&page_size=1000",
+			mockBehavior:       func(r *mock_service.MockRatingService) {},
+			expectedStatusCode: 400,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockRatingService(c)
+			tc.mockBehavior(service)
+
+			handler := NewRatingHandler(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/movies/reviews"+tc.query, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.GetMovieReviewsHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+		})
+	}
+}
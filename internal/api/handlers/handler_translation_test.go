@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetMovieTranslationHandler(t *testing.T) {
+	movieID := uuid.New()
+	type mockBehavior func(r *mock_service.MockTranslationService)
+	testCases := []struct {
+		name                 string
+		query                string
+		body                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			query: "?id=" + movieID.String() + "&lang=EN",
+			body:  `{"title":" The Irony of Fate ","description":"New Year's Eve"}`,
+			mockBehavior: func(r *mock_service.MockTranslationService) {
+				r.EXPECT().SetMovieTranslation(gomock.Any(), &domain.MovieTranslation{
+					MovieID:     movieID,
+					Locale:      "en",
+					Title:       "The Irony of Fate",
+					Description: "New Year's Eve",
+				}).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"Movie translation saved successfully"}`,
+		},
+		{
+			name:                 "Missing lang",
+			query:                "?id=" + movieID.String(),
+			body:                 `{"title":"The Irony of Fate"}`,
+			mockBehavior:         func(r *mock_service.MockTranslationService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"lang parameter is required"}`,
+		},
+		{
+			name:                 "Invalid lang",
+			query:                "?id=" + movieID.String() + "&lang=en-US",
+			body:                 `{"title":"The Irony of Fate"}`,
+			mockBehavior:         func(r *mock_service.MockTranslationService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid lang parameter"}`,
+		},
+		{
+			name:  "Invalid translation",
+			query: "?id=" + movieID.String() + "&lang=en",
+			body:  `{"title":""}`,
+			mockBehavior: func(r *mock_service.MockTranslationService) {
+				r.EXPECT().SetMovieTranslation(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("%w: title must be 1 to 150 characters", domain.ErrInvalidTranslation))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid translation: title must be 1 to 150 characters"}`,
+		},
+		{
+			name:  "Unknown movie",
+			query: "?id=" + movieID.String() + "&lang=en",
+			body:  `{"title":"The Irony of Fate"}`,
+			mockBehavior: func(r *mock_service.MockTranslationService) {
+				r.EXPECT().SetMovieTranslation(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"Movie not found"}`,
+		},
+		{
+			name:  "Internal Server Error",
+			query: "?id=" + movieID.String() + "&lang=en",
+			body:  `{"title":"The Irony of Fate"}`,
+			mockBehavior: func(r *mock_service.MockTranslationService) {
+				r.EXPECT().SetMovieTranslation(gomock.Any(), gomock.Any()).Return(errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to set movie translation"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockTranslationService(c)
+			tc.mockBehavior(service)
+
+			handler := NewTranslationHandler(service)
+
+			req := httptest.NewRequest(http.MethodPut, "/movies/translations"+tc.query, bytes.NewBufferString(tc.body))
+			recorder := httptest.NewRecorder()
+
+			handler.SetMovieTranslationHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.JSONEq(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestGetActorTranslationsHandler(t *testing.T) {
+	actorID := uuid.New()
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	service := mock_service.NewMockTranslationService(c)
+	service.EXPECT().GetActorTranslations(gomock.Any(), actorID).Return([]*domain.ActorTranslation{
+		{ActorID: actorID, Locale: "en", Name: "Andrey", Surname: "Myagkov"},
+	}, nil)
+
+	handler := NewTranslationHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/actors/translations?id="+actorID.String(), nil)
+	recorder := httptest.NewRecorder()
+
+	handler.GetActorTranslationsHandler(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `[{"lang":"en","name":"Andrey","surname":"Myagkov"}]`, recorder.Body.String())
+}
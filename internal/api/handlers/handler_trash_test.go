@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetTrashHandler(t *testing.T) {
+	movieID := uuid.MustParse("6f1c2a3e-8b5f-4d43-9f7e-0c1d2e3f4a5b")
+	actorID := uuid.MustParse("0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	deletedAt := time.Date(2024, 4, 22, 10, 0, 0, 0, time.UTC)
+	type mockBehavior func(r *mock_service.MockTrashService)
+
+	testCases := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(r *mock_service.MockTrashService) {
+				r.EXPECT().GetTrash(gomock.Any()).Return(&domain.Trash{
+					Movies: []*domain.TrashedMovie{{
+						Movie:     &domain.Movie{ID: movieID, Title: "Heat", Date: time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC)},
+						DeletedAt: deletedAt,
+					}},
+					Actors: []*domain.TrashedActor{{
+						Actor:     &domain.Actor{ID: actorID, Name: "Al", Surname: "Pacino", Sex: "male"},
+						DeletedAt: deletedAt,
+					}},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"movies":[{"movie_id":"` + movieID.String() + `","title":"Heat","description":"",` +
+				`"date":"1995-12-15T00:00:00Z","deleted_at":"2024-04-22T10:00:00Z"}],` +
+				`"actors":[{"actor_id":"` + actorID.String() + `","name":"Al","surname":"Pacino","sex":"male",` +
+				`"birthdate":"0001-01-01T00:00:00Z","deleted_at":"2024-04-22T10:00:00Z"}]}`,
+		},
+		{
+			name: "Empty",
+			mockBehavior: func(r *mock_service.MockTrashService) {
+				r.EXPECT().GetTrash(gomock.Any()).Return(&domain.Trash{}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"movies":[],"actors":[]}`,
+		},
+		{
+			name: "Internal Server Error",
+			mockBehavior: func(r *mock_service.MockTrashService) {
+				r.EXPECT().GetTrash(gomock.Any()).Return(nil, errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to get trash"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockTrashService(c)
+			tc.mockBehavior(service)
+
+			handler := NewTrashHandler(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/trash", nil)
+			recorder := httptest.NewRecorder()
+
+			handler.GetTrashHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestRestoreMovieHandler(t *testing.T) {
+	movieID := uuid.New()
+	type mockBehavior func(r *mock_service.MockTrashService)
+
+	testCases := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			query: "?id=" + movieID.String(),
+			mockBehavior: func(r *mock_service.MockTrashService) {
+				r.EXPECT().RestoreMovie(gomock.Any(), movieID).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"Movie restored successfully"}`,
+		},
+		{
+			name:                 "Invalid ID",
+			query:                "?id=123",
+			mockBehavior:         func(r *mock_service.MockTrashService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid movie ID"}`,
+		},
+		{
+			name:  "Not in trash",
+			query: "?id=" + movieID.String(),
+			mockBehavior: func(r *mock_service.MockTrashService) {
+				r.EXPECT().RestoreMovie(gomock.Any(), movieID).Return(fmt.Errorf("restore movie: %w", domain.ErrNotFound))
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"Movie not found in trash"}`,
+		},
+		{
+			name:  "Internal Server Error",
+			query: "?id=" + movieID.String(),
+			mockBehavior: func(r *mock_service.MockTrashService) {
+				r.EXPECT().RestoreMovie(gomock.Any(), movieID).Return(errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to restore movie"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockTrashService(c)
+			tc.mockBehavior(service)
+
+			handler := NewTrashHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/trash/movies/restore"+tc.query, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.RestoreMovieHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestRestoreActorHandler(t *testing.T) {
+	actorID := uuid.New()
+	type mockBehavior func(r *mock_service.MockTrashService)
+
+	testCases := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			query: "?id=" + actorID.String(),
+			mockBehavior: func(r *mock_service.MockTrashService) {
+				r.EXPECT().RestoreActor(gomock.Any(), actorID).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"Actor restored successfully"}`,
+		},
+		{
+			name:                 "Missing ID",
+			query:                "",
+			mockBehavior:         func(r *mock_service.MockTrashService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Actor ID parameter is required"}`,
+		},
+		{
+			name:  "Not in trash",
+			query: "?id=" + actorID.String(),
+			mockBehavior: func(r *mock_service.MockTrashService) {
+				r.EXPECT().RestoreActor(gomock.Any(), actorID).Return(fmt.Errorf("restore actor: %w", domain.ErrNotFound))
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"Actor not found in trash"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockTrashService(c)
+			tc.mockBehavior(service)
+
+			handler := NewTrashHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/trash/actors/restore"+tc.query, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.RestoreActorHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetMovieVersionsHandler(t *testing.T) {
+	movieID := uuid.MustParse("6f1c2a3e-8b5f-4d43-9f7e-0c1d2e3f4a5b")
+	userID := uuid.MustParse("0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	createdAt := time.Date(2024, 4, 26, 10, 0, 0, 0, time.UTC)
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+	service := mock_service.NewMockMovieService(c)
+	service.EXPECT().GetMovieVersions(gomock.Any(), movieID).Return([]*domain.EntityVersion{
+		{Number: 2, UserID: userID, State: json.RawMessage(`{"Title":"Ronin"}`), CreatedAt: createdAt},
+		{Number: 1, State: json.RawMessage(`{"Title":"Heat"}`), CreatedAt: createdAt},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/movies/versions?id="+movieID.String(), nil)
+	recorder := httptest.NewRecorder()
+	NewMovieHandler(service).GetMovieVersionsHandler(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, `[{"version":2,"user_id":"`+userID.String()+`","created_at":"2024-04-26T10:00:00Z","state":{"Title":"Ronin"}},`+
+		`{"version":1,"user_id":null,"created_at":"2024-04-26T10:00:00Z","state":{"Title":"Heat"}}]`, recorder.Body.String())
+}
+
+func TestDiffMovieVersionsHandler(t *testing.T) {
+	movieID := uuid.New()
+	type mockBehavior func(r *mock_service.MockMovieService)
+
+	testCases := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			query: "?id=" + movieID.String() + "&from=1&to=3",
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().DiffMovieVersions(gomock.Any(), movieID, 1, 3).
+					Return(map[string]domain.AuditChange{"Title": {Before: "Heat", After: "Ronin"}}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"from":1,"to":3,"changes":{"Title":{"before":"Heat","after":"Ronin"}}}`,
+		},
+		{
+			name:                 "Missing version",
+			query:                "?id=" + movieID.String() + "&from=1",
+			mockBehavior:         func(r *mock_service.MockMovieService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Missing to parameter"}`,
+		},
+		{
+			name:                 "Invalid version",
+			query:                "?id=" + movieID.String() + "&from=0&to=1",
+			mockBehavior:         func(r *mock_service.MockMovieService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid from parameter"}`,
+		},
+		{
+			name:  "Not Found",
+			query: "?id=" + movieID.String() + "&from=1&to=9",
+			mockBehavior: func(r *mock_service.MockMovieService) {
+				r.EXPECT().DiffMovieVersions(gomock.Any(), movieID, 1, 9).
+					Return(nil, fmt.Errorf("diff movie versions: %w", domain.ErrNotFound))
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"Movie version not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockMovieService(c)
+			tc.mockBehavior(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/movies/versions/diff"+tc.query, nil)
+			recorder := httptest.NewRecorder()
+			NewMovieHandler(service).DiffMovieVersionsHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestRestoreActorVersionHandler(t *testing.T) {
+	actorID := uuid.New()
+	type mockBehavior func(r *mock_service.MockActorService)
+
+	testCases := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			query: "?id=" + actorID.String() + "&version=2",
+			mockBehavior: func(r *mock_service.MockActorService) {
+				r.EXPECT().RestoreActorVersion(gomock.Any(), actorID, 2).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"Actor version restored successfully"}`,
+		},
+		{
+			name:  "Not Found",
+			query: "?id=" + actorID.String() + "&version=2",
+			mockBehavior: func(r *mock_service.MockActorService) {
+				r.EXPECT().RestoreActorVersion(gomock.Any(), actorID, 2).
+					Return(fmt.Errorf("restore actor version: %w", domain.ErrNotFound))
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"Actor version not found"}`,
+		},
+		{
+			name:  "Internal Server Error",
+			query: "?id=" + actorID.String() + "&version=2",
+			mockBehavior: func(r *mock_service.MockActorService) {
+				r.EXPECT().RestoreActorVersion(gomock.Any(), actorID, 2).Return(errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to restore actor version"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockActorService(c)
+			tc.mockBehavior(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/actors/versions/restore"+tc.query, nil)
+			recorder := httptest.NewRecorder()
+			NewActorHandler(service).RestoreActorVersionHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
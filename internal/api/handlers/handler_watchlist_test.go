@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddToWatchlistHandler(t *testing.T) {
+	movieID := uuid.New()
+	user := &usecase.UserInfo{UserID: uuid.New(), Role: domain.USER}
+	type mockBehavior func(r *mock_service.MockWatchlistService)
+	testCases := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(r *mock_service.MockWatchlistService) {
+				r.EXPECT().AddToWatchlist(gomock.Any(), user.UserID, movieID).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"Movie added to watchlist"}`,
+		},
+		{
+			name: "Movie not found",
+			mockBehavior: func(r *mock_service.MockWatchlistService) {
+				r.EXPECT().AddToWatchlist(gomock.Any(), user.UserID, movieID).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"Movie not found"}`,
+		},
+		{
+			name: "Internal Server Error",
+			mockBehavior: func(r *mock_service.MockWatchlistService) {
+				r.EXPECT().AddToWatchlist(gomock.Any(), user.UserID, movieID).Return(errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to add movie to watchlist"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockWatchlistService(c)
+			tc.mockBehavior(service)
+
+			handler := NewWatchlistHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/me/watchlist?id="+movieID.String(), nil)
+			req = req.WithContext(context.WithValue(req.Context(), usecase.UserCtx, user))
+			recorder := httptest.NewRecorder()
+
+			handler.AddToWatchlistHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestGetWatchlistHandler(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	user := &usecase.UserInfo{UserID: uuid.New(), Role: domain.USER}
+	movieID := uuid.New()
+	addedAt := time.Date(2024, 4, 8, 12, 0, 0, 0, time.UTC)
+
+	service := mock_service.NewMockWatchlistService(c)
+	service.EXPECT().GetWatchlist(gomock.Any(), user.UserID).Return([]*domain.WatchlistEntry{
+		{Movie: &domain.Movie{ID: movieID, Title: "Movie", InWatchlist: true}, AddedAt: addedAt},
+	}, nil)
+
+	handler := NewWatchlistHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/me/watchlist", nil)
+	req = req.WithContext(context.WithValue(req.Context(), usecase.UserCtx, user))
+	recorder := httptest.NewRecorder()
+
+	handler.GetWatchlistHandler(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `[{"movie_id":"`+movieID.String()+`","title":"Movie","description":"",`+
+		`"date":"0001-01-01T00:00:00Z","rating":0,"added_at":"2024-04-08T12:00:00Z"}]`, recorder.Body.String())
+}
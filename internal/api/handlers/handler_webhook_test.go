@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"bytes"
+	mock_service "cinema_service/internal/api/handlers/mocks"
+	"cinema_service/internal/domain"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhookHandler(t *testing.T) {
+	subscriptionID := uuid.MustParse("2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f")
+	createdAt := time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)
+	type mockBehavior func(r *mock_service.MockWebhookService)
+
+	testCases := []struct {
+		name                 string
+		body                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedLocation     string
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			body: `{"url":"https://partner.example.com/hooks","event_types":["movie.created"]}`,
+			mockBehavior: func(r *mock_service.MockWebhookService) {
+				r.EXPECT().CreateSubscription(gomock.Any(), &domain.WebhookSubscription{
+					URL:        "https://partner.example.com/hooks",
+					EventTypes: []string{domain.EventMovieCreated},
+				}).DoAndReturn(func(_ any, subscription *domain.WebhookSubscription) error {
+					subscription.ID = subscriptionID
+					subscription.Secret = "generated"
+					subscription.CreatedAt = createdAt
+					return nil
+				})
+			},
+			expectedStatusCode: 201,
+			expectedLocation:   "/api/v1/webhooks?id=" + subscriptionID.String(),
+			expectedResponseBody: `{"id":"` + subscriptionID.String() + `","url":"https://partner.example.com/hooks",` +
+				`"event_types":["movie.created"],"secret":"generated","created_at":"2024-05-04T10:00:00Z"}`,
+		},
+		{
+			name:                 "Invalid payload",
+			body:                 `{"url":`,
+			mockBehavior:         func(r *mock_service.MockWebhookService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Invalid request payload"}`,
+		},
+		{
+			name: "Invalid subscription",
+			body: `{"url":"partner","event_types":["movie.created"]}`,
+			mockBehavior: func(r *mock_service.MockWebhookService) {
+				r.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("%w: invalid URL", domain.ErrInvalidSubscription))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"invalid webhook subscription: invalid URL"}`,
+		},
+		{
+			name: "Internal Server Error",
+			body: `{"url":"https://partner.example.com/hooks","event_types":["movie.created"]}`,
+			mockBehavior: func(r *mock_service.MockWebhookService) {
+				r.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Return(errors.New("dummy error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"error":"Failed to create webhook subscription"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockWebhookService(c)
+			tc.mockBehavior(service)
+
+			handler := NewWebhookHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(tc.body))
+			recorder := httptest.NewRecorder()
+
+			handler.CreateWebhookHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedLocation, recorder.Header().Get("Location"))
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestGetWebhooksHandler(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	subscriptionID := uuid.MustParse("2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f")
+	service := mock_service.NewMockWebhookService(c)
+	service.EXPECT().GetSubscriptions(gomock.Any()).Return([]*domain.WebhookSubscription{{
+		ID:         subscriptionID,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{domain.EventCastChanged},
+		Secret:     "secret",
+		CreatedAt:  time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC),
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+	recorder := httptest.NewRecorder()
+	NewWebhookHandler(service).GetWebhooksHandler(recorder, req)
+
+	// Secrets are not listed.
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, `[{"id":"`+subscriptionID.String()+`","url":"https://partner.example.com/hooks",`+
+		`"event_types":["cast.changed"],"created_at":"2024-05-04T10:00:00Z"}]`, recorder.Body.String())
+}
+
+func TestGetDeliveriesHandler(t *testing.T) {
+	subscriptionID := uuid.MustParse("2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f")
+	deliveryID := uuid.MustParse("3d4e5f6a-7b8c-4d9e-8f0a-1b2c3d4e5f6a")
+	eventID := uuid.MustParse("4e5f6a7b-8c9d-4e0f-9a1b-2c3d4e5f6a7b")
+	createdAt := time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)
+	type mockBehavior func(r *mock_service.MockWebhookService)
+
+	testCases := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			query: "?id=" + subscriptionID.String() + "&page=2&page_size=10",
+			mockBehavior: func(r *mock_service.MockWebhookService) {
+				r.EXPECT().GetDeliveries(gomock.Any(), subscriptionID, 10, 10).Return([]*domain.WebhookDelivery{{
+					ID:             deliveryID,
+					SubscriptionID: subscriptionID,
+					EventID:        eventID,
+					EventType:      domain.EventMovieCreated,
+					Body:           []byte(`{"type":"movie.created"}`),
+					Status:         domain.DeliveryDelivered,
+					Attempts:       2,
+					NextAttemptAt:  createdAt,
+					ResponseStatus: 204,
+					CreatedAt:      createdAt,
+					DeliveredAt:    createdAt.Add(time.Minute),
+				}}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `[{"id":"` + deliveryID.String() + `","subscription_id":"` + subscriptionID.String() + `",` +
+				`"event_id":"` + eventID.String() + `","event_type":"movie.created","body":{"type":"movie.created"},` +
+				`"status":"delivered","attempts":2,"next_attempt_at":"2024-05-04T10:00:00Z","response_status":204,` +
+				`"last_error":"","created_at":"2024-05-04T10:00:00Z","delivered_at":"2024-05-04T10:01:00Z"}]`,
+		},
+		{
+			name:                 "Missing ID",
+			query:                "",
+			mockBehavior:         func(r *mock_service.MockWebhookService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"Subscription ID parameter is required"}`,
+		},
+		{
+			name:  "Not found",
+			query: "?id=" + subscriptionID.String(),
+			mockBehavior: func(r *mock_service.MockWebhookService) {
+				r.EXPECT().GetDeliveries(gomock.Any(), subscriptionID, 20, 0).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"Subscription not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockWebhookService(c)
+			tc.mockBehavior(service)
+
+			handler := NewWebhookHandler(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/webhooks/deliveries"+tc.query, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.GetDeliveriesHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tc.expectedResponseBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestRedeliverHandler(t *testing.T) {
+	deliveryID := uuid.MustParse("3d4e5f6a-7b8c-4d9e-8f0a-1b2c3d4e5f6a")
+	type mockBehavior func(r *mock_service.MockWebhookService)
+
+	testCases := []struct {
+		name               string
+		query              string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:  "OK",
+			query: "?id=" + deliveryID.String(),
+			mockBehavior: func(r *mock_service.MockWebhookService) {
+				r.EXPECT().Redeliver(gomock.Any(), deliveryID).
+					Return(&domain.WebhookDelivery{ID: deliveryID, Status: domain.DeliveryPending}, nil)
+			},
+			expectedStatusCode: 202,
+		},
+		{
+			name:               "Invalid ID",
+			query:              "?id=123",
+			mockBehavior:       func(r *mock_service.MockWebhookService) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name:  "Not found",
+			query: "?id=" + deliveryID.String(),
+			mockBehavior: func(r *mock_service.MockWebhookService) {
+				r.EXPECT().Redeliver(gomock.Any(), deliveryID).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_service.NewMockWebhookService(c)
+			tc.mockBehavior(service)
+
+			handler := NewWebhookHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/deliveries/redeliver"+tc.query, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.RedeliverHandler(recorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+		})
+	}
+}
+
+func TestGetWebhookHandlerByID(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	subscriptionID := uuid.MustParse("2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f")
+	service := mock_service.NewMockWebhookService(c)
+	service.EXPECT().GetSubscription(gomock.Any(), subscriptionID).Return(&domain.WebhookSubscription{
+		ID:         subscriptionID,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{domain.EventCastChanged},
+		Secret:     "secret",
+		CreatedAt:  time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC),
+	}, nil)
+	service.EXPECT().GetSubscription(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("get webhook subscription: %w", domain.ErrNotFound))
+	handler := NewWebhookHandler(service)
+
+	// The Location of a created subscription.
+	recorder := httptest.NewRecorder()
+	handler.GetWebhooksHandler(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks?id="+subscriptionID.String(), nil))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, `{"id":"`+subscriptionID.String()+`","url":"https://partner.example.com/hooks",`+
+		`"event_types":["cast.changed"],"created_at":"2024-05-04T10:00:00Z"}`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	handler.GetWebhooksHandler(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks?id="+uuid.NewString(), nil))
+	assert.Equal(t, 404, recorder.Code)
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+//go:generate mockgen -source=import.go -destination=mocks/importServiceMock.go
+
+// maxImportSize limits the size of an uploaded catalogue file.
+const maxImportSize = 64 << 20
+
+type ImportService interface {
+	Import(ctx context.Context, kind string, format string, r io.Reader, dryRun bool) (*domain.ImportReport, error)
+}
+
+type ImportHandler struct {
+	service ImportService
+}
+
+func NewImportHandler(service ImportService) *ImportHandler {
+	return &ImportHandler{service: service}
+}
+
+// ImportHandler upserts catalogue records in bulk.
+// @Summary Import Catalogue
+// @Description Validates a CSV or NDJSON file of movies, actors or credits row by row and upserts the valid rows.
+// @Description The format is taken from the format parameter or the Content-Type header.
+// @Description CSV list columns such as countries separate values with "|".
+// @Description Imports are bulk loads: they write no audit log entries, versions or catalogue events.
+// @Description Ratings of existing movies are kept, and rows of movies or actors in the trash are rejected.
+// @Tags Catalogue
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Security ApiKeyAuth
+// @Param kind query string true "Record kind" Enums(movies, actors, credits)
+// @Param format query string false "File format" Enums(csv, ndjson)
+// @Param dry_run query bool false "Only validate the rows"
+// @Success 200 {object} models.ImportReport
+// @Failure 400 {object} errorResponse
+// @Failure 413 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /import [post]
+func (h *ImportHandler) ImportHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	kind := query.Get("kind")
+	if kind == "" {
+		NewErrorResponse(w, http.StatusBadRequest, "kind parameter is required")
+		return
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = formatFromContentType(r.Header.Get("Content-Type"))
+	}
+	if format == "" {
+		NewErrorResponse(w, http.StatusBadRequest, "Unsupported import format")
+		return
+	}
+
+	dryRun := false
+	if value := query.Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			NewErrorResponse(w, http.StatusBadRequest, "Invalid dry_run parameter")
+			return
+		}
+		dryRun = parsed
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	report, err := h.service.Import(r.Context(), kind, format, body, dryRun)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.Is(err, domain.ErrInvalidImport):
+			NewErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.As(err, &maxBytesErr):
+			NewErrorResponse(w, http.StatusRequestEntityTooLarge, "Import file is too large")
+		default:
+			NewErrorResponse(w, http.StatusInternalServerError, "Failed to import catalogue")
+		}
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, toImportReport(report))
+}
+
+func formatFromContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch mediaType {
+	case "text/csv":
+		return domain.FormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return domain.FormatNDJSON
+	}
+	return ""
+}
+
+func toImportReport(report *domain.ImportReport) models.ImportReport {
+	result := models.ImportReport{
+		Kind:     report.Kind,
+		DryRun:   report.DryRun,
+		Rows:     report.Rows,
+		Imported: report.Imported,
+		Errors:   make([]models.ImportRowError, 0, len(report.Errors)),
+	}
+	for _, rowErr := range report.Errors {
+		result.Errors = append(result.Errors, models.ImportRowError{Line: rowErr.Line, Error: rowErr.Message})
+	}
+	return result
+}
+
+func (h *ImportHandler) RegisterImport(mux *http.ServeMux,
+	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("POST /api/v1/import", logging(authentication(authorization(h.ImportHandler))))
+	return mux
+}
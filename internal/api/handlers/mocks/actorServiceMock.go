@@ -56,17 +56,62 @@ func (mr *MockActorServiceMockRecorder) CreateActor(ctx, act any) *gomock.Call {
 }
 
 // DeleteActor mocks base method.
-func (m *MockActorService) DeleteActor(ctx context.Context, actorID uuid.UUID) error {
+func (m *MockActorService) DeleteActor(ctx context.Context, actorID uuid.UUID, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteActor", ctx, actorID)
+	ret := m.ctrl.Call(m, "DeleteActor", ctx, actorID, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteActor indicates an expected call of DeleteActor.
-func (mr *MockActorServiceMockRecorder) DeleteActor(ctx, actorID any) *gomock.Call {
+func (mr *MockActorServiceMockRecorder) DeleteActor(ctx, actorID, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockActorService)(nil).DeleteActor), ctx, actorID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockActorService)(nil).DeleteActor), ctx, actorID, version)
+}
+
+// DiffActorVersions mocks base method.
+func (m *MockActorService) DiffActorVersions(ctx context.Context, actorID uuid.UUID, from, to int) (map[string]domain.AuditChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffActorVersions", ctx, actorID, from, to)
+	ret0, _ := ret[0].(map[string]domain.AuditChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffActorVersions indicates an expected call of DiffActorVersions.
+func (mr *MockActorServiceMockRecorder) DiffActorVersions(ctx, actorID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffActorVersions", reflect.TypeOf((*MockActorService)(nil).DiffActorVersions), ctx, actorID, from, to)
+}
+
+// GetActor mocks base method.
+func (m *MockActorService) GetActor(ctx context.Context, actorID uuid.UUID) (*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActor", ctx, actorID)
+	ret0, _ := ret[0].(*domain.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActor indicates an expected call of GetActor.
+func (mr *MockActorServiceMockRecorder) GetActor(ctx, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActor", reflect.TypeOf((*MockActorService)(nil).GetActor), ctx, actorID)
+}
+
+// GetActorVersions mocks base method.
+func (m *MockActorService) GetActorVersions(ctx context.Context, actorID uuid.UUID) ([]*domain.EntityVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorVersions", ctx, actorID)
+	ret0, _ := ret[0].([]*domain.EntityVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorVersions indicates an expected call of GetActorVersions.
+func (mr *MockActorServiceMockRecorder) GetActorVersions(ctx, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorVersions", reflect.TypeOf((*MockActorService)(nil).GetActorVersions), ctx, actorID)
 }
 
 // GetActors mocks base method.
@@ -84,6 +129,35 @@ func (mr *MockActorServiceMockRecorder) GetActors(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActors", reflect.TypeOf((*MockActorService)(nil).GetActors), ctx)
 }
 
+// PatchActor mocks base method.
+func (m *MockActorService) PatchActor(ctx context.Context, actorID uuid.UUID, version int, apply func(*domain.Actor) error) (*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchActor", ctx, actorID, version, apply)
+	ret0, _ := ret[0].(*domain.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchActor indicates an expected call of PatchActor.
+func (mr *MockActorServiceMockRecorder) PatchActor(ctx, actorID, version, apply any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchActor", reflect.TypeOf((*MockActorService)(nil).PatchActor), ctx, actorID, version, apply)
+}
+
+// RestoreActorVersion mocks base method.
+func (m *MockActorService) RestoreActorVersion(ctx context.Context, actorID uuid.UUID, number int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreActorVersion", ctx, actorID, number)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreActorVersion indicates an expected call of RestoreActorVersion.
+func (mr *MockActorServiceMockRecorder) RestoreActorVersion(ctx, actorID, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreActorVersion", reflect.TypeOf((*MockActorService)(nil).RestoreActorVersion), ctx, actorID, number)
+}
+
 // UpdateActor mocks base method.
 func (m *MockActorService) UpdateActor(ctx context.Context, act *domain.Actor) error {
 	m.ctrl.T.Helper()
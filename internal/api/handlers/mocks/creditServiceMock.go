@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: credit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=credit.go -destination=mocks/creditServiceMock.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCreditService is a mock of CreditService interface.
+type MockCreditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditServiceMockRecorder
+}
+
+// MockCreditServiceMockRecorder is the mock recorder for MockCreditService.
+type MockCreditServiceMockRecorder struct {
+	mock *MockCreditService
+}
+
+// NewMockCreditService creates a new mock instance.
+func NewMockCreditService(ctrl *gomock.Controller) *MockCreditService {
+	mock := &MockCreditService{ctrl: ctrl}
+	mock.recorder = &MockCreditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreditService) EXPECT() *MockCreditServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCredit mocks base method.
+func (m *MockCreditService) CreateCredit(ctx context.Context, credit *domain.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredit", ctx, credit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCredit indicates an expected call of CreateCredit.
+func (mr *MockCreditServiceMockRecorder) CreateCredit(ctx, credit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredit", reflect.TypeOf((*MockCreditService)(nil).CreateCredit), ctx, credit)
+}
+
+// DeleteCredit mocks base method.
+func (m *MockCreditService) DeleteCredit(ctx context.Context, creditID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCredit", ctx, creditID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCredit indicates an expected call of DeleteCredit.
+func (mr *MockCreditServiceMockRecorder) DeleteCredit(ctx, creditID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCredit", reflect.TypeOf((*MockCreditService)(nil).DeleteCredit), ctx, creditID)
+}
+
+// GetCredit mocks base method.
+func (m *MockCreditService) GetCredit(ctx context.Context, creditID uuid.UUID) (*domain.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredit", ctx, creditID)
+	ret0, _ := ret[0].(*domain.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredit indicates an expected call of GetCredit.
+func (mr *MockCreditServiceMockRecorder) GetCredit(ctx, creditID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredit", reflect.TypeOf((*MockCreditService)(nil).GetCredit), ctx, creditID)
+}
+
+// GetFilmography mocks base method.
+func (m *MockCreditService) GetFilmography(ctx context.Context, personID uuid.UUID) (map[string][]*domain.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmography", ctx, personID)
+	ret0, _ := ret[0].(map[string][]*domain.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmography indicates an expected call of GetFilmography.
+func (mr *MockCreditServiceMockRecorder) GetFilmography(ctx, personID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmography", reflect.TypeOf((*MockCreditService)(nil).GetFilmography), ctx, personID)
+}
+
+// GetMovieCredits mocks base method.
+func (m *MockCreditService) GetMovieCredits(ctx context.Context, movieID uuid.UUID) (map[string][]*domain.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieCredits", ctx, movieID)
+	ret0, _ := ret[0].(map[string][]*domain.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieCredits indicates an expected call of GetMovieCredits.
+func (mr *MockCreditServiceMockRecorder) GetMovieCredits(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieCredits", reflect.TypeOf((*MockCreditService)(nil).GetMovieCredits), ctx, movieID)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=event.go -destination=mocks/eventFeedMock.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	domain "cinema_service/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventFeed is a mock of EventFeed interface.
+type MockEventFeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventFeedMockRecorder
+}
+
+// MockEventFeedMockRecorder is the mock recorder for MockEventFeed.
+type MockEventFeedMockRecorder struct {
+	mock *MockEventFeed
+}
+
+// NewMockEventFeed creates a new mock instance.
+func NewMockEventFeed(ctrl *gomock.Controller) *MockEventFeed {
+	mock := &MockEventFeed{ctrl: ctrl}
+	mock.recorder = &MockEventFeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventFeed) EXPECT() *MockEventFeedMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockEventFeed) Subscribe(lastID *domain.FeedID) ([]domain.FeedEntry, bool, <-chan domain.FeedEntry, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", lastID)
+	ret0, _ := ret[0].([]domain.FeedEntry)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(<-chan domain.FeedEntry)
+	ret3, _ := ret[3].(func())
+	return ret0, ret1, ret2, ret3
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventFeedMockRecorder) Subscribe(lastID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEventFeed)(nil).Subscribe), lastID)
+}
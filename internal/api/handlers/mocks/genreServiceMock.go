@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: genre.go
+//
+// Generated by this command:
+//
+//	mockgen -source=genre.go -destination=mocks/genreServiceMock.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGenreService is a mock of GenreService interface.
+type MockGenreService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreServiceMockRecorder
+}
+
+// MockGenreServiceMockRecorder is the mock recorder for MockGenreService.
+type MockGenreServiceMockRecorder struct {
+	mock *MockGenreService
+}
+
+// NewMockGenreService creates a new mock instance.
+func NewMockGenreService(ctrl *gomock.Controller) *MockGenreService {
+	mock := &MockGenreService{ctrl: ctrl}
+	mock.recorder = &MockGenreServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreService) EXPECT() *MockGenreServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateGenre mocks base method.
+func (m *MockGenreService) CreateGenre(ctx context.Context, genre *domain.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockGenreServiceMockRecorder) CreateGenre(ctx, genre any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockGenreService)(nil).CreateGenre), ctx, genre)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockGenreService) DeleteGenre(ctx context.Context, genreID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, genreID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockGenreServiceMockRecorder) DeleteGenre(ctx, genreID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockGenreService)(nil).DeleteGenre), ctx, genreID)
+}
+
+// GetGenre mocks base method.
+func (m *MockGenreService) GetGenre(ctx context.Context, genreID uuid.UUID) (*domain.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenre", ctx, genreID)
+	ret0, _ := ret[0].(*domain.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenre indicates an expected call of GetGenre.
+func (mr *MockGenreServiceMockRecorder) GetGenre(ctx, genreID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenre", reflect.TypeOf((*MockGenreService)(nil).GetGenre), ctx, genreID)
+}
+
+// GetGenres mocks base method.
+func (m *MockGenreService) GetGenres(ctx context.Context) ([]*domain.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenres", ctx)
+	ret0, _ := ret[0].([]*domain.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenres indicates an expected call of GetGenres.
+func (mr *MockGenreServiceMockRecorder) GetGenres(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockGenreService)(nil).GetGenres), ctx)
+}
+
+// UpdateGenre mocks base method.
+func (m *MockGenreService) UpdateGenre(ctx context.Context, genre *domain.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGenre", ctx, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGenre indicates an expected call of UpdateGenre.
+func (mr *MockGenreServiceMockRecorder) UpdateGenre(ctx, genre any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockGenreService)(nil).UpdateGenre), ctx, genre)
+}
@@ -56,17 +56,77 @@ func (mr *MockMovieServiceMockRecorder) CreateMovie(ctx, movie any) *gomock.Call
 }
 
 // DeleteMovie mocks base method.
-func (m *MockMovieService) DeleteMovie(ctx context.Context, movieID uuid.UUID) error {
+func (m *MockMovieService) DeleteMovie(ctx context.Context, movieID uuid.UUID, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMovie", ctx, movieID)
+	ret := m.ctrl.Call(m, "DeleteMovie", ctx, movieID, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteMovie indicates an expected call of DeleteMovie.
-func (mr *MockMovieServiceMockRecorder) DeleteMovie(ctx, movieID any) *gomock.Call {
+func (mr *MockMovieServiceMockRecorder) DeleteMovie(ctx, movieID, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieService)(nil).DeleteMovie), ctx, movieID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieService)(nil).DeleteMovie), ctx, movieID, version)
+}
+
+// DiffMovieVersions mocks base method.
+func (m *MockMovieService) DiffMovieVersions(ctx context.Context, movieID uuid.UUID, from, to int) (map[string]domain.AuditChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffMovieVersions", ctx, movieID, from, to)
+	ret0, _ := ret[0].(map[string]domain.AuditChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffMovieVersions indicates an expected call of DiffMovieVersions.
+func (mr *MockMovieServiceMockRecorder) DiffMovieVersions(ctx, movieID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffMovieVersions", reflect.TypeOf((*MockMovieService)(nil).DiffMovieVersions), ctx, movieID, from, to)
+}
+
+// GetGenreFacets mocks base method.
+func (m *MockMovieService) GetGenreFacets(ctx context.Context, filter domain.MovieFilter) ([]*domain.GenreFacet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenreFacets", ctx, filter)
+	ret0, _ := ret[0].([]*domain.GenreFacet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenreFacets indicates an expected call of GetGenreFacets.
+func (mr *MockMovieServiceMockRecorder) GetGenreFacets(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenreFacets", reflect.TypeOf((*MockMovieService)(nil).GetGenreFacets), ctx, filter)
+}
+
+// GetMovie mocks base method.
+func (m *MockMovieService) GetMovie(ctx context.Context, movieID uuid.UUID) (*domain.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovie", ctx, movieID)
+	ret0, _ := ret[0].(*domain.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovie indicates an expected call of GetMovie.
+func (mr *MockMovieServiceMockRecorder) GetMovie(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovie", reflect.TypeOf((*MockMovieService)(nil).GetMovie), ctx, movieID)
+}
+
+// GetMovieVersions mocks base method.
+func (m *MockMovieService) GetMovieVersions(ctx context.Context, movieID uuid.UUID) ([]*domain.EntityVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieVersions", ctx, movieID)
+	ret0, _ := ret[0].([]*domain.EntityVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieVersions indicates an expected call of GetMovieVersions.
+func (mr *MockMovieServiceMockRecorder) GetMovieVersions(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieVersions", reflect.TypeOf((*MockMovieService)(nil).GetMovieVersions), ctx, movieID)
 }
 
 // GetMoviesBySnippet mocks base method.
@@ -85,7 +145,7 @@ func (mr *MockMovieServiceMockRecorder) GetMoviesBySnippet(ctx, snippet any) *go
 }
 
 // GetMoviesFilter mocks base method.
-func (m *MockMovieService) GetMoviesFilter(ctx context.Context, filter string) ([]*domain.Movie, error) {
+func (m *MockMovieService) GetMoviesFilter(ctx context.Context, filter domain.MovieFilter) ([]*domain.Movie, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMoviesFilter", ctx, filter)
 	ret0, _ := ret[0].([]*domain.Movie)
@@ -99,6 +159,35 @@ func (mr *MockMovieServiceMockRecorder) GetMoviesFilter(ctx, filter any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviesFilter", reflect.TypeOf((*MockMovieService)(nil).GetMoviesFilter), ctx, filter)
 }
 
+// PatchMovie mocks base method.
+func (m *MockMovieService) PatchMovie(ctx context.Context, movieID uuid.UUID, version int, apply func(*domain.Movie) error) (*domain.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchMovie", ctx, movieID, version, apply)
+	ret0, _ := ret[0].(*domain.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchMovie indicates an expected call of PatchMovie.
+func (mr *MockMovieServiceMockRecorder) PatchMovie(ctx, movieID, version, apply any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchMovie", reflect.TypeOf((*MockMovieService)(nil).PatchMovie), ctx, movieID, version, apply)
+}
+
+// RestoreMovieVersion mocks base method.
+func (m *MockMovieService) RestoreMovieVersion(ctx context.Context, movieID uuid.UUID, number int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMovieVersion", ctx, movieID, number)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMovieVersion indicates an expected call of RestoreMovieVersion.
+func (mr *MockMovieServiceMockRecorder) RestoreMovieVersion(ctx, movieID, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMovieVersion", reflect.TypeOf((*MockMovieService)(nil).RestoreMovieVersion), ctx, movieID, number)
+}
+
 // UpdateMovie mocks base method.
 func (m *MockMovieService) UpdateMovie(ctx context.Context, movie *domain.Movie) error {
 	m.ctrl.T.Helper()
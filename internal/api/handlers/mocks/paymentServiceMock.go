@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: payment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=payment.go -destination=mocks/paymentServiceMock.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentService is a mock of PaymentService interface.
+type MockPaymentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentServiceMockRecorder
+}
+
+// MockPaymentServiceMockRecorder is the mock recorder for MockPaymentService.
+type MockPaymentServiceMockRecorder struct {
+	mock *MockPaymentService
+}
+
+// NewMockPaymentService creates a new mock instance.
+func NewMockPaymentService(ctrl *gomock.Controller) *MockPaymentService {
+	mock := &MockPaymentService{ctrl: ctrl}
+	mock.recorder = &MockPaymentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentService) EXPECT() *MockPaymentServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelPayment mocks base method.
+func (m *MockPaymentService) CancelPayment(ctx context.Context, bookingID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPayment", ctx, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelPayment indicates an expected call of CancelPayment.
+func (mr *MockPaymentServiceMockRecorder) CancelPayment(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPayment", reflect.TypeOf((*MockPaymentService)(nil).CancelPayment), ctx, bookingID)
+}
+
+// CapturePayment mocks base method.
+func (m *MockPaymentService) CapturePayment(ctx context.Context, bookingID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CapturePayment", ctx, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CapturePayment indicates an expected call of CapturePayment.
+func (mr *MockPaymentServiceMockRecorder) CapturePayment(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CapturePayment", reflect.TypeOf((*MockPaymentService)(nil).CapturePayment), ctx, bookingID)
+}
+
+// CreatePayment mocks base method.
+func (m *MockPaymentService) CreatePayment(ctx context.Context, bookingID uuid.UUID, amount int64, currency string) (*domain.Payment, *domain.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, bookingID, amount, currency)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(*domain.PaymentIntent)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockPaymentServiceMockRecorder) CreatePayment(ctx, bookingID, amount, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPaymentService)(nil).CreatePayment), ctx, bookingID, amount, currency)
+}
+
+// HandleWebhook mocks base method.
+func (m *MockPaymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebhook", ctx, payload, signature)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockPaymentServiceMockRecorder) HandleWebhook(ctx, payload, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*MockPaymentService)(nil).HandleWebhook), ctx, payload, signature)
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rating.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rating.go -destination=mocks/ratingServiceMock.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRatingService is a mock of RatingService interface.
+type MockRatingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingServiceMockRecorder
+}
+
+// MockRatingServiceMockRecorder is the mock recorder for MockRatingService.
+type MockRatingServiceMockRecorder struct {
+	mock *MockRatingService
+}
+
+// NewMockRatingService creates a new mock instance.
+func NewMockRatingService(ctrl *gomock.Controller) *MockRatingService {
+	mock := &MockRatingService{ctrl: ctrl}
+	mock.recorder = &MockRatingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingService) EXPECT() *MockRatingServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateReview mocks base method.
+func (m *MockRatingService) CreateReview(ctx context.Context, review *domain.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockRatingServiceMockRecorder) CreateReview(ctx, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockRatingService)(nil).CreateReview), ctx, review)
+}
+
+// DeleteRating mocks base method.
+func (m *MockRatingService) DeleteRating(ctx context.Context, userID, movieID uuid.UUID) (*domain.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRating", ctx, userID, movieID)
+	ret0, _ := ret[0].(*domain.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRating indicates an expected call of DeleteRating.
+func (mr *MockRatingServiceMockRecorder) DeleteRating(ctx, userID, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRating", reflect.TypeOf((*MockRatingService)(nil).DeleteRating), ctx, userID, movieID)
+}
+
+// GetMovieReviews mocks base method.
+func (m *MockRatingService) GetMovieReviews(ctx context.Context, movieID uuid.UUID, limit, offset int) ([]*domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieReviews", ctx, movieID, limit, offset)
+	ret0, _ := ret[0].([]*domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieReviews indicates an expected call of GetMovieReviews.
+func (mr *MockRatingServiceMockRecorder) GetMovieReviews(ctx, movieID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieReviews", reflect.TypeOf((*MockRatingService)(nil).GetMovieReviews), ctx, movieID, limit, offset)
+}
+
+// GetRatingSummary mocks base method.
+func (m *MockRatingService) GetRatingSummary(ctx context.Context, movieID uuid.UUID) (*domain.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingSummary", ctx, movieID)
+	ret0, _ := ret[0].(*domain.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingSummary indicates an expected call of GetRatingSummary.
+func (mr *MockRatingServiceMockRecorder) GetRatingSummary(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingSummary", reflect.TypeOf((*MockRatingService)(nil).GetRatingSummary), ctx, movieID)
+}
+
+// GetReviewsByStatus mocks base method.
+func (m *MockRatingService) GetReviewsByStatus(ctx context.Context, status string, limit, offset int) ([]*domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewsByStatus", ctx, status, limit, offset)
+	ret0, _ := ret[0].([]*domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewsByStatus indicates an expected call of GetReviewsByStatus.
+func (mr *MockRatingServiceMockRecorder) GetReviewsByStatus(ctx, status, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByStatus", reflect.TypeOf((*MockRatingService)(nil).GetReviewsByStatus), ctx, status, limit, offset)
+}
+
+// ModerateReview mocks base method.
+func (m *MockRatingService) ModerateReview(ctx context.Context, reviewID uuid.UUID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReview", ctx, reviewID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModerateReview indicates an expected call of ModerateReview.
+func (mr *MockRatingServiceMockRecorder) ModerateReview(ctx, reviewID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReview", reflect.TypeOf((*MockRatingService)(nil).ModerateReview), ctx, reviewID, status)
+}
+
+// RateMovie mocks base method.
+func (m *MockRatingService) RateMovie(ctx context.Context, rating *domain.Rating) (*domain.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateMovie", ctx, rating)
+	ret0, _ := ret[0].(*domain.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateMovie indicates an expected call of RateMovie.
+func (mr *MockRatingServiceMockRecorder) RateMovie(ctx, rating any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateMovie", reflect.TypeOf((*MockRatingService)(nil).RateMovie), ctx, rating)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: translation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=translation.go -destination=mocks/translationServiceMock.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTranslationService is a mock of TranslationService interface.
+type MockTranslationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationServiceMockRecorder
+}
+
+// MockTranslationServiceMockRecorder is the mock recorder for MockTranslationService.
+type MockTranslationServiceMockRecorder struct {
+	mock *MockTranslationService
+}
+
+// NewMockTranslationService creates a new mock instance.
+func NewMockTranslationService(ctrl *gomock.Controller) *MockTranslationService {
+	mock := &MockTranslationService{ctrl: ctrl}
+	mock.recorder = &MockTranslationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationService) EXPECT() *MockTranslationServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteActorTranslation mocks base method.
+func (m *MockTranslationService) DeleteActorTranslation(ctx context.Context, actorID uuid.UUID, locale string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActorTranslation", ctx, actorID, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActorTranslation indicates an expected call of DeleteActorTranslation.
+func (mr *MockTranslationServiceMockRecorder) DeleteActorTranslation(ctx, actorID, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActorTranslation", reflect.TypeOf((*MockTranslationService)(nil).DeleteActorTranslation), ctx, actorID, locale)
+}
+
+// DeleteMovieTranslation mocks base method.
+func (m *MockTranslationService) DeleteMovieTranslation(ctx context.Context, movieID uuid.UUID, locale string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovieTranslation", ctx, movieID, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMovieTranslation indicates an expected call of DeleteMovieTranslation.
+func (mr *MockTranslationServiceMockRecorder) DeleteMovieTranslation(ctx, movieID, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovieTranslation", reflect.TypeOf((*MockTranslationService)(nil).DeleteMovieTranslation), ctx, movieID, locale)
+}
+
+// GetActorTranslations mocks base method.
+func (m *MockTranslationService) GetActorTranslations(ctx context.Context, actorID uuid.UUID) ([]*domain.ActorTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorTranslations", ctx, actorID)
+	ret0, _ := ret[0].([]*domain.ActorTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorTranslations indicates an expected call of GetActorTranslations.
+func (mr *MockTranslationServiceMockRecorder) GetActorTranslations(ctx, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorTranslations", reflect.TypeOf((*MockTranslationService)(nil).GetActorTranslations), ctx, actorID)
+}
+
+// GetMovieTranslations mocks base method.
+func (m *MockTranslationService) GetMovieTranslations(ctx context.Context, movieID uuid.UUID) ([]*domain.MovieTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieTranslations", ctx, movieID)
+	ret0, _ := ret[0].([]*domain.MovieTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieTranslations indicates an expected call of GetMovieTranslations.
+func (mr *MockTranslationServiceMockRecorder) GetMovieTranslations(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieTranslations", reflect.TypeOf((*MockTranslationService)(nil).GetMovieTranslations), ctx, movieID)
+}
+
+// SetActorTranslation mocks base method.
+func (m *MockTranslationService) SetActorTranslation(ctx context.Context, translation *domain.ActorTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActorTranslation", ctx, translation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetActorTranslation indicates an expected call of SetActorTranslation.
+func (mr *MockTranslationServiceMockRecorder) SetActorTranslation(ctx, translation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActorTranslation", reflect.TypeOf((*MockTranslationService)(nil).SetActorTranslation), ctx, translation)
+}
+
+// SetMovieTranslation mocks base method.
+func (m *MockTranslationService) SetMovieTranslation(ctx context.Context, translation *domain.MovieTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMovieTranslation", ctx, translation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMovieTranslation indicates an expected call of SetMovieTranslation.
+func (mr *MockTranslationServiceMockRecorder) SetMovieTranslation(ctx, translation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMovieTranslation", reflect.TypeOf((*MockTranslationService)(nil).SetMovieTranslation), ctx, translation)
+}
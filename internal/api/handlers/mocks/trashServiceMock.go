@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: trash.go
+//
+// Generated by this command:
+//
+//	mockgen -source=trash.go -destination=mocks/trashServiceMock.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrashService is a mock of TrashService interface.
+type MockTrashService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashServiceMockRecorder
+}
+
+// MockTrashServiceMockRecorder is the mock recorder for MockTrashService.
+type MockTrashServiceMockRecorder struct {
+	mock *MockTrashService
+}
+
+// NewMockTrashService creates a new mock instance.
+func NewMockTrashService(ctrl *gomock.Controller) *MockTrashService {
+	mock := &MockTrashService{ctrl: ctrl}
+	mock.recorder = &MockTrashServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashService) EXPECT() *MockTrashServiceMockRecorder {
+	return m.recorder
+}
+
+// GetTrash mocks base method.
+func (m *MockTrashService) GetTrash(ctx context.Context) (*domain.Trash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", ctx)
+	ret0, _ := ret[0].(*domain.Trash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockTrashServiceMockRecorder) GetTrash(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockTrashService)(nil).GetTrash), ctx)
+}
+
+// RestoreActor mocks base method.
+func (m *MockTrashService) RestoreActor(ctx context.Context, actorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreActor", ctx, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreActor indicates an expected call of RestoreActor.
+func (mr *MockTrashServiceMockRecorder) RestoreActor(ctx, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreActor", reflect.TypeOf((*MockTrashService)(nil).RestoreActor), ctx, actorID)
+}
+
+// RestoreMovie mocks base method.
+func (m *MockTrashService) RestoreMovie(ctx context.Context, movieID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMovie", ctx, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMovie indicates an expected call of RestoreMovie.
+func (mr *MockTrashServiceMockRecorder) RestoreMovie(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMovie", reflect.TypeOf((*MockTrashService)(nil).RestoreMovie), ctx, movieID)
+}
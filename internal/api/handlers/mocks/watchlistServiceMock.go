@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: watchlist.go
+//
+// Generated by this command:
+//
+//	mockgen -source=watchlist.go -destination=mocks/watchlistServiceMock.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWatchlistService is a mock of WatchlistService interface.
+type MockWatchlistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatchlistServiceMockRecorder
+}
+
+// MockWatchlistServiceMockRecorder is the mock recorder for MockWatchlistService.
+type MockWatchlistServiceMockRecorder struct {
+	mock *MockWatchlistService
+}
+
+// NewMockWatchlistService creates a new mock instance.
+func NewMockWatchlistService(ctrl *gomock.Controller) *MockWatchlistService {
+	mock := &MockWatchlistService{ctrl: ctrl}
+	mock.recorder = &MockWatchlistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatchlistService) EXPECT() *MockWatchlistServiceMockRecorder {
+	return m.recorder
+}
+
+// AddFavoriteActor mocks base method.
+func (m *MockWatchlistService) AddFavoriteActor(ctx context.Context, userID, actorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavoriteActor", ctx, userID, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFavoriteActor indicates an expected call of AddFavoriteActor.
+func (mr *MockWatchlistServiceMockRecorder) AddFavoriteActor(ctx, userID, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavoriteActor", reflect.TypeOf((*MockWatchlistService)(nil).AddFavoriteActor), ctx, userID, actorID)
+}
+
+// AddToWatchlist mocks base method.
+func (m *MockWatchlistService) AddToWatchlist(ctx context.Context, userID, movieID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWatchlist", ctx, userID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToWatchlist indicates an expected call of AddToWatchlist.
+func (mr *MockWatchlistServiceMockRecorder) AddToWatchlist(ctx, userID, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWatchlist", reflect.TypeOf((*MockWatchlistService)(nil).AddToWatchlist), ctx, userID, movieID)
+}
+
+// GetFavoriteActors mocks base method.
+func (m *MockWatchlistService) GetFavoriteActors(ctx context.Context, userID uuid.UUID) ([]*domain.FavoriteActor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavoriteActors", ctx, userID)
+	ret0, _ := ret[0].([]*domain.FavoriteActor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavoriteActors indicates an expected call of GetFavoriteActors.
+func (mr *MockWatchlistServiceMockRecorder) GetFavoriteActors(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavoriteActors", reflect.TypeOf((*MockWatchlistService)(nil).GetFavoriteActors), ctx, userID)
+}
+
+// GetWatchlist mocks base method.
+func (m *MockWatchlistService) GetWatchlist(ctx context.Context, userID uuid.UUID) ([]*domain.WatchlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlist", ctx, userID)
+	ret0, _ := ret[0].([]*domain.WatchlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlist indicates an expected call of GetWatchlist.
+func (mr *MockWatchlistServiceMockRecorder) GetWatchlist(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlist", reflect.TypeOf((*MockWatchlistService)(nil).GetWatchlist), ctx, userID)
+}
+
+// RemoveFavoriteActor mocks base method.
+func (m *MockWatchlistService) RemoveFavoriteActor(ctx context.Context, userID, actorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFavoriteActor", ctx, userID, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFavoriteActor indicates an expected call of RemoveFavoriteActor.
+func (mr *MockWatchlistServiceMockRecorder) RemoveFavoriteActor(ctx, userID, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavoriteActor", reflect.TypeOf((*MockWatchlistService)(nil).RemoveFavoriteActor), ctx, userID, actorID)
+}
+
+// RemoveFromWatchlist mocks base method.
+func (m *MockWatchlistService) RemoveFromWatchlist(ctx context.Context, userID, movieID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWatchlist", ctx, userID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromWatchlist indicates an expected call of RemoveFromWatchlist.
+func (mr *MockWatchlistServiceMockRecorder) RemoveFromWatchlist(ctx, userID, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWatchlist", reflect.TypeOf((*MockWatchlistService)(nil).RemoveFromWatchlist), ctx, userID, movieID)
+}
@@ -3,13 +3,24 @@ package models
 import (
 	"cinema_service/internal/domain"
 	"time"
+
+	"github.com/google/uuid"
 )
 
+// Actor is the actor object of writes, patched as a whole by PATCH
+// requests.
 type Actor struct {
-	Name      string    `json:"name,omitempty"`
-	Surname   string    `json:"surname,omitempty"`
-	Sex       string    `json:"sex,omitempty"`
-	Birthdate time.Time `json:"birthdate,omitempty"`
+	Name      string    `json:"name"`
+	Surname   string    `json:"surname"`
+	Sex       string    `json:"sex"`
+	Birthdate time.Time `json:"birthdate"`
+}
+
+// NewActor is the actor object of creates. A new ID is generated when it is
+// omitted.
+type NewActor struct {
+	ID uuid.UUID `json:"id"`
+	Actor
 }
 
 type ActorMovies struct {
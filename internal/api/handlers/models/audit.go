@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditRecord struct {
+	ID uuid.UUID `json:"id"`
+	// UserID is null for changes made without a user.
+	UserID    *uuid.UUID             `json:"user_id"`
+	Action    string                 `json:"action"`
+	Entity    string                 `json:"entity"`
+	EntityID  uuid.UUID              `json:"entity_id"`
+	Changes   map[string]AuditChange `json:"changes"`
+	RequestID string                 `json:"request_id"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreditInput struct {
+	MovieID      uuid.UUID `json:"movie_id"`
+	PersonID     uuid.UUID `json:"person_id"`
+	Role         string    `json:"role" enums:"ACTOR,DIRECTOR,WRITER,PRODUCER"`
+	Character    string    `json:"character,omitempty"`
+	BillingOrder int       `json:"billing_order,omitempty"`
+}
+
+// CreditRecord is a single credit, as created or retrieved by its ID.
+type CreditRecord struct {
+	ID uuid.UUID `json:"id"`
+	CreditInput
+}
+
+type Credit struct {
+	ID           uuid.UUID `json:"id"`
+	PersonID     uuid.UUID `json:"person_id"`
+	Name         string    `json:"name"`
+	Surname      string    `json:"surname"`
+	Character    string    `json:"character,omitempty"`
+	BillingOrder int       `json:"billing_order,omitempty"`
+}
+
+type FilmographyEntry struct {
+	ID        uuid.UUID `json:"id"`
+	MovieID   uuid.UUID `json:"movie_id"`
+	Title     string    `json:"title"`
+	Date      time.Time `json:"date" format:"2006-01-02"`
+	Rating    float32   `json:"rating"`
+	Character string    `json:"character,omitempty"`
+}
+
+// CreditGroup holds the credits of one role of a movie.
+type CreditGroup struct {
+	Role    string   `json:"role"`
+	Credits []Credit `json:"credits"`
+}
+
+// FilmographyGroup holds the movies a person made in one role.
+type FilmographyGroup struct {
+	Role   string             `json:"role"`
+	Movies []FilmographyEntry `json:"movies"`
+}
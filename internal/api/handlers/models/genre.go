@@ -0,0 +1,12 @@
+package models
+
+import "github.com/google/uuid"
+
+type GenreInput struct {
+	Name string `json:"name"`
+}
+
+type Genre struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
@@ -2,11 +2,38 @@ package models
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
+// Movie is the movie object of writes. PATCH requests patch all of its
+// members, so zero values are not omitted. The rating is computed from the
+// votes of users and cannot be written.
 type Movie struct {
-	Title       string    `json:"title,omitempty"`
-	Description string    `json:"description,omitempty"`
-	Date        time.Time `json:"date,omitempty" format:"2006-01-02"`
-	Rating      float32   `json:"rating,omitempty"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Date        time.Time `json:"date" format:"2006-01-02"`
+
+	DurationMinutes  int      `json:"duration_minutes"`
+	AgeRating        string   `json:"age_rating" enums:"0+,6+,12+,16+,18+"`
+	Countries        []string `json:"countries" example:"RU,US"`
+	OriginalLanguage string   `json:"original_language" example:"ru"`
+	IMDbID           string   `json:"imdb_id" example:"tt0133093"`
+	TMDBID           int      `json:"tmdb_id"`
+	// Genres replaces the genres of the movie; omit it or send null to keep
+	// them as is.
+	Genres []uuid.UUID `json:"genres"`
+}
+
+// NewMovie is the movie object of creates. A new ID is generated when it is
+// omitted.
+type NewMovie struct {
+	ID uuid.UUID `json:"id"`
+	Movie
+}
+
+type GenreFacet struct {
+	GenreID uuid.UUID `json:"genre_id"`
+	Name    string    `json:"name"`
+	Count   int       `json:"count"`
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentInput struct {
+	BookingID uuid.UUID `json:"booking_id"`
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+}
+
+type Payment struct {
+	ID           uuid.UUID `json:"id"`
+	BookingID    uuid.UUID `json:"booking_id"`
+	IntentID     string    `json:"intent_id"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+	Amount       int64     `json:"amount"`
+	Currency     string    `json:"currency"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RatingInput struct {
+	Score int `json:"score"`
+}
+
+type RatingSummary struct {
+	MovieID uuid.UUID `json:"movie_id"`
+	Rating  float32   `json:"rating"`
+	Votes   int       `json:"votes"`
+}
+
+type ReviewInput struct {
+	Text string `json:"text"`
+}
+
+type ReviewStatusInput struct {
+	Status string `json:"status"`
+}
+
+type Review struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	MovieID   uuid.UUID `json:"movie_id"`
+	Text      string    `json:"text"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
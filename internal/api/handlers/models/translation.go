@@ -0,0 +1,23 @@
+package models
+
+type MovieTranslationInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+type MovieTranslation struct {
+	Lang        string `json:"lang" example:"en"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+type ActorTranslationInput struct {
+	Name    string `json:"name"`
+	Surname string `json:"surname,omitempty"`
+}
+
+type ActorTranslation struct {
+	Lang    string `json:"lang" example:"en"`
+	Name    string `json:"name"`
+	Surname string `json:"surname,omitempty"`
+}
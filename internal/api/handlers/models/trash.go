@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Trash struct {
+	Movies []TrashedMovie `json:"movies"`
+	Actors []TrashedActor `json:"actors"`
+}
+
+type TrashedMovie struct {
+	MovieID     uuid.UUID `json:"movie_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Date        time.Time `json:"date" format:"2006-01-02"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+type TrashedActor struct {
+	ActorID   uuid.UUID `json:"actor_id"`
+	Name      string    `json:"name"`
+	Surname   string    `json:"surname"`
+	Sex       string    `json:"sex"`
+	Birthdate time.Time `json:"birthdate"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EntityVersion struct {
+	Version int `json:"version"`
+	// UserID is null when the author of the version is unknown.
+	UserID    *uuid.UUID      `json:"user_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	State     json.RawMessage `json:"state" swaggertype:"object"`
+}
+
+type VersionDiff struct {
+	From    int                    `json:"from"`
+	To      int                    `json:"to"`
+	Changes map[string]AuditChange `json:"changes"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WatchlistEntry struct {
+	MovieID     uuid.UUID `json:"movie_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Date        time.Time `json:"date" format:"2006-01-02"`
+	Rating      float32   `json:"rating"`
+	AddedAt     time.Time `json:"added_at"`
+}
+
+type FavoriteActor struct {
+	ActorID   uuid.UUID `json:"actor_id"`
+	Name      string    `json:"name"`
+	Surname   string    `json:"surname"`
+	Sex       string    `json:"sex"`
+	Birthdate time.Time `json:"birthdate"`
+	AddedAt   time.Time `json:"added_at"`
+}
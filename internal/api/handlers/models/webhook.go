@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookSubscriptionInput struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	// Secret signs the deliveries. A random secret is generated if it is
+	// empty.
+	Secret string `json:"secret"`
+}
+
+type WebhookSubscription struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	// Secret is only returned when the subscription is created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Body           json.RawMessage `json:"body" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	// ResponseStatus is 0 if no response was received.
+	ResponseStatus int       `json:"response_status"`
+	LastError      string    `json:"last_error"`
+	CreatedAt      time.Time `json:"created_at"`
+	// DeliveredAt is null until the subscriber accepts the delivery.
+	DeliveredAt *time.Time `json:"delivered_at"`
+}
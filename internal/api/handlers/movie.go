@@ -3,9 +3,12 @@ package handlers
 import (
 	"cinema_service/internal/api/handlers/models"
 	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 )
@@ -13,11 +16,18 @@ import (
 //go:generate mockgen -source=movie.go -destination=mocks/movieServiceMock.go
 
 type MovieService interface {
+	GetMovie(ctx context.Context, movieID uuid.UUID) (*domain.Movie, error)
 	CreateMovie(ctx context.Context, movie *domain.Movie) error
 	UpdateMovie(ctx context.Context, movie *domain.Movie) error
-	DeleteMovie(ctx context.Context, movieID uuid.UUID) error
-	GetMoviesFilter(ctx context.Context, filter string) ([]*domain.Movie, error)
+	PatchMovie(ctx context.Context, movieID uuid.UUID, version int,
+		apply func(movie *domain.Movie) error) (*domain.Movie, error)
+	DeleteMovie(ctx context.Context, movieID uuid.UUID, version int) error
+	GetMoviesFilter(ctx context.Context, filter domain.MovieFilter) ([]*domain.Movie, error)
+	GetGenreFacets(ctx context.Context, filter domain.MovieFilter) ([]*domain.GenreFacet, error)
 	GetMoviesBySnippet(ctx context.Context, snippet string) ([]*domain.Movie, error)
+	GetMovieVersions(ctx context.Context, movieID uuid.UUID) ([]*domain.EntityVersion, error)
+	DiffMovieVersions(ctx context.Context, movieID uuid.UUID, from int, to int) (map[string]domain.AuditChange, error)
+	RestoreMovieVersion(ctx context.Context, movieID uuid.UUID, number int) error
 }
 
 type MovieHandler struct {
@@ -28,40 +38,71 @@ func NewMovieHandler(service MovieService) *MovieHandler {
 	return &MovieHandler{service: service}
 }
 
+// GetMovieHandler retrieves a movie.
+// @Summary Get Movie
+// @Description Retrieves a movie with its genres. The ETag holds the version of the movie and a hash of the response, which also changes with the language, translations, genres and credits; send it in If-Match to update or delete the movie only if nobody changed it since.
+// @Tags Movies
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Success 200 {object} models.Movie
+// @Success 304 "Not modified"
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies [get]
+func (h *MovieHandler) GetMovieHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	movie, err := h.service.GetMovie(r.Context(), movieID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Movie not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get movie")
+		return
+	}
+
+	sendJSONWithETag(w, r, movie.Version, movie)
+}
+
 // CreateMovieHandler creates a new movie.
 // @Summary Create Movie
 // @Description Creates a new movie
 // @Tags Movies
 // @Accept json
 // @Security ApiKeyAuth
-// @Param movie body models.Movie true "Movie object"
-// @Success 201 {object} statusResponse
+// @Param Idempotency-Key header string false "Key that makes retries of the request replay its response"
+// @Param movie body models.NewMovie true "Movie object"
+// @Success 201 {object} domain.Movie
+// @Header 201 {string} Location "URL of the movie"
+// @Header 201 {string} ETag "Version of the movie"
 // @Failure 400 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 422 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Router /movies [post]
 func (h *MovieHandler) CreateMovieHandler(w http.ResponseWriter, r *http.Request) {
-	var input models.Movie
+	var input models.NewMovie
 	err := json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
 		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
 		return
 	}
 
-	movie := &domain.Movie{
-		Title:       input.Title,
-		Description: input.Description,
-		Date:        input.Date,
-		Rating:      input.Rating,
-	}
+	movie := movieFromInput(input.ID, input.Movie)
 	err = h.service.CreateMovie(r.Context(), movie)
 	if err != nil {
-		NewErrorResponse(w, http.StatusInternalServerError, "Failed to create movie")
+		writeMovieError(w, err, "Failed to create movie")
 		return
 	}
 
-	sendJSONResponse(w, http.StatusCreated, statusResponse{
-		Status: "Movie created successfully",
-	})
+	sendCreated(w, "/api/v1/movies?id="+movie.ID.String(), versionETag(movie.Version), movie)
 }
 
 // UpdateMovieHandler @Summary Update Movie
@@ -70,9 +111,13 @@ func (h *MovieHandler) CreateMovieHandler(w http.ResponseWriter, r *http.Request
 // @Accept json
 // @Security ApiKeyAuth
 // @Param id query string true "Movie ID"
+// @Param If-Match header string false "ETag of the version to update"
 // @Param movie body models.Movie true "Movie object"
 // @Success 200 {object} statusResponse
 // @Failure 400 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 412 {object} errorResponse
+// @Failure 428 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Router /movies [put]
 func (h *MovieHandler) UpdateMovieHandler(w http.ResponseWriter, r *http.Request) {
@@ -88,39 +133,113 @@ func (h *MovieHandler) UpdateMovieHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	version, ok := parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	var input models.Movie
 	err = json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
 		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
 		return
 	}
-	movie := &domain.Movie{
-		ID:          id,
-		Title:       input.Title,
-		Description: input.Description,
-		Date:        input.Date,
-		Rating:      input.Rating,
-	}
+	movie := movieFromInput(id, input)
+	movie.Version = version
 
 	err = h.service.UpdateMovie(r.Context(), movie)
+	if errors.Is(err, domain.ErrNotFound) {
+		writeMissingTarget(w, r, "Movie not found")
+		return
+	}
 	if err != nil {
-		NewErrorResponse(w, http.StatusInternalServerError, "Failed to update movie")
+		writeMovieError(w, err, "Failed to update movie")
 		return
 	}
+	if movie.Version != 0 {
+		w.Header().Set("ETag", versionETag(movie.Version))
+	}
 
 	sendJSONResponse(w, http.StatusOK, statusResponse{
 		Status: "Movie updated successfully",
 	})
 }
 
+// PatchMovieHandler partially updates a movie.
+// @Summary Patch Movie
+// @Description Applies a JSON Merge Patch (application/merge-patch+json) or a JSON Patch (application/json-patch+json) to the movie object and stores the fields that changed
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Movie ID"
+// @Param If-Match header string false "ETag of the version to update"
+// @Param patch body object true "Patch of a models.Movie"
+// @Success 200 {object} models.Movie
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 412 {object} errorResponse
+// @Failure 415 {object} errorResponse
+// @Failure 428 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/{id} [patch]
+func (h *MovieHandler) PatchMovieHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid movie ID")
+		return
+	}
+
+	version, ok := parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	patch, ok := readPatch(w, r)
+	if !ok {
+		return
+	}
+
+	movie, err := h.service.PatchMovie(r.Context(), id, version, func(movie *domain.Movie) error {
+		var input models.Movie
+		if err := patch(movieToInput(movie), &input); err != nil {
+			return err
+		}
+		patched := movieFromInput(movie.ID, input)
+		patched.Rating = movie.Rating
+		patched.Version = movie.Version
+		*movie = *patched
+		return nil
+	})
+	if err != nil {
+		if writePatchError(w, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Movie not found")
+			return
+		}
+		writeMovieError(w, err, "Failed to patch movie")
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(movie.Version))
+	sendJSONResponse(w, http.StatusOK, movie)
+}
+
 // DeleteMovieHandler
 // @Summary Delete Movie
-// @Description Deletes a movie
+// @Description Moves a movie to the trash, from where it can be restored until it is purged
 // @Tags Movies
 // @Security ApiKeyAuth
 // @Param id query string true "Movie ID"
+// @Param If-Match header string false "ETag of the version to delete"
 // @Success 200 {object} statusResponse
 // @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 412 {object} errorResponse
+// @Failure 428 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Router /movies [delete]
 func (h *MovieHandler) DeleteMovieHandler(w http.ResponseWriter, r *http.Request) {
@@ -136,9 +255,21 @@ func (h *MovieHandler) DeleteMovieHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = h.service.DeleteMovie(r.Context(), movieID)
+	version, ok := parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	err = h.service.DeleteMovie(r.Context(), movieID, version)
 	if err != nil {
-		NewErrorResponse(w, http.StatusInternalServerError, "Failed to delete movie")
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			NewErrorResponse(w, http.StatusNotFound, "Movie not found")
+		case errors.Is(err, domain.ErrVersionConflict):
+			NewErrorResponse(w, http.StatusPreconditionFailed, "Movie was changed since the If-Match version")
+		default:
+			NewErrorResponse(w, http.StatusInternalServerError, "Failed to delete movie")
+		}
 		return
 	}
 
@@ -153,7 +284,15 @@ func (h *MovieHandler) DeleteMovieHandler(w http.ResponseWriter, r *http.Request
 // @Tags Movies
 // @Security ApiKeyAuth
 // @Param filter query string true "Filter"
+// @Param genre query []string false "Genre IDs, a movie must have all of them" collectionFormat(multi)
+// @Param country query string false "Production country (ISO 3166-1 alpha-2)"
+// @Param language query string false "Original language (ISO 639-1)"
+// @Param max_age_rating query string false "Highest age rating" Enums(0+, 6+, 12+, 16+, 18+)
+// @Param min_duration query int false "Minimum duration in minutes"
+// @Param max_duration query int false "Maximum duration in minutes"
+// @Param If-None-Match header string false "ETag of a cached copy"
 // @Success 200 {array} models.Movie
+// @Success 304 "Not modified"
 // @Failure 500 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Router /movies/filter [get]
@@ -165,14 +304,170 @@ func (h *MovieHandler) GetMoviesFilterHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	movies, err := h.service.GetMoviesFilter(r.Context(), filter)
+	movieFilter, ok := parseMovieFilter(w, r)
+	if !ok {
+		return
+	}
+	movieFilter.Sort = filter
+
+	movies, err := h.service.GetMoviesFilter(r.Context(), movieFilter)
 	if err != nil {
 		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get movies")
 		return
 	}
 
-	sendJSONResponse(w, http.StatusOK, movies)
+	sendJSONWithETag(w, r, 0, movies)
+
+}
+
+// GetGenreFacetsHandler counts movies per genre.
+// @Summary Get Genre Facets
+// @Description Counts movies of each genre among the movies matching the filter
+// @Tags Movies
+// @Produce json
+// @Security ApiKeyAuth
+// @Param genre query []string false "Genre IDs, a movie must have all of them" collectionFormat(multi)
+// @Param country query string false "Production country (ISO 3166-1 alpha-2)"
+// @Param language query string false "Original language (ISO 639-1)"
+// @Param max_age_rating query string false "Highest age rating" Enums(0+, 6+, 12+, 16+, 18+)
+// @Param min_duration query int false "Minimum duration in minutes"
+// @Param max_duration query int false "Maximum duration in minutes"
+// @Success 200 {array} models.GenreFacet
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/facets [get]
+func (h *MovieHandler) GetGenreFacetsHandler(w http.ResponseWriter, r *http.Request) {
+	movieFilter, ok := parseMovieFilter(w, r)
+	if !ok {
+		return
+	}
+
+	facets, err := h.service.GetGenreFacets(r.Context(), movieFilter)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get genre facets")
+		return
+	}
+
+	result := make([]models.GenreFacet, 0, len(facets))
+	for _, facet := range facets {
+		result = append(result, models.GenreFacet{
+			GenreID: facet.Genre.ID,
+			Name:    facet.Genre.Name,
+			Count:   facet.Count,
+		})
+	}
 
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+// parseMovieFilter reads the listing filters shared by the movie listing
+// and facet endpoints. The sort order is left to the caller.
+func parseMovieFilter(w http.ResponseWriter, r *http.Request) (domain.MovieFilter, bool) {
+	query := r.URL.Query()
+	filter := domain.MovieFilter{
+		Country:      query.Get("country"),
+		Language:     query.Get("language"),
+		MaxAgeRating: query.Get("max_age_rating"),
+	}
+
+	for _, value := range query["genre"] {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			NewErrorResponse(w, http.StatusBadRequest, "Invalid genre ID")
+			return filter, false
+		}
+		filter.GenreIDs = append(filter.GenreIDs, id)
+	}
+
+	if filter.MaxAgeRating != "" && domain.AgeRatingRank(filter.MaxAgeRating) < 0 {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid age rating")
+		return filter, false
+	}
+
+	for param, dest := range map[string]*int{
+		"min_duration": &filter.MinDuration,
+		"max_duration": &filter.MaxDuration,
+	} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			NewErrorResponse(w, http.StatusBadRequest, "Invalid "+param+" parameter")
+			return filter, false
+		}
+		*dest = parsed
+	}
+
+	return filter, true
+}
+
+func movieFromInput(id uuid.UUID, input models.Movie) *domain.Movie {
+	return &domain.Movie{
+		ID:               id,
+		Title:            input.Title,
+		Description:      input.Description,
+		Date:             input.Date,
+		DurationMinutes:  input.DurationMinutes,
+		AgeRating:        input.AgeRating,
+		Countries:        input.Countries,
+		OriginalLanguage: input.OriginalLanguage,
+		IMDbID:           input.IMDbID,
+		TMDBID:           input.TMDBID,
+		Genres:           toGenres(input.Genres),
+	}
+}
+
+// movieToInput returns the movie object of a movie, which PATCH requests
+// patch.
+func movieToInput(movie *domain.Movie) models.Movie {
+	genres := make([]uuid.UUID, 0, len(movie.Genres))
+	for _, genre := range movie.Genres {
+		genres = append(genres, genre.ID)
+	}
+	return models.Movie{
+		Title:            movie.Title,
+		Description:      movie.Description,
+		Date:             movie.Date,
+		DurationMinutes:  movie.DurationMinutes,
+		AgeRating:        movie.AgeRating,
+		Countries:        movie.Countries,
+		OriginalLanguage: movie.OriginalLanguage,
+		IMDbID:           movie.IMDbID,
+		TMDBID:           movie.TMDBID,
+		Genres:           genres,
+	}
+}
+
+// writeMovieError maps errors of movie writes to responses.
+func writeMovieError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidMovie):
+		NewErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrUnknownGenre):
+		NewErrorResponse(w, http.StatusBadRequest, "Unknown genre")
+	case errors.Is(err, domain.ErrNotFound):
+		NewErrorResponse(w, http.StatusNotFound, "Movie not found")
+	case errors.Is(err, domain.ErrAlreadyExists):
+		NewErrorResponse(w, http.StatusConflict, "Movie with this ID or external ID already exists")
+	case errors.Is(err, domain.ErrVersionConflict):
+		NewErrorResponse(w, http.StatusPreconditionFailed, "Movie was changed since the If-Match version")
+	default:
+		NewErrorResponse(w, http.StatusInternalServerError, message)
+	}
+}
+
+func toGenres(ids []uuid.UUID) []*domain.Genre {
+	if ids == nil {
+		return nil
+	}
+
+	genres := make([]*domain.Genre, 0, len(ids))
+	for _, id := range ids {
+		genres = append(genres, &domain.Genre{ID: id})
+	}
+	return genres
 }
 
 // GetMoviesBySnippetHandler retrieves movies based on a snippet.
@@ -181,7 +476,9 @@ func (h *MovieHandler) GetMoviesFilterHandler(w http.ResponseWriter, r *http.Req
 // @Tags Movies
 // @Security ApiKeyAuth
 // @Param snippet query string true "Snippet"
+// @Param If-None-Match header string false "ETag of a cached copy"
 // @Success 200 {array} models.Movie
+// @Success 304 "Not modified"
 // @Failure 500 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Router /movies/snippet [get]
@@ -199,16 +496,135 @@ func (h *MovieHandler) GetMoviesBySnippetHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	sendJSONResponse(w, http.StatusOK, movies)
+	sendJSONWithETag(w, r, 0, movies)
+}
+
+// GetMovieVersionsHandler lists the versions of a movie.
+// @Summary Get Movie Versions
+// @Description Lists the versions of a movie kept on every update, newest first
+// @Tags Movies
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Success 200 {array} models.EntityVersion
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/versions [get]
+func (h *MovieHandler) GetMovieVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	versions, err := h.service.GetMovieVersions(r.Context(), movieID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get movie versions")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, toVersionModels(versions))
+}
+
+// DiffMovieVersionsHandler compares two versions of a movie.
+// @Summary Diff Movie Versions
+// @Description Returns the fields that differ between two versions of a movie
+// @Tags Movies
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Param from query int true "Version to compare from"
+// @Param to query int true "Version to compare to"
+// @Success 200 {object} models.VersionDiff
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/versions/diff [get]
+func (h *MovieHandler) DiffMovieVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+	from, ok := parseVersionParam(w, r, "from")
+	if !ok {
+		return
+	}
+	to, ok := parseVersionParam(w, r, "to")
+	if !ok {
+		return
+	}
+
+	changes, err := h.service.DiffMovieVersions(r.Context(), movieID, from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Movie version not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to diff movie versions")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, models.VersionDiff{From: from, To: to, Changes: toChangeModels(changes)})
+}
+
+// RestoreMovieVersionHandler reverts a movie to one of its versions.
+// @Summary Restore Movie Version
+// @Description Updates a movie to the state of one of its versions. The rating is kept.
+// @Tags Movies
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Param version query int true "Version to restore"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/versions/restore [post]
+func (h *MovieHandler) RestoreMovieVersionHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+	number, ok := parseVersionParam(w, r, "version")
+	if !ok {
+		return
+	}
+
+	err := h.service.RestoreMovieVersion(r.Context(), movieID, number)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUnknownGenre):
+			NewErrorResponse(w, http.StatusConflict, "A genre of the movie version no longer exists")
+		case errors.Is(err, domain.ErrNotFound):
+			NewErrorResponse(w, http.StatusNotFound, "Movie version not found")
+		case errors.Is(err, domain.ErrAlreadyExists):
+			NewErrorResponse(w, http.StatusConflict, "Movie with this external ID already exists")
+		default:
+			NewErrorResponse(w, http.StatusInternalServerError, "Failed to restore movie version")
+		}
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Movie version restored successfully",
+	})
 }
 
 // TODO: authorization
+// RegisterMovie adds the movie routes to mux. ifMatch guards the updates
+// and deletes, see middleware.RequireIfMatch; cacheControl marks the reads
+// that are the same for every user, see middleware.CacheControl.
 func (h *MovieHandler) RegisterMovie(mux *http.ServeMux,
-	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	authentication Middleware, authorization Middleware, logging Middleware, ifMatch Middleware,
+	idempotent Middleware, cacheControl Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/movies", logging(authentication(cacheControl(h.GetMovieHandler))))
 	mux.HandleFunc("GET /api/v1/movies/filter", logging(authentication(h.GetMoviesFilterHandler)))
 	mux.HandleFunc("GET /api/v1/movies/snippet", logging(authentication(h.GetMoviesBySnippetHandler)))
-	mux.HandleFunc("POST /api/v1/movies", logging(authentication(authorization(h.CreateMovieHandler))))
-	mux.HandleFunc("PUT /api/v1/movies", logging(authentication(authorization(h.UpdateMovieHandler))))
-	mux.HandleFunc("DELETE /api/v1/movies", logging(authentication(authorization(h.DeleteMovieHandler))))
+	mux.HandleFunc("GET /api/v1/movies/facets", logging(authentication(h.GetGenreFacetsHandler)))
+	mux.HandleFunc("POST /api/v1/movies", logging(authentication(authorization(idempotent(h.CreateMovieHandler)))))
+	mux.HandleFunc("PUT /api/v1/movies", logging(authentication(authorization(ifMatch(h.UpdateMovieHandler)))))
+	mux.HandleFunc("PATCH /api/v1/movies/{id}", logging(authentication(authorization(ifMatch(h.PatchMovieHandler)))))
+	mux.HandleFunc("DELETE /api/v1/movies", logging(authentication(authorization(ifMatch(h.DeleteMovieHandler)))))
+	mux.HandleFunc("GET /api/v1/movies/versions", logging(authentication(authorization(h.GetMovieVersionsHandler))))
+	mux.HandleFunc("GET /api/v1/movies/versions/diff", logging(authentication(authorization(h.DiffMovieVersionsHandler))))
+	mux.HandleFunc("POST /api/v1/movies/versions/restore", logging(authentication(authorization(h.RestoreMovieVersionHandler))))
 	return mux
 }
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"bytes"
+	"cinema_service/internal/jsonpatch"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+const (
+	mergePatchType = "application/merge-patch+json"
+	jsonPatchType  = "application/json-patch+json"
+)
+
+// patcher applies the patch of a request to the JSON encoding of doc and
+// decodes the result into v. Members that v does not have make the patch
+// invalid.
+type patcher func(doc any, v any) error
+
+// readPatch reads the body of a PATCH request, a JSON Merge Patch or a JSON
+// Patch according to its Content-Type. It writes a 415 response for other
+// formats.
+func readPatch(w http.ResponseWriter, r *http.Request) (patcher, bool) {
+	var apply func(doc []byte, patch []byte) ([]byte, error)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case mergePatchType:
+		apply = jsonpatch.MergePatch
+	case jsonPatchType:
+		apply = jsonpatch.Apply
+	default:
+		w.Header().Set("Accept-Patch", mergePatchType+", "+jsonPatchType)
+		NewErrorResponse(w, http.StatusUnsupportedMediaType, "Unsupported patch format")
+		return nil, false
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return nil, false
+	}
+
+	return func(doc any, v any) error {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		if data, err = apply(data, patch); err != nil {
+			return err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err = decoder.Decode(v); err != nil {
+			return fmt.Errorf("%w: %v", jsonpatch.ErrInvalidPatch, err)
+		}
+		return nil
+	}, true
+}
+
+// writePatchError writes the response for an error of applying a patch and
+// reports whether err was one.
+func writePatchError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		NewErrorResponse(w, http.StatusConflict, "Patch test failed")
+	case errors.Is(err, jsonpatch.ErrInvalidPatch):
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid patch document")
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=payment.go -destination=mocks/paymentServiceMock.go
+
+const paymentSignatureHeader = "X-Payment-Signature"
+
+type PaymentService interface {
+	CreatePayment(ctx context.Context, bookingID uuid.UUID, amount int64, currency string) (*domain.Payment, *domain.PaymentIntent, error)
+	CapturePayment(ctx context.Context, bookingID uuid.UUID) error
+	CancelPayment(ctx context.Context, bookingID uuid.UUID) error
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+}
+
+type PaymentHandler struct {
+	service PaymentService
+}
+
+func NewPaymentHandler(service PaymentService) *PaymentHandler {
+	return &PaymentHandler{service: service}
+}
+
+// CreatePaymentHandler starts a payment for a booking.
+// @Summary Create Payment
+// @Description Creates a payment intent for a booking. The current user becomes the owner of the payment. The amount is not checked against the booking here: an admin confirms it when capturing the payment. A booking has a single payment.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param payment body models.PaymentInput true "Payment object"
+// @Success 201 {object} models.Payment
+// @Failure 400 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /payments [post]
+func (h *PaymentHandler) CreatePaymentHandler(w http.ResponseWriter, r *http.Request) {
+	var input models.PaymentInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if input.BookingID == uuid.Nil || input.Amount <= 0 || len(input.Currency) != 3 {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	payment, intent, err := h.service.CreatePayment(r.Context(), input.BookingID, input.Amount, input.Currency)
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		NewErrorResponse(w, http.StatusConflict, "Booking already has a payment")
+		return
+	}
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to create payment")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusCreated, models.Payment{
+		ID:           payment.ID,
+		BookingID:    payment.BookingID,
+		IntentID:     payment.IntentID,
+		ClientSecret: intent.ClientSecret,
+		Amount:       payment.Amount,
+		Currency:     payment.Currency,
+		Status:       payment.Status,
+		CreatedAt:    payment.CreatedAt,
+	})
+}
+
+// CapturePaymentHandler captures a pending payment.
+// @Summary Capture Payment
+// @Description Captures the pending payment of a booking. Admin only.
+// @Tags Payments
+// @Security ApiKeyAuth
+// @Param booking_id query string true "Booking ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /payments/capture [post]
+func (h *PaymentHandler) CapturePaymentHandler(w http.ResponseWriter, r *http.Request) {
+	bookingID, ok := parseBookingID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.service.CapturePayment(r.Context(), bookingID)
+	if err != nil {
+		sendPaymentError(w, err, "Failed to capture payment")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Payment captured successfully",
+	})
+}
+
+// CancelPaymentHandler cancels the payment of a cancelled booking.
+// @Summary Cancel Payment
+// @Description Cancels a pending payment or refunds a captured one. Only the owner of the payment or an admin may cancel it.
+// @Tags Payments
+// @Security ApiKeyAuth
+// @Param booking_id query string true "Booking ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 403 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /payments/cancel [post]
+func (h *PaymentHandler) CancelPaymentHandler(w http.ResponseWriter, r *http.Request) {
+	bookingID, ok := parseBookingID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.service.CancelPayment(r.Context(), bookingID)
+	if err != nil {
+		sendPaymentError(w, err, "Failed to cancel payment")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Payment cancelled successfully",
+	})
+}
+
+// PaymentWebhookHandler receives payment provider notifications.
+// @Summary Payment Webhook
+// @Description Receives signed payment provider notifications
+// @Tags Payments
+// @Accept json
+// @Param X-Payment-Signature header string true "Webhook signature"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Router /payments/webhook [post]
+func (h *PaymentHandler) PaymentWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	err = h.service.HandleWebhook(r.Context(), payload, r.Header.Get(paymentSignatureHeader))
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Failed to handle webhook")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Webhook accepted",
+	})
+}
+
+// sendPaymentError answers a failed capture or cancellation.
+func sendPaymentError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		NewErrorResponse(w, http.StatusNotFound, "Payment not found")
+	case errors.Is(err, domain.ErrForbidden):
+		NewErrorResponse(w, http.StatusForbidden, "Payment belongs to another user")
+	case errors.Is(err, domain.ErrPaymentState):
+		NewErrorResponse(w, http.StatusConflict, "Payment is in invalid state")
+	default:
+		NewErrorResponse(w, http.StatusInternalServerError, message)
+	}
+}
+
+func parseBookingID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	return parseUUIDParam(w, r, "booking_id", "Booking")
+}
+
+func (h *PaymentHandler) RegisterPayment(mux *http.ServeMux,
+	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("POST /api/v1/payments", logging(authentication(h.CreatePaymentHandler)))
+	mux.HandleFunc("POST /api/v1/payments/capture", logging(authentication(authorization(h.CapturePaymentHandler))))
+	mux.HandleFunc("POST /api/v1/payments/cancel", logging(authentication(h.CancelPaymentHandler)))
+	mux.HandleFunc("POST /api/v1/payments/webhook", logging(h.PaymentWebhookHandler))
+	return mux
+}
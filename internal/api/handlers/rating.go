@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=rating.go -destination=mocks/ratingServiceMock.go
+
+const maxReviewLength = 5000
+
+type RatingService interface {
+	RateMovie(ctx context.Context, rating *domain.Rating) (*domain.RatingSummary, error)
+	DeleteRating(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) (*domain.RatingSummary, error)
+	GetRatingSummary(ctx context.Context, movieID uuid.UUID) (*domain.RatingSummary, error)
+	CreateReview(ctx context.Context, review *domain.Review) error
+	GetMovieReviews(ctx context.Context, movieID uuid.UUID, limit int, offset int) ([]*domain.Review, error)
+	GetReviewsByStatus(ctx context.Context, status string, limit int, offset int) ([]*domain.Review, error)
+	ModerateReview(ctx context.Context, reviewID uuid.UUID, status string) error
+}
+
+type RatingHandler struct {
+	service RatingService
+}
+
+func NewRatingHandler(service RatingService) *RatingHandler {
+	return &RatingHandler{service: service}
+}
+
+// RateMovieHandler sets the current user's rating of a movie.
+// @Summary Rate Movie
+// @Description Sets the current user's rating (0-10) of a movie and returns the recomputed movie rating
+// @Tags Ratings
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Param rating body models.RatingInput true "Rating object"
+// @Success 200 {object} models.RatingSummary
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/rating [put]
+func (h *RatingHandler) RateMovieHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	var input models.RatingInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	summary, err := h.service.RateMovie(r.Context(), &domain.Rating{
+		UserID:  user.UserID,
+		MovieID: movieID,
+		Score:   input.Score,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidScore) {
+			NewErrorResponse(w, http.StatusBadRequest, "Score must be between 0 and 10")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to rate movie")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, toRatingSummaryModel(summary))
+}
+
+// DeleteRatingHandler removes the current user's rating of a movie.
+// @Summary Delete Rating
+// @Description Removes the current user's rating of a movie and returns the recomputed movie rating
+// @Tags Ratings
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Success 200 {object} models.RatingSummary
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/rating [delete]
+func (h *RatingHandler) DeleteRatingHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	summary, err := h.service.DeleteRating(r.Context(), user.UserID, movieID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to delete rating")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, toRatingSummaryModel(summary))
+}
+
+// GetRatingSummaryHandler returns the aggregate rating of a movie.
+// @Summary Get Movie Rating
+// @Description Returns the aggregate rating and vote count of a movie
+// @Tags Ratings
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Success 200 {object} models.RatingSummary
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/rating [get]
+func (h *RatingHandler) GetRatingSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	summary, err := h.service.GetRatingSummary(r.Context(), movieID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get movie rating")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, toRatingSummaryModel(summary))
+}
+
+// CreateReviewHandler submits the current user's review of a movie.
+// @Summary Create Review
+// @Description Submits or replaces the current user's review of a movie; the review is shown after moderation
+// @Tags Ratings
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Param review body models.ReviewInput true "Review object"
+// @Success 201 {object} models.Review
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/reviews [post]
+func (h *RatingHandler) CreateReviewHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	var input models.ReviewInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	text := strings.TrimSpace(input.Text)
+	if text == "" || utf8.RuneCountInString(text) > maxReviewLength {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid review text")
+		return
+	}
+
+	review := &domain.Review{
+		UserID:  user.UserID,
+		MovieID: movieID,
+		Text:    text,
+	}
+	err = h.service.CreateReview(r.Context(), review)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to create review")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusCreated, toReviewModel(review))
+}
+
+// GetMovieReviewsHandler lists approved reviews of a movie.
+// @Summary Get Movie Reviews
+// @Description Lists approved reviews of a movie, newest first
+// @Tags Ratings
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {array} models.Review
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/reviews [get]
+func (h *RatingHandler) GetMovieReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	reviews, err := h.service.GetMovieReviews(r.Context(), movieID, limit, offset)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get reviews")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, toReviewModels(reviews))
+}
+
+// GetReviewsHandler lists reviews by moderation status.
+// @Summary Get Reviews for Moderation
+// @Description Lists reviews of all movies with the given status
+// @Tags Ratings
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "Review status" Enums(PENDING, APPROVED, REJECTED)
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {array} models.Review
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /reviews [get]
+func (h *RatingHandler) GetReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = domain.ReviewPending
+	}
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	reviews, err := h.service.GetReviewsByStatus(r.Context(), status, limit, offset)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidReviewStatus) {
+			NewErrorResponse(w, http.StatusBadRequest, "Invalid review status")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get reviews")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, toReviewModels(reviews))
+}
+
+// ModerateReviewHandler approves or rejects a review.
+// @Summary Moderate Review
+// @Description Sets the moderation status of a review
+// @Tags Ratings
+// @Accept json
+// @Security ApiKeyAuth
+// @Param id query string true "Review ID"
+// @Param status body models.ReviewStatusInput true "Review status"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /reviews/status [put]
+func (h *RatingHandler) ModerateReviewHandler(w http.ResponseWriter, r *http.Request) {
+	reviewID, ok := parseUUIDParam(w, r, "id", "Review")
+	if !ok {
+		return
+	}
+
+	var input models.ReviewStatusInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	err = h.service.ModerateReview(r.Context(), reviewID, input.Status)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidReviewStatus):
+			NewErrorResponse(w, http.StatusBadRequest, "Invalid review status")
+		case errors.Is(err, domain.ErrNotFound):
+			NewErrorResponse(w, http.StatusNotFound, "Review not found")
+		default:
+			NewErrorResponse(w, http.StatusInternalServerError, "Failed to moderate review")
+		}
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Review moderated successfully",
+	})
+}
+
+func toRatingSummaryModel(summary *domain.RatingSummary) models.RatingSummary {
+	return models.RatingSummary{
+		MovieID: summary.MovieID,
+		Rating:  summary.Rating,
+		Votes:   summary.Votes,
+	}
+}
+
+func toReviewModel(review *domain.Review) models.Review {
+	return models.Review{
+		ID:        review.ID,
+		UserID:    review.UserID,
+		MovieID:   review.MovieID,
+		Text:      review.Text,
+		Status:    review.Status,
+		CreatedAt: review.CreatedAt,
+	}
+}
+
+func toReviewModels(reviews []*domain.Review) []models.Review {
+	result := make([]models.Review, 0, len(reviews))
+	for _, review := range reviews {
+		result = append(result, toReviewModel(review))
+	}
+	return result
+}
+
+func (h *RatingHandler) RegisterRating(mux *http.ServeMux,
+	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/movies/rating", logging(authentication(h.GetRatingSummaryHandler)))
+	mux.HandleFunc("PUT /api/v1/movies/rating", logging(authentication(h.RateMovieHandler)))
+	mux.HandleFunc("DELETE /api/v1/movies/rating", logging(authentication(h.DeleteRatingHandler)))
+	mux.HandleFunc("GET /api/v1/movies/reviews", logging(authentication(h.GetMovieReviewsHandler)))
+	mux.HandleFunc("POST /api/v1/movies/reviews", logging(authentication(h.CreateReviewHandler)))
+	mux.HandleFunc("GET /api/v1/reviews", logging(authentication(authorization(h.GetReviewsHandler))))
+	mux.HandleFunc("PUT /api/v1/reviews/status", logging(authentication(authorization(h.ModerateReviewHandler))))
+	return mux
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"cinema_service/internal/usecase"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// parseUUIDParam reads a required UUID query parameter and writes a 400
+// response if it is missing or malformed.
+func parseUUIDParam(w http.ResponseWriter, r *http.Request, param string, name string) (uuid.UUID, bool) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		NewErrorResponse(w, http.StatusBadRequest, name+" ID parameter is required")
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid "+strings.ToLower(name)+" ID")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseOptionalUUID reads an optional UUID query parameter and writes a 400
+// response if it is malformed.
+func parseOptionalUUID(w http.ResponseWriter, r *http.Request, param string) (uuid.UUID, bool) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return uuid.Nil, true
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid "+param+" parameter")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseOptionalTime reads an optional RFC 3339 query parameter and writes a
+// 400 response if it is malformed.
+func parseOptionalTime(w http.ResponseWriter, r *http.Request, param string) (time.Time, bool) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return time.Time{}, true
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid "+param+" parameter")
+		return time.Time{}, false
+	}
+	return parsed.UTC(), true
+}
+
+// parsePagination reads the optional page and page_size query parameters and
+// returns them as limit and offset.
+func parsePagination(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	page, pageSize := 1, defaultPageSize
+
+	if value := r.URL.Query().Get("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			NewErrorResponse(w, http.StatusBadRequest, "Invalid page parameter")
+			return 0, 0, false
+		}
+		page = parsed
+	}
+
+	if value := r.URL.Query().Get("page_size"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxPageSize {
+			NewErrorResponse(w, http.StatusBadRequest, "Invalid page_size parameter")
+			return 0, 0, false
+		}
+		pageSize = parsed
+	}
+
+	return pageSize, (page - 1) * pageSize, true
+}
+
+// currentUser returns the authenticated user and writes a 401 response if
+// the request did not pass through the authentication middleware.
+func currentUser(w http.ResponseWriter, r *http.Request) (*usecase.UserInfo, bool) {
+	user, ok := usecase.UserFromContext(r.Context())
+	if !ok {
+		NewErrorResponse(w, http.StatusUnauthorized, "User context not found")
+		return nil, false
+	}
+	return user, true
+}
@@ -21,7 +21,7 @@ type statusResponse struct {
 func sendJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	jsonResponse, err := json.Marshal(data)
 	if err != nil {
-		slog.Error("Failed to marshal JSON response:", err)
+		slog.Error("Failed to marshal JSON response", "err", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
@@ -30,12 +30,22 @@ func sendJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.WriteHeader(statusCode)
 	_, err = w.Write(jsonResponse)
 	if err != nil {
-		slog.Error("Failed to write header:", err)
+		slog.Error("Failed to write response", "err", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 }
 
+// sendCreated sends a 201 response with a created resource, the URL it can
+// be read from and, if etag is not empty, its ETag.
+func sendCreated(w http.ResponseWriter, location string, etag string, data interface{}) {
+	w.Header().Set("Location", location)
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	sendJSONResponse(w, http.StatusCreated, data)
+}
+
 func NewErrorResponse(w http.ResponseWriter, statusCode int, message string) {
 	slog.Error(message)
 
@@ -50,10 +60,10 @@ func NewErrorResponse(w http.ResponseWriter, statusCode int, message string) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	_, err = w.Write(jsonResponse)
 	w.WriteHeader(statusCode)
+	_, err = w.Write(jsonResponse)
 	if err != nil {
-		slog.Error("Failed to write header:", err)
+		slog.Error("Failed to write response", "err", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
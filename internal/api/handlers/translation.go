@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=translation.go -destination=mocks/translationServiceMock.go
+
+type TranslationService interface {
+	SetMovieTranslation(ctx context.Context, translation *domain.MovieTranslation) error
+	DeleteMovieTranslation(ctx context.Context, movieID uuid.UUID, locale string) error
+	GetMovieTranslations(ctx context.Context, movieID uuid.UUID) ([]*domain.MovieTranslation, error)
+	SetActorTranslation(ctx context.Context, translation *domain.ActorTranslation) error
+	DeleteActorTranslation(ctx context.Context, actorID uuid.UUID, locale string) error
+	GetActorTranslations(ctx context.Context, actorID uuid.UUID) ([]*domain.ActorTranslation, error)
+}
+
+type TranslationHandler struct {
+	service TranslationService
+}
+
+func NewTranslationHandler(service TranslationService) *TranslationHandler {
+	return &TranslationHandler{service: service}
+}
+
+// GetMovieTranslationsHandler lists the translations of a movie.
+// @Summary Get Movie Translations
+// @Description Lists the translations of a movie ordered by locale
+// @Tags Translations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Success 200 {array} models.MovieTranslation
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/translations [get]
+func (h *TranslationHandler) GetMovieTranslationsHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	translations, err := h.service.GetMovieTranslations(r.Context(), movieID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get movie translations")
+		return
+	}
+
+	result := make([]models.MovieTranslation, 0, len(translations))
+	for _, translation := range translations {
+		result = append(result, models.MovieTranslation{
+			Lang:        translation.Locale,
+			Title:       translation.Title,
+			Description: translation.Description,
+		})
+	}
+
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+// SetMovieTranslationHandler creates or replaces a translation of a movie.
+// @Summary Set Movie Translation
+// @Description Creates or replaces the title and description of a movie in one locale
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Param lang query string true "Locale (ISO 639-1)"
+// @Param translation body models.MovieTranslationInput true "Translation object"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/translations [put]
+func (h *TranslationHandler) SetMovieTranslationHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+	locale, ok := parseLocaleParam(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.MovieTranslationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	err := h.service.SetMovieTranslation(r.Context(), &domain.MovieTranslation{
+		MovieID:     movieID,
+		Locale:      locale,
+		Title:       strings.TrimSpace(input.Title),
+		Description: input.Description,
+	})
+	if err != nil {
+		writeTranslationError(w, err, "Movie not found", "Failed to set movie translation")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Movie translation saved successfully",
+	})
+}
+
+// DeleteMovieTranslationHandler removes a translation of a movie.
+// @Summary Delete Movie Translation
+// @Description Removes the translation of a movie into one locale
+// @Tags Translations
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Param lang query string true "Locale (ISO 639-1)"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /movies/translations [delete]
+func (h *TranslationHandler) DeleteMovieTranslationHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+	locale, ok := parseLocaleParam(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteMovieTranslation(r.Context(), movieID, locale)
+	if err != nil {
+		writeTranslationError(w, err, "Translation not found", "Failed to delete movie translation")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Movie translation deleted successfully",
+	})
+}
+
+// GetActorTranslationsHandler lists the translations of an actor's name.
+// @Summary Get Actor Translations
+// @Description Lists the translations of an actor's name ordered by locale
+// @Tags Translations
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Actor ID"
+// @Success 200 {array} models.ActorTranslation
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /actors/translations [get]
+func (h *TranslationHandler) GetActorTranslationsHandler(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+
+	translations, err := h.service.GetActorTranslations(r.Context(), actorID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get actor translations")
+		return
+	}
+
+	result := make([]models.ActorTranslation, 0, len(translations))
+	for _, translation := range translations {
+		result = append(result, models.ActorTranslation{
+			Lang:    translation.Locale,
+			Name:    translation.Name,
+			Surname: translation.Surname,
+		})
+	}
+
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+// SetActorTranslationHandler creates or replaces a translation of an actor's name.
+// @Summary Set Actor Translation
+// @Description Creates or replaces the name of an actor in one locale
+// @Tags Translations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Actor ID"
+// @Param lang query string true "Locale (ISO 639-1)"
+// @Param translation body models.ActorTranslationInput true "Translation object"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /actors/translations [put]
+func (h *TranslationHandler) SetActorTranslationHandler(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+	locale, ok := parseLocaleParam(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.ActorTranslationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	err := h.service.SetActorTranslation(r.Context(), &domain.ActorTranslation{
+		ActorID: actorID,
+		Locale:  locale,
+		Name:    strings.TrimSpace(input.Name),
+		Surname: strings.TrimSpace(input.Surname),
+	})
+	if err != nil {
+		writeTranslationError(w, err, "Actor not found", "Failed to set actor translation")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Actor translation saved successfully",
+	})
+}
+
+// DeleteActorTranslationHandler removes a translation of an actor's name.
+// @Summary Delete Actor Translation
+// @Description Removes the translation of an actor's name into one locale
+// @Tags Translations
+// @Security ApiKeyAuth
+// @Param id query string true "Actor ID"
+// @Param lang query string true "Locale (ISO 639-1)"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /actors/translations [delete]
+func (h *TranslationHandler) DeleteActorTranslationHandler(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+	locale, ok := parseLocaleParam(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteActorTranslation(r.Context(), actorID, locale)
+	if err != nil {
+		writeTranslationError(w, err, "Translation not found", "Failed to delete actor translation")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Actor translation deleted successfully",
+	})
+}
+
+// parseLocaleParam reads the required lang query parameter.
+func parseLocaleParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	locale := strings.ToLower(r.URL.Query().Get("lang"))
+	if locale == "" {
+		NewErrorResponse(w, http.StatusBadRequest, "lang parameter is required")
+		return "", false
+	}
+	if !domain.ValidLocale(locale) {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid lang parameter")
+		return "", false
+	}
+	return locale, true
+}
+
+func writeTranslationError(w http.ResponseWriter, err error, notFound string, message string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTranslation):
+		NewErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		NewErrorResponse(w, http.StatusNotFound, notFound)
+	default:
+		NewErrorResponse(w, http.StatusInternalServerError, message)
+	}
+}
+
+func (h *TranslationHandler) RegisterTranslation(mux *http.ServeMux,
+	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/movies/translations", logging(authentication(authorization(h.GetMovieTranslationsHandler))))
+	mux.HandleFunc("PUT /api/v1/movies/translations", logging(authentication(authorization(h.SetMovieTranslationHandler))))
+	mux.HandleFunc("DELETE /api/v1/movies/translations", logging(authentication(authorization(h.DeleteMovieTranslationHandler))))
+	mux.HandleFunc("GET /api/v1/actors/translations", logging(authentication(authorization(h.GetActorTranslationsHandler))))
+	mux.HandleFunc("PUT /api/v1/actors/translations", logging(authentication(authorization(h.SetActorTranslationHandler))))
+	mux.HandleFunc("DELETE /api/v1/actors/translations", logging(authentication(authorization(h.DeleteActorTranslationHandler))))
+	return mux
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=trash.go -destination=mocks/trashServiceMock.go
+
+type TrashService interface {
+	GetTrash(ctx context.Context) (*domain.Trash, error)
+	RestoreMovie(ctx context.Context, movieID uuid.UUID) error
+	RestoreActor(ctx context.Context, actorID uuid.UUID) error
+}
+
+type TrashHandler struct {
+	service TrashService
+}
+
+func NewTrashHandler(service TrashService) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// GetTrashHandler lists deleted movies and actors.
+// @Summary Get Trash
+// @Description Lists deleted movies and actors that can still be restored, most recently deleted first
+// @Tags Trash
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Trash
+// @Failure 500 {object} errorResponse
+// @Router /trash [get]
+func (h *TrashHandler) GetTrashHandler(w http.ResponseWriter, r *http.Request) {
+	trash, err := h.service.GetTrash(r.Context())
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get trash")
+		return
+	}
+
+	result := models.Trash{
+		Movies: make([]models.TrashedMovie, 0, len(trash.Movies)),
+		Actors: make([]models.TrashedActor, 0, len(trash.Actors)),
+	}
+	for _, trashed := range trash.Movies {
+		result.Movies = append(result.Movies, models.TrashedMovie{
+			MovieID:     trashed.Movie.ID,
+			Title:       trashed.Movie.Title,
+			Description: trashed.Movie.Description,
+			Date:        trashed.Movie.Date,
+			DeletedAt:   trashed.DeletedAt,
+		})
+	}
+	for _, trashed := range trash.Actors {
+		result.Actors = append(result.Actors, models.TrashedActor{
+			ActorID:   trashed.Actor.ID,
+			Name:      trashed.Actor.Name,
+			Surname:   trashed.Actor.Surname,
+			Sex:       trashed.Actor.Sex,
+			Birthdate: trashed.Actor.Birthdate,
+			DeletedAt: trashed.DeletedAt,
+		})
+	}
+
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+// RestoreMovieHandler takes a movie out of the trash.
+// @Summary Restore Movie
+// @Description Restores a deleted movie together with its credits, genres, translations, ratings and watchlist entries and publishes a movie.restored event
+// @Tags Trash
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /trash/movies/restore [post]
+func (h *TrashHandler) RestoreMovieHandler(w http.ResponseWriter, r *http.Request) {
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	err := h.service.RestoreMovie(r.Context(), movieID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Movie not found in trash")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to restore movie")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Movie restored successfully",
+	})
+}
+
+// RestoreActorHandler takes an actor out of the trash.
+// @Summary Restore Actor
+// @Description Restores a deleted actor together with their credits, translations and favorites and publishes an actor.restored event
+// @Tags Trash
+// @Security ApiKeyAuth
+// @Param id query string true "Actor ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /trash/actors/restore [post]
+func (h *TrashHandler) RestoreActorHandler(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+
+	err := h.service.RestoreActor(r.Context(), actorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Actor not found in trash")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to restore actor")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Actor restored successfully",
+	})
+}
+
+func (h *TrashHandler) RegisterTrash(mux *http.ServeMux,
+	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/trash", logging(authentication(authorization(h.GetTrashHandler))))
+	mux.HandleFunc("POST /api/v1/trash/movies/restore", logging(authentication(authorization(h.RestoreMovieHandler))))
+	mux.HandleFunc("POST /api/v1/trash/actors/restore", logging(authentication(authorization(h.RestoreActorHandler))))
+	return mux
+}
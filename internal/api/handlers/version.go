@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// parseVersionParam reads a required version number query parameter and
+// writes a 400 response if it is missing or malformed.
+func parseVersionParam(w http.ResponseWriter, r *http.Request, param string) (int, bool) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		NewErrorResponse(w, http.StatusBadRequest, "Missing "+param+" parameter")
+		return 0, false
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 1 {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid "+param+" parameter")
+		return 0, false
+	}
+	return number, true
+}
+
+func toVersionModels(versions []*domain.EntityVersion) []models.EntityVersion {
+	result := make([]models.EntityVersion, 0, len(versions))
+	for _, version := range versions {
+		model := models.EntityVersion{
+			Version:   version.Number,
+			CreatedAt: version.CreatedAt,
+			State:     version.State,
+		}
+		if version.UserID != uuid.Nil {
+			userID := version.UserID
+			model.UserID = &userID
+		}
+		result = append(result, model)
+	}
+	return result
+}
+
+func toChangeModels(changes map[string]domain.AuditChange) map[string]models.AuditChange {
+	result := make(map[string]models.AuditChange, len(changes))
+	for field, change := range changes {
+		result[field] = models.AuditChange{Before: change.Before, After: change.After}
+	}
+	return result
+}
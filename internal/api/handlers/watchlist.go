@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=watchlist.go -destination=mocks/watchlistServiceMock.go
+
+type WatchlistService interface {
+	AddToWatchlist(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error
+	RemoveFromWatchlist(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error
+	GetWatchlist(ctx context.Context, userID uuid.UUID) ([]*domain.WatchlistEntry, error)
+	AddFavoriteActor(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error
+	RemoveFavoriteActor(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error
+	GetFavoriteActors(ctx context.Context, userID uuid.UUID) ([]*domain.FavoriteActor, error)
+}
+
+type WatchlistHandler struct {
+	service WatchlistService
+}
+
+func NewWatchlistHandler(service WatchlistService) *WatchlistHandler {
+	return &WatchlistHandler{service: service}
+}
+
+// GetWatchlistHandler lists the current user's watchlist.
+// @Summary Get Watchlist
+// @Description Lists movies on the current user's watchlist, most recently added first
+// @Tags Me
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.WatchlistEntry
+// @Failure 401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /me/watchlist [get]
+func (h *WatchlistHandler) GetWatchlistHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := h.service.GetWatchlist(r.Context(), user.UserID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get watchlist")
+		return
+	}
+
+	result := make([]models.WatchlistEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, models.WatchlistEntry{
+			MovieID:     entry.Movie.ID,
+			Title:       entry.Movie.Title,
+			Description: entry.Movie.Description,
+			Date:        entry.Movie.Date,
+			Rating:      entry.Movie.Rating,
+			AddedAt:     entry.AddedAt,
+		})
+	}
+
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+// AddToWatchlistHandler adds a movie to the current user's watchlist.
+// @Summary Add to Watchlist
+// @Description Adds a movie to the current user's watchlist
+// @Tags Me
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /me/watchlist [post]
+func (h *WatchlistHandler) AddToWatchlistHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	err := h.service.AddToWatchlist(r.Context(), user.UserID, movieID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Movie not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to add movie to watchlist")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Movie added to watchlist",
+	})
+}
+
+// RemoveFromWatchlistHandler removes a movie from the current user's watchlist.
+// @Summary Remove from Watchlist
+// @Description Removes a movie from the current user's watchlist
+// @Tags Me
+// @Security ApiKeyAuth
+// @Param id query string true "Movie ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /me/watchlist [delete]
+func (h *WatchlistHandler) RemoveFromWatchlistHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	movieID, ok := parseUUIDParam(w, r, "id", "Movie")
+	if !ok {
+		return
+	}
+
+	err := h.service.RemoveFromWatchlist(r.Context(), user.UserID, movieID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to remove movie from watchlist")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Movie removed from watchlist",
+	})
+}
+
+// GetFavoriteActorsHandler lists the current user's favourite actors.
+// @Summary Get Favorite Actors
+// @Description Lists the current user's favourite actors, most recently added first
+// @Tags Me
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.FavoriteActor
+// @Failure 401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /me/favorites/actors [get]
+func (h *WatchlistHandler) GetFavoriteActorsHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+
+	favorites, err := h.service.GetFavoriteActors(r.Context(), user.UserID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get favorite actors")
+		return
+	}
+
+	result := make([]models.FavoriteActor, 0, len(favorites))
+	for _, favorite := range favorites {
+		result = append(result, models.FavoriteActor{
+			ActorID:   favorite.Actor.ID,
+			Name:      favorite.Actor.Name,
+			Surname:   favorite.Actor.Surname,
+			Sex:       favorite.Actor.Sex,
+			Birthdate: favorite.Actor.Birthdate,
+			AddedAt:   favorite.AddedAt,
+		})
+	}
+
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+// AddFavoriteActorHandler adds an actor to the current user's favourites.
+// @Summary Add Favorite Actor
+// @Description Adds an actor to the current user's favourites
+// @Tags Me
+// @Security ApiKeyAuth
+// @Param id query string true "Actor ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /me/favorites/actors [post]
+func (h *WatchlistHandler) AddFavoriteActorHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	actorID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+
+	err := h.service.AddFavoriteActor(r.Context(), user.UserID, actorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Actor not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to add favorite actor")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Actor added to favorites",
+	})
+}
+
+// RemoveFavoriteActorHandler removes an actor from the current user's favourites.
+// @Summary Remove Favorite Actor
+// @Description Removes an actor from the current user's favourites
+// @Tags Me
+// @Security ApiKeyAuth
+// @Param id query string true "Actor ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /me/favorites/actors [delete]
+func (h *WatchlistHandler) RemoveFavoriteActorHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := currentUser(w, r)
+	if !ok {
+		return
+	}
+	actorID, ok := parseUUIDParam(w, r, "id", "Actor")
+	if !ok {
+		return
+	}
+
+	err := h.service.RemoveFavoriteActor(r.Context(), user.UserID, actorID)
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to remove favorite actor")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Actor removed from favorites",
+	})
+}
+
+func (h *WatchlistHandler) RegisterWatchlist(mux *http.ServeMux,
+	authentication Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("GET /api/v1/me/watchlist", logging(authentication(h.GetWatchlistHandler)))
+	mux.HandleFunc("POST /api/v1/me/watchlist", logging(authentication(h.AddToWatchlistHandler)))
+	mux.HandleFunc("DELETE /api/v1/me/watchlist", logging(authentication(h.RemoveFromWatchlistHandler)))
+	mux.HandleFunc("GET /api/v1/me/favorites/actors", logging(authentication(h.GetFavoriteActorsHandler)))
+	mux.HandleFunc("POST /api/v1/me/favorites/actors", logging(authentication(h.AddFavoriteActorHandler)))
+	mux.HandleFunc("DELETE /api/v1/me/favorites/actors", logging(authentication(h.RemoveFavoriteActorHandler)))
+	return mux
+}
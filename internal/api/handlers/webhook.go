@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"cinema_service/internal/api/handlers/models"
+	"cinema_service/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=webhook.go -destination=mocks/webhookServiceMock.go
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+	GetSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, subscriptionID uuid.UUID) (*domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID uuid.UUID) error
+	GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int, offset int) ([]*domain.WebhookDelivery, error)
+	Redeliver(ctx context.Context, deliveryID uuid.UUID) (*domain.WebhookDelivery, error)
+}
+
+type WebhookHandler struct {
+	service WebhookService
+}
+
+func NewWebhookHandler(service WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// CreateWebhookHandler registers a webhook subscription.
+// @Summary Create Webhook Subscription
+// @Description Registers a URL that catalogue events of the given types are posted to. Deliveries are signed with the secret in the X-Webhook-Signature header, "sha256=" followed by the hex HMAC-SHA256 of X-Webhook-Timestamp, a dot and the body. The secret is only returned here.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param subscription body models.WebhookSubscriptionInput true "Subscription object"
+// @Success 201 {object} models.WebhookSubscription
+// @Header 201 {string} Location "URL of the subscription"
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	var input models.WebhookSubscriptionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	subscription := &domain.WebhookSubscription{URL: input.URL, EventTypes: input.EventTypes, Secret: input.Secret}
+	if err := h.service.CreateSubscription(r.Context(), subscription); err != nil {
+		if errors.Is(err, domain.ErrInvalidSubscription) {
+			NewErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to create webhook subscription")
+		return
+	}
+
+	result := toWebhookSubscriptionModel(subscription)
+	result.Secret = subscription.Secret
+	sendCreated(w, "/api/v1/webhooks?id="+subscription.ID.String(), "", result)
+}
+
+// GetWebhooksHandler lists webhook subscriptions, or returns a single
+// subscription if an ID is given.
+// @Summary Get Webhook Subscriptions
+// @Description Lists webhook subscriptions, oldest first, or returns the subscription with the given ID, without their secrets
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string false "Subscription ID"
+// @Success 200 {array} models.WebhookSubscription
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("id") {
+		h.getWebhook(w, r)
+		return
+	}
+
+	subscriptions, err := h.service.GetSubscriptions(r.Context())
+	if err != nil {
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get webhook subscriptions")
+		return
+	}
+
+	result := make([]models.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result = append(result, toWebhookSubscriptionModel(subscription))
+	}
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+func (h *WebhookHandler) getWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseUUIDParam(w, r, "id", "Subscription")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.service.GetSubscription(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Subscription not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get webhook subscription")
+		return
+	}
+	sendJSONResponse(w, http.StatusOK, toWebhookSubscriptionModel(subscription))
+}
+
+// DeleteWebhookHandler removes a webhook subscription.
+// @Summary Delete Webhook Subscription
+// @Description Removes a webhook subscription with its delivery log
+// @Tags Webhooks
+// @Security ApiKeyAuth
+// @Param id query string true "Subscription ID"
+// @Success 200 {object} statusResponse
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /webhooks [delete]
+func (h *WebhookHandler) DeleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseUUIDParam(w, r, "id", "Subscription")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSubscription(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Subscription not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to delete webhook subscription")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, statusResponse{
+		Status: "Subscription deleted successfully",
+	})
+}
+
+// GetDeliveriesHandler lists the deliveries of a webhook subscription.
+// @Summary Get Webhook Deliveries
+// @Description Lists the delivery log of a webhook subscription, newest first
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Subscription ID"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /webhooks/deliveries [get]
+func (h *WebhookHandler) GetDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseUUIDParam(w, r, "id", "Subscription")
+	if !ok {
+		return
+	}
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(r.Context(), id, limit, offset)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Subscription not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to get webhook deliveries")
+		return
+	}
+
+	result := make([]models.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, toWebhookDeliveryModel(delivery))
+	}
+	sendJSONResponse(w, http.StatusOK, result)
+}
+
+// RedeliverHandler sends a webhook delivery again.
+// @Summary Redeliver Webhook
+// @Description Queues a delivery to be sent again with a fresh set of attempts, whatever its status
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "Delivery ID"
+// @Success 202 {object} models.WebhookDelivery
+// @Failure 400 {object} errorResponse
+// @Failure 404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Router /webhooks/deliveries/redeliver [post]
+func (h *WebhookHandler) RedeliverHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseUUIDParam(w, r, "id", "Delivery")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.Redeliver(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			NewErrorResponse(w, http.StatusNotFound, "Delivery not found")
+			return
+		}
+		NewErrorResponse(w, http.StatusInternalServerError, "Failed to redeliver webhook")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusAccepted, toWebhookDeliveryModel(delivery))
+}
+
+// toWebhookSubscriptionModel converts a subscription without its secret.
+func toWebhookSubscriptionModel(subscription *domain.WebhookSubscription) models.WebhookSubscription {
+	return models.WebhookSubscription{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+func toWebhookDeliveryModel(delivery *domain.WebhookDelivery) models.WebhookDelivery {
+	result := models.WebhookDelivery{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Body:           delivery.Body,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if !delivery.DeliveredAt.IsZero() {
+		deliveredAt := delivery.DeliveredAt
+		result.DeliveredAt = &deliveredAt
+	}
+	return result
+}
+
+func (h *WebhookHandler) RegisterWebhook(mux *http.ServeMux,
+	authentication Middleware, authorization Middleware, logging Middleware) *http.ServeMux {
+	mux.HandleFunc("POST /api/v1/webhooks", logging(authentication(authorization(h.CreateWebhookHandler))))
+	mux.HandleFunc("GET /api/v1/webhooks", logging(authentication(authorization(h.GetWebhooksHandler))))
+	mux.HandleFunc("DELETE /api/v1/webhooks", logging(authentication(authorization(h.DeleteWebhookHandler))))
+	mux.HandleFunc("GET /api/v1/webhooks/deliveries", logging(authentication(authorization(h.GetDeliveriesHandler))))
+	mux.HandleFunc("POST /api/v1/webhooks/deliveries/redeliver",
+		logging(authentication(authorization(h.RedeliverHandler))))
+	return mux
+}
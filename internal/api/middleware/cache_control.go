@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"cinema_service/internal/api/handlers"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CacheControl sets the Cache-Control header of successful and not
+// modified responses, which are the same for every user, to let clients
+// and shared caches keep them for maxAge. A zero maxAge makes them
+// revalidate with the ETag first. Error responses are not cached.
+func CacheControl(maxAge time.Duration) handlers.Middleware {
+	value := "public, no-cache"
+	if maxAge > 0 {
+		value = fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+	}
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			next(&cacheControlWriter{ResponseWriter: w, value: value}, r)
+		}
+	}
+}
+
+// cacheControlWriter sets Cache-Control when the status code is written.
+type cacheControlWriter struct {
+	http.ResponseWriter
+	value       string
+	wroteHeader bool
+}
+
+func (rw *cacheControlWriter) WriteHeader(code int) {
+	if !rw.wroteHeader {
+		rw.wroteHeader = true
+		if code == http.StatusOK || code == http.StatusNotModified {
+			rw.Header().Set("Cache-Control", rw.value)
+		}
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *cacheControlWriter) Write(data []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	return rw.ResponseWriter.Write(data)
+}
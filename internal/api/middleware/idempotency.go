@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bytes"
+	"cinema_service/internal/api/handlers"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marks responses replayed for a retry.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotentBodySize limits the body read into memory to fingerprint
+	// a request.
+	maxIdempotentBodySize = 1 << 20
+	// completeAttempts is how many times storing a response is tried.
+	completeAttempts = 3
+	// completeRetryDelay is the wait before the second attempt to store a
+	// response; it grows with every attempt.
+	completeRetryDelay = 50 * time.Millisecond
+)
+
+// replayedHeaders are the response headers stored with the response to an
+// idempotent request. Others, like X-Request-ID, belong to each request.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, userID uuid.UUID, key string, fingerprint string) (*domain.IdempotencyKey, error)
+	Complete(ctx context.Context, key *domain.IdempotencyKey) error
+	Release(ctx context.Context, userID uuid.UUID, key string) error
+}
+
+// Idempotency makes requests with an Idempotency-Key header safe to retry.
+// The response to the first request with a key is stored per user and
+// replayed for retries with the same method, URL and body; a key sent with
+// a different request is rejected with 422. Server errors are not stored,
+// so those requests can be retried; neither are responses of handlers that
+// panic, whose keys are released as well. A response that fails to be
+// stored leaves its key reserved, so retries are rejected with 409 until it
+// expires instead of repeating what the request did.
+// Bodies over maxIdempotentBodySize are rejected with 413. Requests without
+// the header pass through. It must run after Authenticate.
+func Idempotency(service IdempotencyService) handlers.Middleware {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotencyKeyHeader)
+			if key == "" {
+				next(w, r)
+				return
+			}
+			// Keys follow the rules of request IDs.
+			if !validRequestID(key) {
+				handlers.NewErrorResponse(w, http.StatusBadRequest, "Invalid Idempotency-Key header")
+				return
+			}
+			user, ok := r.Context().Value(UserCtx).(*usecase.UserInfo)
+			if !ok || user == nil {
+				handlers.NewErrorResponse(w, http.StatusUnauthorized, "User information not found")
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					handlers.NewErrorResponse(w, http.StatusRequestEntityTooLarge, "Request payload is too large")
+					return
+				}
+				handlers.NewErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			stored, err := service.Begin(r.Context(), user.UserID, key, requestFingerprint(r, body))
+			switch {
+			case errors.Is(err, domain.ErrIdempotencyKeyReused):
+				handlers.NewErrorResponse(w, http.StatusUnprocessableEntity, "Idempotency-Key was used for a different request")
+				return
+			case errors.Is(err, domain.ErrRequestInProgress):
+				handlers.NewErrorResponse(w, http.StatusConflict, "A request with this Idempotency-Key is in progress")
+				return
+			case err != nil:
+				slog.Error("Failed to begin idempotent request", "err", err)
+				handlers.NewErrorResponse(w, http.StatusInternalServerError, "Failed to check Idempotency-Key")
+				return
+			case stored != nil:
+				replay(w, stored)
+				return
+			}
+
+			// The client may be gone, which is when it will retry.
+			ctx := context.WithoutCancel(r.Context())
+			// Until a response is stored the key is reserved and retries
+			// are rejected with 409, so it is released when the request had
+			// no effect to protect: on server errors and panics.
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				if err := service.Release(ctx, user.UserID, key); err != nil {
+					slog.Error("Failed to release idempotency key", "err", err)
+				}
+			}()
+
+			recorder := &recordingWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next(recorder, r)
+
+			if recorder.statusCode >= http.StatusInternalServerError {
+				return
+			}
+			completed = true
+			header := make(map[string][]string)
+			for _, name := range replayedHeaders {
+				if values := w.Header().Values(name); len(values) > 0 {
+					header[name] = values
+				}
+			}
+			response := &domain.IdempotencyKey{
+				UserID:     user.UserID,
+				Key:        key,
+				StatusCode: recorder.statusCode,
+				Header:     header,
+				Body:       recorder.body.Bytes(),
+			}
+			for attempt := 1; ; attempt++ {
+				err = service.Complete(ctx, response)
+				if err == nil {
+					return
+				}
+				if attempt == completeAttempts {
+					break
+				}
+				time.Sleep(time.Duration(attempt) * completeRetryDelay)
+			}
+			slog.Error("Failed to store idempotent response, the key stays reserved", "err", err)
+		}
+	}
+}
+
+// requestFingerprint identifies a request by its method, URL and body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, stored *domain.IdempotencyKey) {
+	for name, values := range stored.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+	if _, err := w.Write(stored.Body); err != nil {
+		slog.Error("Failed to write response", "err", err)
+	}
+}
+
+// recordingWriter keeps a copy of the status code and body of a response.
+type recordingWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rw *recordingWriter) WriteHeader(code int) {
+	rw.statusCode = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recordingWriter) Write(data []byte) (int, error) {
+	rw.body.Write(data)
+	return rw.ResponseWriter.Write(data)
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"cinema_service/internal/api/handlers"
+	"net/http"
+)
+
+// RequireIfMatch rejects writes without an If-Match header with 428
+// Precondition Required if required is set, so that clients cannot
+// overwrite changes they have not seen. Otherwise requests pass through and
+// an If-Match header is only checked when present.
+func RequireIfMatch(required bool) handlers.Middleware {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		if !required {
+			return next
+		}
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-Match") == "" {
+				handlers.NewErrorResponse(w, http.StatusPreconditionRequired, "If-Match header is required")
+				return
+			}
+			next(w, r)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Localize stores the locales preferred by the client in the request
+// context. The lang query parameter takes precedence over Accept-Language.
+func Localize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+
+		locales := PreferredLocales(r)
+		if len(locales) > 0 {
+			r = r.WithContext(usecase.WithLocales(r.Context(), locales))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// PreferredLocales returns the language codes the client asked for, most
+// preferred first. Region subtags are dropped, so "en-US" becomes "en".
+func PreferredLocales(r *http.Request) []string {
+	var locales []string
+	add := func(tag string) {
+		locale, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !domain.ValidLocale(locale) {
+			return
+		}
+		for _, existing := range locales {
+			if existing == locale {
+				return
+			}
+		}
+		locales = append(locales, locale)
+	}
+
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		add(lang)
+	}
+
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, quality: quality})
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].quality > tags[j].quality
+	})
+	for _, tag := range tags {
+		add(tag.tag)
+	}
+
+	return locales
+}
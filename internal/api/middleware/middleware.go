@@ -10,10 +10,10 @@ import (
 	"strconv"
 	"strings"
 )
-type ContextKey string
+type ContextKey = usecase.ContextKey
 
 const (
-	UserCtx ContextKey = "user_info"
+	UserCtx = usecase.UserCtx
 )
 
 //go:generate mockgen -source=middleware.go -destination=mocks/mock.go
@@ -98,6 +98,7 @@ func (m *UserMiddleware) LoggingMiddleware(next http.HandlerFunc) http.HandlerFu
 
 		slog.Info(
 			"request ", "Method" , r.Method,  "Path", r.URL.Path, "Status", strconv.Itoa(statusCode), "Size" , 	responseSize,
+			"RequestID", usecase.RequestIDFromContext(r.Context()),
 		)
 	})
 }
@@ -130,3 +131,9 @@ func (rw *ResponseWriter) StatusCode() int {
 func (rw *ResponseWriter) Size() int {
 	return rw.size
 }
+
+// Unwrap lets http.ResponseController reach the underlying writer, so that
+// streamed responses can be flushed.
+func (rw *ResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
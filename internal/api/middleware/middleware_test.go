@@ -1,11 +1,16 @@
 package middleware
 
 import (
+	"cinema_service/internal/api/handlers"
 	mock_service "cinema_service/internal/api/middleware/mocks"
 	"cinema_service/internal/domain"
+	"cinema_service/internal/repository/memory"
 	"cinema_service/internal/usecase"
 	"context"
 	"errors"
+	"io"
+	"strings"
+	"time"
 
 	"net/http"
 	"net/http/httptest"
@@ -193,3 +198,279 @@ func TestRequireAdmin(t *testing.T) {
 	
 	}
 }
+
+func TestPreferredLocales(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		expected       []string
+	}{
+		{
+			name:     "None",
+			expected: nil,
+		},
+		{
+			name:           "Accept-Language by quality",
+			acceptLanguage: "en;q=0.5, ru-RU, de;q=0.8",
+			expected:       []string{"ru", "de", "en"},
+		},
+		{
+			name:           "Lang parameter first",
+			query:          "?lang=en",
+			acceptLanguage: "ru, en-GB;q=0.9",
+			expected:       []string{"en", "ru"},
+		},
+		{
+			name:           "Skips wildcard and rejected",
+			acceptLanguage: "*, fr;q=0, es;q=abc, it",
+			expected:       []string{"it"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/movies"+tc.query, nil)
+			if tc.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+			assert.Equal(t, tc.expected, PreferredLocales(req))
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	var locales []string
+	handler := Localize(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locales = usecase.LocalesFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/api/v1/movies", nil)
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9,ru;q=0.8")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, []string{"en", "ru"}, locales)
+	assert.Equal(t, "Accept-Language", recorder.Header().Get("Vary"))
+}
+
+func TestRequestID(t *testing.T) {
+	var requestID string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = usecase.RequestIDFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/api/v1/movies", nil)
+	req.Header.Set("X-Request-ID", "req-42")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, "req-42", requestID)
+	assert.Equal(t, "req-42", recorder.Header().Get("X-Request-ID"))
+
+	req = httptest.NewRequest("GET", "/api/v1/movies", nil)
+	req.Header.Set("X-Request-ID", "bad id\n")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	_, err := uuid.Parse(requestID)
+	assert.NoError(t, err)
+	assert.Equal(t, requestID, recorder.Header().Get("X-Request-ID"))
+}
+
+func TestRequireIfMatch(t *testing.T) {
+	called := false
+	next := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}
+
+	req := httptest.NewRequest("PUT", "/api/v1/movies", nil)
+	recorder := httptest.NewRecorder()
+	RequireIfMatch(true)(next)(recorder, req)
+	assert.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+	assert.False(t, called)
+
+	req.Header.Set("If-Match", `"1"`)
+	RequireIfMatch(true)(next)(httptest.NewRecorder(), req)
+	assert.True(t, called)
+
+	called = false
+	RequireIfMatch(false)(next)(httptest.NewRecorder(), httptest.NewRequest("PUT", "/api/v1/movies", nil))
+	assert.True(t, called)
+}
+
+func TestCacheControl(t *testing.T) {
+	status := http.StatusOK
+	next := func(w http.ResponseWriter, r *http.Request) {
+		handlers.NewErrorResponse(w, status, "response")
+	}
+	send := func(maxAge time.Duration) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		CacheControl(maxAge)(next)(recorder, httptest.NewRequest("GET", "/api/v1/actors", nil))
+		return recorder
+	}
+
+	assert.Equal(t, "public, max-age=60", send(time.Minute).Header().Get("Cache-Control"))
+	assert.Equal(t, "public, no-cache", send(0).Header().Get("Cache-Control"))
+
+	status = http.StatusNotFound
+	assert.Empty(t, send(time.Minute).Header().Get("Cache-Control"))
+}
+
+func TestLoggingMiddlewareFlush(t *testing.T) {
+	next := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, ": heartbeat\n\n")
+		assert.NoError(t, http.NewResponseController(w).Flush())
+	}
+	recorder := httptest.NewRecorder()
+	NewUserMiddleware(nil).LoggingMiddleware(next)(recorder, httptest.NewRequest("GET", "/api/v1/events", nil))
+
+	assert.True(t, recorder.Flushed)
+	assert.Equal(t, ": heartbeat\n\n", recorder.Body.String())
+}
+
+func TestIdempotency(t *testing.T) {
+	calls := 0
+	next := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "fail") {
+			handlers.NewErrorResponse(w, http.StatusInternalServerError, "Failed to create movie")
+			return
+		}
+		w.Header().Set("Location", "/api/v1/movies?id=1")
+		w.Header().Set("X-Request-ID", "first")
+		handlers.NewErrorResponse(w, http.StatusCreated, "created")
+	}
+	service := usecase.NewIdempotencyService(memory.NewStorage(), time.Hour)
+	handler := Idempotency(service)(next)
+	alice := &usecase.UserInfo{UserID: uuid.New(), Role: domain.ADMIN}
+	send := func(user *usecase.UserInfo, key string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/movies", strings.NewReader(body))
+		req.Header.Set("Idempotency-Key", key)
+		req = req.WithContext(context.WithValue(req.Context(), UserCtx, user))
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+
+	first := send(alice, "key-1", `{"title":"Heat"}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	retry := send(alice, "key-1", `{"title":"Heat"}`)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "/api/v1/movies?id=1", retry.Header().Get("Location"))
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Empty(t, retry.Header().Get("X-Request-ID"))
+
+	reused := send(alice, "key-1", `{"title":"Ronin"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	assert.Equal(t, 1, calls)
+
+	// Keys of other users are separate.
+	bob := &usecase.UserInfo{UserID: uuid.New(), Role: domain.ADMIN}
+	assert.Equal(t, http.StatusCreated, send(bob, "key-1", `{"title":"Ronin"}`).Code)
+	assert.Equal(t, 2, calls)
+
+	// Server errors are not replayed.
+	assert.Equal(t, http.StatusInternalServerError, send(alice, "key-2", `"fail"`).Code)
+	assert.Equal(t, http.StatusInternalServerError, send(alice, "key-2", `"fail"`).Code)
+	assert.Equal(t, 4, calls)
+
+	assert.Equal(t, http.StatusBadRequest, send(alice, "bad key", `{}`).Code)
+
+	// Requests without a key are not tracked.
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest("POST", "/api/v1/movies", strings.NewReader(`{}`)))
+	handler(recorder, httptest.NewRequest("POST", "/api/v1/movies", strings.NewReader(`{}`)))
+	assert.Equal(t, 6, calls)
+}
+
+func TestIdempotencyInProgress(t *testing.T) {
+	service := usecase.NewIdempotencyService(memory.NewStorage(), time.Hour)
+	user := &usecase.UserInfo{UserID: uuid.New(), Role: domain.ADMIN}
+	var inner *httptest.ResponseRecorder
+	handler := Idempotency(service)(func(w http.ResponseWriter, r *http.Request) {
+		// The retry arrives while the first request is being handled.
+		inner = httptest.NewRecorder()
+		Idempotency(service)(func(http.ResponseWriter, *http.Request) {
+			t.Error("retry must not be handled")
+		})(inner, r.Clone(r.Context()))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	req := httptest.NewRequest("POST", "/api/v1/actors", strings.NewReader(`{}`))
+	req.Header.Set("Idempotency-Key", "key")
+	req = req.WithContext(context.WithValue(req.Context(), UserCtx, user))
+	handler(httptest.NewRecorder(), req)
+	assert.Equal(t, http.StatusConflict, inner.Code)
+}
+
+// failingComplete is an IdempotencyService that fails to store responses
+// the given number of times.
+type failingComplete struct {
+	*usecase.IdempotencyService
+	failures int
+	calls    int
+}
+
+func (s *failingComplete) Complete(ctx context.Context, key *domain.IdempotencyKey) error {
+	s.calls++
+	if s.calls <= s.failures {
+		return errors.New("connection reset")
+	}
+	return s.IdempotencyService.Complete(ctx, key)
+}
+
+func TestIdempotencyReleasesKey(t *testing.T) {
+	user := &usecase.UserInfo{UserID: uuid.New(), Role: domain.ADMIN}
+	send := func(handler http.HandlerFunc, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/movies", strings.NewReader(body))
+		req.Header.Set("Idempotency-Key", "key")
+		req = req.WithContext(context.WithValue(req.Context(), UserCtx, user))
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	created := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}
+
+	t.Run("Panic", func(t *testing.T) {
+		service := usecase.NewIdempotencyService(memory.NewStorage(), time.Hour)
+		panicking := Idempotency(service)(func(http.ResponseWriter, *http.Request) {
+			panic("handler failed")
+		})
+		assert.Panics(t, func() { send(panicking, `{}`) })
+		assert.Equal(t, http.StatusCreated, send(Idempotency(service)(created), `{}`).Code)
+	})
+
+	t.Run("Response stored on a later attempt", func(t *testing.T) {
+		service := &failingComplete{IdempotencyService: usecase.NewIdempotencyService(memory.NewStorage(), time.Hour), failures: completeAttempts - 1}
+		assert.Equal(t, http.StatusCreated, send(Idempotency(service)(created), `{}`).Code)
+		assert.Equal(t, completeAttempts, service.calls)
+		retry := send(Idempotency(service)(created), `{}`)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("Failed to store response", func(t *testing.T) {
+		service := &failingComplete{IdempotencyService: usecase.NewIdempotencyService(memory.NewStorage(), time.Hour), failures: completeAttempts}
+		assert.Equal(t, http.StatusCreated, send(Idempotency(service)(created), `{}`).Code)
+		// The request had its effect, so a retry must not repeat it.
+		retry := send(Idempotency(service)(func(http.ResponseWriter, *http.Request) {
+			t.Error("request must not be handled again")
+		}), `{}`)
+		assert.Equal(t, http.StatusConflict, retry.Code)
+	})
+
+	t.Run("Body too large", func(t *testing.T) {
+		service := usecase.NewIdempotencyService(memory.NewStorage(), time.Hour)
+		handler := Idempotency(service)(func(http.ResponseWriter, *http.Request) {
+			t.Error("request must not be handled")
+		})
+		body := `"` + strings.Repeat("a", maxIdempotentBodySize) + `"`
+		assert.Equal(t, http.StatusRequestEntityTooLarge, send(handler, body).Code)
+	})
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"cinema_service/internal/usecase"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds request IDs taken from clients, which end up in
+// logs and the audit log.
+const maxRequestIDLength = 128
+
+// RequestID stores the ID of the request in the request context and echoes
+// it in the X-Request-ID response header. A client-supplied X-Request-ID is
+// kept if it is short printable ASCII; otherwise a new UUID is generated.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(usecase.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < '!' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, c *LRU, key string) (string, bool) {
+	t.Helper()
+	value, ok, err := c.Get(context.Background(), key)
+	require.NoError(t, err)
+	return string(value), ok
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2, time.Minute)
+	require.NoError(t, c.Set(ctx, "a", []byte("1")))
+	require.NoError(t, c.Set(ctx, "b", []byte("2")))
+
+	value, ok := get(t, c, "a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+
+	require.NoError(t, c.Set(ctx, "c", []byte("3")))
+	_, ok = get(t, c, "b")
+	assert.False(t, ok)
+	_, ok = get(t, c, "a")
+	assert.True(t, ok)
+	_, ok = get(t, c, "c")
+	assert.True(t, ok)
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLRU(10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1")))
+	now = now.Add(59 * time.Second)
+	_, ok := get(t, c, "a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = get(t, c, "a")
+	assert.False(t, ok)
+	assert.Zero(t, c.order.Len())
+}
+
+func TestLRUDeletePrefix(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10, time.Minute)
+	for _, key := range []string{"movies:id:1", "movies:filter:{}", "actors:list:"} {
+		require.NoError(t, c.Set(ctx, key, []byte(key)))
+	}
+
+	require.NoError(t, c.DeletePrefix(ctx, "movies:"))
+	_, ok := get(t, c, "movies:id:1")
+	assert.False(t, ok)
+	_, ok = get(t, c, "movies:filter:{}")
+	assert.False(t, ok)
+	value, ok := get(t, c, "actors:list:")
+	assert.True(t, ok)
+	assert.Equal(t, "actors:list:", value)
+}
@@ -11,4 +11,6 @@ type Actor struct {
 	Surname   string
 	Sex       string
 	Birthdate time.Time
+	// Version is incremented by every update, as for movies.
+	Version int
 }
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited actions.
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+	// AuditRestore records a movie or actor restored from the trash.
+	AuditRestore = "restore"
+)
+
+// Audited entities.
+const (
+	AuditEntityMovie = "movie"
+	AuditEntityActor = "actor"
+	AuditEntityUser  = "user"
+)
+
+// AuditRecord describes one change made through the API or the admin
+// tools. Records are never modified or deleted.
+type AuditRecord struct {
+	ID uuid.UUID
+	// UserID is the user who made the change, uuid.Nil for changes made
+	// without a user, such as bootstrapping the first admin.
+	UserID   uuid.UUID
+	Action   string
+	Entity   string
+	EntityID uuid.UUID
+	// Changes maps the changed fields of the entity to their values
+	// before and after the change.
+	Changes   map[string]AuditChange
+	RequestID string
+	CreatedAt time.Time
+}
+
+// AuditChange holds the values of a field before and after a change. Before
+// is nil on creation and After on deletion.
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditFilter selects audit records. Zero fields do not filter; From is
+// inclusive and To exclusive.
+type AuditFilter struct {
+	UserID   uuid.UUID
+	Entity   string
+	EntityID uuid.UUID
+	From     time.Time
+	To       time.Time
+	Limit    int
+	Offset   int
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "github.com/google/uuid"
+
+const (
+	RoleActor    = "ACTOR"
+	RoleDirector = "DIRECTOR"
+	RoleWriter   = "WRITER"
+	RoleProducer = "PRODUCER"
+)
+
+// Roles lists credit roles in the order they are rendered.
+var Roles = []string{RoleDirector, RoleWriter, RoleProducer, RoleActor}
+
+// Credit is a person's participation in a movie. Character and
+// BillingOrder are only meaningful for acting credits.
+type Credit struct {
+	ID           uuid.UUID
+	MovieID      uuid.UUID
+	PersonID     uuid.UUID
+	Role         string
+	Character    string
+	BillingOrder int
+	Person       *Actor
+	Movie        *Movie
+}
+
+func IsRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "errors"
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrVersionConflict is returned when an entity was changed since the
+	// version a client expects.
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrForbidden is returned when the current user may not act on an
+	// entity they do not own.
+	ErrForbidden = errors.New("forbidden")
+)
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Types of catalogue events.
+const (
+	EventMovieCreated = "movie.created"
+	EventMovieUpdated = "movie.updated"
+	EventMovieDeleted = "movie.deleted"
+	// EventMovieRestored is published when a movie is restored from the
+	// trash. Its payload is the restored movie.
+	EventMovieRestored = "movie.restored"
+	EventActorCreated  = "actor.created"
+	EventActorUpdated  = "actor.updated"
+	EventActorDeleted  = "actor.deleted"
+	// EventActorRestored is published when an actor is restored from the
+	// trash. Its payload is the restored actor.
+	EventActorRestored = "actor.restored"
+	// EventCastChanged is published when a credit of a movie is added or
+	// removed. Its entity is the movie and its payload holds the Credit
+	// and whether it was Removed.
+	EventCastChanged = "cast.changed"
+)
+
+// EventTypes lists the types of catalogue events.
+var EventTypes = []string{
+	EventMovieCreated, EventMovieUpdated, EventMovieDeleted, EventMovieRestored,
+	EventActorCreated, EventActorUpdated, EventActorDeleted, EventActorRestored,
+	EventCastChanged,
+}
+
+// Event is a change of the catalogue. Events are stored in an outbox in the
+// transaction of the change and delivered at least once afterwards, so
+// consumers must ignore events whose ID they have seen.
+type Event struct {
+	ID uuid.UUID
+	// Sequence is set by the storage and increases with every event
+	// appended.
+	Sequence int64
+	Type     string
+	EntityID uuid.UUID
+	// Payload is the JSON encoding of the entity after the change, or
+	// before it for deletions.
+	Payload   json.RawMessage
+	RequestID string
+	CreatedAt time.Time
+
+	// Attempts counts the failed deliveries. A pending event is delivered
+	// again at NextAttemptAt.
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	// PublishedAt is zero until the event is delivered to every sink.
+	PublishedAt time.Time
+}
+
+// EventMessage is the JSON form in which events are sent to consumers.
+type EventMessage struct {
+	ID        uuid.UUID       `json:"id"`
+	Sequence  int64           `json:"sequence"`
+	Type      string          `json:"type"`
+	EntityID  uuid.UUID       `json:"entity_id"`
+	Payload   json.RawMessage `json:"payload"`
+	RequestID string          `json:"request_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (e *Event) Message() EventMessage {
+	return EventMessage{
+		ID:        e.ID,
+		Sequence:  e.Sequence,
+		Type:      e.Type,
+		EntityID:  e.EntityID,
+		Payload:   e.Payload,
+		RequestID: e.RequestID,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// FeedEntry is an event in the live feed of the catalogue. Entry IDs are
+// assigned by the feed in the order events reach it, so unlike sequences
+// they never go back when an event is delivered late.
+type FeedEntry struct {
+	ID    FeedID
+	Event *Event
+}
+
+// FeedID identifies an entry of the live feed. The feed starts counting
+// again when the server restarts, so the epoch, set when the feed is
+// created, tells apart the entries of different runs.
+type FeedID struct {
+	Epoch int64
+	Seq   int64
+}
+
+// String returns the ID as sent to clients: "<epoch>-<seq>".
+func (id FeedID) String() string {
+	return strconv.FormatInt(id.Epoch, 10) + "-" + strconv.FormatInt(id.Seq, 10)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "github.com/google/uuid"
+
+type Genre struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// GenreFacet is the number of movies of a genre within a filtered listing.
+type GenreFacet struct {
+	Genre *Genre
+	Count int
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent
+	// again with a different request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused for a different request")
+	// ErrRequestInProgress is returned when a request is retried before the
+	// first request with its idempotency key has been handled.
+	ErrRequestInProgress = errors.New("request in progress")
+)
+
+// IdempotencyKey is a key a user sent in the Idempotency-Key header of a
+// request, together with the response to replay when the request is
+// retried.
+type IdempotencyKey struct {
+	UserID uuid.UUID
+	Key    string
+	// Fingerprint identifies the request the key was first sent with.
+	Fingerprint string
+	// StatusCode is zero while the first request is being handled.
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+	CreatedAt  time.Time
+	// ExpiresAt is when the key can be used for another request.
+	ExpiresAt time.Time
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "errors"
+
+// Kinds of catalogue records that can be imported and exported in bulk.
+const (
+	ImportMovies  = "movies"
+	ImportActors  = "actors"
+	ImportCredits = "credits"
+)
+
+// CatalogueKinds lists the kinds of catalogue records.
+var CatalogueKinds = []string{ImportMovies, ImportActors, ImportCredits}
+
+// Formats of bulk catalogue files. FormatJSON, a single array, is only
+// produced by exports.
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatJSON   = "json"
+)
+
+var (
+	ErrInvalidImport = errors.New("invalid import")
+	ErrInvalidExport = errors.New("invalid export")
+)
+
+// ImportRowError describes a rejected row. Line is the line of the row in
+// the uploaded file, counting from one.
+type ImportRowError struct {
+	Line    int
+	Message string
+}
+
+// ImportReport summarises a bulk import. On dry runs Imported counts the
+// rows that passed validation and nothing is written.
+type ImportReport struct {
+	Kind     string
+	DryRun   bool
+	Rows     int
+	Imported int
+	Errors   []ImportRowError
+}
@@ -1,14 +1,100 @@
 package domain
 
 import (
-	"github.com/google/uuid"
+	"errors"
+	"fmt"
+	"regexp"
 	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidMovie = errors.New("invalid movie")
+
+// MaxTitleLength is the longest movie title in characters.
+const MaxTitleLength = 150
+
+// AgeRatings are the supported age certifications, youngest first.
+var AgeRatings = []string{"0+", "6+", "12+", "16+", "18+"}
+
+var (
+	countryCode  = regexp.MustCompile(`^[A-Z]{2}$`)
+	languageCode = regexp.MustCompile(`^[a-z]{2}$`)
+	imdbID       = regexp.MustCompile(`^tt[0-9]{7,9}$`)
 )
 
 type Movie struct {
 	ID          uuid.UUID
 	Title       string
 	Description string
-	Date        time.Time
-	Rating      float32
+	// Date is the release date.
+	Date   time.Time
+	Rating float32
+	// DurationMinutes and TMDBID are zero and the other metadata fields
+	// empty when unknown.
+	DurationMinutes  int
+	AgeRating        string
+	Countries        []string
+	OriginalLanguage string
+	IMDbID           string
+	TMDBID           int
+	// Genres is nil when the genres of the movie are unknown or, on
+	// updates, should be left unchanged.
+	Genres []*Genre
+	// InWatchlist is set on listings for the user who requested them.
+	InWatchlist bool
+	// Version is incremented by every update. On updates and deletes a
+	// non-zero Version is the version the client expects to change.
+	Version int
+}
+
+// Validate checks the metadata of the movie. Countries are ISO 3166-1
+// alpha-2 codes and the original language an ISO 639-1 code.
+func (m *Movie) Validate() error {
+	if m.DurationMinutes < 0 {
+		return fmt.Errorf("%w: duration must be positive", ErrInvalidMovie)
+	}
+	if m.AgeRating != "" && AgeRatingRank(m.AgeRating) < 0 {
+		return fmt.Errorf("%w: unsupported age rating %q", ErrInvalidMovie, m.AgeRating)
+	}
+	for _, country := range m.Countries {
+		if !countryCode.MatchString(country) {
+			return fmt.Errorf("%w: invalid country code %q", ErrInvalidMovie, country)
+		}
+	}
+	if m.OriginalLanguage != "" && !languageCode.MatchString(m.OriginalLanguage) {
+		return fmt.Errorf("%w: invalid language code %q", ErrInvalidMovie, m.OriginalLanguage)
+	}
+	if m.IMDbID != "" && !imdbID.MatchString(m.IMDbID) {
+		return fmt.Errorf("%w: invalid IMDb ID %q", ErrInvalidMovie, m.IMDbID)
+	}
+	if m.TMDBID < 0 {
+		return fmt.Errorf("%w: invalid TMDB ID", ErrInvalidMovie)
+	}
+	return nil
+}
+
+// AgeRatingRank returns the position of rating in AgeRatings or -1 if the
+// rating is not supported.
+func AgeRatingRank(rating string) int {
+	for i, r := range AgeRatings {
+		if r == rating {
+			return i
+		}
+	}
+	return -1
+}
+
+// MovieFilter selects and orders movie listings. Sort is one of "title",
+// "rating" or "date"; a movie must have all of GenreIDs to match. Empty
+// and zero fields do not filter.
+type MovieFilter struct {
+	Sort     string
+	GenreIDs []uuid.UUID
+	Country  string
+	Language string
+	// MaxAgeRating keeps movies certified for this age or younger.
+	MaxAgeRating string
+	MinDuration  int
+	MaxDuration  int
 }
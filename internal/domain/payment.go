@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrPaymentState is returned when a payment is not in the status an
+// operation requires, for example because it changed concurrently.
+var ErrPaymentState = errors.New("payment is in invalid state")
+
+const (
+	PaymentPending  = "PENDING"
+	PaymentCaptured = "CAPTURED"
+	PaymentFailed   = "FAILED"
+	PaymentRefunded = "REFUNDED"
+	PaymentCanceled = "CANCELED"
+)
+
+const (
+	PaymentEventSucceeded = "payment.succeeded"
+	PaymentEventFailed    = "payment.failed"
+	PaymentEventRefunded  = "payment.refunded"
+)
+
+// paymentTransitions lists the statuses a payment may move to from each
+// status. A failed payment can still be captured when the customer retries
+// the same intent; refunded and canceled payments are final.
+var paymentTransitions = map[string][]string{
+	PaymentPending:  {PaymentCaptured, PaymentFailed, PaymentCanceled},
+	PaymentFailed:   {PaymentCaptured, PaymentCanceled},
+	PaymentCaptured: {PaymentRefunded},
+}
+
+// CanTransitionPayment reports whether a payment in status from may move to
+// status to.
+func CanTransitionPayment(from, to string) bool {
+	return slices.Contains(paymentTransitions[from], to)
+}
+
+// Payment is the payment state of a single booking. Amount is stored in
+// minor currency units (kopecks, cents). UserID is the user who started the
+// payment; it is nil for payments made before owners were recorded.
+type Payment struct {
+	ID        uuid.UUID
+	BookingID uuid.UUID
+	UserID    uuid.UUID
+	IntentID  string
+	Amount    int64
+	Currency  string
+	Status    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PaymentIntent is what a payment provider returns when a payment is started.
+type PaymentIntent struct {
+	ID           string
+	ClientSecret string
+	Amount       int64
+	Currency     string
+}
+
+// PaymentEvent is a verified webhook notification from a payment provider.
+type PaymentEvent struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	IntentID string `json:"intent_id"`
+}
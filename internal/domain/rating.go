@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MinRatingScore = 0
+	MaxRatingScore = 10
+)
+
+const (
+	ReviewPending  = "PENDING"
+	ReviewApproved = "APPROVED"
+	ReviewRejected = "REJECTED"
+)
+
+type Rating struct {
+	UserID    uuid.UUID
+	MovieID   uuid.UUID
+	Score     int
+	CreatedAt time.Time
+}
+
+type RatingSummary struct {
+	MovieID uuid.UUID
+	Rating  float32
+	Votes   int
+}
+
+type Review struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	MovieID   uuid.UUID
+	Text      string
+	Status    string
+	CreatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// SigningKey is an HMAC key for access tokens. The key that is not retired
+// signs new tokens; retired keys only verify tokens issued before the
+// rotation.
+type SigningKey struct {
+	ID        string
+	Secret    []byte
+	CreatedAt time.Time
+	// RetiredAt is zero for the current key.
+	RetiredAt time.Time
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidTranslation = errors.New("invalid translation")
+
+// MovieTranslation is the title and description of a movie in one locale.
+// An empty description falls back to the original one.
+type MovieTranslation struct {
+	MovieID     uuid.UUID
+	Locale      string
+	Title       string
+	Description string
+}
+
+// ActorTranslation is the name of an actor in one locale. An empty surname
+// falls back to the original one.
+type ActorTranslation struct {
+	ActorID uuid.UUID
+	Locale  string
+	Name    string
+	Surname string
+}
+
+// ValidLocale reports whether locale is a lowercase ISO 639-1 code.
+func ValidLocale(locale string) bool {
+	return languageCode.MatchString(locale)
+}
+
+func (t *MovieTranslation) Validate() error {
+	if !ValidLocale(t.Locale) {
+		return fmt.Errorf("%w: invalid locale %q", ErrInvalidTranslation, t.Locale)
+	}
+	if t.Title == "" || utf8.RuneCountInString(t.Title) > MaxTitleLength {
+		return fmt.Errorf("%w: title must be 1 to %d characters", ErrInvalidTranslation, MaxTitleLength)
+	}
+	return nil
+}
+
+func (t *ActorTranslation) Validate() error {
+	if !ValidLocale(t.Locale) {
+		return fmt.Errorf("%w: invalid locale %q", ErrInvalidTranslation, t.Locale)
+	}
+	if t.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTranslation)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// TrashedMovie is a deleted movie that can still be restored.
+type TrashedMovie struct {
+	Movie     *Movie
+	DeletedAt time.Time
+}
+
+// TrashedActor is a deleted actor that can still be restored.
+type TrashedActor struct {
+	Actor     *Actor
+	DeletedAt time.Time
+}
+
+// Trash lists the deleted movies and actors, most recently deleted first.
+type Trash struct {
+	Movies []*TrashedMovie
+	Actors []*TrashedActor
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EntityVersion is a snapshot of a movie or an actor after a change.
+// Versions of an entity are numbered from 1 in the order they were taken.
+type EntityVersion struct {
+	Entity   string
+	EntityID uuid.UUID
+	Number   int
+	// State is the JSON encoding of the entity.
+	State json.RawMessage
+	// UserID is the user who made the change, uuid.Nil if unknown.
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+type WatchlistEntry struct {
+	Movie   *Movie
+	AddedAt time.Time
+}
+
+type FavoriteActor struct {
+	Actor   *Actor
+	AddedAt time.Time
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidSubscription = errors.New("invalid webhook subscription")
+
+// States of webhook deliveries.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	// DeliveryFailed deliveries ran out of attempts. They are only sent
+	// again when redelivered.
+	DeliveryFailed = "failed"
+)
+
+// WebhookSubscription asks for the catalogue events of EventTypes to be
+// posted to URL, signed with Secret.
+type WebhookSubscription struct {
+	ID         uuid.UUID
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  time.Time
+}
+
+func (s *WebhookSubscription) Validate() error {
+	parsed, err := url.Parse(s.URL)
+	if err != nil || parsed.Scheme != "http" && parsed.Scheme != "https" || parsed.Host == "" {
+		return fmt.Errorf("%w: invalid URL %q", ErrInvalidSubscription, s.URL)
+	}
+	if len(s.EventTypes) == 0 {
+		return fmt.Errorf("%w: no event types", ErrInvalidSubscription)
+	}
+	for _, eventType := range s.EventTypes {
+		if !slices.Contains(EventTypes, eventType) {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidSubscription, eventType)
+		}
+	}
+	if s.Secret == "" {
+		return fmt.Errorf("%w: empty secret", ErrInvalidSubscription)
+	}
+	return nil
+}
+
+// WebhookDelivery is the delivery of an event to a subscription. There is
+// at most one delivery of an event per subscription.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	// Body is the EventMessage posted to the subscriber.
+	Body   json.RawMessage
+	Status string
+	// Attempts counts the attempts since the delivery was created or
+	// redelivered. A pending delivery is attempted at NextAttemptAt.
+	Attempts      int
+	NextAttemptAt time.Time
+	// ResponseStatus is the status code of the last response, zero if
+	// there was none, and LastError the reason the last attempt failed.
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+	// DeliveredAt is zero until the subscriber accepts the delivery.
+	DeliveredAt time.Time
+}
@@ -0,0 +1,130 @@
+package events
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// feedBufferSize is the number of entries a subscriber may fall behind
+// before it is dropped.
+const feedBufferSize = 64
+
+// Feed keeps the latest events in a log of bounded size and passes new
+// events to subscribers, so that clients can follow the catalogue live and
+// resume after a disconnect. It is a sink and safe for concurrent use.
+type Feed struct {
+	mu   sync.Mutex
+	size int
+	log  []domain.FeedEntry
+	// epoch is the time the feed was created in nanoseconds, which makes
+	// the entry IDs of this run differ from those issued before a restart.
+	epoch  int64
+	lastID int64
+	// logged holds the IDs of the events in the log, which are not logged
+	// again when the outbox delivers them twice.
+	logged      map[uuid.UUID]struct{}
+	subscribers map[chan domain.FeedEntry]struct{}
+	closed      bool
+}
+
+func NewFeed(size int) *Feed {
+	return &Feed{
+		size:        size,
+		epoch:       time.Now().UnixNano(),
+		logged:      make(map[uuid.UUID]struct{}),
+		subscribers: make(map[chan domain.FeedEntry]struct{}),
+	}
+}
+
+func (f *Feed) Deliver(_ context.Context, event *domain.Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.logged[event.ID]; ok || f.closed {
+		return nil
+	}
+	copied := *event
+	f.lastID++
+	entry := domain.FeedEntry{ID: domain.FeedID{Epoch: f.epoch, Seq: f.lastID}, Event: &copied}
+
+	if len(f.log) == f.size {
+		delete(f.logged, f.log[0].Event.ID)
+		f.log = append(f.log[:0], f.log[1:]...)
+	}
+	f.log = append(f.log, entry)
+	f.logged[event.ID] = struct{}{}
+
+	for updates := range f.subscribers {
+		select {
+		case updates <- entry:
+		default:
+			// A subscriber that cannot keep up is dropped; it resumes
+			// from the log when it reconnects.
+			delete(f.subscribers, updates)
+			close(updates)
+		}
+	}
+	return nil
+}
+
+// Subscribe returns the logged entries after lastID and a channel of the
+// entries that follow them. complete is false if some entries after lastID
+// are no longer logged, or lastID was not issued by this feed, as after a
+// restart; the backlog then holds the whole log. A nil lastID subscribes
+// to new entries only. The channel is closed when the subscriber falls
+// behind or the feed is closed; cancel must be called when the subscriber
+// is done.
+func (f *Feed) Subscribe(lastID *domain.FeedID) (backlog []domain.FeedEntry, complete bool,
+	updates <-chan domain.FeedEntry, cancel func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	after := f.lastID
+	complete = true
+	if lastID != nil {
+		after = lastID.Seq
+		if lastID.Epoch != f.epoch || after > f.lastID {
+			after, complete = 0, false
+		} else if len(f.log) > 0 && after < f.log[0].ID.Seq-1 {
+			complete = false
+		}
+	}
+	for _, entry := range f.log {
+		if entry.ID.Seq > after {
+			backlog = append(backlog, entry)
+		}
+	}
+
+	ch := make(chan domain.FeedEntry, feedBufferSize)
+	if f.closed {
+		close(ch)
+		return backlog, complete, ch, func() {}
+	}
+	f.subscribers[ch] = struct{}{}
+	cancel = func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.subscribers[ch]; ok {
+			delete(f.subscribers, ch)
+			close(ch)
+		}
+	}
+	return backlog, complete, ch, cancel
+}
+
+// Close ends all subscriptions, so that streams finish when the server
+// shuts down.
+func (f *Feed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	for updates := range f.subscribers {
+		delete(f.subscribers, updates)
+		close(updates)
+	}
+}
@@ -0,0 +1,98 @@
+package events
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entryIDs(entries []domain.FeedEntry) []int64 {
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID.Seq)
+	}
+	return ids
+}
+
+func TestFeedResume(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed(3)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, feed.Deliver(ctx, newEvent()))
+	}
+
+	backlog, complete, _, cancel := feed.Subscribe(&domain.FeedID{Epoch: feed.epoch, Seq: 2})
+	defer cancel()
+	assert.True(t, complete)
+	assert.Equal(t, []int64{3, 4}, entryIDs(backlog))
+
+	// Entry 2 was dropped from the log.
+	backlog, complete, _, cancel = feed.Subscribe(&domain.FeedID{Epoch: feed.epoch, Seq: 0})
+	defer cancel()
+	assert.False(t, complete)
+	assert.Equal(t, []int64{2, 3, 4}, entryIDs(backlog))
+
+	// Entry 3 was issued before a restart, so it is not entry 3 of this run.
+	backlog, complete, _, cancel = feed.Subscribe(&domain.FeedID{Epoch: feed.epoch - 1, Seq: 3})
+	defer cancel()
+	assert.False(t, complete)
+	assert.Equal(t, []int64{2, 3, 4}, entryIDs(backlog))
+
+	// Entry 10 was never issued.
+	backlog, complete, _, cancel = feed.Subscribe(&domain.FeedID{Epoch: feed.epoch, Seq: 10})
+	defer cancel()
+	assert.False(t, complete)
+	assert.Equal(t, []int64{2, 3, 4}, entryIDs(backlog))
+
+	backlog, complete, _, cancel = feed.Subscribe(nil)
+	defer cancel()
+	assert.True(t, complete)
+	assert.Empty(t, backlog)
+}
+
+func TestFeedUpdates(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed(10)
+	_, _, updates, cancel := feed.Subscribe(nil)
+
+	event := newEvent()
+	require.NoError(t, feed.Deliver(ctx, event))
+	// Events delivered twice by the outbox are logged once.
+	require.NoError(t, feed.Deliver(ctx, event))
+
+	entry := <-updates
+	assert.Equal(t, domain.FeedID{Epoch: feed.epoch, Seq: 1}, entry.ID)
+	assert.Equal(t, strconv.FormatInt(feed.epoch, 10)+"-1", entry.ID.String())
+	assert.Equal(t, event.ID, entry.Event.ID)
+	assert.Empty(t, updates)
+
+	cancel()
+	_, ok := <-updates
+	assert.False(t, ok)
+	cancel()
+}
+
+func TestFeedDropsSlowSubscribers(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed(1000)
+	_, _, slow, cancel := feed.Subscribe(nil)
+	defer cancel()
+
+	for i := 0; i <= feedBufferSize; i++ {
+		require.NoError(t, feed.Deliver(ctx, newEvent()))
+	}
+	for range slow {
+	}
+
+	_, _, updates, _ := feed.Subscribe(nil)
+	feed.Close()
+	_, ok := <-updates
+	assert.False(t, ok)
+	_, _, updates, _ = feed.Subscribe(nil)
+	_, ok = <-updates
+	assert.False(t, ok)
+}
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPSender posts webhook deliveries to their subscribers.
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender returns a sender using client, or a client with the
+// webhook timeout if client is nil.
+func NewHTTPSender(client *http.Client) *HTTPSender {
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	return &HTTPSender{client: client}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, url string, header map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("send webhook: %w", err)
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	// Draining the body lets the connection be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"cinema_service/internal/domain"
+	"cinema_service/internal/repository/memory"
+	"cinema_service/internal/usecase"
+	"context"
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver is a subscriber that checks signatures and answers with status.
+type receiver struct {
+	mu       sync.Mutex
+	secret   string
+	status   int
+	received [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	timestamp, err := strconv.ParseInt(r.Header.Get(usecase.WebhookTimestampHeader), 10, 64)
+	signature := usecase.SignWebhook(rc.secret, timestamp, body)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(r.Header.Get(usecase.WebhookSignatureHeader))) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	rc.received = append(rc.received, body)
+	w.WriteHeader(rc.status)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+	rc := &receiver{secret: "partner-secret", status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	storage := memory.NewStorage()
+	service := usecase.NewWebhookService(storage, NewHTTPSender(server.Client()), 5)
+	subscription := &domain.WebhookSubscription{URL: server.URL, EventTypes: []string{domain.EventMovieCreated},
+		Secret: rc.secret}
+	require.NoError(t, service.CreateSubscription(ctx, subscription))
+
+	event := newEvent()
+	require.NoError(t, service.Deliver(ctx, event))
+	require.NoError(t, service.Deliver(ctx, &domain.Event{ID: event.ID, Type: domain.EventActorCreated}))
+
+	// The receiver is down: the delivery is retried later.
+	attempted, err := service.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	deliveries, err := service.GetDeliveries(ctx, subscription.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, domain.DeliveryPending, deliveries[0].Status)
+	assert.Equal(t, http.StatusServiceUnavailable, deliveries[0].ResponseStatus)
+	attempted, err = service.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, attempted)
+
+	// A redelivery is sent right away.
+	rc.mu.Lock()
+	rc.status = http.StatusOK
+	rc.mu.Unlock()
+	_, err = service.Redeliver(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+	attempted, err = service.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	deliveries, err = service.GetDeliveries(ctx, subscription.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	require.Len(t, rc.received, 2)
+	assert.JSONEq(t, string(deliveries[0].Body), string(rc.received[1]))
+}
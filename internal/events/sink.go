@@ -0,0 +1,121 @@
+// Package events implements the sinks that catalogue events are delivered
+// to by usecase.EventService.
+package events
+
+import (
+	"bytes"
+	"cinema_service/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Memory keeps delivered events in process, for tests and local runs. It
+// is safe for concurrent use.
+type Memory struct {
+	mu     sync.Mutex
+	events []*domain.Event
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Deliver(_ context.Context, event *domain.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *event
+	m.events = append(m.events, &copied)
+	return nil
+}
+
+// Events returns the delivered events in the order they were delivered.
+func (m *Memory) Events() []*domain.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*domain.Event(nil), m.events...)
+}
+
+// Log writes events to the default logger.
+type Log struct{}
+
+func (Log) Deliver(_ context.Context, event *domain.Event) error {
+	slog.Info("Event", "id", event.ID, "sequence", event.Sequence, "type", event.Type,
+		"entity_id", event.EntityID, "request_id", event.RequestID)
+	return nil
+}
+
+// webhookTimeout bounds a delivery to a webhook, so that a slow receiver
+// does not hold up the dispatcher.
+const webhookTimeout = 10 * time.Second
+
+// Webhook posts events as JSON to a URL. Responses other than 2xx are
+// failures and the event is delivered again.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string, client *http.Client) *Webhook {
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	return &Webhook{url: url, client: client}
+}
+
+func (w *Webhook) Deliver(ctx context.Context, event *domain.Event) error {
+	body, err := json.Marshal(event.Message())
+	if err != nil {
+		return fmt.Errorf("deliver event to webhook: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("deliver event to webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", event.ID.String())
+	req.Header.Set("X-Event-Type", event.Type)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("deliver event to webhook: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("deliver event to webhook: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Broker is a message broker, like Kafka or NATS, that events are
+// published to.
+type Broker interface {
+	// Publish sends a message to a topic. Messages with the same key are
+	// kept in order.
+	Publish(ctx context.Context, topic string, key string, body []byte) error
+}
+
+// BrokerSink publishes events to a Broker. The topic is the event type and
+// the key the entity, so the events of an entity stay in order.
+type BrokerSink struct {
+	broker Broker
+}
+
+func NewBrokerSink(broker Broker) *BrokerSink {
+	return &BrokerSink{broker: broker}
+}
+
+func (s *BrokerSink) Deliver(ctx context.Context, event *domain.Event) error {
+	body, err := json.Marshal(event.Message())
+	if err != nil {
+		return fmt.Errorf("deliver event to broker: %w", err)
+	}
+	if err = s.broker.Publish(ctx, event.Type, event.EntityID.String(), body); err != nil {
+		return fmt.Errorf("deliver event to broker: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package events
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEvent() *domain.Event {
+	return &domain.Event{
+		ID:        uuid.New(),
+		Sequence:  7,
+		Type:      domain.EventMovieCreated,
+		EntityID:  uuid.New(),
+		Payload:   json.RawMessage(`{"Title":"Heat"}`),
+		CreatedAt: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	event := newEvent()
+	status := http.StatusNoContent
+	var received domain.EventMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, event.ID.String(), r.Header.Get("X-Event-ID"))
+		assert.Equal(t, domain.EventMovieCreated, r.Header.Get("X-Event-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	webhook := NewWebhook(server.URL, server.Client())
+
+	require.NoError(t, webhook.Deliver(context.Background(), event))
+	assert.Equal(t, event.Message(), received)
+
+	status = http.StatusBadGateway
+	assert.ErrorContains(t, webhook.Deliver(context.Background(), event), "status 502")
+}
+
+type fakeBroker struct {
+	topic, key string
+	body       []byte
+	err        error
+}
+
+func (b *fakeBroker) Publish(_ context.Context, topic string, key string, body []byte) error {
+	b.topic, b.key, b.body = topic, key, body
+	return b.err
+}
+
+func TestBrokerSink(t *testing.T) {
+	event := newEvent()
+	broker := &fakeBroker{}
+	sink := NewBrokerSink(broker)
+
+	require.NoError(t, sink.Deliver(context.Background(), event))
+	assert.Equal(t, domain.EventMovieCreated, broker.topic)
+	assert.Equal(t, event.EntityID.String(), broker.key)
+	assert.JSONEq(t, `{
+		"id": "`+event.ID.String()+`",
+		"sequence": 7,
+		"type": "movie.created",
+		"entity_id": "`+event.EntityID.String()+`",
+		"payload": {"Title": "Heat"},
+		"created_at": "2024-05-02T10:00:00Z"
+	}`, string(broker.body))
+
+	broker.err = errors.New("broker unavailable")
+	assert.ErrorIs(t, sink.Deliver(context.Background(), event), broker.err)
+}
+
+func TestMemory(t *testing.T) {
+	memory := NewMemory()
+	event := newEvent()
+	require.NoError(t, memory.Deliver(context.Background(), event))
+	event.Attempts = 3
+
+	events := memory.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, event.ID, events[0].ID)
+	assert.Zero(t, events[0].Attempts)
+}
+
+func TestHTTPSender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "sha256=abc", r.Header.Get("X-Webhook-Signature"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"type":"movie.created"}`, string(body))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	sender := NewHTTPSender(server.Client())
+
+	status, err := sender.Send(context.Background(), server.URL,
+		map[string]string{"X-Webhook-Signature": "sha256=abc"}, []byte(`{"type":"movie.created"}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, status)
+
+	server.Close()
+	_, err = sender.Send(context.Background(), server.URL, nil, nil)
+	assert.Error(t, err)
+}
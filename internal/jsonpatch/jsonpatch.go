@@ -0,0 +1,285 @@
+// Package jsonpatch applies JSON Merge Patch (RFC 7386) and JSON Patch
+// (RFC 6902) documents to JSON documents.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPatch is returned for malformed patches and for operations
+	// on locations that do not exist.
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrTestFailed is returned when a test operation does not match.
+	ErrTestFailed = errors.New("patch test failed")
+)
+
+// MergePatch applies a JSON Merge Patch to doc.
+func MergePatch(doc []byte, patch []byte) ([]byte, error) {
+	var target, changes any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("merge patch: %w", err)
+	}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(merge(target, changes))
+}
+
+func merge(target any, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]any)
+	if !ok {
+		object = map[string]any{}
+	}
+	for name, value := range changes {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		object[name] = merge(object[name], value)
+	}
+	return object
+}
+
+type operation struct {
+	Op   string `json:"op"`
+	Path string `json:"path"`
+	From string `json:"from"`
+	// Value is empty if the operation has no value; a JSON null is kept
+	// as "null".
+	Value json.RawMessage `json:"value"`
+}
+
+// Apply applies a JSON Patch to doc. The operations are applied in order
+// and the patch fails as a whole if one of them fails.
+func Apply(doc []byte, patch []byte) ([]byte, error) {
+	var target any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("apply patch: %w", err)
+	}
+	var operations []operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	for i, op := range operations {
+		var err error
+		if target, err = op.apply(target); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+func (op operation) apply(doc any) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, fmt.Errorf("%w: %s without value", ErrInvalidPatch, op.Op)
+		}
+		var value any
+		if err = json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			if doc, err = remove(doc, path); err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		}
+		current, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("%w: %s", ErrTestFailed, op.Path)
+		}
+		return doc, nil
+	case "remove":
+		if len(path) == 0 {
+			return nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+		}
+		return remove(doc, path)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			return add(doc, path, deepCopy(value))
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("%w: cannot move %s into itself", ErrInvalidPatch, op.From)
+		}
+		if doc, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: invalid pointer %q", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch container := doc.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: no member %q", ErrInvalidPatch, token)
+			}
+			doc = value
+		case []any:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = container[index]
+		default:
+			return nil, fmt.Errorf("%w: cannot index a scalar with %q", ErrInvalidPatch, token)
+		}
+	}
+	return doc, nil
+}
+
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(doc, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			container[token] = value
+			return container, nil
+		case []any:
+			if token == "-" {
+				return append(container, value), nil
+			}
+			index, err := arrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		default:
+			return nil, fmt.Errorf("%w: cannot add %q to a scalar", ErrInvalidPatch, token)
+		}
+	})
+}
+
+func remove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	return update(doc, path, func(parent any, token string) (any, error) {
+		switch container := parent.(type) {
+		case map[string]any:
+			if _, ok := container[token]; !ok {
+				return nil, fmt.Errorf("%w: no member %q", ErrInvalidPatch, token)
+			}
+			delete(container, token)
+			return container, nil
+		case []any:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(container[:index], container[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: cannot remove %q from a scalar", ErrInvalidPatch, token)
+		}
+	})
+}
+
+// update calls fn with the container holding the last token of path and
+// stores the container it returns in its place, as appending to an array
+// may move it.
+func update(doc any, path []string, fn func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+
+	child, err := get(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	child, err = update(child, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+	switch container := doc.(type) {
+	case map[string]any:
+		container[path[0]] = child
+	case []any:
+		index, _ := arrayIndex(path[0], len(container)-1)
+		container[index] = child
+	}
+	return doc, nil
+}
+
+// arrayIndex parses an array index of at most last. Leading zeros are not
+// allowed by RFC 6901.
+func arrayIndex(token string, last int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > last || strconv.Itoa(index) != token {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+	return index, nil
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		clone := make(map[string]any, len(v))
+		for name, member := range v {
+			clone[name] = deepCopy(member)
+		}
+		return clone
+	case []any:
+		clone := make([]any, len(v))
+		for i, element := range v {
+			clone[i] = deepCopy(element)
+		}
+		return clone
+	default:
+		return value
+	}
+}
@@ -0,0 +1,143 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{name: "Replace member", doc: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "Add member", doc: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{name: "Remove member", doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{name: "Replace array", doc: `{"a":["b"]}`, patch: `{"a":["c","d"]}`, expected: `{"a":["c","d"]}`},
+		{name: "Nested object", doc: `{"a":{"b":"c","d":"e"}}`, patch: `{"a":{"d":null,"f":1}}`, expected: `{"a":{"b":"c","f":1}}`},
+		{name: "Object over scalar", doc: `{"a":"b"}`, patch: `{"a":{"c":null,"d":1}}`, expected: `{"a":{"d":1}}`},
+		{name: "Replace document", doc: `{"a":"b"}`, patch: `["c"]`, expected: `["c"]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := MergePatch([]byte(tc.doc), []byte(tc.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(result))
+		})
+	}
+
+	_, err := MergePatch([]byte(`{}`), []byte(`{`))
+	assert.ErrorIs(t, err, ErrInvalidPatch)
+}
+
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+		err      error
+	}{
+		{
+			name:     "Add member",
+			doc:      `{"foo":"bar"}`,
+			patch:    `[{"op":"add","path":"/baz","value":"qux"}]`,
+			expected: `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:     "Add array element",
+			doc:      `{"foo":["bar","baz"]}`,
+			patch:    `[{"op":"add","path":"/foo/1","value":"qux"},{"op":"add","path":"/foo/-","value":"end"}]`,
+			expected: `{"foo":["bar","qux","baz","end"]}`,
+		},
+		{
+			name:     "Remove array element",
+			doc:      `{"foo":["bar","qux","baz"]}`,
+			patch:    `[{"op":"remove","path":"/foo/1"}]`,
+			expected: `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:     "Replace with null",
+			doc:      `{"baz":"qux","foo":"bar"}`,
+			patch:    `[{"op":"replace","path":"/baz","value":null}]`,
+			expected: `{"baz":null,"foo":"bar"}`,
+		},
+		{
+			name:     "Move member",
+			doc:      `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch:    `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			expected: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:     "Copy member",
+			doc:      `{"a":{"b":[1]}}`,
+			patch:    `[{"op":"copy","from":"/a","path":"/c"},{"op":"add","path":"/c/b/-","value":2}]`,
+			expected: `{"a":{"b":[1]},"c":{"b":[1,2]}}`,
+		},
+		{
+			name:     "Escaped pointer",
+			doc:      `{"a/b":1,"m~n":2}`,
+			patch:    `[{"op":"test","path":"/a~1b","value":1},{"op":"remove","path":"/m~0n"}]`,
+			expected: `{"a/b":1}`,
+		},
+		{
+			name:  "Failed test",
+			doc:   `{"baz":"qux"}`,
+			patch: `[{"op":"test","path":"/baz","value":"bar"}]`,
+			err:   ErrTestFailed,
+		},
+		{
+			name:  "Replace missing member",
+			doc:   `{"baz":"qux"}`,
+			patch: `[{"op":"replace","path":"/foo","value":"bar"}]`,
+			err:   ErrInvalidPatch,
+		},
+		{
+			name:  "Index out of bounds",
+			doc:   `{"foo":["bar"]}`,
+			patch: `[{"op":"add","path":"/foo/2","value":"baz"}]`,
+			err:   ErrInvalidPatch,
+		},
+		{
+			name:  "Leading zero",
+			doc:   `{"foo":["bar","baz"]}`,
+			patch: `[{"op":"remove","path":"/foo/01"}]`,
+			err:   ErrInvalidPatch,
+		},
+		{
+			name:  "Missing value",
+			doc:   `{}`,
+			patch: `[{"op":"add","path":"/foo"}]`,
+			err:   ErrInvalidPatch,
+		},
+		{
+			name:  "Unknown operation",
+			doc:   `{}`,
+			patch: `[{"op":"merge","path":"/foo","value":1}]`,
+			err:   ErrInvalidPatch,
+		},
+		{
+			name:  "Move into itself",
+			doc:   `{"a":{"b":1}}`,
+			patch: `[{"op":"move","from":"/a","path":"/a/c"}]`,
+			err:   ErrInvalidPatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Apply([]byte(tc.doc), []byte(tc.patch))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(result))
+		})
+	}
+}
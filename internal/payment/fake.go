@@ -0,0 +1,162 @@
+package payment
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrIntentNotFound   = errors.New("payment intent not found")
+	ErrInvalidState     = errors.New("payment intent is in invalid state")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+const (
+	intentRequiresCapture = "requires_capture"
+	intentSucceeded       = "succeeded"
+	intentRefunded        = "refunded"
+	intentCanceled        = "canceled"
+)
+
+type fakeIntent struct {
+	amount   int64
+	refunded int64
+	currency string
+	status   string
+}
+
+// FakeProvider is an in-process payment provider for local runs and tests.
+// Intent IDs are sequential, so the same sequence of calls always produces
+// the same IDs, and webhooks are signed with HMAC-SHA256 over the raw body.
+// Captures and refunds remember their idempotency keys like a real provider.
+type FakeProvider struct {
+	secret []byte
+
+	mu      sync.Mutex
+	seq     int
+	intents map[string]*fakeIntent
+	// applied holds the idempotency keys of successful captures and refunds.
+	applied map[string]struct{}
+}
+
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{
+		secret:  []byte(secret),
+		intents: make(map[string]*fakeIntent),
+		applied: make(map[string]struct{}),
+	}
+}
+
+func (p *FakeProvider) CreateIntent(_ context.Context, amount int64, currency string, bookingID uuid.UUID) (*domain.PaymentIntent, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("create intent: amount must be positive")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.seq++
+	id := fmt.Sprintf("pi_fake_%06d", p.seq)
+	p.intents[id] = &fakeIntent{
+		amount:   amount,
+		currency: currency,
+		status:   intentRequiresCapture,
+	}
+
+	return &domain.PaymentIntent{
+		ID:           id,
+		ClientSecret: id + "_secret_" + bookingID.String(),
+		Amount:       amount,
+		Currency:     currency,
+	}, nil
+}
+
+func (p *FakeProvider) Capture(_ context.Context, intentID string, idempotencyKey string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.applied[idempotencyKey]; ok {
+		return nil
+	}
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return fmt.Errorf("capture %s: %w", intentID, ErrIntentNotFound)
+	}
+	if intent.status != intentRequiresCapture {
+		return fmt.Errorf("capture %s: %w", intentID, ErrInvalidState)
+	}
+	intent.status = intentSucceeded
+	p.applied[idempotencyKey] = struct{}{}
+	return nil
+}
+
+func (p *FakeProvider) CancelIntent(_ context.Context, intentID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return fmt.Errorf("cancel %s: %w", intentID, ErrIntentNotFound)
+	}
+	if intent.status != intentRequiresCapture && intent.status != intentCanceled {
+		return fmt.Errorf("cancel %s: %w", intentID, ErrInvalidState)
+	}
+	intent.status = intentCanceled
+	return nil
+}
+
+func (p *FakeProvider) Refund(_ context.Context, intentID string, amount int64, idempotencyKey string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.applied[idempotencyKey]; ok {
+		return nil
+	}
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return fmt.Errorf("refund %s: %w", intentID, ErrIntentNotFound)
+	}
+	if intent.status != intentSucceeded || amount <= 0 || intent.refunded+amount > intent.amount {
+		return fmt.Errorf("refund %s: %w", intentID, ErrInvalidState)
+	}
+	intent.refunded += amount
+	if intent.refunded == intent.amount {
+		intent.status = intentRefunded
+	}
+	p.applied[idempotencyKey] = struct{}{}
+	return nil
+}
+
+func (p *FakeProvider) VerifyWebhook(payload []byte, signature string) (*domain.PaymentEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, p.sign(payload)) {
+		return nil, ErrInvalidSignature
+	}
+
+	event := &domain.PaymentEvent{}
+	if err = json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("decode webhook: %w", err)
+	}
+	return event, nil
+}
+
+// SignWebhook returns the signature header value the provider would send
+// with payload. It is used to simulate provider callbacks locally.
+func (p *FakeProvider) SignWebhook(payload []byte) string {
+	return hex.EncodeToString(p.sign(payload))
+}
+
+func (p *FakeProvider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
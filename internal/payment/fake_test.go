@@ -0,0 +1,79 @@
+package payment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeProviderLifecycle(t *testing.T) {
+	provider := NewFakeProvider("secret")
+	ctx := context.Background()
+
+	intent, err := provider.CreateIntent(ctx, 1000, "RUB", uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, "pi_fake_000001", intent.ID)
+
+	assert.ErrorIs(t, provider.Refund(ctx, intent.ID, 1000, "refund-0"), ErrInvalidState)
+	require.NoError(t, provider.Capture(ctx, intent.ID, "capture-1"))
+	// A retry with the same key succeeds without capturing again.
+	require.NoError(t, provider.Capture(ctx, intent.ID, "capture-1"))
+	assert.ErrorIs(t, provider.Capture(ctx, intent.ID, "capture-2"), ErrInvalidState)
+
+	require.NoError(t, provider.Refund(ctx, intent.ID, 400, "refund-1"))
+	require.NoError(t, provider.Refund(ctx, intent.ID, 400, "refund-1"))
+	assert.ErrorIs(t, provider.Refund(ctx, intent.ID, 700, "refund-2"), ErrInvalidState)
+	require.NoError(t, provider.Refund(ctx, intent.ID, 600, "refund-3"))
+
+	assert.ErrorIs(t, provider.Capture(ctx, "pi_unknown", "capture-3"), ErrIntentNotFound)
+	assert.ErrorIs(t, provider.CancelIntent(ctx, intent.ID), ErrInvalidState)
+
+	unused, err := provider.CreateIntent(ctx, 500, "RUB", uuid.New())
+	require.NoError(t, err)
+	require.NoError(t, provider.CancelIntent(ctx, unused.ID))
+	require.NoError(t, provider.CancelIntent(ctx, unused.ID))
+	assert.ErrorIs(t, provider.Capture(ctx, unused.ID, "capture-4"), ErrInvalidState)
+}
+
+func TestFakeProviderVerifyWebhook(t *testing.T) {
+	provider := NewFakeProvider("secret")
+	payload := []byte(`{"id":"evt_1","type":"payment.succeeded","intent_id":"pi_fake_000001"}`)
+
+	testCases := []struct {
+		name      string
+		signature string
+		wantErr   bool
+	}{
+		{
+			name:      "Valid signature",
+			signature: provider.SignWebhook(payload),
+			wantErr:   false,
+		},
+		{
+			name:      "Signed with another secret",
+			signature: NewFakeProvider("other").SignWebhook(payload),
+			wantErr:   true,
+		},
+		{
+			name:      "Malformed signature",
+			signature: "not-hex",
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event, err := provider.VerifyWebhook(payload, tc.signature)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSignature)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "evt_1", event.ID)
+			assert.Equal(t, "pi_fake_000001", event.IntentID)
+		})
+	}
+}
@@ -3,10 +3,11 @@ package repository
 import (
 	"cinema_service/internal/domain"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/pkg/errors"
 )
 
 type StorageActor struct {
@@ -20,35 +21,77 @@ func NewStorageActor(dbPool *pgxpool.Pool) StorageActor {
 	return StorageActor
 }
 
+func (s *StorageActor) GetActorByID(ctx context.Context, actorID uuid.UUID) (*domain.Actor, error) {
+	act := &domain.Actor{}
+	if err := conn(ctx, s.db).QueryRow(ctx,
+		`SELECT id, name, surname, sex, birthdate, version FROM "actors" WHERE id = $1 AND deleted_at IS NULL`,
+		actorID,
+	).Scan(&act.ID, &act.Name, &act.Surname, &act.Sex, &act.Birthdate, &act.Version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get actor by id: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get actor by id: %w", err)
+	}
+	return act, nil
+}
+
+// CreateActor stores a new actor. A new ID is generated unless act has one.
 func (s *StorageActor) CreateActor(ctx context.Context, act *domain.Actor) error {
-	act.ID = uuid.New()
-	if _, err := s.db.Exec(ctx,
+	if act.ID == uuid.Nil {
+		act.ID = uuid.New()
+	}
+	if _, err := conn(ctx, s.db).Exec(ctx,
 		`INSERT INTO "actors" (id, name, surname, sex, birthdate) 
 			VALUES ($1, $2, $3, $4, $5)`,
 		&act.ID, &act.Name, &act.Surname, &act.Sex, &act.Birthdate,
 	); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("create actor: %w", domain.ErrAlreadyExists)
+		}
 		return fmt.Errorf("create actor: %w", err)
 	}
+	act.Version = 1
 	return nil
 }
 func (s *StorageActor) UpdateActor(ctx context.Context, act *domain.Actor) error {
-	if _, err := s.db.Exec(
+	if _, err := conn(ctx, s.db).Exec(
 		ctx,
-		`UPDATE "actors" SET name = $2, surname = $3, sex = $4, birthdate = $5
-              WHERE id = $1`,
+		`UPDATE "actors" SET name = $2, surname = $3, sex = $4, birthdate = $5, version = version + 1
+              WHERE id = $1 AND deleted_at IS NULL`,
 		&act.ID, &act.Name, &act.Surname, &act.Sex, &act.Birthdate,
 	); err != nil {
 		return fmt.Errorf("update actor: %w", err)
 	}
 	return nil
 }
+
+// actorFieldColumns are the columns UpdateActorFields writes, by field of
+// domain.Actor.
+var actorFieldColumns = map[string]fieldColumn[domain.Actor]{
+	"Name":      {"name = %s", func(a *domain.Actor) any { return a.Name }},
+	"Surname":   {"surname = %s", func(a *domain.Actor) any { return a.Surname }},
+	"Sex":       {"sex = %s", func(a *domain.Actor) any { return a.Sex }},
+	"Birthdate": {"birthdate = %s", func(a *domain.Actor) any { return a.Birthdate }},
+}
+
+// UpdateActorFields writes only the columns of the given fields of an actor
+// and increments their version.
+func (s *StorageActor) UpdateActorFields(ctx context.Context, act *domain.Actor, fields []string) error {
+	query, args := updateFields("actors", actorFieldColumns, act.ID, act, fields)
+	if _, err := conn(ctx, s.db).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("update actor fields: %w", err)
+	}
+	return nil
+}
+
 func (s *StorageActor) GetActors(ctx context.Context) (map[*domain.Actor][]*domain.Movie, error) {
 	var actors []*domain.Actor
-	rows, err := s.db.Query(ctx, `
-		SELECT a.id, a.name, a.surname, a.sex, a.birthdate, m.id, m.title, m.description, m.rating, m.created_at 
+	rows, err := conn(ctx, s.db).Query(ctx, `
+		SELECT a.id, a.name, a.surname, a.sex, a.birthdate, a.version, m.id, m.title, m.description, m.rating, COALESCE(m.release_date, '0001-01-01')
 		FROM actors a
-		INNER JOIN actors_movies am ON a.id = am.actors_movie_id
-		INNER JOIN movies m ON am.movies_id = m.id
+		INNER JOIN (SELECT DISTINCT person_id, movie_id FROM credits WHERE role = 'ACTOR') am ON a.id = am.person_id
+		INNER JOIN movies m ON am.movie_id = m.id
+		WHERE a.deleted_at IS NULL AND m.deleted_at IS NULL
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("get actors: %w", err)
@@ -60,7 +103,7 @@ func (s *StorageActor) GetActors(ctx context.Context) (map[*domain.Actor][]*doma
 		movie := &domain.Movie{}
 
 		if err = rows.Scan(
-			&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.Birthdate,
+			&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.Birthdate, &actor.Version,
 			&movie.ID, &movie.Title, &movie.Description, &movie.Rating, &movie.Date,
 		); err != nil {
 			return nil, fmt.Errorf("get actors: %w", err)
@@ -87,22 +130,35 @@ func (s *StorageActor) GetActors(ctx context.Context) (map[*domain.Actor][]*doma
 
 	return actorFilms, nil
 }
-func (s *StorageActor) DeleteActor(ctx context.Context, actorID uuid.UUID) error {
-	result, err := s.db.Exec(ctx,
-		`DELETE FROM "movies" WHERE id=$1`,
-		actorID,
-	)
+func (s *StorageActor) GetActorTranslations(ctx context.Context, actorIDs []uuid.UUID, locales []string) ([]*domain.ActorTranslation, error) {
+	translations, err := getActorTranslations(ctx, conn(ctx, s.db), actorIDs, locales)
+	if err != nil {
+		return nil, fmt.Errorf("get actor translations: %w", err)
+	}
+	return translations, nil
+}
+
+func (s *StorageActor) GetMovieTranslations(ctx context.Context, movieIDs []uuid.UUID, locales []string) ([]*domain.MovieTranslation, error) {
+	translations, err := getMovieTranslations(ctx, conn(ctx, s.db), movieIDs, locales)
 	if err != nil {
-		return errors.Wrap(err, "failed to delete actor")
+		return nil, fmt.Errorf("get movie translations: %w", err)
 	}
+	return translations, nil
+}
 
-	rowsAffected := result.RowsAffected()
+// DeleteActor moves an actor to the trash, keeping their credits,
+// translations and favorites for a restore.
+func (s *StorageActor) DeleteActor(ctx context.Context, actorID uuid.UUID) error {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE "actors" SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`,
+		actorID,
+	)
 	if err != nil {
-		return errors.Wrap(err, "failed to get rows affected")
+		return fmt.Errorf("delete actor: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return errors.New("actor not found")
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("delete actor: %w", domain.ErrNotFound)
 	}
 
 	return nil
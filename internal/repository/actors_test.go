@@ -0,0 +1,33 @@
+package repository_test
+
+import (
+	"cinema_service/internal/domain"
+	"cinema_service/internal/repository"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeleteActorKeepsMovies guards against deleting from the wrong table:
+// a movie sharing the actor's ID must survive.
+func TestDeleteActorKeepsMovies(t *testing.T) {
+	dbPool := openTestDB(t)
+	storageActor := repository.NewStorageActor(dbPool)
+	storageImport := repository.NewStorageImport(dbPool)
+	storageMovie := repository.NewStorageMovie(dbPool)
+	ctx := context.Background()
+
+	actor := &domain.Actor{Name: "Al", Surname: "Pacino"}
+	require.NoError(t, storageActor.CreateActor(ctx, actor))
+	_, err := storageImport.ImportMovies(ctx, []*domain.Movie{{ID: actor.ID, Title: "Heat"}})
+	require.NoError(t, err)
+
+	require.NoError(t, storageActor.DeleteActor(ctx, actor.ID))
+	assert.ErrorIs(t, storageActor.DeleteActor(ctx, actor.ID), domain.ErrNotFound)
+
+	movie, err := storageMovie.GetMovieByID(ctx, actor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Heat", movie.Title)
+}
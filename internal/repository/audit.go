@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageAudit struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageAudit(dbPool *pgxpool.Pool) StorageAudit {
+	StorageAudit := StorageAudit{
+		db: dbPool,
+	}
+	return StorageAudit
+}
+
+// AppendAuditRecord stores a record in the audit log. The ID and creation
+// time are set by the storage.
+func (s *StorageAudit) AppendAuditRecord(ctx context.Context, record *domain.AuditRecord) error {
+	record.ID = uuid.New()
+	changes := record.Changes
+	if changes == nil {
+		changes = map[string]domain.AuditChange{}
+	}
+	err := conn(ctx, s.db).QueryRow(ctx,
+		`INSERT INTO audit_log (id, user_id, action, entity, entity_id, changes, request_id)
+		VALUES ($1, NULLIF($2, '00000000-0000-0000-0000-000000000000'::uuid), $3, $4, $5, $6, $7)
+		RETURNING created_at`,
+		record.ID, record.UserID, record.Action, record.Entity, record.EntityID, changes, record.RequestID,
+	).Scan(&record.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("append audit record: %w", err)
+	}
+	return nil
+}
+
+// GetAuditRecords returns the records matching filter, newest first.
+func (s *StorageAudit) GetAuditRecords(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditRecord, error) {
+	var userID, entityID *uuid.UUID
+	if filter.UserID != uuid.Nil {
+		userID = &filter.UserID
+	}
+	if filter.EntityID != uuid.Nil {
+		entityID = &filter.EntityID
+	}
+	var entity *string
+	if filter.Entity != "" {
+		entity = &filter.Entity
+	}
+	var from, to any
+	if !filter.From.IsZero() {
+		from = filter.From
+	}
+	if !filter.To.IsZero() {
+		to = filter.To
+	}
+	var limit *int
+	if filter.Limit > 0 {
+		limit = &filter.Limit
+	}
+
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT id, COALESCE(user_id, '00000000-0000-0000-0000-000000000000'::uuid), action, entity, entity_id,
+			changes, request_id, created_at
+		FROM audit_log
+		WHERE ($1::uuid IS NULL OR user_id = $1)
+			AND ($2::varchar IS NULL OR entity = $2)
+			AND ($3::uuid IS NULL OR entity_id = $3)
+			AND ($4::timestamp IS NULL OR created_at >= $4)
+			AND ($5::timestamp IS NULL OR created_at < $5)
+		ORDER BY created_at DESC, id
+		LIMIT $6 OFFSET $7`,
+		userID, entity, entityID, from, to, limit, filter.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get audit records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*domain.AuditRecord
+	for rows.Next() {
+		record := &domain.AuditRecord{}
+		if err = rows.Scan(
+			&record.ID, &record.UserID, &record.Action, &record.Entity, &record.EntityID,
+			&record.Changes, &record.RequestID, &record.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("get audit records: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get audit records: %w", err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageCredit struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageCredit(dbPool *pgxpool.Pool) StorageCredit {
+	StorageCredit := StorageCredit{
+		db: dbPool,
+	}
+	return StorageCredit
+}
+
+func (s *StorageCredit) CreateCredit(ctx context.Context, credit *domain.Credit) error {
+	credit.ID = uuid.New()
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO "credits" (id, movie_id, person_id, role, character_name, billing_order)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+		credit.ID, credit.MovieID, credit.PersonID, credit.Role, credit.Character, credit.BillingOrder,
+	); err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return fmt.Errorf("create credit: %w", domain.ErrNotFound)
+		case isUniqueViolation(err):
+			return fmt.Errorf("create credit: %w", domain.ErrAlreadyExists)
+		}
+		return fmt.Errorf("create credit: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageCredit) DeleteCredit(ctx context.Context, creditID uuid.UUID) error {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM "credits" WHERE id = $1`,
+		creditID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete credit: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("delete credit: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageCredit) GetCredit(ctx context.Context, creditID uuid.UUID) (*domain.Credit, error) {
+	credit := &domain.Credit{}
+	err := conn(ctx, s.db).QueryRow(ctx,
+		`SELECT id, movie_id, person_id, role, character_name, billing_order
+		FROM credits WHERE id = $1`,
+		creditID,
+	).Scan(&credit.ID, &credit.MovieID, &credit.PersonID, &credit.Role, &credit.Character, &credit.BillingOrder)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get credit: %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get credit: %w", err)
+	}
+	return credit, nil
+}
+
+// GetMovieCredits returns the credits of a movie with the credited people,
+// ordered by role and billing order.
+func (s *StorageCredit) GetMovieCredits(ctx context.Context, movieID uuid.UUID) ([]*domain.Credit, error) {
+	var credits []*domain.Credit
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT c.id, c.movie_id, c.person_id, c.role, c.character_name, c.billing_order,
+			a.name, a.surname, a.sex, a.birthdate, a.version
+		FROM credits c
+		INNER JOIN actors a ON c.person_id = a.id
+		INNER JOIN movies m ON c.movie_id = m.id
+		WHERE c.movie_id = $1 AND a.deleted_at IS NULL AND m.deleted_at IS NULL
+		ORDER BY c.role, c.billing_order, a.surname, a.name`,
+		movieID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get movie credits: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		credit := &domain.Credit{Person: &domain.Actor{}}
+		if err = rows.Scan(
+			&credit.ID, &credit.MovieID, &credit.PersonID, &credit.Role, &credit.Character, &credit.BillingOrder,
+			&credit.Person.Name, &credit.Person.Surname, &credit.Person.Sex, &credit.Person.Birthdate,
+			&credit.Person.Version,
+		); err != nil {
+			return nil, fmt.Errorf("get movie credits: %w", err)
+		}
+		credit.Person.ID = credit.PersonID
+		credits = append(credits, credit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get movie credits: %w", err)
+	}
+
+	return credits, nil
+}
+
+// GetPersonCredits returns the filmography of a person, newest movies first.
+func (s *StorageCredit) GetPersonCredits(ctx context.Context, personID uuid.UUID) ([]*domain.Credit, error) {
+	var credits []*domain.Credit
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT c.id, c.movie_id, c.person_id, c.role, c.character_name, c.billing_order,
+			m.title, m.description, m.rating, COALESCE(m.release_date, '0001-01-01')
+		FROM credits c
+		INNER JOIN movies m ON c.movie_id = m.id
+		INNER JOIN actors a ON c.person_id = a.id
+		WHERE c.person_id = $1 AND m.deleted_at IS NULL AND a.deleted_at IS NULL
+		ORDER BY c.role, m.release_date DESC NULLS LAST`,
+		personID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get person credits: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		credit := &domain.Credit{Movie: &domain.Movie{}}
+		if err = rows.Scan(
+			&credit.ID, &credit.MovieID, &credit.PersonID, &credit.Role, &credit.Character, &credit.BillingOrder,
+			&credit.Movie.Title, &credit.Movie.Description, &credit.Movie.Rating, &credit.Movie.Date,
+		); err != nil {
+			return nil, fmt.Errorf("get person credits: %w", err)
+		}
+		credit.Movie.ID = credit.MovieID
+		credits = append(credits, credit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get person credits: %w", err)
+	}
+
+	return credits, nil
+}
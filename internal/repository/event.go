@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageEvent struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageEvent(dbPool *pgxpool.Pool) StorageEvent {
+	StorageEvent := StorageEvent{
+		db: dbPool,
+	}
+	return StorageEvent
+}
+
+// AppendEvent stores an event in the outbox. The sequence is set by the
+// storage.
+func (s *StorageEvent) AppendEvent(ctx context.Context, event *domain.Event) error {
+	err := conn(ctx, s.db).QueryRow(ctx,
+		`INSERT INTO event_outbox (id, type, entity_id, payload, request_id, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING sequence`,
+		event.ID, event.Type, event.EntityID, []byte(event.Payload), event.RequestID, event.CreatedAt,
+		event.NextAttemptAt,
+	).Scan(&event.Sequence)
+	if err != nil {
+		return fmt.Errorf("append event: %w", err)
+	}
+	return nil
+}
+
+// ClaimPendingEvents postpones the due events to until in one statement.
+// Rows claimed by a concurrent dispatcher are skipped rather than waited
+// for.
+func (s *StorageEvent) ClaimPendingEvents(ctx context.Context, now, until time.Time, limit int) ([]*domain.Event, error) {
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`UPDATE event_outbox SET next_attempt_at = $2
+		WHERE sequence IN (
+			SELECT sequence FROM event_outbox
+			WHERE published_at IS NULL AND next_attempt_at <= $1
+			ORDER BY sequence
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+eventColumns,
+		now, until, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("claim pending events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.Event
+	for rows.Next() {
+		event := &domain.Event{}
+		if err = scanEvent(rows, event); err != nil {
+			return nil, fmt.Errorf("claim pending events: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("claim pending events: %w", err)
+	}
+
+	// RETURNING does not keep the order of the subquery.
+	slices.SortFunc(events, func(a, b *domain.Event) int {
+		return cmp.Compare(a.Sequence, b.Sequence)
+	})
+	return events, nil
+}
+
+func (s *StorageEvent) MarkEventPublished(ctx context.Context, eventID uuid.UUID, publishedAt time.Time) error {
+	tag, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE event_outbox SET published_at = $2 WHERE id = $1`,
+		eventID, publishedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("mark event published: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("mark event published: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageEvent) MarkEventFailed(ctx context.Context, event *domain.Event) error {
+	tag, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE event_outbox SET attempts = $2, next_attempt_at = $3, last_error = $4 WHERE id = $1`,
+		event.ID, event.Attempts, event.NextAttemptAt, event.LastError,
+	)
+	if err != nil {
+		return fmt.Errorf("mark event failed: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("mark event failed: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// PurgePublishedEvents removes the events published before
+// publishedBefore. Pending events are kept however old they are.
+func (s *StorageEvent) PurgePublishedEvents(ctx context.Context, publishedBefore time.Time) (int, error) {
+	tag, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM event_outbox WHERE published_at < $1`,
+		publishedBefore,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("purge events: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// eventColumns is the column list scanned by scanEvent.
+const eventColumns = `id, sequence, type, entity_id, payload, request_id, created_at,
+	attempts, next_attempt_at, last_error, COALESCE(published_at, '0001-01-01')`
+
+func scanEvent(row pgx.Row, event *domain.Event) error {
+	var payload []byte
+	if err := row.Scan(
+		&event.ID, &event.Sequence, &event.Type, &event.EntityID, &payload, &event.RequestID, &event.CreatedAt,
+		&event.Attempts, &event.NextAttemptAt, &event.LastError, &event.PublishedAt,
+	); err != nil {
+		return err
+	}
+	event.Payload = payload
+	return nil
+}
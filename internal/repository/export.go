@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageExport struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageExport(dbPool *pgxpool.Pool) StorageExport {
+	StorageExport := StorageExport{
+		db: dbPool,
+	}
+	return StorageExport
+}
+
+// ExportMovies calls fn for every movie ordered by ID without loading the
+// whole table. Returning an error from fn stops the export. Deleted movies,
+// actors and their credits are not exported.
+func (s *StorageExport) ExportMovies(ctx context.Context, fn func(*domain.Movie) error) error {
+	rows, err := conn(ctx, s.db).Query(ctx, `SELECT `+movieColumns+` FROM movies m WHERE m.deleted_at IS NULL ORDER BY m.id`)
+	if err != nil {
+		return fmt.Errorf("export movies: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		movie := &domain.Movie{}
+		if err = scanMovie(rows, movie); err != nil {
+			return fmt.Errorf("export movies: %w", err)
+		}
+		if err = fn(movie); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("export movies: %w", err)
+	}
+	return nil
+}
+
+// ExportActors calls fn for every actor ordered by ID.
+func (s *StorageExport) ExportActors(ctx context.Context, fn func(*domain.Actor) error) error {
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT id, COALESCE(name, ''), COALESCE(surname, ''), COALESCE(sex, ''),
+			COALESCE(birthdate, '0001-01-01'), version
+		FROM actors WHERE deleted_at IS NULL ORDER BY id`)
+	if err != nil {
+		return fmt.Errorf("export actors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		actor := &domain.Actor{}
+		if err = rows.Scan(&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.Birthdate, &actor.Version); err != nil {
+			return fmt.Errorf("export actors: %w", err)
+		}
+		if err = fn(actor); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("export actors: %w", err)
+	}
+	return nil
+}
+
+// ExportCredits calls fn for every credit ordered by movie, role and
+// billing order.
+func (s *StorageExport) ExportCredits(ctx context.Context, fn func(*domain.Credit) error) error {
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT c.id, c.movie_id, c.person_id, c.role, c.character_name, c.billing_order
+		FROM credits c
+		INNER JOIN movies m ON c.movie_id = m.id
+		INNER JOIN actors a ON c.person_id = a.id
+		WHERE m.deleted_at IS NULL AND a.deleted_at IS NULL
+		ORDER BY c.movie_id, c.role, c.billing_order, c.id`)
+	if err != nil {
+		return fmt.Errorf("export credits: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		credit := &domain.Credit{}
+		if err = rows.Scan(
+			&credit.ID, &credit.MovieID, &credit.PersonID, &credit.Role, &credit.Character, &credit.BillingOrder,
+		); err != nil {
+			return fmt.Errorf("export credits: %w", err)
+		}
+		if err = fn(credit); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("export credits: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// fieldColumn is how a field of an entity is written: an assignment with a
+// %s verb for the parameter, and the value of the field.
+type fieldColumn[T any] struct {
+	assignment string
+	value      func(*T) any
+}
+
+// updateFields builds an UPDATE of the columns of fields of a live row of
+// table that increments the version of the row. Fields without a column are
+// skipped.
+func updateFields[T any](table string, columns map[string]fieldColumn[T],
+	id uuid.UUID, entity *T, fields []string) (string, []any) {
+	assignments := []string{"version = version + 1"}
+	args := []any{id}
+	for _, field := range fields {
+		column, ok := columns[field]
+		if !ok {
+			continue
+		}
+		args = append(args, column.value(entity))
+		assignments = append(assignments, fmt.Sprintf(column.assignment, "$"+strconv.Itoa(len(args))))
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET %s WHERE id = $1 AND deleted_at IS NULL`,
+		pgx.Identifier{table}.Sanitize(), strings.Join(assignments, ", "))
+	return query, args
+}
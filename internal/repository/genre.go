@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageGenre struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageGenre(dbPool *pgxpool.Pool) StorageGenre {
+	StorageGenre := StorageGenre{
+		db: dbPool,
+	}
+	return StorageGenre
+}
+
+func (s *StorageGenre) CreateGenre(ctx context.Context, genre *domain.Genre) error {
+	genre.ID = uuid.New()
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO "genres" (id, name) VALUES ($1, $2)`,
+		genre.ID, genre.Name,
+	); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("create genre: %w", domain.ErrAlreadyExists)
+		}
+		return fmt.Errorf("create genre: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageGenre) UpdateGenre(ctx context.Context, genre *domain.Genre) error {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE "genres" SET name = $2 WHERE id = $1`,
+		genre.ID, genre.Name,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("update genre: %w", domain.ErrAlreadyExists)
+		}
+		return fmt.Errorf("update genre: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("update genre: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageGenre) DeleteGenre(ctx context.Context, genreID uuid.UUID) error {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM "genres" WHERE id = $1`,
+		genreID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete genre: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("delete genre: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageGenre) GetGenre(ctx context.Context, genreID uuid.UUID) (*domain.Genre, error) {
+	genre := &domain.Genre{}
+	err := conn(ctx, s.db).QueryRow(ctx,
+		`SELECT id, name FROM "genres" WHERE id = $1`,
+		genreID,
+	).Scan(&genre.ID, &genre.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get genre: %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get genre: %w", err)
+	}
+	return genre, nil
+}
+
+func (s *StorageGenre) GetGenres(ctx context.Context) ([]*domain.Genre, error) {
+	var genres []*domain.Genre
+	rows, err := conn(ctx, s.db).Query(ctx, `SELECT id, name FROM "genres" ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("get genres: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		genre := &domain.Genre{}
+		if err = rows.Scan(&genre.ID, &genre.Name); err != nil {
+			return nil, fmt.Errorf("get genres: %w", err)
+		}
+		genres = append(genres, genre)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get genres: %w", err)
+	}
+
+	return genres, nil
+}
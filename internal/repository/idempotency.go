@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageIdempotency struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageIdempotency(dbPool *pgxpool.Pool) StorageIdempotency {
+	StorageIdempotency := StorageIdempotency{
+		db: dbPool,
+	}
+	return StorageIdempotency
+}
+
+// CreateIdempotencyKey stores a new key, replacing an expired key of the
+// same user.
+func (s *StorageIdempotency) CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error {
+	tag, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO idempotency_keys (user_id, key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, key) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, header = NULL, body = NULL,
+				created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= EXCLUDED.created_at`,
+		key.UserID, key.Key, key.Fingerprint, key.CreatedAt, key.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create idempotency key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("create idempotency key: %w", domain.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (s *StorageIdempotency) GetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) (*domain.IdempotencyKey, error) {
+	stored := &domain.IdempotencyKey{UserID: userID, Key: key}
+	err := conn(ctx, s.db).QueryRow(ctx,
+		`SELECT fingerprint, COALESCE(status_code, 0), header, body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2`,
+		userID, key,
+	).Scan(&stored.Fingerprint, &stored.StatusCode, &stored.Header, &stored.Body, &stored.CreatedAt, &stored.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get idempotency key: %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get idempotency key: %w", err)
+	}
+	return stored, nil
+}
+
+// SaveIdempotencyResponse stores the response to the request of a key.
+func (s *StorageIdempotency) SaveIdempotencyResponse(ctx context.Context, key *domain.IdempotencyKey) error {
+	tag, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE idempotency_keys SET status_code = $3, header = $4, body = $5
+		WHERE user_id = $1 AND key = $2`,
+		key.UserID, key.Key, key.StatusCode, key.Header, key.Body,
+	)
+	if err != nil {
+		return fmt.Errorf("save idempotency response: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("save idempotency response: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageIdempotency) DeleteIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2`,
+		userID, key,
+	); err != nil {
+		return fmt.Errorf("delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PurgeIdempotencyKeys removes the keys that expired before expiredBefore.
+func (s *StorageIdempotency) PurgeIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (int, error) {
+	tag, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM idempotency_keys WHERE expires_at < $1`,
+		expiredBefore,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("purge idempotency keys: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageImport struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageImport(dbPool *pgxpool.Pool) StorageImport {
+	StorageImport := StorageImport{
+		db: dbPool,
+	}
+	return StorageImport
+}
+
+// ImportMovies upserts a batch of movies by ID. Genres and ratings of
+// existing movies are left untouched. Movies in the trash are not changed;
+// their IDs are returned.
+func (s *StorageImport) ImportMovies(ctx context.Context, movies []*domain.Movie) ([]uuid.UUID, error) {
+	columns := []string{
+		"id", "title", "description", "rating", "release_date", "duration_minutes", "age_rating",
+		"countries", "original_language", "imdb_id", "tmdb_id",
+	}
+	trashed, err := s.copyAndUpsert(ctx, "movies", columns, true, len(movies), func(i int) ([]any, error) {
+		movie := movies[i]
+		return []any{
+			movie.ID, movie.Title, movie.Description, movie.Rating, nullTime(movie.Date),
+			nullInt(movie.DurationMinutes), nullString(movie.AgeRating), countriesOrEmpty(movie.Countries),
+			nullString(movie.OriginalLanguage), nullString(movie.IMDbID), nullInt(movie.TMDBID),
+		}, nil
+	}, `ON CONFLICT (id) DO UPDATE SET title = EXCLUDED.title, description = EXCLUDED.description,
+		release_date = EXCLUDED.release_date,
+		duration_minutes = EXCLUDED.duration_minutes, age_rating = EXCLUDED.age_rating,
+		countries = EXCLUDED.countries, original_language = EXCLUDED.original_language,
+		imdb_id = EXCLUDED.imdb_id, tmdb_id = EXCLUDED.tmdb_id, version = movies.version + 1
+		WHERE movies.deleted_at IS NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("import movies: %w", err)
+	}
+	return trashed, nil
+}
+
+// ImportActors upserts a batch of actors by ID. Actors in the trash are not
+// changed; their IDs are returned.
+func (s *StorageImport) ImportActors(ctx context.Context, actors []*domain.Actor) ([]uuid.UUID, error) {
+	columns := []string{"id", "name", "surname", "sex", "birthdate"}
+	trashed, err := s.copyAndUpsert(ctx, "actors", columns, true, len(actors), func(i int) ([]any, error) {
+		actor := actors[i]
+		return []any{actor.ID, actor.Name, actor.Surname, actor.Sex, nullTime(actor.Birthdate)}, nil
+	}, `ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, surname = EXCLUDED.surname,
+		sex = EXCLUDED.sex, birthdate = EXCLUDED.birthdate, version = actors.version + 1
+		WHERE actors.deleted_at IS NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("import actors: %w", err)
+	}
+	return trashed, nil
+}
+
+// ImportCredits upserts a batch of credits. A credit is identified by its
+// movie, person, role and character, so only the billing order is updated.
+func (s *StorageImport) ImportCredits(ctx context.Context, credits []*domain.Credit) error {
+	columns := []string{"id", "movie_id", "person_id", "role", "character_name", "billing_order"}
+	_, err := s.copyAndUpsert(ctx, "credits", columns, false, len(credits), func(i int) ([]any, error) {
+		credit := credits[i]
+		return []any{
+			credit.ID, credit.MovieID, credit.PersonID, credit.Role, credit.Character, credit.BillingOrder,
+		}, nil
+	}, `ON CONFLICT (movie_id, person_id, role, character_name) DO UPDATE SET billing_order = EXCLUDED.billing_order`)
+	if err != nil {
+		return fmt.Errorf("import credits: %w", err)
+	}
+	return nil
+}
+
+// copyAndUpsert streams rows into a temporary copy of table with COPY and
+// moves them into table with an upsert, all in one transaction. COPY
+// cannot resolve conflicts by itself. For tables with a trash it returns
+// the IDs of the rows that match trashed records, which onConflict must
+// leave alone.
+func (s *StorageImport) copyAndUpsert(ctx context.Context, table string, columns []string, trash bool,
+	length int, next func(int) ([]any, error), onConflict string) ([]uuid.UUID, error) {
+	tx, err := conn(ctx, s.db).Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	staging := pgx.Identifier{"import_" + table}
+	if _, err = tx.Exec(ctx, fmt.Sprintf(
+		`CREATE TEMPORARY TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP`,
+		staging.Sanitize(), pgx.Identifier{table}.Sanitize(),
+	)); err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.CopyFrom(ctx, staging, columns, pgx.CopyFromSlice(length, next)); err != nil {
+		return nil, err
+	}
+
+	var trashed []uuid.UUID
+	if trash {
+		if trashed, err = trashedIDs(ctx, tx, table, staging); err != nil {
+			return nil, err
+		}
+	}
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = pgx.Identifier{column}.Sanitize()
+	}
+	list := strings.Join(quoted, ", ")
+
+	if _, err = tx.Exec(ctx, fmt.Sprintf(
+		`INSERT INTO %s (%s) SELECT %s FROM %s %s`,
+		pgx.Identifier{table}.Sanitize(), list, list, staging.Sanitize(), onConflict,
+	)); err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return nil, fmt.Errorf("%w: %s", domain.ErrNotFound, err)
+		case isUniqueViolation(err):
+			return nil, fmt.Errorf("%w: %s", domain.ErrAlreadyExists, err)
+		}
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return trashed, nil
+}
+
+// trashedIDs returns the IDs of the staged rows that match records of table
+// in the trash.
+func trashedIDs(ctx context.Context, tx pgx.Tx, table string, staging pgx.Identifier) ([]uuid.UUID, error) {
+	rows, err := tx.Query(ctx, fmt.Sprintf(
+		`SELECT t.id FROM %s t JOIN %s s ON s.id = t.id WHERE t.deleted_at IS NOT NULL`,
+		pgx.Identifier{table}.Sanitize(), staging.Sanitize(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func nullString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func nullInt(value int) any {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
+
+func nullTime(value time.Time) any {
+	if value.IsZero() {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) GetActorByID(ctx context.Context, actorID uuid.UUID) (*domain.Actor, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	actor, ok := s.liveActor(actorID)
+	if !ok {
+		return nil, fmt.Errorf("get actor by id: %w", domain.ErrNotFound)
+	}
+	return &actor, nil
+}
+
+func (s *Storage) CreateActor(ctx context.Context, act *domain.Actor) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if act.ID == uuid.Nil {
+		act.ID = uuid.New()
+	}
+	if _, ok := s.actors[act.ID]; ok {
+		return fmt.Errorf("create actor: %w", domain.ErrAlreadyExists)
+	}
+	s.storeActor(act)
+	act.Version = 1
+	return nil
+}
+
+func (s *Storage) UpdateActor(ctx context.Context, act *domain.Actor) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.liveActor(act.ID); ok {
+		s.storeActor(act)
+	}
+	return nil
+}
+
+// UpdateActorFields writes only the given fields of an actor and
+// increments their version.
+func (s *Storage) UpdateActorFields(ctx context.Context, act *domain.Actor, fields []string) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	updated, ok := s.liveActor(act.ID)
+	if !ok {
+		return nil
+	}
+	copyFields(&updated, act, fields)
+	s.storeActor(&updated)
+	return nil
+}
+
+// storeActor inserts or replaces an actor and increments their version.
+// The caller holds the lock.
+func (s *Storage) storeActor(act *domain.Actor) {
+	stored := *act
+	stored.Version = s.actors[act.ID].Version + 1
+	s.actors[act.ID] = stored
+}
+
+// GetActors returns the actors with acting credits and the movies they
+// acted in.
+func (s *Storage) GetActors(ctx context.Context) (map[*domain.Actor][]*domain.Movie, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	byID := make(map[uuid.UUID]*domain.Actor)
+	seen := make(map[[2]uuid.UUID]struct{})
+	actorFilms := make(map[*domain.Actor][]*domain.Movie)
+	for _, credit := range s.credits {
+		if credit.Role != domain.RoleActor {
+			continue
+		}
+		record, movieOK := s.liveMovie(credit.MovieID)
+		a, actorOK := s.liveActor(credit.PersonID)
+		if !movieOK || !actorOK {
+			continue
+		}
+		pair := [2]uuid.UUID{credit.PersonID, credit.MovieID}
+		if _, ok := seen[pair]; ok {
+			continue
+		}
+		seen[pair] = struct{}{}
+
+		actor, ok := byID[credit.PersonID]
+		if !ok {
+			actor = &a
+			byID[actor.ID] = actor
+		}
+		actorFilms[actor] = append(actorFilms[actor], movieSummary(&record.movie))
+	}
+	for _, movies := range actorFilms {
+		sortByID(movies, func(m *domain.Movie) uuid.UUID { return m.ID })
+	}
+	return actorFilms, nil
+}
+
+func (s *Storage) GetActorTranslations(ctx context.Context, actorIDs []uuid.UUID, locales []string) ([]*domain.ActorTranslation, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	return s.actorTranslationsOf(actorIDs, locales), nil
+}
+
+// DeleteActor moves an actor to the trash.
+func (s *Storage) DeleteActor(ctx context.Context, actorID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.liveActor(actorID); !ok {
+		return fmt.Errorf("delete actor: %w", domain.ErrNotFound)
+	}
+	s.deletedActors[actorID] = now()
+	return nil
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"maps"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) AppendAuditRecord(ctx context.Context, record *domain.AuditRecord) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	record.ID = uuid.New()
+	record.CreatedAt = now()
+	stored := *record
+	stored.Changes = maps.Clone(record.Changes)
+	s.auditLog = append(s.auditLog, stored)
+	return nil
+}
+
+// GetAuditRecords returns the records matching filter, newest first.
+func (s *Storage) GetAuditRecords(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditRecord, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var records []*domain.AuditRecord
+	for i := len(s.auditLog) - 1; i >= 0; i-- {
+		record := s.auditLog[i]
+		if !auditMatches(&record, filter) {
+			continue
+		}
+		record.Changes = maps.Clone(record.Changes)
+		records = append(records, &record)
+	}
+
+	if filter.Offset >= len(records) {
+		return nil, nil
+	}
+	records = records[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(records) {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
+
+func auditMatches(record *domain.AuditRecord, filter domain.AuditFilter) bool {
+	switch {
+	case filter.UserID != uuid.Nil && record.UserID != filter.UserID:
+		return false
+	case filter.Entity != "" && record.Entity != filter.Entity:
+		return false
+	case filter.EntityID != uuid.Nil && record.EntityID != filter.EntityID:
+		return false
+	case !filter.From.IsZero() && record.CreatedAt.Before(filter.From):
+		return false
+	case !filter.To.IsZero() && !record.CreatedAt.Before(filter.To):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) CreateCredit(ctx context.Context, credit *domain.Credit) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	credit.ID = uuid.New()
+	if err := s.checkCredit(credit); err != nil {
+		return fmt.Errorf("create credit: %w", err)
+	}
+	if _, ok := s.creditByKey(credit); ok {
+		return fmt.Errorf("create credit: %w", domain.ErrAlreadyExists)
+	}
+	s.storeCredit(credit)
+	return nil
+}
+
+func (s *Storage) DeleteCredit(ctx context.Context, creditID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.credits[creditID]; !ok {
+		return fmt.Errorf("delete credit: %w", domain.ErrNotFound)
+	}
+	delete(s.credits, creditID)
+	return nil
+}
+
+func (s *Storage) GetCredit(ctx context.Context, creditID uuid.UUID) (*domain.Credit, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	credit, ok := s.credits[creditID]
+	if !ok {
+		return nil, fmt.Errorf("get credit: %w", domain.ErrNotFound)
+	}
+	return &credit, nil
+}
+
+// GetMovieCredits returns the credits of a movie with the credited people,
+// ordered by role and billing order.
+func (s *Storage) GetMovieCredits(ctx context.Context, movieID uuid.UUID) ([]*domain.Credit, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var credits []*domain.Credit
+	if _, ok := s.liveMovie(movieID); !ok {
+		return credits, nil
+	}
+	for _, credit := range s.credits {
+		if credit.MovieID != movieID {
+			continue
+		}
+		person, ok := s.liveActor(credit.PersonID)
+		if !ok {
+			continue
+		}
+		credit.Person = &person
+		credits = append(credits, &credit)
+	}
+	sort.Slice(credits, func(i, j int) bool {
+		a, b := credits[i], credits[j]
+		switch {
+		case a.Role != b.Role:
+			return a.Role < b.Role
+		case a.BillingOrder != b.BillingOrder:
+			return a.BillingOrder < b.BillingOrder
+		case a.Person.Surname != b.Person.Surname:
+			return a.Person.Surname < b.Person.Surname
+		}
+		return a.Person.Name < b.Person.Name
+	})
+	return credits, nil
+}
+
+// GetPersonCredits returns the filmography of a person, newest movies first.
+func (s *Storage) GetPersonCredits(ctx context.Context, personID uuid.UUID) ([]*domain.Credit, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var credits []*domain.Credit
+	if _, ok := s.liveActor(personID); !ok {
+		return credits, nil
+	}
+	for _, credit := range s.credits {
+		if credit.PersonID != personID {
+			continue
+		}
+		record, ok := s.liveMovie(credit.MovieID)
+		if !ok {
+			continue
+		}
+		credit.Movie = movieSummary(&record.movie)
+		credits = append(credits, &credit)
+	}
+	sort.Slice(credits, func(i, j int) bool {
+		a, b := credits[i], credits[j]
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		// Movies without a release date come last.
+		if a.Movie.Date.IsZero() != b.Movie.Date.IsZero() {
+			return b.Movie.Date.IsZero()
+		}
+		return a.Movie.Date.After(b.Movie.Date)
+	})
+	return credits, nil
+}
+
+// checkCredit checks the foreign keys of a credit. The caller holds the
+// lock.
+func (s *Storage) checkCredit(credit *domain.Credit) error {
+	_, movieOK := s.movies[credit.MovieID]
+	_, personOK := s.actors[credit.PersonID]
+	if !movieOK || !personOK {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// creditByKey finds the credit with the movie, person, role and character
+// of credit. The caller holds the lock.
+func (s *Storage) creditByKey(credit *domain.Credit) (domain.Credit, bool) {
+	for _, c := range s.credits {
+		if c.MovieID == credit.MovieID && c.PersonID == credit.PersonID &&
+			c.Role == credit.Role && c.Character == credit.Character {
+			return c, true
+		}
+	}
+	return domain.Credit{}, false
+}
+
+// storeCredit stores the columns of a credit. The caller holds the write
+// lock.
+func (s *Storage) storeCredit(credit *domain.Credit) {
+	stored := *credit
+	stored.Person = nil
+	stored.Movie = nil
+	s.credits[credit.ID] = stored
+}
@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"bytes"
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppendEvent stores an event in the outbox. Like a Postgres sequence, the
+// sequence numbers of rolled back events are not reused.
+func (s *Storage) AppendEvent(ctx context.Context, event *domain.Event) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	s.lastEventSequence++
+	event.Sequence = s.lastEventSequence
+	s.outbox = append(s.outbox, *copyEvent(*event))
+	return nil
+}
+
+func (s *Storage) ClaimPendingEvents(ctx context.Context, now, until time.Time, limit int) ([]*domain.Event, error) {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	var events []*domain.Event
+	for i := range s.outbox {
+		if len(events) == limit {
+			break
+		}
+		event := &s.outbox[i]
+		if event.PublishedAt.IsZero() && !event.NextAttemptAt.After(now) {
+			event.NextAttemptAt = until
+			events = append(events, copyEvent(*event))
+		}
+	}
+	return events, nil
+}
+
+func (s *Storage) MarkEventPublished(ctx context.Context, eventID uuid.UUID, publishedAt time.Time) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	event, ok := s.outboxEvent(eventID)
+	if !ok {
+		return fmt.Errorf("mark event published: %w", domain.ErrNotFound)
+	}
+	event.PublishedAt = publishedAt
+	return nil
+}
+
+func (s *Storage) MarkEventFailed(ctx context.Context, failed *domain.Event) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	event, ok := s.outboxEvent(failed.ID)
+	if !ok {
+		return fmt.Errorf("mark event failed: %w", domain.ErrNotFound)
+	}
+	event.Attempts = failed.Attempts
+	event.NextAttemptAt = failed.NextAttemptAt
+	event.LastError = failed.LastError
+	return nil
+}
+
+// PurgePublishedEvents removes the events published before
+// publishedBefore. Pending events are kept however old they are.
+func (s *Storage) PurgePublishedEvents(ctx context.Context, publishedBefore time.Time) (int, error) {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	kept := s.outbox[:0]
+	for _, event := range s.outbox {
+		if event.PublishedAt.IsZero() || !event.PublishedAt.Before(publishedBefore) {
+			kept = append(kept, event)
+		}
+	}
+	purged := len(s.outbox) - len(kept)
+	s.outbox = kept
+	return purged, nil
+}
+
+// outboxEvent returns the stored event with an ID. The caller holds the
+// write lock.
+func (s *Storage) outboxEvent(eventID uuid.UUID) (*domain.Event, bool) {
+	for i := range s.outbox {
+		if s.outbox[i].ID == eventID {
+			return &s.outbox[i], true
+		}
+	}
+	return nil, false
+}
+
+func copyEvent(event domain.Event) *domain.Event {
+	event.Payload = bytes.Clone(event.Payload)
+	return &event
+}
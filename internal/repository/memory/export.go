@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"bytes"
+	"cinema_service/internal/domain"
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// ExportMovies calls fn for every movie ordered by ID. The movies are
+// copied first, so fn may call back into the storage. Returning an error
+// from fn stops the export. Deleted movies, actors and their credits are
+// not exported.
+func (s *Storage) ExportMovies(ctx context.Context, fn func(*domain.Movie) error) error {
+	s.rlock(ctx)
+	movies := make([]*domain.Movie, 0, len(s.movies))
+	for id, record := range s.movies {
+		if _, ok := s.liveMovie(id); !ok {
+			continue
+		}
+		movies = append(movies, copyMovie(&record.movie))
+	}
+	s.runlock(ctx)
+
+	sortByID(movies, func(m *domain.Movie) uuid.UUID { return m.ID })
+	for _, movie := range movies {
+		if err := fn(movie); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportActors calls fn for every actor ordered by ID.
+func (s *Storage) ExportActors(ctx context.Context, fn func(*domain.Actor) error) error {
+	s.rlock(ctx)
+	actors := make([]*domain.Actor, 0, len(s.actors))
+	for id := range s.actors {
+		actor, ok := s.liveActor(id)
+		if !ok {
+			continue
+		}
+		actors = append(actors, &actor)
+	}
+	s.runlock(ctx)
+
+	sortByID(actors, func(a *domain.Actor) uuid.UUID { return a.ID })
+	for _, actor := range actors {
+		if err := fn(actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportCredits calls fn for every credit ordered by movie, role and
+// billing order.
+func (s *Storage) ExportCredits(ctx context.Context, fn func(*domain.Credit) error) error {
+	s.rlock(ctx)
+	credits := make([]*domain.Credit, 0, len(s.credits))
+	for _, credit := range s.credits {
+		_, movieOK := s.liveMovie(credit.MovieID)
+		_, personOK := s.liveActor(credit.PersonID)
+		if !movieOK || !personOK {
+			continue
+		}
+		credits = append(credits, &credit)
+	}
+	s.runlock(ctx)
+
+	sort.Slice(credits, func(i, j int) bool {
+		a, b := credits[i], credits[j]
+		switch {
+		case a.MovieID != b.MovieID:
+			return bytes.Compare(a.MovieID[:], b.MovieID[:]) < 0
+		case a.Role != b.Role:
+			return a.Role < b.Role
+		case a.BillingOrder != b.BillingOrder:
+			return a.BillingOrder < b.BillingOrder
+		}
+		return bytes.Compare(a.ID[:], b.ID[:]) < 0
+	})
+	for _, credit := range credits {
+		if err := fn(credit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) CreateGenre(ctx context.Context, genre *domain.Genre) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	genre.ID = uuid.New()
+	if s.genreNameTaken(genre) {
+		return fmt.Errorf("create genre: %w", domain.ErrAlreadyExists)
+	}
+	s.genres[genre.ID] = *genre
+	return nil
+}
+
+func (s *Storage) UpdateGenre(ctx context.Context, genre *domain.Genre) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.genres[genre.ID]; !ok {
+		return fmt.Errorf("update genre: %w", domain.ErrNotFound)
+	}
+	if s.genreNameTaken(genre) {
+		return fmt.Errorf("update genre: %w", domain.ErrAlreadyExists)
+	}
+	s.genres[genre.ID] = *genre
+	return nil
+}
+
+func (s *Storage) DeleteGenre(ctx context.Context, genreID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.genres[genreID]; !ok {
+		return fmt.Errorf("delete genre: %w", domain.ErrNotFound)
+	}
+	delete(s.genres, genreID)
+	for movieID, genres := range s.movieGenres {
+		delete(genres, genreID)
+		if len(genres) == 0 {
+			delete(s.movieGenres, movieID)
+		}
+	}
+	return nil
+}
+
+func (s *Storage) GetGenre(ctx context.Context, genreID uuid.UUID) (*domain.Genre, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	genre, ok := s.genres[genreID]
+	if !ok {
+		return nil, fmt.Errorf("get genre: %w", domain.ErrNotFound)
+	}
+	return &genre, nil
+}
+
+func (s *Storage) GetGenres(ctx context.Context) ([]*domain.Genre, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var genres []*domain.Genre
+	for _, genre := range s.genres {
+		genres = append(genres, &genre)
+	}
+	sort.Slice(genres, func(i, j int) bool { return genres[i].Name < genres[j].Name })
+	return genres, nil
+}
+
+// genreNameTaken reports whether another genre has the name of genre. The
+// caller holds the lock.
+func (s *Storage) genreNameTaken(genre *domain.Genre) bool {
+	for id, g := range s.genres {
+		if id != genre.ID && g.Name == genre.Name {
+			return true
+		}
+	}
+	return false
+}
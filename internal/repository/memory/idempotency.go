@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type idempotencyKeyID struct {
+	userID uuid.UUID
+	key    string
+}
+
+// CreateIdempotencyKey stores a new key, replacing an expired key of the
+// same user.
+func (s *Storage) CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	id := idempotencyKeyID{userID: key.UserID, key: key.Key}
+	if stored, ok := s.idempotencyKeys[id]; ok && stored.ExpiresAt.After(key.CreatedAt) {
+		return fmt.Errorf("create idempotency key: %w", domain.ErrAlreadyExists)
+	}
+	s.idempotencyKeys[id] = domain.IdempotencyKey{
+		UserID:      key.UserID,
+		Key:         key.Key,
+		Fingerprint: key.Fingerprint,
+		CreatedAt:   key.CreatedAt,
+		ExpiresAt:   key.ExpiresAt,
+	}
+	return nil
+}
+
+func (s *Storage) GetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) (*domain.IdempotencyKey, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	stored, ok := s.idempotencyKeys[idempotencyKeyID{userID: userID, key: key}]
+	if !ok {
+		return nil, fmt.Errorf("get idempotency key: %w", domain.ErrNotFound)
+	}
+	stored.Header = cloneHeader(stored.Header)
+	stored.Body = slices.Clone(stored.Body)
+	return &stored, nil
+}
+
+// SaveIdempotencyResponse stores the response to the request of a key.
+func (s *Storage) SaveIdempotencyResponse(ctx context.Context, key *domain.IdempotencyKey) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	id := idempotencyKeyID{userID: key.UserID, key: key.Key}
+	stored, ok := s.idempotencyKeys[id]
+	if !ok {
+		return fmt.Errorf("save idempotency response: %w", domain.ErrNotFound)
+	}
+	stored.StatusCode = key.StatusCode
+	stored.Header = cloneHeader(key.Header)
+	stored.Body = slices.Clone(key.Body)
+	s.idempotencyKeys[id] = stored
+	return nil
+}
+
+func (s *Storage) DeleteIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	delete(s.idempotencyKeys, idempotencyKeyID{userID: userID, key: key})
+	return nil
+}
+
+// PurgeIdempotencyKeys removes the keys that expired before expiredBefore.
+func (s *Storage) PurgeIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (int, error) {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	purged := 0
+	for id, key := range s.idempotencyKeys {
+		if key.ExpiresAt.Before(expiredBefore) {
+			delete(s.idempotencyKeys, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func cloneHeader(header map[string][]string) map[string][]string {
+	if header == nil {
+		return nil
+	}
+	clone := maps.Clone(header)
+	for name, values := range clone {
+		clone[name] = slices.Clone(values)
+	}
+	return clone
+}
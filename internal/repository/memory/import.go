@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ImportMovies upserts a batch of movies by ID. Genres, ratings and vote
+// counts of existing movies are left untouched, and movies in the trash are
+// not changed; their IDs are returned. A batch is stored entirely or not at
+// all.
+func (s *Storage) ImportMovies(ctx context.Context, movies []*domain.Movie) ([]uuid.UUID, error) {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	imported := make(map[uuid.UUID]struct{}, len(movies))
+	for _, movie := range movies {
+		imported[movie.ID] = struct{}{}
+	}
+	imdbIDs := make(map[string]uuid.UUID)
+	tmdbIDs := make(map[int]uuid.UUID)
+	claim := func(movie *domain.Movie) error {
+		if movie.IMDbID != "" {
+			if id, ok := imdbIDs[movie.IMDbID]; ok && id != movie.ID {
+				return fmt.Errorf("%w: IMDb ID %s", domain.ErrAlreadyExists, movie.IMDbID)
+			}
+			imdbIDs[movie.IMDbID] = movie.ID
+		}
+		if movie.TMDBID != 0 {
+			if id, ok := tmdbIDs[movie.TMDBID]; ok && id != movie.ID {
+				return fmt.Errorf("%w: TMDB ID %d", domain.ErrAlreadyExists, movie.TMDBID)
+			}
+			tmdbIDs[movie.TMDBID] = movie.ID
+		}
+		return nil
+	}
+	for id, record := range s.movies {
+		if _, ok := imported[id]; !ok {
+			// Stored movies are unique already.
+			_ = claim(&record.movie)
+		}
+	}
+	for _, movie := range movies {
+		if err := claim(movie); err != nil {
+			return nil, fmt.Errorf("import movies: %w", err)
+		}
+	}
+
+	var trashed []uuid.UUID
+	for _, movie := range movies {
+		if _, deleted := s.deletedMovies[movie.ID]; deleted {
+			trashed = append(trashed, movie.ID)
+			continue
+		}
+		if record, ok := s.movies[movie.ID]; ok {
+			stored := *movie
+			stored.Rating = record.movie.Rating
+			movie = &stored
+		}
+		s.storeMovie(movie)
+	}
+	return trashed, nil
+}
+
+// ImportActors upserts a batch of actors by ID. Actors in the trash are not
+// changed; their IDs are returned.
+func (s *Storage) ImportActors(ctx context.Context, actors []*domain.Actor) ([]uuid.UUID, error) {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	var trashed []uuid.UUID
+	for _, actor := range actors {
+		if _, deleted := s.deletedActors[actor.ID]; deleted {
+			trashed = append(trashed, actor.ID)
+			continue
+		}
+		s.storeActor(actor)
+	}
+	return trashed, nil
+}
+
+// ImportCredits upserts a batch of credits. A credit is identified by its
+// movie, person, role and character, so only the billing order is updated.
+// A batch is stored entirely or not at all.
+func (s *Storage) ImportCredits(ctx context.Context, credits []*domain.Credit) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	for _, credit := range credits {
+		if err := s.checkCredit(credit); err != nil {
+			return fmt.Errorf("import credits: %w: movie %s, person %s", err, credit.MovieID, credit.PersonID)
+		}
+		if _, ok := s.creditByKey(credit); ok {
+			continue
+		}
+		if _, taken := s.credits[credit.ID]; taken {
+			return fmt.Errorf("import credits: %w: credit %s", domain.ErrAlreadyExists, credit.ID)
+		}
+	}
+
+	for _, credit := range credits {
+		if existing, ok := s.creditByKey(credit); ok {
+			existing.BillingOrder = credit.BillingOrder
+			s.credits[existing.ID] = existing
+			continue
+		}
+		s.storeCredit(credit)
+	}
+	return nil
+}
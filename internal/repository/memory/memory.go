@@ -0,0 +1,238 @@
+// Package memory keeps the catalogue, users and payments in process
+// memory. It implements the same repository interfaces as the Postgres
+// storage, including its constraint errors, and is meant for demos and
+// tests. Everything is lost when the process exits.
+package memory
+
+import (
+	"bytes"
+	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Storage is safe for concurrent use. Methods return copies, so callers
+// may modify the results. Methods lock mu through lock and rlock, which
+// leave it alone inside transactions.
+type Storage struct {
+	mu sync.RWMutex
+
+	actors   map[uuid.UUID]domain.Actor
+	movies   map[uuid.UUID]*movieRecord
+	users    map[uuid.UUID]domain.User
+	genres   map[uuid.UUID]domain.Genre
+	credits  map[uuid.UUID]domain.Credit
+	payments map[uuid.UUID]domain.Payment
+
+	// deletedMovies and deletedActors hold the deletion times of records
+	// in the trash. Trashed records stay in movies and actors, but reads
+	// skip them.
+	deletedMovies map[uuid.UUID]time.Time
+	deletedActors map[uuid.UUID]time.Time
+
+	// movieGenres maps a movie to the set of its genres.
+	movieGenres map[uuid.UUID]map[uuid.UUID]struct{}
+	ratings     map[ratingKey]domain.Rating
+	reviews     map[uuid.UUID]domain.Review
+	// watchlist and favorites hold entries in the order they were added.
+	watchlist map[uuid.UUID][]listEntry
+	favorites map[uuid.UUID][]listEntry
+
+	movieTranslations map[uuid.UUID]map[string]domain.MovieTranslation
+	actorTranslations map[uuid.UUID]map[string]domain.ActorTranslation
+
+	webhookEvents map[string]struct{}
+	signingKeys   []domain.SigningKey
+	// auditLog holds audit records in the order they were appended.
+	auditLog []domain.AuditRecord
+	// versions holds the versions of each entity, oldest first.
+	versions map[versionKey][]domain.EntityVersion
+
+	idempotencyKeys map[idempotencyKeyID]domain.IdempotencyKey
+
+	// outbox holds events by sequence. lastEventSequence is not restored
+	// when a transaction rolls back.
+	outbox            []domain.Event
+	lastEventSequence int64
+
+	webhookSubscriptions map[uuid.UUID]domain.WebhookSubscription
+	webhookDeliveries    map[uuid.UUID]domain.WebhookDelivery
+}
+
+var (
+	_ usecase.ActorsRepo      = (*Storage)(nil)
+	_ usecase.MovieRepo       = (*Storage)(nil)
+	_ usecase.UserRepo        = (*Storage)(nil)
+	_ usecase.PaymentRepo     = (*Storage)(nil)
+	_ usecase.RatingRepo      = (*Storage)(nil)
+	_ usecase.WatchlistRepo   = (*Storage)(nil)
+	_ usecase.GenreRepo       = (*Storage)(nil)
+	_ usecase.CreditRepo      = (*Storage)(nil)
+	_ usecase.TranslationRepo = (*Storage)(nil)
+	_ usecase.ImportRepo      = (*Storage)(nil)
+	_ usecase.ExportRepo      = (*Storage)(nil)
+	_ usecase.SigningKeyRepo  = (*Storage)(nil)
+	_ usecase.TrashRepo       = (*Storage)(nil)
+	_ usecase.AuditRepo       = (*Storage)(nil)
+	_ usecase.VersionRepo     = (*Storage)(nil)
+	_ usecase.IdempotencyRepo = (*Storage)(nil)
+	_ usecase.EventRepo       = (*Storage)(nil)
+	_ usecase.WebhookRepo     = (*Storage)(nil)
+	_ usecase.Transactor      = (*Storage)(nil)
+)
+
+type movieRecord struct {
+	movie domain.Movie
+	votes int
+}
+
+type ratingKey struct {
+	userID  uuid.UUID
+	movieID uuid.UUID
+}
+
+type listEntry struct {
+	id      uuid.UUID
+	addedAt time.Time
+}
+
+func NewStorage() *Storage {
+	return &Storage{
+		actors:            make(map[uuid.UUID]domain.Actor),
+		movies:            make(map[uuid.UUID]*movieRecord),
+		users:             make(map[uuid.UUID]domain.User),
+		genres:            make(map[uuid.UUID]domain.Genre),
+		credits:           make(map[uuid.UUID]domain.Credit),
+		payments:          make(map[uuid.UUID]domain.Payment),
+		deletedMovies:     make(map[uuid.UUID]time.Time),
+		deletedActors:     make(map[uuid.UUID]time.Time),
+		movieGenres:       make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		ratings:           make(map[ratingKey]domain.Rating),
+		reviews:           make(map[uuid.UUID]domain.Review),
+		watchlist:         make(map[uuid.UUID][]listEntry),
+		favorites:         make(map[uuid.UUID][]listEntry),
+		movieTranslations: make(map[uuid.UUID]map[string]domain.MovieTranslation),
+		actorTranslations: make(map[uuid.UUID]map[string]domain.ActorTranslation),
+		webhookEvents:     make(map[string]struct{}),
+		versions:          make(map[versionKey][]domain.EntityVersion),
+		idempotencyKeys:   make(map[idempotencyKeyID]domain.IdempotencyKey),
+
+		webhookSubscriptions: make(map[uuid.UUID]domain.WebhookSubscription),
+		webhookDeliveries:    make(map[uuid.UUID]domain.WebhookDelivery),
+	}
+}
+
+// now returns the current time at the microsecond precision of Postgres
+// timestamps.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// copyFields copies the named fields of src to dst. The ID is never copied
+// and names of missing fields are ignored.
+func copyFields[T any](dst *T, src *T, fields []string) {
+	to := reflect.ValueOf(dst).Elem()
+	from := reflect.ValueOf(src).Elem()
+	for _, name := range fields {
+		if name == "ID" {
+			continue
+		}
+		if field := to.FieldByName(name); field.IsValid() {
+			field.Set(from.FieldByName(name))
+		}
+	}
+}
+
+// sortByID orders records the way Postgres orders uuid columns.
+func sortByID[T any](records []T, id func(T) uuid.UUID) {
+	sort.Slice(records, func(i, j int) bool {
+		a, b := id(records[i]), id(records[j])
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+}
+
+// removeEntry drops id from a watchlist or favorites list.
+func removeEntry(entries []listEntry, id uuid.UUID) []listEntry {
+	for i, entry := range entries {
+		if entry.id == id {
+			return append(entries[:i:i], entries[i+1:]...)
+		}
+	}
+	return entries
+}
+
+// liveMovie returns a movie that exists and is not in the trash. The caller
+// holds the lock.
+func (s *Storage) liveMovie(movieID uuid.UUID) (*movieRecord, bool) {
+	record, ok := s.movies[movieID]
+	if !ok {
+		return nil, false
+	}
+	if _, deleted := s.deletedMovies[movieID]; deleted {
+		return nil, false
+	}
+	return record, true
+}
+
+// liveActor returns an actor that exists and is not in the trash. The
+// caller holds the lock.
+func (s *Storage) liveActor(actorID uuid.UUID) (domain.Actor, bool) {
+	actor, ok := s.actors[actorID]
+	if !ok {
+		return domain.Actor{}, false
+	}
+	if _, deleted := s.deletedActors[actorID]; deleted {
+		return domain.Actor{}, false
+	}
+	return actor, true
+}
+
+// deleteMovie removes a movie and everything that references it, like the
+// ON DELETE CASCADE foreign keys do. The caller holds the write lock.
+func (s *Storage) deleteMovie(movieID uuid.UUID) {
+	delete(s.movies, movieID)
+	delete(s.deletedMovies, movieID)
+	delete(s.movieGenres, movieID)
+	delete(s.movieTranslations, movieID)
+	delete(s.versions, versionKey{entity: domain.AuditEntityMovie, entityID: movieID})
+	for id, credit := range s.credits {
+		if credit.MovieID == movieID {
+			delete(s.credits, id)
+		}
+	}
+	for key := range s.ratings {
+		if key.movieID == movieID {
+			delete(s.ratings, key)
+		}
+	}
+	for id, review := range s.reviews {
+		if review.MovieID == movieID {
+			delete(s.reviews, id)
+		}
+	}
+	for userID, entries := range s.watchlist {
+		s.watchlist[userID] = removeEntry(entries, movieID)
+	}
+}
+
+// deleteActor removes an actor and everything that references it. The
+// caller holds the write lock.
+func (s *Storage) deleteActor(actorID uuid.UUID) {
+	delete(s.actors, actorID)
+	delete(s.deletedActors, actorID)
+	delete(s.actorTranslations, actorID)
+	delete(s.versions, versionKey{entity: domain.AuditEntityActor, entityID: actorID})
+	for id, credit := range s.credits {
+		if credit.PersonID == actorID {
+			delete(s.credits, id)
+		}
+	}
+	for userID, entries := range s.favorites {
+		s.favorites[userID] = removeEntry(entries, actorID)
+	}
+}
@@ -0,0 +1,85 @@
+package memory_test
+
+import (
+	"cinema_service/internal/domain"
+	"cinema_service/internal/repository/memory"
+	"cinema_service/internal/repository/repotest"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRepositories(storage *memory.Storage) repotest.Repositories {
+	return repotest.Repositories{
+		Actors:       storage,
+		Movies:       storage,
+		Users:        storage,
+		Payments:     storage,
+		Ratings:      storage,
+		Watchlist:    storage,
+		Genres:       storage,
+		Credits:      storage,
+		Translations: storage,
+		Import:       storage,
+		Export:       storage,
+		SigningKeys:  storage,
+		Trash:        storage,
+		Audit:        storage,
+		Versions:     storage,
+		Idempotency:  storage,
+		Events:       storage,
+		Webhooks:     storage,
+		Transactor:   storage,
+	}
+}
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		return newRepositories(memory.NewStorage())
+	})
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	storage := memory.NewStorage()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				movie := &domain.Movie{Title: "Heat"}
+				assert.NoError(t, storage.CreateMovie(ctx, movie))
+				_, err := storage.GetMovies(ctx)
+				assert.NoError(t, err)
+				assert.NoError(t, storage.DeleteMovie(ctx, movie.ID))
+			}
+		}()
+	}
+	wg.Wait()
+
+	movies, err := storage.GetMovies(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, movies)
+}
+
+func TestResultsAreCopies(t *testing.T) {
+	storage := memory.NewStorage()
+	ctx := context.Background()
+	movie := &domain.Movie{Title: "Heat", Countries: []string{"US"}}
+	require.NoError(t, storage.CreateMovie(ctx, movie))
+	movie.Countries[0] = "GB"
+
+	movies, err := storage.GetMovies(ctx)
+	require.NoError(t, err)
+	movies[0].Title = "Ronin"
+
+	stored, err := storage.GetMovieByID(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Heat", stored.Title)
+	assert.Equal(t, []string{"US"}, stored.Countries)
+}
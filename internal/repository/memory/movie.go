@@ -0,0 +1,268 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) GetMovieByID(ctx context.Context, movieID uuid.UUID) (*domain.Movie, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	record, ok := s.liveMovie(movieID)
+	if !ok {
+		return nil, fmt.Errorf("get movie by id: %w", domain.ErrNotFound)
+	}
+	return s.movieWithGenres(&record.movie), nil
+}
+
+func (s *Storage) CreateMovie(ctx context.Context, movie *domain.Movie) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if movie.ID == uuid.Nil {
+		movie.ID = uuid.New()
+	}
+	if _, ok := s.movies[movie.ID]; ok {
+		return fmt.Errorf("create movie: %w", domain.ErrAlreadyExists)
+	}
+	if err := s.checkMovieUnique(movie); err != nil {
+		return fmt.Errorf("create movie: %w", err)
+	}
+	stored := copyMovie(movie)
+	stored.Rating = 0
+	s.storeMovie(stored)
+	movie.Version = 1
+	return nil
+}
+
+func (s *Storage) GetMovies(ctx context.Context) ([]*domain.Movie, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var movies []*domain.Movie
+	for id, record := range s.movies {
+		if _, ok := s.liveMovie(id); ok {
+			movies = append(movies, s.movieWithGenres(&record.movie))
+		}
+	}
+	sortByID(movies, func(m *domain.Movie) uuid.UUID { return m.ID })
+	return movies, nil
+}
+
+// GetMoviesBySnippet matches the snippet case-sensitively against titles,
+// translated titles and the names of the credited actors.
+func (s *Storage) GetMoviesBySnippet(ctx context.Context, snippet string) ([]*domain.Movie, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var movies []*domain.Movie
+	for id, record := range s.movies {
+		if _, ok := s.liveMovie(id); ok && s.movieMatches(&record.movie, snippet) {
+			movies = append(movies, s.movieWithGenres(&record.movie))
+		}
+	}
+	sortByID(movies, func(m *domain.Movie) uuid.UUID { return m.ID })
+	return movies, nil
+}
+
+func (s *Storage) movieMatches(movie *domain.Movie, snippet string) bool {
+	if strings.Contains(movie.Title, snippet) {
+		return true
+	}
+	for _, translation := range s.movieTranslations[movie.ID] {
+		if strings.Contains(translation.Title, snippet) {
+			return true
+		}
+	}
+	for _, credit := range s.credits {
+		if credit.MovieID != movie.ID || credit.Role != domain.RoleActor {
+			continue
+		}
+		actor, ok := s.liveActor(credit.PersonID)
+		if !ok {
+			continue
+		}
+		if strings.Contains(actor.Name, snippet) {
+			return true
+		}
+		for _, translation := range s.actorTranslations[credit.PersonID] {
+			if strings.Contains(translation.Name, snippet) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Storage) UpdateMovie(ctx context.Context, movie *domain.Movie) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	record, ok := s.liveMovie(movie.ID)
+	if !ok {
+		return nil
+	}
+	if err := s.checkMovieUnique(movie); err != nil {
+		return fmt.Errorf("update movie: %w", err)
+	}
+	updated := copyMovie(movie)
+	updated.Rating = record.movie.Rating
+	s.storeMovie(updated)
+	return nil
+}
+
+// UpdateMovieFields writes only the given fields of a movie and increments
+// its version.
+func (s *Storage) UpdateMovieFields(ctx context.Context, movie *domain.Movie, fields []string) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	record, ok := s.liveMovie(movie.ID)
+	if !ok {
+		return nil
+	}
+	updated := copyMovie(&record.movie)
+	copyFields(updated, movie, fields)
+	updated.Rating = record.movie.Rating
+	if err := s.checkMovieUnique(updated); err != nil {
+		return fmt.Errorf("update movie fields: %w", err)
+	}
+	s.storeMovie(updated)
+	return nil
+}
+
+// DeleteMovie moves a movie to the trash.
+func (s *Storage) DeleteMovie(ctx context.Context, movieID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.liveMovie(movieID); ok {
+		s.deletedMovies[movieID] = now()
+	}
+	return nil
+}
+
+func (s *Storage) GetWatchlistMovieIDs(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	ids := make(map[uuid.UUID]struct{})
+	for _, entry := range s.watchlist[userID] {
+		ids[entry.id] = struct{}{}
+	}
+	return ids, nil
+}
+
+// SetMovieGenres replaces the genres of a movie. Nothing changes if a genre
+// does not exist.
+func (s *Storage) SetMovieGenres(ctx context.Context, movieID uuid.UUID, genreIDs []uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	genres := make(map[uuid.UUID]struct{}, len(genreIDs))
+	for _, id := range genreIDs {
+		if _, ok := s.genres[id]; !ok {
+			return fmt.Errorf("set movie genres: %w", domain.ErrNotFound)
+		}
+		genres[id] = struct{}{}
+	}
+	if _, ok := s.movies[movieID]; !ok && len(genres) > 0 {
+		return fmt.Errorf("set movie genres: %w", domain.ErrNotFound)
+	}
+
+	delete(s.movieGenres, movieID)
+	if len(genres) > 0 {
+		s.movieGenres[movieID] = genres
+	}
+	return nil
+}
+
+func (s *Storage) GetMovieTranslations(ctx context.Context, movieIDs []uuid.UUID, locales []string) ([]*domain.MovieTranslation, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	return s.movieTranslationsOf(movieIDs, locales), nil
+}
+
+// checkMovieUnique enforces the unique external IDs of movies. The caller
+// holds the lock.
+func (s *Storage) checkMovieUnique(movie *domain.Movie) error {
+	for id, record := range s.movies {
+		if id == movie.ID {
+			continue
+		}
+		if movie.IMDbID != "" && record.movie.IMDbID == movie.IMDbID ||
+			movie.TMDBID != 0 && record.movie.TMDBID == movie.TMDBID {
+			return domain.ErrAlreadyExists
+		}
+	}
+	return nil
+}
+
+// storeMovie inserts or replaces the columns of a movie, keeping its vote
+// count. Genres and the watchlist flag are not movie columns. The caller
+// holds the write lock.
+func (s *Storage) storeMovie(movie *domain.Movie) {
+	stored := copyMovie(movie)
+	stored.Genres = nil
+	stored.InWatchlist = false
+	if stored.Countries == nil {
+		stored.Countries = []string{}
+	}
+
+	record, ok := s.movies[movie.ID]
+	if !ok {
+		record = &movieRecord{}
+		s.movies[movie.ID] = record
+	}
+	stored.Version = record.movie.Version + 1
+	record.movie = *stored
+}
+
+// movieWithGenres returns a copy of movie with its genres ordered by name.
+// The caller holds the lock.
+func (s *Storage) movieWithGenres(movie *domain.Movie) *domain.Movie {
+	result := copyMovie(movie)
+	result.Genres = []*domain.Genre{}
+	for id := range s.movieGenres[movie.ID] {
+		genre := s.genres[id]
+		result.Genres = append(result.Genres, &genre)
+	}
+	sort.Slice(result.Genres, func(i, j int) bool {
+		return result.Genres[i].Name < result.Genres[j].Name
+	})
+	return result
+}
+
+// movieSummary returns the columns of a movie shown in listings of other
+// records, such as watchlists and filmographies.
+func movieSummary(movie *domain.Movie) *domain.Movie {
+	return &domain.Movie{
+		ID:          movie.ID,
+		Title:       movie.Title,
+		Description: movie.Description,
+		Rating:      movie.Rating,
+		Date:        movie.Date,
+	}
+}
+
+func copyMovie(movie *domain.Movie) *domain.Movie {
+	result := *movie
+	if movie.Countries != nil {
+		result.Countries = append([]string{}, movie.Countries...)
+	}
+	if movie.Genres != nil {
+		result.Genres = make([]*domain.Genre, len(movie.Genres))
+		for i, genre := range movie.Genres {
+			g := *genre
+			result.Genres[i] = &g
+		}
+	}
+	return &result
+}
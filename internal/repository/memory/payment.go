@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// CreatePayment stores a payment. Booking and intent IDs are unique.
+func (s *Storage) CreatePayment(ctx context.Context, payment *domain.Payment) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	for _, p := range s.payments {
+		if p.BookingID == payment.BookingID || p.IntentID == payment.IntentID {
+			return fmt.Errorf("create payment: %w", domain.ErrAlreadyExists)
+		}
+	}
+	payment.ID = uuid.New()
+	payment.CreatedAt = now()
+	payment.UpdatedAt = payment.CreatedAt
+	s.payments[payment.ID] = *payment
+	return nil
+}
+
+func (s *Storage) GetPaymentByBookingID(ctx context.Context, bookingID uuid.UUID) (*domain.Payment, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	for _, payment := range s.payments {
+		if payment.BookingID == bookingID {
+			return &payment, nil
+		}
+	}
+	return nil, fmt.Errorf("get payment by booking id: %w", domain.ErrNotFound)
+}
+
+func (s *Storage) GetPaymentByIntentID(ctx context.Context, intentID string) (*domain.Payment, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	for _, payment := range s.payments {
+		if payment.IntentID == intentID {
+			return &payment, nil
+		}
+	}
+	return nil, fmt.Errorf("get payment by intent id: %w", domain.ErrNotFound)
+}
+
+func (s *Storage) UpdatePaymentStatus(ctx context.Context, paymentID uuid.UUID, from string, to string) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	payment, ok := s.payments[paymentID]
+	if !ok || payment.Status != from {
+		return fmt.Errorf("update payment status: %w", domain.ErrPaymentState)
+	}
+	payment.Status = to
+	payment.UpdatedAt = now()
+	s.payments[paymentID] = payment
+	return nil
+}
+
+// SaveWebhookEvent records a provider event ID and reports whether it was
+// seen for the first time.
+func (s *Storage) SaveWebhookEvent(ctx context.Context, eventID string) (bool, error) {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.webhookEvents[eventID]; ok {
+		return false, nil
+	}
+	s.webhookEvents[eventID] = struct{}{}
+	return true, nil
+}
@@ -0,0 +1,173 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) UpsertRating(ctx context.Context, rating *domain.Rating) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if !s.userAndMovieExist(rating.UserID, rating.MovieID) {
+		return fmt.Errorf("upsert rating: %w", domain.ErrNotFound)
+	}
+	stored := *rating
+	stored.CreatedAt = now()
+	s.ratings[ratingKey{userID: rating.UserID, movieID: rating.MovieID}] = stored
+	return nil
+}
+
+func (s *Storage) DeleteRating(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	delete(s.ratings, ratingKey{userID: userID, movieID: movieID})
+	return nil
+}
+
+func (s *Storage) GetRatingStats(ctx context.Context, movieID uuid.UUID) (float64, int, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var sum, count int
+	for key, rating := range s.ratings {
+		if key.movieID == movieID {
+			sum += rating.Score
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0, nil
+	}
+	return float64(sum) / float64(count), count, nil
+}
+
+func (s *Storage) GetGlobalRatingMean(ctx context.Context) (float64, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	if len(s.ratings) == 0 {
+		return 0, nil
+	}
+	var sum int
+	for _, rating := range s.ratings {
+		sum += rating.Score
+	}
+	return float64(sum) / float64(len(s.ratings)), nil
+}
+
+// GetRatedMovieIDs returns the IDs of the movies with at least one vote.
+func (s *Storage) GetRatedMovieIDs(ctx context.Context) ([]uuid.UUID, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	rated := make(map[uuid.UUID]struct{})
+	for key := range s.ratings {
+		rated[key.movieID] = struct{}{}
+	}
+	movieIDs := make([]uuid.UUID, 0, len(rated))
+	for id := range rated {
+		movieIDs = append(movieIDs, id)
+	}
+	sortByID(movieIDs, func(id uuid.UUID) uuid.UUID { return id })
+	return movieIDs, nil
+}
+
+func (s *Storage) UpdateMovieRating(ctx context.Context, summary *domain.RatingSummary) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if record, ok := s.movies[summary.MovieID]; ok {
+		record.movie.Rating = summary.Rating
+		record.votes = summary.Votes
+	}
+	return nil
+}
+
+func (s *Storage) GetRatingSummary(ctx context.Context, movieID uuid.UUID) (*domain.RatingSummary, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	record, ok := s.liveMovie(movieID)
+	if !ok {
+		return nil, fmt.Errorf("get rating summary: %w", domain.ErrNotFound)
+	}
+	return &domain.RatingSummary{MovieID: movieID, Rating: record.movie.Rating, Votes: record.votes}, nil
+}
+
+// UpsertReview stores the user's review of a movie. Editing a review sends
+// it back to moderation and keeps its ID.
+func (s *Storage) UpsertReview(ctx context.Context, review *domain.Review) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if !s.userAndMovieExist(review.UserID, review.MovieID) {
+		return fmt.Errorf("upsert review: %w", domain.ErrNotFound)
+	}
+	review.ID = uuid.New()
+	for id, r := range s.reviews {
+		if r.UserID == review.UserID && r.MovieID == review.MovieID {
+			review.ID = id
+			break
+		}
+	}
+	review.CreatedAt = now()
+	s.reviews[review.ID] = *review
+	return nil
+}
+
+// GetReviews lists reviews with the given status, newest first. A nil
+// movieID lists reviews of all movies.
+func (s *Storage) GetReviews(ctx context.Context, movieID uuid.UUID, status string, limit int, offset int) ([]*domain.Review, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var reviews []*domain.Review
+	for _, review := range s.reviews {
+		if review.Status != status || movieID != uuid.Nil && review.MovieID != movieID {
+			continue
+		}
+		if _, ok := s.liveMovie(review.MovieID); !ok {
+			continue
+		}
+		reviews = append(reviews, &review)
+	}
+	sort.Slice(reviews, func(i, j int) bool {
+		return reviews[i].CreatedAt.After(reviews[j].CreatedAt)
+	})
+
+	if offset >= len(reviews) {
+		return nil, nil
+	}
+	reviews = reviews[offset:]
+	if limit < len(reviews) {
+		reviews = reviews[:limit]
+	}
+	return reviews, nil
+}
+
+func (s *Storage) UpdateReviewStatus(ctx context.Context, reviewID uuid.UUID, status string) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	review, ok := s.reviews[reviewID]
+	if !ok {
+		return fmt.Errorf("update review status: %w", domain.ErrNotFound)
+	}
+	review.Status = status
+	s.reviews[reviewID] = review
+	return nil
+}
+
+// userAndMovieExist checks the foreign keys of ratings and reviews. The
+// caller holds the lock.
+func (s *Storage) userAndMovieExist(userID uuid.UUID, movieID uuid.UUID) bool {
+	_, userOK := s.users[userID]
+	_, movieOK := s.movies[movieID]
+	return userOK && movieOK
+}
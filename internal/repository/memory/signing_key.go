@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// GetSigningKeys returns the current key and the keys retired after
+// retiredAfter, newest first.
+func (s *Storage) GetSigningKeys(ctx context.Context, retiredAfter time.Time) ([]*domain.SigningKey, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var keys []*domain.SigningKey
+	for _, key := range s.signingKeys {
+		if key.RetiredAt.IsZero() || key.RetiredAt.After(retiredAfter) {
+			key.Secret = append([]byte{}, key.Secret...)
+			keys = append(keys, &key)
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].CreatedAt.After(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+// RotateSigningKey retires the current key, stores key as the new current
+// one and deletes keys retired before pruneBefore.
+func (s *Storage) RotateSigningKey(ctx context.Context, key *domain.SigningKey, pruneBefore time.Time) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	for _, k := range s.signingKeys {
+		if k.ID == key.ID {
+			return fmt.Errorf("rotate signing key: %w", domain.ErrAlreadyExists)
+		}
+	}
+
+	rotatedAt := now()
+	kept := s.signingKeys[:0:0]
+	for _, k := range s.signingKeys {
+		if k.RetiredAt.IsZero() {
+			k.RetiredAt = rotatedAt
+		}
+		if !k.RetiredAt.Before(pruneBefore) {
+			kept = append(kept, k)
+		}
+	}
+
+	key.CreatedAt = rotatedAt
+	key.RetiredAt = time.Time{}
+	stored := *key
+	stored.Secret = append([]byte{}, key.Secret...)
+	s.signingKeys = append(kept, stored)
+	return nil
+}
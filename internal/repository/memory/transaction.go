@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type txKey struct{}
+
+// inTx reports whether ctx belongs to a transaction of s. The transaction
+// holds the write lock until it ends, so its methods must not lock again.
+func (s *Storage) inTx(ctx context.Context) bool {
+	tx, ok := ctx.Value(txKey{}).(*Storage)
+	return ok && tx == s
+}
+
+func (s *Storage) lock(ctx context.Context) {
+	if !s.inTx(ctx) {
+		s.mu.Lock()
+	}
+}
+
+func (s *Storage) unlock(ctx context.Context) {
+	if !s.inTx(ctx) {
+		s.mu.Unlock()
+	}
+}
+
+func (s *Storage) rlock(ctx context.Context) {
+	if !s.inTx(ctx) {
+		s.mu.RLock()
+	}
+}
+
+func (s *Storage) runlock(ctx context.Context) {
+	if !s.inTx(ctx) {
+		s.mu.RUnlock()
+	}
+}
+
+// WithinTransaction runs fn with the storage locked for the context passed
+// to fn, so transactions are serialized and never conflict. If fn returns
+// an error, the storage is restored to the state it had when fn started. A
+// nested call only restores its own changes.
+//
+// Methods called from fn with a context that does not come from it wait
+// for the transaction to end.
+func (s *Storage) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !s.inTx(ctx) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ctx = context.WithValue(ctx, txKey{}, s)
+	}
+
+	saved := s.snapshot()
+	if err := fn(ctx); err != nil {
+		s.restore(saved)
+		return err
+	}
+	return nil
+}
+
+// snapshot returns a copy of the data of s that shares nothing mutable
+// with it. Only movie records are modified in place; other values are
+// replaced, so copying the maps is enough for them. The caller holds the
+// write lock.
+func (s *Storage) snapshot() *Storage {
+	movies := make(map[uuid.UUID]*movieRecord, len(s.movies))
+	for id, record := range s.movies {
+		movies[id] = &movieRecord{movie: *copyMovie(&record.movie), votes: record.votes}
+	}
+
+	return &Storage{
+		actors:            maps.Clone(s.actors),
+		movies:            movies,
+		users:             maps.Clone(s.users),
+		genres:            maps.Clone(s.genres),
+		credits:           maps.Clone(s.credits),
+		payments:          maps.Clone(s.payments),
+		deletedMovies:     maps.Clone(s.deletedMovies),
+		deletedActors:     maps.Clone(s.deletedActors),
+		movieGenres:       cloneNested(s.movieGenres),
+		ratings:           maps.Clone(s.ratings),
+		reviews:           maps.Clone(s.reviews),
+		watchlist:         cloneLists(s.watchlist),
+		favorites:         cloneLists(s.favorites),
+		movieTranslations: cloneNested(s.movieTranslations),
+		actorTranslations: cloneNested(s.actorTranslations),
+		webhookEvents:     maps.Clone(s.webhookEvents),
+		signingKeys:       slices.Clone(s.signingKeys),
+		auditLog:          slices.Clone(s.auditLog),
+		versions:          cloneVersions(s.versions),
+		idempotencyKeys:   maps.Clone(s.idempotencyKeys),
+		outbox:            slices.Clone(s.outbox),
+
+		webhookSubscriptions: maps.Clone(s.webhookSubscriptions),
+		webhookDeliveries:    maps.Clone(s.webhookDeliveries),
+	}
+}
+
+// restore replaces the data of s with a snapshot. The caller holds the
+// write lock.
+func (s *Storage) restore(saved *Storage) {
+	s.actors = saved.actors
+	s.movies = saved.movies
+	s.users = saved.users
+	s.genres = saved.genres
+	s.credits = saved.credits
+	s.payments = saved.payments
+	s.deletedMovies = saved.deletedMovies
+	s.deletedActors = saved.deletedActors
+	s.movieGenres = saved.movieGenres
+	s.ratings = saved.ratings
+	s.reviews = saved.reviews
+	s.watchlist = saved.watchlist
+	s.favorites = saved.favorites
+	s.movieTranslations = saved.movieTranslations
+	s.actorTranslations = saved.actorTranslations
+	s.webhookEvents = saved.webhookEvents
+	s.signingKeys = saved.signingKeys
+	s.auditLog = saved.auditLog
+	s.versions = saved.versions
+	s.idempotencyKeys = saved.idempotencyKeys
+	s.outbox = saved.outbox
+	s.webhookSubscriptions = saved.webhookSubscriptions
+	s.webhookDeliveries = saved.webhookDeliveries
+}
+
+func cloneNested[K comparable, V any](m map[uuid.UUID]map[K]V) map[uuid.UUID]map[K]V {
+	clone := make(map[uuid.UUID]map[K]V, len(m))
+	for id, inner := range m {
+		clone[id] = maps.Clone(inner)
+	}
+	return clone
+}
+
+func cloneLists(m map[uuid.UUID][]listEntry) map[uuid.UUID][]listEntry {
+	clone := make(map[uuid.UUID][]listEntry, len(m))
+	for id, entries := range m {
+		clone[id] = slices.Clone(entries)
+	}
+	return clone
+}
+
+func cloneVersions(m map[versionKey][]domain.EntityVersion) map[versionKey][]domain.EntityVersion {
+	clone := make(map[versionKey][]domain.EntityVersion, len(m))
+	for key, versions := range m {
+		clone[key] = slices.Clone(versions)
+	}
+	return clone
+}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) UpsertMovieTranslation(ctx context.Context, translation *domain.MovieTranslation) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.movies[translation.MovieID]; !ok {
+		return fmt.Errorf("upsert movie translation: %w", domain.ErrNotFound)
+	}
+	if s.movieTranslations[translation.MovieID] == nil {
+		s.movieTranslations[translation.MovieID] = make(map[string]domain.MovieTranslation)
+	}
+	s.movieTranslations[translation.MovieID][translation.Locale] = *translation
+	return nil
+}
+
+func (s *Storage) DeleteMovieTranslation(ctx context.Context, movieID uuid.UUID, locale string) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.movieTranslations[movieID][locale]; !ok {
+		return fmt.Errorf("delete movie translation: %w", domain.ErrNotFound)
+	}
+	delete(s.movieTranslations[movieID], locale)
+	return nil
+}
+
+func (s *Storage) ListMovieTranslations(ctx context.Context, movieID uuid.UUID) ([]*domain.MovieTranslation, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	return s.movieTranslationsOf([]uuid.UUID{movieID}, nil), nil
+}
+
+func (s *Storage) UpsertActorTranslation(ctx context.Context, translation *domain.ActorTranslation) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.actors[translation.ActorID]; !ok {
+		return fmt.Errorf("upsert actor translation: %w", domain.ErrNotFound)
+	}
+	if s.actorTranslations[translation.ActorID] == nil {
+		s.actorTranslations[translation.ActorID] = make(map[string]domain.ActorTranslation)
+	}
+	s.actorTranslations[translation.ActorID][translation.Locale] = *translation
+	return nil
+}
+
+func (s *Storage) DeleteActorTranslation(ctx context.Context, actorID uuid.UUID, locale string) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.actorTranslations[actorID][locale]; !ok {
+		return fmt.Errorf("delete actor translation: %w", domain.ErrNotFound)
+	}
+	delete(s.actorTranslations[actorID], locale)
+	return nil
+}
+
+func (s *Storage) ListActorTranslations(ctx context.Context, actorID uuid.UUID) ([]*domain.ActorTranslation, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	return s.actorTranslationsOf([]uuid.UUID{actorID}, nil), nil
+}
+
+// movieTranslationsOf returns the translations of the given movies ordered
+// by locale. A nil locales slice selects every locale. The caller holds the
+// lock.
+func (s *Storage) movieTranslationsOf(movieIDs []uuid.UUID, locales []string) []*domain.MovieTranslation {
+	var translations []*domain.MovieTranslation
+	for _, id := range uniqueIDs(movieIDs) {
+		for locale, translation := range s.movieTranslations[id] {
+			if locales == nil || contains(locales, locale) {
+				translations = append(translations, &translation)
+			}
+		}
+	}
+	sort.SliceStable(translations, func(i, j int) bool { return translations[i].Locale < translations[j].Locale })
+	return translations
+}
+
+// actorTranslationsOf returns the translations of the given actors ordered
+// by locale. A nil locales slice selects every locale. The caller holds the
+// lock.
+func (s *Storage) actorTranslationsOf(actorIDs []uuid.UUID, locales []string) []*domain.ActorTranslation {
+	var translations []*domain.ActorTranslation
+	for _, id := range uniqueIDs(actorIDs) {
+		for locale, translation := range s.actorTranslations[id] {
+			if locales == nil || contains(locales, locale) {
+				translations = append(translations, &translation)
+			}
+		}
+	}
+	sort.SliceStable(translations, func(i, j int) bool { return translations[i].Locale < translations[j].Locale })
+	return translations
+}
+
+// uniqueIDs drops repeated IDs, which match a row only once in SQL.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	var result []uuid.UUID
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
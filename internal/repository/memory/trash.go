@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"bytes"
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) GetDeletedMovies(ctx context.Context) ([]*domain.TrashedMovie, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var movies []*domain.TrashedMovie
+	for id, deletedAt := range s.deletedMovies {
+		movies = append(movies, &domain.TrashedMovie{
+			Movie:     copyMovie(&s.movies[id].movie),
+			DeletedAt: deletedAt,
+		})
+	}
+	sortByDeletion(movies, func(m *domain.TrashedMovie) (time.Time, uuid.UUID) {
+		return m.DeletedAt, m.Movie.ID
+	})
+	return movies, nil
+}
+
+func (s *Storage) GetDeletedActors(ctx context.Context) ([]*domain.TrashedActor, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var actors []*domain.TrashedActor
+	for id, deletedAt := range s.deletedActors {
+		actor := s.actors[id]
+		actors = append(actors, &domain.TrashedActor{Actor: &actor, DeletedAt: deletedAt})
+	}
+	sortByDeletion(actors, func(a *domain.TrashedActor) (time.Time, uuid.UUID) {
+		return a.DeletedAt, a.Actor.ID
+	})
+	return actors, nil
+}
+
+func (s *Storage) RestoreMovie(ctx context.Context, movieID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.deletedMovies[movieID]; !ok {
+		return fmt.Errorf("restore movie: %w", domain.ErrNotFound)
+	}
+	delete(s.deletedMovies, movieID)
+	return nil
+}
+
+func (s *Storage) RestoreActor(ctx context.Context, actorID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.deletedActors[actorID]; !ok {
+		return fmt.Errorf("restore actor: %w", domain.ErrNotFound)
+	}
+	delete(s.deletedActors, actorID)
+	return nil
+}
+
+// PurgeDeleted removes movies and actors deleted before deletedBefore with
+// everything that references them.
+func (s *Storage) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	purged := 0
+	for id, deletedAt := range s.deletedMovies {
+		if deletedAt.Before(deletedBefore) {
+			s.deleteMovie(id)
+			purged++
+		}
+	}
+	for id, deletedAt := range s.deletedActors {
+		if deletedAt.Before(deletedBefore) {
+			s.deleteActor(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// sortByDeletion orders trash listings most recently deleted first.
+func sortByDeletion[T any](records []T, key func(T) (time.Time, uuid.UUID)) {
+	sort.Slice(records, func(i, j int) bool {
+		at, a := key(records[i])
+		bt, b := key(records[j])
+		if !at.Equal(bt) {
+			return at.After(bt)
+		}
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (s *Storage) GetUser(ctx context.Context, login string, password string) (*domain.User, error) {
+	s.rlock(ctx)
+	user, ok := s.userByLogin(login)
+	s.runlock(ctx)
+	if !ok {
+		return nil, fmt.Errorf("get user: %w", domain.ErrNotFound)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(user.Password, []byte(password)); err != nil {
+		return nil, fmt.Errorf("wrong password: %w", err)
+	}
+	return user, nil
+}
+
+// CreateUser stores a user whose password is already hashed. Logins are
+// unique.
+func (s *Storage) CreateUser(ctx context.Context, user *domain.User) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.userByLogin(user.Login); ok {
+		return fmt.Errorf("create user: %w", domain.ErrAlreadyExists)
+	}
+	user.ID = uuid.New()
+	user.CreatedAt = now()
+	stored := *user
+	stored.Password = append([]byte{}, user.Password...)
+	s.users[user.ID] = stored
+	return nil
+}
+
+// userByLogin returns a copy of the user with login. The caller holds the
+// lock.
+func (s *Storage) userByLogin(login string) (*domain.User, bool) {
+	for _, user := range s.users {
+		if user.Login == login {
+			user.Password = append([]byte{}, user.Password...)
+			return &user, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"bytes"
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type versionKey struct {
+	entity   string
+	entityID uuid.UUID
+}
+
+func (s *Storage) AddVersion(ctx context.Context, version *domain.EntityVersion) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	key := versionKey{entity: version.Entity, entityID: version.EntityID}
+	version.Number = len(s.versions[key]) + 1
+	version.CreatedAt = now()
+	stored := *version
+	stored.State = bytes.Clone(version.State)
+	s.versions[key] = append(s.versions[key], stored)
+	return nil
+}
+
+func (s *Storage) GetVersions(ctx context.Context, entity string, entityID uuid.UUID) ([]*domain.EntityVersion, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	history := s.versions[versionKey{entity: entity, entityID: entityID}]
+	var versions []*domain.EntityVersion
+	for i := len(history) - 1; i >= 0; i-- {
+		versions = append(versions, copyVersion(history[i]))
+	}
+	return versions, nil
+}
+
+func (s *Storage) GetVersion(ctx context.Context, entity string, entityID uuid.UUID, number int) (*domain.EntityVersion, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	history := s.versions[versionKey{entity: entity, entityID: entityID}]
+	if number < 1 || number > len(history) {
+		return nil, fmt.Errorf("get version: %w", domain.ErrNotFound)
+	}
+	return copyVersion(history[number-1]), nil
+}
+
+func copyVersion(version domain.EntityVersion) *domain.EntityVersion {
+	version.State = bytes.Clone(version.State)
+	return &version
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) AddToWatchlist(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	_, userOK := s.users[userID]
+	_, movieOK := s.liveMovie(movieID)
+	if !userOK || !movieOK {
+		return fmt.Errorf("add to watchlist: %w", domain.ErrNotFound)
+	}
+	s.watchlist[userID] = addEntry(s.watchlist[userID], movieID)
+	return nil
+}
+
+func (s *Storage) RemoveFromWatchlist(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	s.watchlist[userID] = removeEntry(s.watchlist[userID], movieID)
+	return nil
+}
+
+// GetWatchlist returns the movies on the user's watchlist, most recently
+// added first.
+func (s *Storage) GetWatchlist(ctx context.Context, userID uuid.UUID) ([]*domain.WatchlistEntry, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var entries []*domain.WatchlistEntry
+	list := s.watchlist[userID]
+	for i := len(list) - 1; i >= 0; i-- {
+		record, ok := s.liveMovie(list[i].id)
+		if !ok {
+			continue
+		}
+		movie := movieSummary(&record.movie)
+		movie.InWatchlist = true
+		entries = append(entries, &domain.WatchlistEntry{Movie: movie, AddedAt: list[i].addedAt})
+	}
+	return entries, nil
+}
+
+func (s *Storage) AddFavoriteActor(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	_, userOK := s.users[userID]
+	_, actorOK := s.liveActor(actorID)
+	if !userOK || !actorOK {
+		return fmt.Errorf("add favorite actor: %w", domain.ErrNotFound)
+	}
+	s.favorites[userID] = addEntry(s.favorites[userID], actorID)
+	return nil
+}
+
+func (s *Storage) RemoveFavoriteActor(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	s.favorites[userID] = removeEntry(s.favorites[userID], actorID)
+	return nil
+}
+
+// GetFavoriteActors returns the user's favorite actors, most recently added
+// first.
+func (s *Storage) GetFavoriteActors(ctx context.Context, userID uuid.UUID) ([]*domain.FavoriteActor, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var favorites []*domain.FavoriteActor
+	list := s.favorites[userID]
+	for i := len(list) - 1; i >= 0; i-- {
+		actor, ok := s.liveActor(list[i].id)
+		if !ok {
+			continue
+		}
+		favorites = append(favorites, &domain.FavoriteActor{Actor: &actor, AddedAt: list[i].addedAt})
+	}
+	return favorites, nil
+}
+
+// addEntry appends id unless it is already listed.
+func addEntry(entries []listEntry, id uuid.UUID) []listEntry {
+	for _, entry := range entries {
+		if entry.id == id {
+			return entries
+		}
+	}
+	return append(entries, listEntry{id: id, addedAt: now()})
+}
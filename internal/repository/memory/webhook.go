@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"bytes"
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *Storage) CreateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.webhookSubscriptions[subscription.ID]; ok {
+		return fmt.Errorf("create webhook subscription: %w", domain.ErrAlreadyExists)
+	}
+	s.webhookSubscriptions[subscription.ID] = *copySubscription(*subscription)
+	return nil
+}
+
+func (s *Storage) GetWebhookSubscription(ctx context.Context, subscriptionID uuid.UUID) (*domain.WebhookSubscription, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	subscription, ok := s.webhookSubscriptions[subscriptionID]
+	if !ok {
+		return nil, fmt.Errorf("get webhook subscription: %w", domain.ErrNotFound)
+	}
+	return copySubscription(subscription), nil
+}
+
+// GetWebhookSubscriptions returns all subscriptions, oldest first.
+func (s *Storage) GetWebhookSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	subscriptions := make([]*domain.WebhookSubscription, 0, len(s.webhookSubscriptions))
+	for _, subscription := range s.webhookSubscriptions {
+		subscriptions = append(subscriptions, copySubscription(subscription))
+	}
+	slices.SortFunc(subscriptions, func(a, b *domain.WebhookSubscription) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+	return subscriptions, nil
+}
+
+// DeleteWebhookSubscription removes a subscription with its deliveries.
+func (s *Storage) DeleteWebhookSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.webhookSubscriptions[subscriptionID]; !ok {
+		return fmt.Errorf("delete webhook subscription: %w", domain.ErrNotFound)
+	}
+	delete(s.webhookSubscriptions, subscriptionID)
+	for id, delivery := range s.webhookDeliveries {
+		if delivery.SubscriptionID == subscriptionID {
+			delete(s.webhookDeliveries, id)
+		}
+	}
+	return nil
+}
+
+func (s *Storage) CreateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	if _, ok := s.webhookSubscriptions[delivery.SubscriptionID]; !ok {
+		return fmt.Errorf("create webhook delivery: %w", domain.ErrNotFound)
+	}
+	if _, ok := s.webhookDeliveries[delivery.ID]; ok {
+		return fmt.Errorf("create webhook delivery: %w", domain.ErrAlreadyExists)
+	}
+	for _, existing := range s.webhookDeliveries {
+		if existing.SubscriptionID == delivery.SubscriptionID && existing.EventID == delivery.EventID {
+			return fmt.Errorf("create webhook delivery: %w", domain.ErrAlreadyExists)
+		}
+	}
+	s.webhookDeliveries[delivery.ID] = *copyDelivery(*delivery)
+	return nil
+}
+
+func (s *Storage) GetWebhookDelivery(ctx context.Context, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	delivery, ok := s.webhookDeliveries[deliveryID]
+	if !ok {
+		return nil, fmt.Errorf("get webhook delivery: %w", domain.ErrNotFound)
+	}
+	return copyDelivery(delivery), nil
+}
+
+func (s *Storage) GetWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int, offset int) ([]*domain.WebhookDelivery, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var deliveries []*domain.WebhookDelivery
+	for _, delivery := range s.webhookDeliveries {
+		if delivery.SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, copyDelivery(delivery))
+		}
+	}
+	slices.SortFunc(deliveries, func(a, b *domain.WebhookDelivery) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	if offset >= len(deliveries) {
+		return nil, nil
+	}
+	deliveries = deliveries[offset:]
+	if limit < len(deliveries) {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (s *Storage) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	s.rlock(ctx)
+	defer s.runlock(ctx)
+
+	var deliveries []*domain.WebhookDelivery
+	for _, delivery := range s.webhookDeliveries {
+		if delivery.Status == domain.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			deliveries = append(deliveries, copyDelivery(delivery))
+		}
+	}
+	slices.SortFunc(deliveries, func(a, b *domain.WebhookDelivery) int {
+		if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	if limit < len(deliveries) {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (s *Storage) UpdateWebhookDelivery(ctx context.Context, updated *domain.WebhookDelivery) error {
+	s.lock(ctx)
+	defer s.unlock(ctx)
+
+	delivery, ok := s.webhookDeliveries[updated.ID]
+	if !ok {
+		return fmt.Errorf("update webhook delivery: %w", domain.ErrNotFound)
+	}
+	delivery.Status = updated.Status
+	delivery.Attempts = updated.Attempts
+	delivery.NextAttemptAt = updated.NextAttemptAt
+	delivery.ResponseStatus = updated.ResponseStatus
+	delivery.LastError = updated.LastError
+	delivery.DeliveredAt = updated.DeliveredAt
+	s.webhookDeliveries[updated.ID] = delivery
+	return nil
+}
+
+func copySubscription(subscription domain.WebhookSubscription) *domain.WebhookSubscription {
+	subscription.EventTypes = slices.Clone(subscription.EventTypes)
+	return &subscription
+}
+
+func copyDelivery(delivery domain.WebhookDelivery) *domain.WebhookDelivery {
+	delivery.Body = bytes.Clone(delivery.Body)
+	return &delivery
+}
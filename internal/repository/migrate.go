@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// ErrSchemaTooNew means the database was migrated by a newer release.
+var ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Migrator applies the migrations embedded in the binary. Migrations run
+// under a Postgres advisory lock, so concurrent runs wait for each other.
+// Closing it leaves the pool open.
+type Migrator struct {
+	*goose.Provider
+}
+
+func NewMigrator(dbPool *pgxpool.Pool) (*Migrator, error) {
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("open migrations: %w", err)
+	}
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("open migrations: %w", err)
+	}
+
+	db := stdlib.OpenDBFromPool(dbPool)
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, fsys, goose.WithSessionLocker(locker))
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open migrations: %w", err)
+	}
+	return &Migrator{Provider: provider}, nil
+}
+
+// CheckVersion fails with ErrSchemaTooNew if the database has migrations
+// this binary does not know about. It returns the number of pending
+// migrations otherwise.
+func (m *Migrator) CheckVersion(ctx context.Context) (int, error) {
+	current, err := m.GetDBVersion(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("check schema version: %w", err)
+	}
+
+	sources := m.ListSources()
+	var latest int64
+	if len(sources) > 0 {
+		latest = sources[len(sources)-1].Version
+	}
+	if current > latest {
+		return 0, fmt.Errorf("%w: database is at %d, binary knows up to %d", ErrSchemaTooNew, current, latest)
+	}
+
+	pending := 0
+	for _, source := range sources {
+		if source.Version > current {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// MigrateOnStartup checks the schema version and, if apply is set, applies
+// pending migrations.
+func MigrateOnStartup(ctx context.Context, dbPool *pgxpool.Pool, apply bool) error {
+	migrator, err := NewMigrator(dbPool)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	pending, err := migrator.CheckVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if pending == 0 {
+		return nil
+	}
+	if !apply {
+		slog.Warn("Database schema is behind, run cinemactl migrate up", "pending", pending)
+		return nil
+	}
+
+	results, err := migrator.Up(ctx)
+	for _, result := range results {
+		slog.Info("Applied migration", "path", result.Source.Path, "duration", result.Duration)
+	}
+	if err != nil {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package repository_test
+
+import (
+	"cinema_service/internal/repository"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationsRoundTrip(t *testing.T) {
+	dbPool := openTestSchema(t)
+	ctx := context.Background()
+	migrator, err := repository.NewMigrator(dbPool)
+	require.NoError(t, err)
+	defer migrator.Close()
+
+	pending, err := migrator.CheckVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(migrator.ListSources()), pending)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	pending, err = migrator.CheckVersion(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+
+	// Every down migration must undo its up migration.
+	_, err = migrator.DownTo(ctx, 0)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+}
+
+func TestMigrateOnStartup(t *testing.T) {
+	dbPool := openTestSchema(t)
+	ctx := context.Background()
+
+	require.NoError(t, repository.MigrateOnStartup(ctx, dbPool, false))
+	_, err := dbPool.Exec(ctx, `SELECT 1 FROM "movies"`)
+	assert.Error(t, err, "migrations must not be applied without apply")
+
+	require.NoError(t, repository.MigrateOnStartup(ctx, dbPool, true))
+	_, err = dbPool.Exec(ctx, `SELECT 1 FROM "movies"`)
+	assert.NoError(t, err)
+}
+
+func TestCheckVersionRejectsNewerSchema(t *testing.T) {
+	dbPool := openTestDB(t)
+	ctx := context.Background()
+	_, err := dbPool.Exec(ctx,
+		`INSERT INTO goose_db_version (version_id, is_applied) VALUES (99990101000000, true)`)
+	require.NoError(t, err)
+
+	migrator, err := repository.NewMigrator(dbPool)
+	require.NoError(t, err)
+	defer migrator.Close()
+
+	_, err = migrator.CheckVersion(ctx)
+	assert.ErrorIs(t, err, repository.ErrSchemaTooNew)
+}
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "payments"
+(
+    "id"         uuid PRIMARY KEY,
+    "booking_id" uuid        NOT NULL UNIQUE,
+    "intent_id"  varchar     NOT NULL UNIQUE,
+    "amount"     bigint      NOT NULL CHECK (amount > 0),
+    "currency"   varchar(3)  NOT NULL,
+    "status"     varchar     NOT NULL,
+    "created_at" timestamp   NOT NULL DEFAULT now(),
+    "updated_at" timestamp   NOT NULL DEFAULT now()
+);
+
+CREATE TABLE "payment_webhook_events"
+(
+    "id"          varchar PRIMARY KEY,
+    "received_at" timestamp NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "payment_webhook_events";
+DROP TABLE IF EXISTS "payments";
+-- +goose StatementEnd
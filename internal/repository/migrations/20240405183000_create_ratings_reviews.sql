@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE movies ADD COLUMN votes_count integer NOT NULL DEFAULT 0;
+
+CREATE TABLE "movie_ratings"
+(
+    "user_id"    uuid      NOT NULL,
+    "movie_id"   uuid      NOT NULL,
+    "score"      smallint  NOT NULL CHECK (score >= 0 AND score <= 10),
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY ("user_id", "movie_id"),
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "movie_reviews"
+(
+    "id"         uuid PRIMARY KEY,
+    "user_id"    uuid          NOT NULL,
+    "movie_id"   uuid          NOT NULL,
+    "text"       varchar(5000) NOT NULL,
+    "status"     varchar       NOT NULL,
+    "created_at" timestamp     NOT NULL DEFAULT now(),
+    UNIQUE ("user_id", "movie_id"),
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX movie_reviews_movie_id_status_idx ON movie_reviews (movie_id, status, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "movie_reviews";
+DROP TABLE IF EXISTS "movie_ratings";
+ALTER TABLE movies DROP COLUMN votes_count;
+-- +goose StatementEnd
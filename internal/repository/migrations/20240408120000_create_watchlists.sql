@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "watchlist"
+(
+    "user_id"  uuid      NOT NULL,
+    "movie_id" uuid      NOT NULL,
+    "added_at" timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY ("user_id", "movie_id"),
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "favorite_actors"
+(
+    "user_id"  uuid      NOT NULL,
+    "actor_id" uuid      NOT NULL,
+    "added_at" timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY ("user_id", "actor_id"),
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("actor_id") REFERENCES "actors" ("id") ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "favorite_actors";
+DROP TABLE IF EXISTS "watchlist";
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "genres"
+(
+    "id"   uuid PRIMARY KEY,
+    "name" varchar(100) NOT NULL UNIQUE
+);
+
+CREATE TABLE "movies_genres"
+(
+    "movie_id" uuid,
+    "genre_id" uuid,
+    PRIMARY KEY ("movie_id", "genre_id"),
+    FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("genre_id") REFERENCES "genres" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX movies_genres_genre_id_idx ON movies_genres (genre_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "movies_genres";
+DROP TABLE IF EXISTS "genres";
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "credits"
+(
+    "id"             uuid PRIMARY KEY,
+    "movie_id"       uuid    NOT NULL,
+    "person_id"      uuid    NOT NULL,
+    "role"           varchar NOT NULL CHECK (role IN ('ACTOR', 'DIRECTOR', 'WRITER', 'PRODUCER')),
+    "character_name" varchar NOT NULL DEFAULT '',
+    "billing_order"  integer NOT NULL DEFAULT 0,
+    UNIQUE ("movie_id", "person_id", "role", "character_name"),
+    FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("person_id") REFERENCES "actors" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX credits_person_id_idx ON credits (person_id);
+
+INSERT INTO credits (id, movie_id, person_id, role)
+SELECT gen_random_uuid(), movies_id, actors_movie_id, 'ACTOR'
+FROM actors_movies;
+
+DROP TABLE "actors_movies";
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TABLE "actors_movies"
+(
+    "actors_movie_id" uuid,
+    "movies_id"       UUID,
+    PRIMARY KEY ("actors_movie_id", "movies_id"),
+    FOREIGN KEY ("actors_movie_id") REFERENCES "actors" ("id") ON DELETE CASCADE,
+    FOREIGN KEY ("movies_id") REFERENCES movies ("id") ON DELETE CASCADE
+);
+
+INSERT INTO actors_movies (actors_movie_id, movies_id)
+SELECT DISTINCT person_id, movie_id
+FROM credits
+WHERE role = 'ACTOR';
+
+DROP TABLE IF EXISTS "credits";
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE movies
+    ADD COLUMN release_date      date,
+    ADD COLUMN duration_minutes  integer CHECK (duration_minutes > 0),
+    ADD COLUMN age_rating        varchar(3) CHECK (age_rating IN ('0+', '6+', '12+', '16+', '18+')),
+    ADD COLUMN countries         varchar(2)[] NOT NULL DEFAULT '{}',
+    ADD COLUMN original_language varchar(2),
+    ADD COLUMN imdb_id           varchar(12) UNIQUE CHECK (imdb_id ~ '^tt[0-9]{7,9}$'),
+    ADD COLUMN tmdb_id           integer UNIQUE CHECK (tmdb_id > 0);
+
+-- created_at used to hold the release date; it now records when the row was created.
+UPDATE movies SET release_date = created_at::date, created_at = now();
+
+ALTER TABLE movies
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX movies_release_date_idx ON movies (release_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE movies
+    ALTER COLUMN created_at DROP NOT NULL,
+    ALTER COLUMN created_at DROP DEFAULT;
+
+UPDATE movies SET created_at = release_date;
+
+ALTER TABLE movies
+    DROP COLUMN release_date,
+    DROP COLUMN duration_minutes,
+    DROP COLUMN age_rating,
+    DROP COLUMN countries,
+    DROP COLUMN original_language,
+    DROP COLUMN imdb_id,
+    DROP COLUMN tmdb_id;
+-- +goose StatementEnd
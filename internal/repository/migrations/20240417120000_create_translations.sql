@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "movie_translations"
+(
+    "movie_id"    uuid,
+    "locale"      varchar(2) CHECK ("locale" ~ '^[a-z]{2}$'),
+    "title"       varchar(150) NOT NULL,
+    "description" text NOT NULL DEFAULT '',
+    PRIMARY KEY ("movie_id", "locale"),
+    FOREIGN KEY ("movie_id") REFERENCES "movies" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "actor_translations"
+(
+    "actor_id" uuid,
+    "locale"   varchar(2) CHECK ("locale" ~ '^[a-z]{2}$'),
+    "name"     varchar NOT NULL,
+    "surname"  varchar NOT NULL DEFAULT '',
+    PRIMARY KEY ("actor_id", "locale"),
+    FOREIGN KEY ("actor_id") REFERENCES "actors" ("id") ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "actor_translations";
+DROP TABLE IF EXISTS "movie_translations";
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "signing_keys"
+(
+    "id"         varchar(32) PRIMARY KEY,
+    "secret"     bytea     NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    "retired_at" timestamp
+);
+
+-- At most one key signs new tokens.
+CREATE UNIQUE INDEX signing_keys_current_idx ON signing_keys ((retired_at IS NULL)) WHERE retired_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "signing_keys";
+-- +goose StatementEnd
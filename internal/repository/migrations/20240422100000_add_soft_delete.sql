@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "movies" ADD COLUMN "deleted_at" timestamp;
+ALTER TABLE "actors" ADD COLUMN "deleted_at" timestamp;
+
+-- The trash listing and the purge job only look at deleted rows.
+CREATE INDEX movies_deleted_at_idx ON movies (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX actors_deleted_at_idx ON actors (deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Without the column deleted rows would come back, so they are purged.
+DELETE FROM "movies" WHERE deleted_at IS NOT NULL;
+DELETE FROM "actors" WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE "movies" DROP COLUMN "deleted_at";
+ALTER TABLE "actors" DROP COLUMN "deleted_at";
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "audit_log"
+(
+    "id"         uuid PRIMARY KEY,
+    -- user_id is NULL for changes made without a user.
+    "user_id"    uuid,
+    "action"     varchar   NOT NULL,
+    "entity"     varchar   NOT NULL,
+    "entity_id"  uuid      NOT NULL,
+    "changes"    jsonb     NOT NULL DEFAULT '{}',
+    "request_id" varchar   NOT NULL DEFAULT '',
+    "created_at" timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX audit_log_user_id_idx ON audit_log (user_id, created_at);
+CREATE INDEX audit_log_entity_idx ON audit_log (entity, entity_id, created_at);
+
+-- The audit log is append-only.
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE
+    ON audit_log
+    FOR EACH STATEMENT
+EXECUTE FUNCTION audit_log_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "audit_log";
+DROP FUNCTION IF EXISTS audit_log_append_only();
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "entity_versions"
+(
+    "entity"     varchar   NOT NULL,
+    "entity_id"  uuid      NOT NULL,
+    "number"     integer   NOT NULL,
+    "state"      jsonb     NOT NULL,
+    -- user_id is NULL when the author of the version is unknown.
+    "user_id"    uuid,
+    "created_at" timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (entity, entity_id, number)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "entity_versions";
+-- +goose StatementEnd
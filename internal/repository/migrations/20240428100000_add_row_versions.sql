@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- version counts the updates of a row; it backs the ETags of the API.
+ALTER TABLE "movies" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
+ALTER TABLE "actors" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "movies" DROP COLUMN "version";
+ALTER TABLE "actors" DROP COLUMN "version";
+-- +goose StatementEnd
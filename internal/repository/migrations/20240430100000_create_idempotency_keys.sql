@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "idempotency_keys"
+(
+    "user_id"     uuid      NOT NULL,
+    "key"         varchar   NOT NULL,
+    "fingerprint" varchar   NOT NULL,
+    -- status_code is NULL while the first request is being handled.
+    "status_code" integer,
+    "header"      jsonb,
+    "body"        bytea,
+    "created_at"  timestamp NOT NULL,
+    "expires_at"  timestamp NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "idempotency_keys";
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "event_outbox"
+(
+    "sequence"        bigserial PRIMARY KEY,
+    "id"              uuid      NOT NULL UNIQUE,
+    "type"            varchar   NOT NULL,
+    "entity_id"       uuid      NOT NULL,
+    "payload"         jsonb     NOT NULL,
+    "request_id"      varchar   NOT NULL DEFAULT '',
+    "created_at"      timestamp NOT NULL,
+    "attempts"        integer   NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamp NOT NULL,
+    "last_error"      varchar   NOT NULL DEFAULT '',
+    -- published_at is NULL until the event is delivered.
+    "published_at"    timestamp
+);
+
+CREATE INDEX event_outbox_pending_idx ON event_outbox (next_attempt_at) WHERE published_at IS NULL;
+CREATE INDEX event_outbox_published_at_idx ON event_outbox (published_at) WHERE published_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "event_outbox";
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "webhook_subscriptions"
+(
+    "id"          uuid PRIMARY KEY,
+    "url"         varchar   NOT NULL,
+    "event_types" varchar[] NOT NULL,
+    "secret"      varchar   NOT NULL,
+    "created_at"  timestamp NOT NULL
+);
+
+CREATE TABLE "webhook_deliveries"
+(
+    "id"              uuid PRIMARY KEY,
+    "subscription_id" uuid      NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    "event_id"        uuid      NOT NULL,
+    "event_type"      varchar   NOT NULL,
+    -- body is kept as sent, since signatures cover its exact bytes.
+    "body"            bytea     NOT NULL,
+    "status"          varchar   NOT NULL,
+    "attempts"        integer   NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamp NOT NULL,
+    "response_status" integer   NOT NULL DEFAULT 0,
+    "last_error"      varchar   NOT NULL DEFAULT '',
+    "created_at"      timestamp NOT NULL,
+    -- delivered_at is NULL until the subscriber accepts the delivery.
+    "delivered_at"    timestamp,
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_subscription_idx ON webhook_deliveries (subscription_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "webhook_deliveries";
+DROP TABLE IF EXISTS "webhook_subscriptions";
+-- +goose StatementEnd
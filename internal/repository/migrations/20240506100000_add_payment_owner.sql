@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- user_id is NULL for payments started before owners were recorded; only
+-- admins can cancel those.
+ALTER TABLE "payments" ADD COLUMN "user_id" uuid;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "payments" DROP COLUMN IF EXISTS "user_id";
+-- +goose StatementEnd
@@ -3,9 +3,11 @@ package repository
 import (
 	"cinema_service/internal/domain"
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,34 +22,68 @@ func NewStorageMovie(dbPool *pgxpool.Pool) StorageMovie {
 	return StorageMovie
 }
 
+// movieColumns is the column list scanned by scanMovie; queries alias the
+// movies table as m. Optional metadata is read as zero values.
+const movieColumns = `m.id, m.title, m.description, m.rating, COALESCE(m.release_date, '0001-01-01'),
+	COALESCE(m.duration_minutes, 0), COALESCE(m.age_rating, ''), m.countries,
+	COALESCE(m.original_language, ''), COALESCE(m.imdb_id, ''), COALESCE(m.tmdb_id, 0), m.version`
+
+func scanMovie(row pgx.Row, movie *domain.Movie) error {
+	return row.Scan(
+		&movie.ID, &movie.Title, &movie.Description, &movie.Rating, &movie.Date,
+		&movie.DurationMinutes, &movie.AgeRating, &movie.Countries,
+		&movie.OriginalLanguage, &movie.IMDbID, &movie.TMDBID, &movie.Version,
+	)
+}
+
 func (s *StorageMovie) GetMovieByID(ctx context.Context, movieID uuid.UUID) (*domain.Movie, error) {
 	movie := &domain.Movie{}
 
-	if err := s.db.QueryRow(
+	if err := scanMovie(conn(ctx, s.db).QueryRow(
 		ctx,
-		`SELECT id, title, description, rating, created_at FROM "movies" u WHERE u.id = $1`, movieID,
-	).Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Rating, &movie.Rating, &movie.Date); err != nil {
+		`SELECT `+movieColumns+` FROM "movies" m WHERE m.id = $1 AND m.deleted_at IS NULL`, movieID,
+	), movie); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get movie by id: %w", domain.ErrNotFound)
+		}
 		return nil, fmt.Errorf("get movie by id: %w", err)
 	}
 
+	if err := s.attachGenres(ctx, []*domain.Movie{movie}); err != nil {
+		return nil, fmt.Errorf("get movie by id: %w", err)
+	}
 	return movie, nil
 }
 
+// CreateMovie stores a new movie. A new ID is generated unless movie has
+// one. New movies start without votes; their rating is written by
+// StorageRating only.
 func (s *StorageMovie) CreateMovie(ctx context.Context, movie *domain.Movie) error {
-	_, err := s.db.Exec(ctx,
-		`INSERT INTO "movies" (id, title, description, rating, created_at) VALUES($1, $2, $3, $4, $5)`,
-		&movie.ID, &movie.Title, &movie.Description, &movie.Rating, &movie.Rating, &movie.Date,
+	if movie.ID == uuid.Nil {
+		movie.ID = uuid.New()
+	}
+	_, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO "movies" (id, title, description, rating, release_date, duration_minutes, age_rating,
+			countries, original_language, imdb_id, tmdb_id)
+		VALUES($1, $2, $3, 0, $4, NULLIF($5, 0), NULLIF($6, ''), $7, NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, 0))`,
+		&movie.ID, &movie.Title, &movie.Description, &movie.Date,
+		&movie.DurationMinutes, &movie.AgeRating, countriesOrEmpty(movie.Countries),
+		&movie.OriginalLanguage, &movie.IMDbID, &movie.TMDBID,
 	)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("create movie: %w", domain.ErrAlreadyExists)
+		}
 		return fmt.Errorf("create movie: %w", err)
 	}
+	movie.Version = 1
 	return nil
 }
 func (s *StorageMovie) GetMovies(ctx context.Context) ([]*domain.Movie, error) {
 	var movies []*domain.Movie
-	rows, err := s.db.Query(
+	rows, err := conn(ctx, s.db).Query(
 		ctx,
-		`SELECT id, title, description, rating, created_at FROM movies`)
+		`SELECT `+movieColumns+` FROM movies m WHERE m.deleted_at IS NULL`)
 	if err != nil {
 		return nil, fmt.Errorf("get movies: %w", err)
 	}
@@ -56,7 +92,7 @@ func (s *StorageMovie) GetMovies(ctx context.Context) ([]*domain.Movie, error) {
 	for rows.Next() {
 		movie := &domain.Movie{}
 
-		if err := rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Rating, &movie.Date); err != nil {
+		if err := scanMovie(rows, movie); err != nil {
 			return nil, fmt.Errorf("get movies: %w", err)
 		}
 
@@ -67,49 +103,211 @@ func (s *StorageMovie) GetMovies(ctx context.Context) ([]*domain.Movie, error) {
 		return nil, fmt.Errorf("get movies: %w", err)
 	}
 
+	if err := s.attachGenres(ctx, movies); err != nil {
+		return nil, fmt.Errorf("get movies: %w", err)
+	}
+
 	return movies, nil
 }
 func (s *StorageMovie) GetMoviesBySnippet(ctx context.Context, snippet string) ([]*domain.Movie, error) {
 	var movies []*domain.Movie
-	rows, err := s.db.Query(
+	rows, err := conn(ctx, s.db).Query(
 		ctx,
-		`SELECT movies.id, movies.title, movies.description, movies.rating, movies.created_at
-		FROM movies
-		JOIN actors_movies ON movies.id = actors_movies.movies_id
-		JOIN actors ON actors_movies.actors_movie_id = actors.id
-		WHERE movies.title LIKE '%' || $1 || '%'
-		OR actors.name LIKE '%' || $1 || '%'`,
+		`SELECT `+movieColumns+`
+		FROM movies m
+		WHERE m.deleted_at IS NULL AND (m.title LIKE '%' || $1 || '%'
+		OR EXISTS (
+			SELECT 1 FROM movie_translations mt
+			WHERE mt.movie_id = m.id AND mt.title LIKE '%' || $1 || '%'
+		)
+		OR EXISTS (
+			SELECT 1 FROM credits
+			JOIN actors ON credits.person_id = actors.id
+			LEFT JOIN actor_translations atr ON atr.actor_id = actors.id
+			WHERE credits.movie_id = m.id AND credits.role = 'ACTOR' AND actors.deleted_at IS NULL
+			AND (actors.name LIKE '%' || $1 || '%' OR atr.name LIKE '%' || $1 || '%')
+		))`,
 		snippet)
 	if err != nil {
 		return nil, fmt.Errorf("get movie by snippet: %w", err)
 	}
 	for rows.Next() {
 		movie := &domain.Movie{}
-		if err = rows.Scan(&movie.ID, &movie.Title, &movie.Description, &movie.Rating, &movie.Date); err != nil {
+		if err = scanMovie(rows, movie); err != nil {
 			return nil, err
 		}
 		movies = append(movies, movie)
 	}
+	if err = s.attachGenres(ctx, movies); err != nil {
+		return nil, fmt.Errorf("get movie by snippet: %w", err)
+	}
 	return movies, nil
 }
 
+// UpdateMovie writes the columns of a movie except its rating, which only
+// StorageRating writes.
 func (s *StorageMovie) UpdateMovie(ctx context.Context, movie *domain.Movie) error {
-	if _, err := s.db.Exec(
+	if _, err := conn(ctx, s.db).Exec(
 		ctx,
-		`UPDATE "movies" SET title = $2, description = $3, rating = $4, created_at = $5
-		WHERE id = $1`,
-		&movie.ID, &movie.Title, &movie.Description, &movie.Rating, &movie.Date,
+		`UPDATE "movies" SET title = $2, description = $3, release_date = $4,
+			duration_minutes = NULLIF($5, 0), age_rating = NULLIF($6, ''), countries = $7,
+			original_language = NULLIF($8, ''), imdb_id = NULLIF($9, ''), tmdb_id = NULLIF($10, 0), version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL`,
+		&movie.ID, &movie.Title, &movie.Description, &movie.Date,
+		&movie.DurationMinutes, &movie.AgeRating, countriesOrEmpty(movie.Countries),
+		&movie.OriginalLanguage, &movie.IMDbID, &movie.TMDBID,
 	); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("update movie: %w", domain.ErrAlreadyExists)
+		}
 		return fmt.Errorf("update movie: %w", err)
 	}
 	return nil
 }
+
+// movieFieldColumns are the columns UpdateMovieFields writes, by field of
+// domain.Movie. The rating is missing on purpose: it is computed from votes.
+var movieFieldColumns = map[string]fieldColumn[domain.Movie]{
+	"Title":            {"title = %s", func(m *domain.Movie) any { return m.Title }},
+	"Description":      {"description = %s", func(m *domain.Movie) any { return m.Description }},
+	"Date":             {"release_date = %s", func(m *domain.Movie) any { return m.Date }},
+	"DurationMinutes":  {"duration_minutes = NULLIF(%s, 0)", func(m *domain.Movie) any { return m.DurationMinutes }},
+	"AgeRating":        {"age_rating = NULLIF(%s, '')", func(m *domain.Movie) any { return m.AgeRating }},
+	"Countries":        {"countries = %s", func(m *domain.Movie) any { return countriesOrEmpty(m.Countries) }},
+	"OriginalLanguage": {"original_language = NULLIF(%s, '')", func(m *domain.Movie) any { return m.OriginalLanguage }},
+	"IMDbID":           {"imdb_id = NULLIF(%s, '')", func(m *domain.Movie) any { return m.IMDbID }},
+	"TMDBID":           {"tmdb_id = NULLIF(%s, 0)", func(m *domain.Movie) any { return m.TMDBID }},
+}
+
+// UpdateMovieFields writes only the columns of the given fields of a movie
+// and increments its version. Genres are set with SetMovieGenres.
+func (s *StorageMovie) UpdateMovieFields(ctx context.Context, movie *domain.Movie, fields []string) error {
+	query, args := updateFields("movies", movieFieldColumns, movie.ID, movie, fields)
+	if _, err := conn(ctx, s.db).Exec(ctx, query, args...); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("update movie fields: %w", domain.ErrAlreadyExists)
+		}
+		return fmt.Errorf("update movie fields: %w", err)
+	}
+	return nil
+}
+
+// countriesOrEmpty keeps the NOT NULL countries column from receiving NULL
+// for movies without production countries.
+func countriesOrEmpty(countries []string) []string {
+	if countries == nil {
+		return []string{}
+	}
+	return countries
+}
+// DeleteMovie moves a movie to the trash. Its credits, genres and other
+// links are kept, so restoring the movie brings them back.
 func (s *StorageMovie) DeleteMovie(ctx context.Context, movieID uuid.UUID) error {
-	if _, err := s.db.Exec(ctx,
-		`DELETE FROM "movies" WHERE id=$1`,
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE "movies" SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`,
 		movieID,
 	); err != nil {
 		return fmt.Errorf("delete movie: %w", err)
 	}
 	return nil
 }
+
+func (s *StorageMovie) GetWatchlistMovieIDs(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT movie_id FROM "watchlist" WHERE user_id = $1`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get watchlist movie ids: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[uuid.UUID]struct{})
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("get watchlist movie ids: %w", err)
+		}
+		ids[id] = struct{}{}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get watchlist movie ids: %w", err)
+	}
+
+	return ids, nil
+}
+
+// SetMovieGenres replaces the genres of a movie.
+func (s *StorageMovie) SetMovieGenres(ctx context.Context, movieID uuid.UUID, genreIDs []uuid.UUID) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM "movies_genres" WHERE movie_id = $1`,
+		movieID,
+	); err != nil {
+		return fmt.Errorf("set movie genres: %w", err)
+	}
+
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO "movies_genres" (movie_id, genre_id)
+		SELECT $1, genre_id FROM unnest($2::uuid[]) AS genre_id
+		ON CONFLICT DO NOTHING`,
+		movieID, genreIDs,
+	); err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("set movie genres: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("set movie genres: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageMovie) attachGenres(ctx context.Context, movies []*domain.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(movies))
+	byID := make(map[uuid.UUID][]*domain.Movie, len(movies))
+	for _, movie := range movies {
+		movie.Genres = []*domain.Genre{}
+		ids = append(ids, movie.ID)
+		byID[movie.ID] = append(byID[movie.ID], movie)
+	}
+
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT mg.movie_id, g.id, g.name
+		FROM movies_genres mg
+		INNER JOIN genres g ON mg.genre_id = g.id
+		WHERE mg.movie_id = ANY($1)
+		ORDER BY g.name`,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("get movie genres: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movieID uuid.UUID
+		genre := &domain.Genre{}
+		if err = rows.Scan(&movieID, &genre.ID, &genre.Name); err != nil {
+			return fmt.Errorf("get movie genres: %w", err)
+		}
+		for _, movie := range byID[movieID] {
+			movie.Genres = append(movie.Genres, genre)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("get movie genres: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageMovie) GetMovieTranslations(ctx context.Context, movieIDs []uuid.UUID, locales []string) ([]*domain.MovieTranslation, error) {
+	translations, err := getMovieTranslations(ctx, conn(ctx, s.db), movieIDs, locales)
+	if err != nil {
+		return nil, fmt.Errorf("get movie translations: %w", err)
+	}
+	return translations, nil
+}
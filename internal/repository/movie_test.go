@@ -0,0 +1,60 @@
+package repository_test
+
+import (
+	"cinema_service/internal/domain"
+	"cinema_service/internal/repository"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMovieByID(t *testing.T) {
+	dbPool := openTestDB(t)
+	storage := repository.NewStorageMovie(dbPool)
+	ctx := context.Background()
+
+	// CreateMovie binds every metadata column, and GetMovieByID scans each
+	// column once.
+	movie := &domain.Movie{
+		Title:            "Inception",
+		Description:      "A thief who steals corporate secrets",
+		Date:             time.Date(2010, 7, 16, 0, 0, 0, 0, time.UTC),
+		DurationMinutes:  148,
+		AgeRating:        "12+",
+		Countries:        []string{"US", "GB"},
+		OriginalLanguage: "en",
+		IMDbID:           "tt1375666",
+		TMDBID:           27205,
+	}
+	require.NoError(t, storage.CreateMovie(ctx, movie))
+
+	got, err := storage.GetMovieByID(ctx, movie.ID)
+	require.NoError(t, err)
+	movie.Genres = []*domain.Genre{}
+	assert.Equal(t, movie, got)
+
+	_, err = storage.GetMovieByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestCreateMovieWithoutMetadata(t *testing.T) {
+	dbPool := openTestDB(t)
+	storage := repository.NewStorageMovie(dbPool)
+	ctx := context.Background()
+
+	movie := &domain.Movie{Title: "Heat", Date: time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, storage.CreateMovie(ctx, movie))
+
+	got, err := storage.GetMovieByID(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Heat", got.Title)
+	assert.Zero(t, got.DurationMinutes)
+	assert.Empty(t, got.AgeRating)
+	assert.Empty(t, got.Countries)
+	assert.Empty(t, got.IMDbID)
+	assert.Zero(t, got.TMDBID)
+}
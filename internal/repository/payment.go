@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StoragePayment struct {
+	db *pgxpool.Pool
+}
+
+func NewStoragePayment(dbPool *pgxpool.Pool) StoragePayment {
+	StoragePayment := StoragePayment{
+		db: dbPool,
+	}
+	return StoragePayment
+}
+
+func (s *StoragePayment) CreatePayment(ctx context.Context, payment *domain.Payment) error {
+	payment.ID = uuid.New()
+	if err := conn(ctx, s.db).QueryRow(ctx,
+		`INSERT INTO "payments" (id, booking_id, user_id, intent_id, amount, currency, status)
+			VALUES ($1, $2, NULLIF($3, '00000000-0000-0000-0000-000000000000'::uuid), $4, $5, $6, $7)
+			RETURNING created_at, updated_at`,
+		payment.ID, payment.BookingID, payment.UserID, payment.IntentID, payment.Amount, payment.Currency, payment.Status,
+	).Scan(&payment.CreatedAt, &payment.UpdatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("create payment: %w", domain.ErrAlreadyExists)
+		}
+		return fmt.Errorf("create payment: %w", err)
+	}
+	return nil
+}
+
+func (s *StoragePayment) GetPaymentByBookingID(ctx context.Context, bookingID uuid.UUID) (*domain.Payment, error) {
+	payment := &domain.Payment{}
+	if err := conn(ctx, s.db).QueryRow(ctx,
+		`SELECT id, booking_id, COALESCE(user_id, '00000000-0000-0000-0000-000000000000'::uuid), intent_id,
+			amount, currency, status, created_at, updated_at
+		FROM "payments" WHERE booking_id = $1`, bookingID,
+	).Scan(
+		&payment.ID, &payment.BookingID, &payment.UserID, &payment.IntentID, &payment.Amount,
+		&payment.Currency, &payment.Status, &payment.CreatedAt, &payment.UpdatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("get payment by booking id: %w", err)
+	}
+	return payment, nil
+}
+
+func (s *StoragePayment) GetPaymentByIntentID(ctx context.Context, intentID string) (*domain.Payment, error) {
+	payment := &domain.Payment{}
+	if err := conn(ctx, s.db).QueryRow(ctx,
+		`SELECT id, booking_id, COALESCE(user_id, '00000000-0000-0000-0000-000000000000'::uuid), intent_id,
+			amount, currency, status, created_at, updated_at
+		FROM "payments" WHERE intent_id = $1`, intentID,
+	).Scan(
+		&payment.ID, &payment.BookingID, &payment.UserID, &payment.IntentID, &payment.Amount,
+		&payment.Currency, &payment.Status, &payment.CreatedAt, &payment.UpdatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("get payment by intent id: %w", err)
+	}
+	return payment, nil
+}
+
+// UpdatePaymentStatus moves a payment from status from to status to, so
+// concurrent captures and cancellations cannot both apply.
+func (s *StoragePayment) UpdatePaymentStatus(ctx context.Context, paymentID uuid.UUID, from string, to string) error {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE "payments" SET status = $3, updated_at = now() WHERE id = $1 AND status = $2`,
+		paymentID, from, to,
+	)
+	if err != nil {
+		return fmt.Errorf("update payment status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("update payment status: %w", domain.ErrPaymentState)
+	}
+	return nil
+}
+
+// SaveWebhookEvent records a provider event ID and reports whether it was
+// seen for the first time.
+func (s *StoragePayment) SaveWebhookEvent(ctx context.Context, eventID string) (bool, error) {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO "payment_webhook_events" (id) VALUES ($1) ON CONFLICT (id) DO NOTHING`,
+		eventID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("save webhook event: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageRating struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageRating(dbPool *pgxpool.Pool) StorageRating {
+	StorageRating := StorageRating{
+		db: dbPool,
+	}
+	return StorageRating
+}
+
+func (s *StorageRating) UpsertRating(ctx context.Context, rating *domain.Rating) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO "movie_ratings" (user_id, movie_id, score) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, movie_id) DO UPDATE SET score = EXCLUDED.score, created_at = now()`,
+		rating.UserID, rating.MovieID, rating.Score,
+	); err != nil {
+		return fmt.Errorf("upsert rating: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageRating) DeleteRating(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM "movie_ratings" WHERE user_id = $1 AND movie_id = $2`,
+		userID, movieID,
+	); err != nil {
+		return fmt.Errorf("delete rating: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageRating) GetRatingStats(ctx context.Context, movieID uuid.UUID) (float64, int, error) {
+	var (
+		avg   float64
+		count int
+	)
+	if err := conn(ctx, s.db).QueryRow(ctx,
+		`SELECT COALESCE(AVG(score), 0), COUNT(*) FROM "movie_ratings" WHERE movie_id = $1`,
+		movieID,
+	).Scan(&avg, &count); err != nil {
+		return 0, 0, fmt.Errorf("get rating stats: %w", err)
+	}
+	return avg, count, nil
+}
+
+func (s *StorageRating) GetGlobalRatingMean(ctx context.Context) (float64, error) {
+	var avg float64
+	if err := conn(ctx, s.db).QueryRow(ctx,
+		`SELECT COALESCE(AVG(score), 0) FROM "movie_ratings"`,
+	).Scan(&avg); err != nil {
+		return 0, fmt.Errorf("get global rating mean: %w", err)
+	}
+	return avg, nil
+}
+
+// GetRatedMovieIDs returns the IDs of the movies with at least one vote.
+func (s *StorageRating) GetRatedMovieIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT DISTINCT movie_id FROM "movie_ratings" ORDER BY movie_id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get rated movie ids: %w", err)
+	}
+	defer rows.Close()
+
+	var movieIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("get rated movie ids: %w", err)
+		}
+		movieIDs = append(movieIDs, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get rated movie ids: %w", err)
+	}
+	return movieIDs, nil
+}
+
+func (s *StorageRating) UpdateMovieRating(ctx context.Context, summary *domain.RatingSummary) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE "movies" SET rating = $2, votes_count = $3 WHERE id = $1`,
+		summary.MovieID, summary.Rating, summary.Votes,
+	); err != nil {
+		return fmt.Errorf("update movie rating: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageRating) GetRatingSummary(ctx context.Context, movieID uuid.UUID) (*domain.RatingSummary, error) {
+	summary := &domain.RatingSummary{}
+	if err := conn(ctx, s.db).QueryRow(ctx,
+		`SELECT id, COALESCE(rating, 0), votes_count FROM "movies" WHERE id = $1 AND deleted_at IS NULL`,
+		movieID,
+	).Scan(&summary.MovieID, &summary.Rating, &summary.Votes); err != nil {
+		return nil, fmt.Errorf("get rating summary: %w", err)
+	}
+	return summary, nil
+}
+
+// UpsertReview stores the user's review of a movie. Editing a review sends
+// it back to moderation.
+func (s *StorageRating) UpsertReview(ctx context.Context, review *domain.Review) error {
+	review.ID = uuid.New()
+	if err := conn(ctx, s.db).QueryRow(ctx,
+		`INSERT INTO "movie_reviews" (id, user_id, movie_id, text, status) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, movie_id)
+		DO UPDATE SET text = EXCLUDED.text, status = EXCLUDED.status, created_at = now()
+		RETURNING id, created_at`,
+		review.ID, review.UserID, review.MovieID, review.Text, review.Status,
+	).Scan(&review.ID, &review.CreatedAt); err != nil {
+		return fmt.Errorf("upsert review: %w", err)
+	}
+	return nil
+}
+
+// GetReviews lists reviews with the given status, newest first. A nil
+// movieID lists reviews of all movies. Reviews of deleted movies are
+// skipped.
+func (s *StorageRating) GetReviews(ctx context.Context, movieID uuid.UUID, status string, limit int, offset int) ([]*domain.Review, error) {
+	var reviews []*domain.Review
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT r.id, r.user_id, r.movie_id, r.text, r.status, r.created_at FROM "movie_reviews" r
+		INNER JOIN movies m ON r.movie_id = m.id
+		WHERE r.status = $1 AND ($2 = '00000000-0000-0000-0000-000000000000'::uuid OR r.movie_id = $2)
+		AND m.deleted_at IS NULL
+		ORDER BY r.created_at DESC
+		LIMIT $3 OFFSET $4`,
+		status, movieID, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get reviews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		review := &domain.Review{}
+		if err = rows.Scan(
+			&review.ID, &review.UserID, &review.MovieID, &review.Text, &review.Status, &review.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("get reviews: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (s *StorageRating) UpdateReviewStatus(ctx context.Context, reviewID uuid.UUID, status string) error {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE "movie_reviews" SET status = $2 WHERE id = $1`,
+		reviewID, status,
+	)
+	if err != nil {
+		return fmt.Errorf("update review status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("update review status: %w", domain.ErrNotFound)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"time"
 )
@@ -14,6 +15,24 @@ var (
 	ErrDuplicateLogin = errors.New("duplicate login")
 )
 
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// isForeignKeyViolation reports whether err was caused by a reference to a
+// row that does not exist.
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation
+}
+
+// isUniqueViolation reports whether err was caused by a duplicate key.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
 func Connect(c *config.Config) (*pgxpool.Pool, error) {
 	connectionString := c.PostgresDSN()
 
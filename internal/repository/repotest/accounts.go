@@ -0,0 +1,272 @@
+package repotest
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testUsers(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := createUser(t, r, "alice")
+	assert.NotEqual(t, uuid.Nil, alice.ID)
+	assert.False(t, alice.CreatedAt.IsZero())
+
+	user, err := r.Users.GetUser(ctx, "alice", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, user.ID)
+	assert.Equal(t, domain.USER, user.Role)
+
+	_, err = r.Users.GetUser(ctx, "alice", "wrong")
+	assert.Error(t, err)
+	_, err = r.Users.GetUser(ctx, "bob", "bob")
+	assert.Error(t, err)
+
+	duplicate := &domain.User{Login: "alice", Password: alice.Password, Role: domain.ADMIN}
+	assert.ErrorIs(t, r.Users.CreateUser(ctx, duplicate), domain.ErrAlreadyExists)
+}
+
+func testPayments(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	payment := &domain.Payment{
+		BookingID: uuid.New(),
+		UserID:    uuid.New(),
+		IntentID:  "pi_1",
+		Amount:    45000,
+		Currency:  "RUB",
+		Status:    domain.PaymentPending,
+	}
+	require.NoError(t, r.Payments.CreatePayment(ctx, payment))
+	assert.NotEqual(t, uuid.Nil, payment.ID)
+	assert.False(t, payment.CreatedAt.IsZero())
+
+	duplicate := &domain.Payment{BookingID: payment.BookingID, IntentID: "pi_2", Amount: 1, Currency: "RUB", Status: domain.PaymentPending}
+	assert.ErrorIs(t, r.Payments.CreatePayment(ctx, duplicate), domain.ErrAlreadyExists)
+
+	require.NoError(t, r.Payments.UpdatePaymentStatus(ctx, payment.ID, domain.PaymentPending, domain.PaymentCaptured))
+	// The payment is no longer pending.
+	err := r.Payments.UpdatePaymentStatus(ctx, payment.ID, domain.PaymentPending, domain.PaymentFailed)
+	assert.ErrorIs(t, err, domain.ErrPaymentState)
+
+	byBooking, err := r.Payments.GetPaymentByBookingID(ctx, payment.BookingID)
+	require.NoError(t, err)
+	byIntent, err := r.Payments.GetPaymentByIntentID(ctx, "pi_1")
+	require.NoError(t, err)
+	for _, got := range []*domain.Payment{byBooking, byIntent} {
+		assert.Equal(t, payment.ID, got.ID)
+		assert.Equal(t, payment.UserID, got.UserID)
+		assert.Equal(t, int64(45000), got.Amount)
+		assert.Equal(t, domain.PaymentCaptured, got.Status)
+	}
+
+	_, err = r.Payments.GetPaymentByBookingID(ctx, uuid.New())
+	assert.Error(t, err)
+	_, err = r.Payments.GetPaymentByIntentID(ctx, "pi_unknown")
+	assert.Error(t, err)
+
+	first, err := r.Payments.SaveWebhookEvent(ctx, "evt_1")
+	require.NoError(t, err)
+	assert.True(t, first)
+	first, err = r.Payments.SaveWebhookEvent(ctx, "evt_1")
+	require.NoError(t, err)
+	assert.False(t, first)
+}
+
+func testRatings(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := createMovie(t, r, "Heat")
+	other := createMovie(t, r, "Ronin")
+	alice := createUser(t, r, "alice")
+	bob := createUser(t, r, "bob")
+
+	rate := func(user *domain.User, movie *domain.Movie, score int) {
+		t.Helper()
+		require.NoError(t, r.Ratings.UpsertRating(ctx, &domain.Rating{UserID: user.ID, MovieID: movie.ID, Score: score}))
+	}
+	rate(alice, movie, 8)
+	rate(bob, movie, 6)
+	rate(alice, movie, 10)
+	rate(alice, other, 2)
+
+	avg, votes, err := r.Ratings.GetRatingStats(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 8, avg, 1e-9)
+	assert.Equal(t, 2, votes)
+
+	mean, err := r.Ratings.GetGlobalRatingMean(ctx)
+	require.NoError(t, err)
+	assert.InDelta(t, 6, mean, 1e-9)
+
+	createMovie(t, r, "Collateral")
+	rated, err := r.Ratings.GetRatedMovieIDs(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{movie.ID, other.ID}, rated)
+
+	require.NoError(t, r.Ratings.DeleteRating(ctx, bob.ID, movie.ID))
+	avg, votes, err = r.Ratings.GetRatingStats(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 10, avg, 1e-9)
+	assert.Equal(t, 1, votes)
+
+	summary := &domain.RatingSummary{MovieID: movie.ID, Rating: 9, Votes: 1}
+	require.NoError(t, r.Ratings.UpdateMovieRating(ctx, summary))
+	got, err := r.Ratings.GetRatingSummary(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Equal(t, summary, got)
+	// Votes are not edits of the movie, so its version is unchanged and
+	// If-Match updates are not turned away by them.
+	stored, err := r.Movies.GetMovieByID(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.Version)
+
+	_, err = r.Ratings.GetRatingSummary(ctx, uuid.New())
+	assert.Error(t, err)
+}
+
+func testReviews(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := createMovie(t, r, "Heat")
+	other := createMovie(t, r, "Ronin")
+	alice := createUser(t, r, "alice")
+	bob := createUser(t, r, "bob")
+
+	review := func(user *domain.User, movie *domain.Movie, status string) *domain.Review {
+		t.Helper()
+		review := &domain.Review{UserID: user.ID, MovieID: movie.ID, Text: "Review by " + user.Login, Status: status}
+		require.NoError(t, r.Ratings.UpsertReview(ctx, review))
+		// Reviews are listed by creation time.
+		time.Sleep(time.Millisecond)
+		return review
+	}
+	first := review(alice, movie, domain.ReviewPending)
+	second := review(bob, movie, domain.ReviewPending)
+	review(alice, other, domain.ReviewApproved)
+
+	reviews, err := r.Ratings.GetReviews(ctx, movie.ID, domain.ReviewPending, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, reviews, 2)
+	assert.Equal(t, []uuid.UUID{second.ID, first.ID}, []uuid.UUID{reviews[0].ID, reviews[1].ID})
+
+	edited := review(alice, movie, domain.ReviewPending)
+	assert.Equal(t, first.ID, edited.ID)
+
+	reviews, err = r.Ratings.GetReviews(ctx, movie.ID, domain.ReviewPending, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, second.ID, reviews[0].ID)
+
+	reviews, err = r.Ratings.GetReviews(ctx, uuid.Nil, domain.ReviewApproved, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, other.ID, reviews[0].MovieID)
+
+	require.NoError(t, r.Ratings.UpdateReviewStatus(ctx, second.ID, domain.ReviewRejected))
+	reviews, err = r.Ratings.GetReviews(ctx, movie.ID, domain.ReviewRejected, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, reviews, 1)
+	assert.ErrorIs(t, r.Ratings.UpdateReviewStatus(ctx, uuid.New(), domain.ReviewApproved), domain.ErrNotFound)
+}
+
+func testWatchlist(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := createUser(t, r, "alice")
+	heat := createMovie(t, r, "Heat")
+	ronin := createMovie(t, r, "Ronin")
+
+	require.NoError(t, r.Watchlist.AddToWatchlist(ctx, alice.ID, heat.ID))
+	time.Sleep(time.Millisecond)
+	require.NoError(t, r.Watchlist.AddToWatchlist(ctx, alice.ID, ronin.ID))
+	require.NoError(t, r.Watchlist.AddToWatchlist(ctx, alice.ID, heat.ID))
+	assert.ErrorIs(t, r.Watchlist.AddToWatchlist(ctx, alice.ID, uuid.New()), domain.ErrNotFound)
+	trashed := createMovie(t, r, "Solaris")
+	require.NoError(t, r.Movies.DeleteMovie(ctx, trashed.ID))
+	assert.ErrorIs(t, r.Watchlist.AddToWatchlist(ctx, alice.ID, trashed.ID), domain.ErrNotFound)
+
+	entries, err := r.Watchlist.GetWatchlist(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, []string{"Ronin", "Heat"}, []string{entries[0].Movie.Title, entries[1].Movie.Title})
+	assert.True(t, entries[0].Movie.InWatchlist)
+	assert.False(t, entries[0].AddedAt.IsZero())
+
+	ids, err := r.Movies.GetWatchlistMovieIDs(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]struct{}{heat.ID: {}, ronin.ID: {}}, ids)
+
+	require.NoError(t, r.Watchlist.RemoveFromWatchlist(ctx, alice.ID, heat.ID))
+	entries, err = r.Watchlist.GetWatchlist(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, ronin.ID, entries[0].Movie.ID)
+}
+
+func testFavoriteActors(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := createUser(t, r, "alice")
+	pacino := createActor(t, r, "Al", "Pacino")
+	deNiro := createActor(t, r, "Robert", "De Niro")
+
+	require.NoError(t, r.Watchlist.AddFavoriteActor(ctx, alice.ID, pacino.ID))
+	time.Sleep(time.Millisecond)
+	require.NoError(t, r.Watchlist.AddFavoriteActor(ctx, alice.ID, deNiro.ID))
+	require.NoError(t, r.Watchlist.AddFavoriteActor(ctx, alice.ID, pacino.ID))
+	assert.ErrorIs(t, r.Watchlist.AddFavoriteActor(ctx, alice.ID, uuid.New()), domain.ErrNotFound)
+	trashed := createActor(t, r, "Val", "Kilmer")
+	require.NoError(t, r.Actors.DeleteActor(ctx, trashed.ID))
+	assert.ErrorIs(t, r.Watchlist.AddFavoriteActor(ctx, alice.ID, trashed.ID), domain.ErrNotFound)
+
+	favorites, err := r.Watchlist.GetFavoriteActors(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, favorites, 2)
+	assert.Equal(t, deNiro, favorites[0].Actor)
+	assert.Equal(t, pacino, favorites[1].Actor)
+
+	require.NoError(t, r.Watchlist.RemoveFavoriteActor(ctx, alice.ID, pacino.ID))
+	favorites, err = r.Watchlist.GetFavoriteActors(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, favorites, 1)
+	assert.Equal(t, deNiro.ID, favorites[0].Actor.ID)
+}
+
+func testSigningKeys(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	rotate := func(id string, pruneBefore time.Time) {
+		t.Helper()
+		key := &domain.SigningKey{ID: id, Secret: []byte("secret-" + id)}
+		require.NoError(t, r.SigningKeys.RotateSigningKey(ctx, key, pruneBefore))
+		assert.False(t, key.CreatedAt.IsZero())
+		time.Sleep(time.Millisecond)
+	}
+	ids := func(retiredAfter time.Time) []string {
+		t.Helper()
+		keys, err := r.SigningKeys.GetSigningKeys(ctx, retiredAfter)
+		require.NoError(t, err)
+		var ids []string
+		for _, key := range keys {
+			ids = append(ids, key.ID)
+		}
+		return ids
+	}
+	future := time.Now().Add(24 * time.Hour)
+
+	rotate("first", time.Time{})
+	rotate("second", time.Time{})
+	assert.Equal(t, []string{"second", "first"}, ids(time.Time{}))
+	assert.Equal(t, []string{"second"}, ids(future))
+
+	keys, err := r.SigningKeys.GetSigningKeys(ctx, time.Time{})
+	require.NoError(t, err)
+	assert.True(t, keys[0].RetiredAt.IsZero())
+	assert.False(t, keys[1].RetiredAt.IsZero())
+	assert.Equal(t, []byte("secret-second"), keys[0].Secret)
+
+	rotate("third", future)
+	assert.Equal(t, []string{"third"}, ids(time.Time{}))
+
+	assert.Error(t, r.SigningKeys.RotateSigningKey(ctx, &domain.SigningKey{ID: "third", Secret: []byte("x")}, time.Time{}))
+}
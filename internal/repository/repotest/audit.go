@@ -0,0 +1,68 @@
+package repotest
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAuditLog(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := uuid.New()
+	movieID := uuid.New()
+	actorID := uuid.New()
+	appendRecord := func(userID uuid.UUID, entity string, entityID uuid.UUID) *domain.AuditRecord {
+		t.Helper()
+		record := &domain.AuditRecord{
+			UserID:    userID,
+			Action:    domain.AuditUpdate,
+			Entity:    entity,
+			EntityID:  entityID,
+			Changes:   map[string]domain.AuditChange{"Title": {Before: "Heat", After: "Ronin"}},
+			RequestID: "req-" + entity,
+		}
+		require.NoError(t, r.Audit.AppendAuditRecord(ctx, record))
+		assert.NotEqual(t, uuid.Nil, record.ID)
+		assert.False(t, record.CreatedAt.IsZero())
+		time.Sleep(time.Millisecond)
+		return record
+	}
+	ids := func(filter domain.AuditFilter) []uuid.UUID {
+		t.Helper()
+		records, err := r.Audit.GetAuditRecords(ctx, filter)
+		require.NoError(t, err)
+		var ids []uuid.UUID
+		for _, record := range records {
+			ids = append(ids, record.ID)
+		}
+		return ids
+	}
+
+	first := appendRecord(alice, domain.AuditEntityMovie, movieID)
+	second := appendRecord(uuid.Nil, domain.AuditEntityActor, actorID)
+	third := appendRecord(alice, domain.AuditEntityActor, actorID)
+
+	assert.Equal(t, []uuid.UUID{third.ID, second.ID, first.ID}, ids(domain.AuditFilter{}))
+	assert.Equal(t, []uuid.UUID{third.ID, first.ID}, ids(domain.AuditFilter{UserID: alice}))
+	assert.Equal(t, []uuid.UUID{third.ID, second.ID}, ids(domain.AuditFilter{Entity: domain.AuditEntityActor}))
+	assert.Equal(t, []uuid.UUID{first.ID}, ids(domain.AuditFilter{EntityID: movieID}))
+	assert.Equal(t, []uuid.UUID{second.ID}, ids(domain.AuditFilter{From: second.CreatedAt, To: third.CreatedAt}))
+	assert.Equal(t, []uuid.UUID{second.ID}, ids(domain.AuditFilter{Limit: 1, Offset: 1}))
+	assert.Empty(t, ids(domain.AuditFilter{UserID: uuid.New()}))
+
+	records, err := r.Audit.GetAuditRecords(ctx, domain.AuditFilter{EntityID: movieID})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, first, records[0])
+
+	records, err = r.Audit.GetAuditRecords(ctx, domain.AuditFilter{UserID: alice, Entity: domain.AuditEntityActor})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "req-actor", records[0].RequestID)
+	assert.Equal(t, uuid.Nil, second.UserID)
+}
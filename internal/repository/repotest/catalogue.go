@@ -0,0 +1,738 @@
+package repotest
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMovies(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := &domain.Movie{
+		Title:            "Inception",
+		Description:      "A thief who steals corporate secrets",
+		Date:             date(2010, 7, 16),
+		DurationMinutes:  148,
+		AgeRating:        "12+",
+		Countries:        []string{"US", "GB"},
+		OriginalLanguage: "en",
+		IMDbID:           "tt1375666",
+		TMDBID:           27205,
+	}
+	require.NoError(t, r.Movies.CreateMovie(ctx, movie))
+	assert.NotEqual(t, uuid.Nil, movie.ID)
+	assert.Equal(t, 1, movie.Version)
+
+	movies, err := r.Movies.GetMovies(ctx)
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	expected := *movie
+	expected.Genres = []*domain.Genre{}
+	assert.Equal(t, &expected, movies[0])
+
+	// Ratings are written by the rating repository only.
+	require.NoError(t, r.Ratings.UpdateMovieRating(ctx, &domain.RatingSummary{MovieID: movie.ID, Rating: 8, Votes: 1}))
+	movie.Title = "Inception (2010)"
+	movie.Rating = 3
+	movie.Countries = nil
+	movie.IMDbID = ""
+	movie.AgeRating = ""
+	require.NoError(t, r.Movies.UpdateMovie(ctx, movie))
+	require.NoError(t, r.Movies.UpdateMovieFields(ctx, movie, []string{"Rating"}))
+
+	movies, err = r.Movies.GetMovies(ctx)
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	assert.Equal(t, "Inception (2010)", movies[0].Title)
+	assert.Empty(t, movies[0].Countries)
+	assert.Empty(t, movies[0].IMDbID)
+	assert.Empty(t, movies[0].AgeRating)
+	assert.Equal(t, 27205, movies[0].TMDBID)
+	assert.Equal(t, float32(8), movies[0].Rating)
+	assert.Equal(t, 3, movies[0].Version)
+
+	require.NoError(t, r.Movies.DeleteMovie(ctx, movie.ID))
+	movies, err = r.Movies.GetMovies(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, movies)
+
+	assert.NoError(t, r.Movies.DeleteMovie(ctx, uuid.New()))
+}
+
+func testCreateWithID(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := &domain.Movie{ID: uuid.New(), Title: "Heat", Date: date(1995, 12, 15)}
+	id := movie.ID
+	require.NoError(t, r.Movies.CreateMovie(ctx, movie))
+	assert.Equal(t, id, movie.ID)
+	stored, err := r.Movies.GetMovieByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Heat", stored.Title)
+	err = r.Movies.CreateMovie(ctx, &domain.Movie{ID: id, Title: "Ronin"})
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+	// IDs of movies in the trash are taken as well.
+	require.NoError(t, r.Movies.DeleteMovie(ctx, id))
+	err = r.Movies.CreateMovie(ctx, &domain.Movie{ID: id, Title: "Ronin"})
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+
+	actor := &domain.Actor{ID: uuid.New(), Name: "Robert", Surname: "De Niro"}
+	require.NoError(t, r.Actors.CreateActor(ctx, actor))
+	storedActor, err := r.Actors.GetActorByID(ctx, actor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, actor, storedActor)
+	err = r.Actors.CreateActor(ctx, &domain.Actor{ID: actor.ID, Name: "Al"})
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+}
+
+func testMovieExternalIDs(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	first := &domain.Movie{Title: "Heat", IMDbID: "tt0113277", TMDBID: 949}
+	require.NoError(t, r.Movies.CreateMovie(ctx, first))
+
+	duplicate := &domain.Movie{Title: "Heat (copy)", IMDbID: "tt0113277"}
+	assert.ErrorIs(t, r.Movies.CreateMovie(ctx, duplicate), domain.ErrAlreadyExists)
+
+	second := &domain.Movie{Title: "Ronin", IMDbID: "tt0122690", TMDBID: 8195}
+	require.NoError(t, r.Movies.CreateMovie(ctx, second))
+	second.TMDBID = first.TMDBID
+	assert.ErrorIs(t, r.Movies.UpdateMovie(ctx, second), domain.ErrAlreadyExists)
+
+	first.Title = "Heat (1995)"
+	assert.NoError(t, r.Movies.UpdateMovie(ctx, first))
+}
+
+func testMoviesBySnippet(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	inception := createMovie(t, r, "Inception")
+	heat := createMovie(t, r, "Heat")
+	leonardo := createActor(t, r, "Leonardo", "DiCaprio")
+	michael := createActor(t, r, "Michael", "Mann")
+	createCredit(t, r, &domain.Credit{MovieID: inception.ID, PersonID: leonardo.ID, Role: domain.RoleActor})
+	createCredit(t, r, &domain.Credit{MovieID: heat.ID, PersonID: michael.ID, Role: domain.RoleDirector})
+	require.NoError(t, r.Translations.UpsertMovieTranslation(ctx,
+		&domain.MovieTranslation{MovieID: heat.ID, Locale: "ru", Title: "Схватка"}))
+	require.NoError(t, r.Translations.UpsertActorTranslation(ctx,
+		&domain.ActorTranslation{ActorID: leonardo.ID, Locale: "ru", Name: "Леонардо"}))
+
+	tests := []struct {
+		snippet  string
+		expected []uuid.UUID
+	}{
+		{snippet: "cept", expected: []uuid.UUID{inception.ID}},
+		{snippet: "Leo", expected: []uuid.UUID{inception.ID}},
+		{snippet: "Леон", expected: []uuid.UUID{inception.ID}},
+		{snippet: "Схват", expected: []uuid.UUID{heat.ID}},
+		// Directors are not searched and matching is case-sensitive.
+		{snippet: "Michael"},
+		{snippet: "heat"},
+	}
+	for _, tt := range tests {
+		movies, err := r.Movies.GetMoviesBySnippet(ctx, tt.snippet)
+		require.NoError(t, err)
+
+		var ids []uuid.UUID
+		for _, movie := range movies {
+			assert.NotNil(t, movie.Genres)
+			ids = append(ids, movie.ID)
+		}
+		assert.ElementsMatch(t, tt.expected, ids, tt.snippet)
+	}
+}
+
+func testMovieGenres(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := createMovie(t, r, "Heat")
+	drama := createGenre(t, r, "Drama")
+	action := createGenre(t, r, "Action")
+	crime := createGenre(t, r, "Crime")
+
+	genresOf := func() []*domain.Genre {
+		t.Helper()
+		movies, err := r.Movies.GetMovies(ctx)
+		require.NoError(t, err)
+		require.Len(t, movies, 1)
+		return movies[0].Genres
+	}
+
+	require.NoError(t, r.Movies.SetMovieGenres(ctx, movie.ID, []uuid.UUID{drama.ID, action.ID}))
+	assert.Equal(t, []*domain.Genre{action, drama}, genresOf())
+	stored, err := r.Movies.GetMovieByID(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Genre{action, drama}, stored.Genres)
+
+	err = r.Movies.SetMovieGenres(ctx, movie.ID, []uuid.UUID{uuid.New()})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, r.Movies.SetMovieGenres(ctx, movie.ID, []uuid.UUID{crime.ID}))
+	assert.Equal(t, []*domain.Genre{crime}, genresOf())
+
+	require.NoError(t, r.Genres.DeleteGenre(ctx, crime.ID))
+	assert.Empty(t, genresOf())
+}
+
+// linkMovie gives a movie a credit, a genre, a translation, a rating, a
+// review and a watchlist entry.
+func linkMovie(t *testing.T, r Repositories, movie *domain.Movie) (*domain.Actor, *domain.User) {
+	t.Helper()
+	ctx := context.Background()
+	actor := createActor(t, r, "Al", "Pacino")
+	user := createUser(t, r, "alice")
+	genre := createGenre(t, r, "Crime")
+	createCredit(t, r, &domain.Credit{MovieID: movie.ID, PersonID: actor.ID, Role: domain.RoleActor})
+	require.NoError(t, r.Movies.SetMovieGenres(ctx, movie.ID, []uuid.UUID{genre.ID}))
+	require.NoError(t, r.Translations.UpsertMovieTranslation(ctx,
+		&domain.MovieTranslation{MovieID: movie.ID, Locale: "ru", Title: "Схватка"}))
+	require.NoError(t, r.Ratings.UpsertRating(ctx, &domain.Rating{UserID: user.ID, MovieID: movie.ID, Score: 9}))
+	require.NoError(t, r.Ratings.UpsertReview(ctx,
+		&domain.Review{UserID: user.ID, MovieID: movie.ID, Text: "Great", Status: domain.ReviewApproved}))
+	require.NoError(t, r.Watchlist.AddToWatchlist(ctx, user.ID, movie.ID))
+	return actor, user
+}
+
+// testDeleteMovie checks that a deleted movie disappears from every read
+// and comes back with all its links when restored.
+func testDeleteMovie(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := createMovie(t, r, "Heat")
+	createMovie(t, r, "Ronin")
+	actor, user := linkMovie(t, r, movie)
+
+	require.NoError(t, r.Movies.DeleteMovie(ctx, movie.ID))
+
+	movies, err := r.Movies.GetMovies(ctx)
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	assert.Equal(t, "Ronin", movies[0].Title)
+	movies, err = r.Movies.GetMoviesBySnippet(ctx, "Heat")
+	require.NoError(t, err)
+	assert.Empty(t, movies)
+	actors, err := r.Actors.GetActors(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, actors)
+	credits, err := r.Credits.GetPersonCredits(ctx, actor.ID)
+	require.NoError(t, err)
+	assert.Empty(t, credits)
+	credits, err = r.Credits.GetMovieCredits(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Empty(t, credits)
+	reviews, err := r.Ratings.GetReviews(ctx, uuid.Nil, domain.ReviewApproved, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, reviews)
+	watchlist, err := r.Watchlist.GetWatchlist(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, watchlist)
+	_, err = r.Ratings.GetRatingSummary(ctx, movie.ID)
+	assert.Error(t, err)
+
+	// Updates do not reach movies in the trash.
+	movie.Title = "Heat 2"
+	require.NoError(t, r.Movies.UpdateMovie(ctx, movie))
+	_, err = r.Trash.GetMovieByID(ctx, movie.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, r.Trash.RestoreMovie(ctx, movie.ID))
+	restored, err := r.Trash.GetMovieByID(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Heat", restored.Title)
+
+	movies, err = r.Movies.GetMoviesBySnippet(ctx, "Heat")
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	assert.Equal(t, "Heat", movies[0].Title)
+	require.Len(t, movies[0].Genres, 1)
+	credits, err = r.Credits.GetPersonCredits(ctx, actor.ID)
+	require.NoError(t, err)
+	assert.Len(t, credits, 1)
+	reviews, err = r.Ratings.GetReviews(ctx, uuid.Nil, domain.ReviewApproved, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, reviews, 1)
+	watchlist, err = r.Watchlist.GetWatchlist(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, watchlist, 1)
+	translations, err := r.Translations.ListMovieTranslations(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Len(t, translations, 1)
+}
+
+func testPurgeMovieCascades(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := createMovie(t, r, "Heat")
+	actor, user := linkMovie(t, r, movie)
+	require.NoError(t, r.Versions.AddVersion(ctx,
+		&domain.EntityVersion{Entity: domain.AuditEntityMovie, EntityID: movie.ID, State: []byte(`{}`)}))
+
+	require.NoError(t, r.Movies.DeleteMovie(ctx, movie.ID))
+	purged, err := r.Trash.PurgeDeleted(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.ErrorIs(t, r.Trash.RestoreMovie(ctx, movie.ID), domain.ErrNotFound)
+
+	credits, err := r.Credits.GetPersonCredits(ctx, actor.ID)
+	require.NoError(t, err)
+	assert.Empty(t, credits)
+	translations, err := r.Translations.ListMovieTranslations(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Empty(t, translations)
+	_, votes, err := r.Ratings.GetRatingStats(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Zero(t, votes)
+	reviews, err := r.Ratings.GetReviews(ctx, uuid.Nil, domain.ReviewApproved, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, reviews)
+	watchlist, err := r.Watchlist.GetWatchlist(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, watchlist)
+	genres, err := r.Genres.GetGenres(ctx)
+	require.NoError(t, err)
+	assert.Len(t, genres, 1)
+	versions, err := r.Versions.GetVersions(ctx, domain.AuditEntityMovie, movie.ID)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func testActors(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	inception := createMovie(t, r, "Inception")
+	titanic := createMovie(t, r, "Titanic")
+	leonardo := createActor(t, r, "Leonardo", "DiCaprio")
+	director := createActor(t, r, "Christopher", "Nolan")
+	createActor(t, r, "Uncredited", "Actor")
+	createCredit(t, r, &domain.Credit{MovieID: inception.ID, PersonID: leonardo.ID, Role: domain.RoleActor, Character: "Cobb"})
+	createCredit(t, r, &domain.Credit{MovieID: inception.ID, PersonID: leonardo.ID, Role: domain.RoleActor, Character: "Dom"})
+	createCredit(t, r, &domain.Credit{MovieID: titanic.ID, PersonID: leonardo.ID, Role: domain.RoleActor})
+	createCredit(t, r, &domain.Credit{MovieID: inception.ID, PersonID: director.ID, Role: domain.RoleDirector})
+
+	leonardo.Name = "Leo"
+	require.NoError(t, r.Actors.UpdateActor(ctx, leonardo))
+	stored, err := r.Actors.GetActorByID(ctx, leonardo.ID)
+	require.NoError(t, err)
+	// Every update increments the version.
+	leonardo.Version++
+	assert.Equal(t, leonardo, stored)
+	_, err = r.Actors.GetActorByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	actors, err := r.Actors.GetActors(ctx)
+	require.NoError(t, err)
+	require.Len(t, actors, 1)
+	for actor, movies := range actors {
+		assert.Equal(t, leonardo, actor)
+		require.Len(t, movies, 2)
+		titles := []string{movies[0].Title, movies[1].Title}
+		assert.ElementsMatch(t, []string{"Inception", "Titanic"}, titles)
+	}
+
+	require.NoError(t, r.Translations.UpsertActorTranslation(ctx,
+		&domain.ActorTranslation{ActorID: leonardo.ID, Locale: "ru", Name: "Леонардо", Surname: "Ди Каприо"}))
+	require.NoError(t, r.Translations.UpsertActorTranslation(ctx,
+		&domain.ActorTranslation{ActorID: leonardo.ID, Locale: "de", Name: "Leonardo"}))
+	translations, err := r.Actors.GetActorTranslations(ctx, []uuid.UUID{leonardo.ID, director.ID}, []string{"ru", "fr"})
+	require.NoError(t, err)
+	require.Len(t, translations, 1)
+	assert.Equal(t, "Ди Каприо", translations[0].Surname)
+}
+
+func testUpdateFields(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := createMovie(t, r, "Heat")
+	other := createMovie(t, r, "Ronin")
+
+	// Only the listed fields are written; the others keep their values.
+	update := &domain.Movie{ID: movie.ID, Title: "Heat (1995)", Description: "ignored", AgeRating: "16+"}
+	require.NoError(t, r.Movies.UpdateMovieFields(ctx, update, []string{"Title", "AgeRating"}))
+	stored, err := r.Movies.GetMovieByID(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Heat (1995)", stored.Title)
+	assert.Equal(t, "16+", stored.AgeRating)
+	assert.Equal(t, movie.Description, stored.Description)
+	assert.Equal(t, movie.Date, stored.Date)
+	assert.Equal(t, 2, stored.Version)
+
+	other.IMDbID = "tt0113277"
+	require.NoError(t, r.Movies.UpdateMovieFields(ctx, other, []string{"IMDbID"}))
+	update = &domain.Movie{ID: movie.ID, IMDbID: other.IMDbID}
+	err = r.Movies.UpdateMovieFields(ctx, update, []string{"IMDbID"})
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+
+	actor := createActor(t, r, "Robert", "De Niro")
+	birthdate := date(1943, 8, 17)
+	require.NoError(t, r.Actors.UpdateActorFields(ctx,
+		&domain.Actor{ID: actor.ID, Name: "ignored", Birthdate: birthdate}, []string{"Birthdate"}))
+	storedActor, err := r.Actors.GetActorByID(ctx, actor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Robert", storedActor.Name)
+	assert.Equal(t, birthdate, storedActor.Birthdate)
+	assert.Equal(t, 2, storedActor.Version)
+}
+
+func testDeleteActor(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := createMovie(t, r, "Heat")
+	pacino := createActor(t, r, "Al", "Pacino")
+	deNiro := createActor(t, r, "Robert", "De Niro")
+	user := createUser(t, r, "alice")
+	createCredit(t, r, &domain.Credit{MovieID: movie.ID, PersonID: pacino.ID, Role: domain.RoleActor})
+	createCredit(t, r, &domain.Credit{MovieID: movie.ID, PersonID: deNiro.ID, Role: domain.RoleActor})
+	require.NoError(t, r.Translations.UpsertActorTranslation(ctx,
+		&domain.ActorTranslation{ActorID: pacino.ID, Locale: "ru", Name: "Аль"}))
+	require.NoError(t, r.Watchlist.AddFavoriteActor(ctx, user.ID, pacino.ID))
+
+	require.NoError(t, r.Actors.DeleteActor(ctx, pacino.ID))
+	assert.ErrorIs(t, r.Actors.DeleteActor(ctx, pacino.ID), domain.ErrNotFound)
+	_, err := r.Actors.GetActorByID(ctx, pacino.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	credits, err := r.Credits.GetMovieCredits(ctx, movie.ID)
+	require.NoError(t, err)
+	require.Len(t, credits, 1)
+	assert.Equal(t, deNiro.ID, credits[0].PersonID)
+	favorites, err := r.Watchlist.GetFavoriteActors(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, favorites)
+
+	movies, err := r.Movies.GetMovies(ctx)
+	require.NoError(t, err)
+	assert.Len(t, movies, 1)
+
+	require.NoError(t, r.Trash.RestoreActor(ctx, pacino.ID))
+	credits, err = r.Credits.GetMovieCredits(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Len(t, credits, 2)
+	favorites, err = r.Watchlist.GetFavoriteActors(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, favorites, 1)
+	translations, err := r.Translations.ListActorTranslations(ctx, pacino.ID)
+	require.NoError(t, err)
+	assert.Len(t, translations, 1)
+}
+
+func testTrash(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	heat := createMovie(t, r, "Heat")
+	ronin := createMovie(t, r, "Ronin")
+	pacino := createActor(t, r, "Al", "Pacino")
+	user := createUser(t, r, "alice")
+	createCredit(t, r, &domain.Credit{MovieID: ronin.ID, PersonID: pacino.ID, Role: domain.RoleActor})
+	require.NoError(t, r.Watchlist.AddFavoriteActor(ctx, user.ID, pacino.ID))
+
+	movies, err := r.Trash.GetDeletedMovies(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, movies)
+	assert.ErrorIs(t, r.Trash.RestoreMovie(ctx, heat.ID), domain.ErrNotFound)
+	assert.ErrorIs(t, r.Trash.RestoreActor(ctx, pacino.ID), domain.ErrNotFound)
+
+	require.NoError(t, r.Movies.DeleteMovie(ctx, heat.ID))
+	time.Sleep(time.Millisecond)
+	require.NoError(t, r.Movies.DeleteMovie(ctx, ronin.ID))
+	require.NoError(t, r.Actors.DeleteActor(ctx, pacino.ID))
+
+	movies, err = r.Trash.GetDeletedMovies(ctx)
+	require.NoError(t, err)
+	require.Len(t, movies, 2)
+	assert.Equal(t, "Ronin", movies[0].Movie.Title)
+	assert.Equal(t, heat.ID, movies[1].Movie.ID)
+	assert.False(t, movies[1].DeletedAt.IsZero())
+	assert.True(t, movies[0].DeletedAt.After(movies[1].DeletedAt))
+	actors, err := r.Trash.GetDeletedActors(ctx)
+	require.NoError(t, err)
+	require.Len(t, actors, 1)
+	assert.Equal(t, "Pacino", actors[0].Actor.Surname)
+
+	// Nothing was deleted long enough ago to be purged.
+	purged, err := r.Trash.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	require.NoError(t, r.Trash.RestoreMovie(ctx, heat.ID))
+	purged, err = r.Trash.PurgeDeleted(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+
+	movies, err = r.Trash.GetDeletedMovies(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, movies)
+	actors, err = r.Trash.GetDeletedActors(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, actors)
+	assert.ErrorIs(t, r.Trash.RestoreActor(ctx, pacino.ID), domain.ErrNotFound)
+	remaining, err := r.Movies.GetMovies(ctx)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, heat.ID, remaining[0].ID)
+
+	favorites, err := r.Watchlist.GetFavoriteActors(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, favorites)
+	assert.ErrorIs(t, r.Watchlist.AddFavoriteActor(ctx, user.ID, pacino.ID), domain.ErrNotFound)
+}
+
+func testGenres(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	drama := createGenre(t, r, "Drama")
+	comedy := createGenre(t, r, "Comedy")
+
+	assert.ErrorIs(t, r.Genres.CreateGenre(ctx, &domain.Genre{Name: "Drama"}), domain.ErrAlreadyExists)
+
+	genres, err := r.Genres.GetGenres(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Genre{comedy, drama}, genres)
+	genre, err := r.Genres.GetGenre(ctx, drama.ID)
+	require.NoError(t, err)
+	assert.Equal(t, drama, genre)
+	_, err = r.Genres.GetGenre(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.ErrorIs(t, r.Genres.UpdateGenre(ctx, &domain.Genre{ID: comedy.ID, Name: "Drama"}), domain.ErrAlreadyExists)
+	assert.ErrorIs(t, r.Genres.UpdateGenre(ctx, &domain.Genre{ID: uuid.New(), Name: "Horror"}), domain.ErrNotFound)
+	comedy.Name = "Action"
+	require.NoError(t, r.Genres.UpdateGenre(ctx, comedy))
+
+	require.NoError(t, r.Genres.DeleteGenre(ctx, drama.ID))
+	assert.ErrorIs(t, r.Genres.DeleteGenre(ctx, drama.ID), domain.ErrNotFound)
+
+	genres, err = r.Genres.GetGenres(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Genre{comedy}, genres)
+}
+
+func testCredits(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	older := createMovie(t, r, "Older")
+	newer := &domain.Movie{Title: "Newer", Date: date(2015, 1, 1)}
+	require.NoError(t, r.Movies.CreateMovie(ctx, newer))
+	undated := &domain.Movie{Title: "Undated"}
+	require.NoError(t, r.Movies.CreateMovie(ctx, undated))
+	first := createActor(t, r, "Anna", "Bell")
+	second := createActor(t, r, "Bob", "Adams")
+
+	lead := createCredit(t, r, &domain.Credit{MovieID: older.ID, PersonID: second.ID, Role: domain.RoleActor, Character: "Lead", BillingOrder: 1})
+	support := createCredit(t, r, &domain.Credit{MovieID: older.ID, PersonID: first.ID, Role: domain.RoleActor, Character: "Support", BillingOrder: 2})
+	directing := createCredit(t, r, &domain.Credit{MovieID: older.ID, PersonID: first.ID, Role: domain.RoleDirector})
+	createCredit(t, r, &domain.Credit{MovieID: newer.ID, PersonID: first.ID, Role: domain.RoleActor})
+	createCredit(t, r, &domain.Credit{MovieID: undated.ID, PersonID: first.ID, Role: domain.RoleActor})
+
+	err := r.Credits.CreateCredit(ctx, &domain.Credit{MovieID: older.ID, PersonID: first.ID, Role: domain.RoleActor, Character: "Support"})
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+	err = r.Credits.CreateCredit(ctx, &domain.Credit{MovieID: uuid.New(), PersonID: first.ID, Role: domain.RoleActor})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	err = r.Credits.CreateCredit(ctx, &domain.Credit{MovieID: older.ID, PersonID: uuid.New(), Role: domain.RoleActor})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	credits, err := r.Credits.GetMovieCredits(ctx, older.ID)
+	require.NoError(t, err)
+	require.Len(t, credits, 3)
+	assert.Equal(t, []uuid.UUID{lead.ID, support.ID, directing.ID},
+		[]uuid.UUID{credits[0].ID, credits[1].ID, credits[2].ID})
+	assert.Equal(t, second, credits[0].Person)
+	assert.Equal(t, "Lead", credits[0].Character)
+
+	credits, err = r.Credits.GetPersonCredits(ctx, first.ID)
+	require.NoError(t, err)
+	var titles []string
+	for _, credit := range credits {
+		titles = append(titles, credit.Role+" "+credit.Movie.Title)
+		assert.Equal(t, credit.MovieID, credit.Movie.ID)
+	}
+	assert.Equal(t, []string{"ACTOR Newer", "ACTOR Older", "ACTOR Undated", "DIRECTOR Older"}, titles)
+
+	credit, err := r.Credits.GetCredit(ctx, support.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.Credit{
+		ID: support.ID, MovieID: older.ID, PersonID: first.ID, Role: domain.RoleActor, Character: "Support", BillingOrder: 2,
+	}, credit)
+
+	require.NoError(t, r.Credits.DeleteCredit(ctx, directing.ID))
+	assert.ErrorIs(t, r.Credits.DeleteCredit(ctx, directing.ID), domain.ErrNotFound)
+	_, err = r.Credits.GetCredit(ctx, directing.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func testTranslations(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := createMovie(t, r, "Heat")
+	actor := createActor(t, r, "Al", "Pacino")
+
+	upsert := func(locale string, title string) {
+		t.Helper()
+		require.NoError(t, r.Translations.UpsertMovieTranslation(ctx,
+			&domain.MovieTranslation{MovieID: movie.ID, Locale: locale, Title: title, Description: title + "!"}))
+	}
+	upsert("ru", "Жара")
+	upsert("de", "Heat")
+	upsert("ru", "Схватка")
+
+	translations, err := r.Translations.ListMovieTranslations(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.MovieTranslation{
+		{MovieID: movie.ID, Locale: "de", Title: "Heat", Description: "Heat!"},
+		{MovieID: movie.ID, Locale: "ru", Title: "Схватка", Description: "Схватка!"},
+	}, translations)
+
+	translations, err = r.Movies.GetMovieTranslations(ctx, []uuid.UUID{movie.ID}, []string{"ru"})
+	require.NoError(t, err)
+	require.Len(t, translations, 1)
+	assert.Equal(t, "ru", translations[0].Locale)
+
+	translations, err = r.Actors.GetMovieTranslations(ctx, []uuid.UUID{movie.ID}, nil)
+	require.NoError(t, err)
+	assert.Len(t, translations, 2)
+
+	require.NoError(t, r.Translations.DeleteMovieTranslation(ctx, movie.ID, "de"))
+	assert.ErrorIs(t, r.Translations.DeleteMovieTranslation(ctx, movie.ID, "de"), domain.ErrNotFound)
+
+	err = r.Translations.UpsertMovieTranslation(ctx, &domain.MovieTranslation{MovieID: uuid.New(), Locale: "ru", Title: "Нет"})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, r.Translations.UpsertActorTranslation(ctx,
+		&domain.ActorTranslation{ActorID: actor.ID, Locale: "ru", Name: "Аль", Surname: "Пачино"}))
+	actorTranslations, err := r.Translations.ListActorTranslations(ctx, actor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.ActorTranslation{{ActorID: actor.ID, Locale: "ru", Name: "Аль", Surname: "Пачино"}}, actorTranslations)
+
+	require.NoError(t, r.Translations.DeleteActorTranslation(ctx, actor.ID, "ru"))
+	assert.ErrorIs(t, r.Translations.DeleteActorTranslation(ctx, actor.ID, "ru"), domain.ErrNotFound)
+	err = r.Translations.UpsertActorTranslation(ctx, &domain.ActorTranslation{ActorID: uuid.New(), Locale: "ru", Name: "Нет"})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func testImportMovies(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	existing := createMovie(t, r, "Heat")
+	genre := createGenre(t, r, "Crime")
+	require.NoError(t, r.Movies.SetMovieGenres(ctx, existing.ID, []uuid.UUID{genre.ID}))
+	require.NoError(t, r.Ratings.UpdateMovieRating(ctx, &domain.RatingSummary{MovieID: existing.ID, Rating: 6, Votes: 1}))
+	trashed := createMovie(t, r, "Thief")
+	require.NoError(t, r.Movies.DeleteMovie(ctx, trashed.ID))
+
+	imported := &domain.Movie{ID: uuid.New(), Title: "Ronin", IMDbID: "tt0122690", Countries: []string{"US"}}
+	skipped, err := r.Import.ImportMovies(ctx, []*domain.Movie{
+		{ID: existing.ID, Title: "Heat (1995)", Rating: 8, Date: existing.Date},
+		{ID: trashed.ID, Title: "Thief (1981)"},
+		imported,
+	})
+	require.NoError(t, err)
+	// Movies in the trash are left alone.
+	assert.Equal(t, []uuid.UUID{trashed.ID}, skipped)
+
+	movies, err := r.Movies.GetMovies(ctx)
+	require.NoError(t, err)
+	require.Len(t, movies, 2)
+	byID := map[uuid.UUID]*domain.Movie{movies[0].ID: movies[0], movies[1].ID: movies[1]}
+	assert.Equal(t, "Heat (1995)", byID[existing.ID].Title)
+	assert.Equal(t, []*domain.Genre{genre}, byID[existing.ID].Genres)
+	// Ratings are computed from votes, so imports do not overwrite them.
+	assert.EqualValues(t, 6, byID[existing.ID].Rating)
+	assert.Equal(t, "tt0122690", byID[imported.ID].IMDbID)
+	require.NoError(t, r.Trash.RestoreMovie(ctx, trashed.ID))
+	restored, err := r.Movies.GetMovieByID(ctx, trashed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Thief", restored.Title)
+	require.NoError(t, r.Movies.DeleteMovie(ctx, trashed.ID))
+
+	// A batch is stored entirely or not at all.
+	_, err = r.Import.ImportMovies(ctx, []*domain.Movie{
+		{ID: uuid.New(), Title: "Collateral"},
+		{ID: uuid.New(), Title: "Ronin (copy)", IMDbID: "tt0122690"},
+	})
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+	movies, err = r.Movies.GetMovies(ctx)
+	require.NoError(t, err)
+	assert.Len(t, movies, 2)
+}
+
+func testImportCredits(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	movie := createMovie(t, r, "Inception")
+	actor := &domain.Actor{ID: uuid.New(), Name: "Leonardo", Surname: "DiCaprio", Birthdate: date(1974, 11, 11)}
+	_, err := r.Import.ImportActors(ctx, []*domain.Actor{actor})
+	require.NoError(t, err)
+	actor.Sex = "male"
+	_, err = r.Import.ImportActors(ctx, []*domain.Actor{actor})
+	require.NoError(t, err)
+	// The second import updated the actor.
+	actor.Version = 2
+
+	trashed := &domain.Actor{ID: uuid.New(), Name: "Tom", Surname: "Hardy", Birthdate: date(1977, 9, 15)}
+	_, err = r.Import.ImportActors(ctx, []*domain.Actor{trashed})
+	require.NoError(t, err)
+	require.NoError(t, r.Actors.DeleteActor(ctx, trashed.ID))
+	skipped, err := r.Import.ImportActors(ctx, []*domain.Actor{{ID: trashed.ID, Name: "Thomas", Birthdate: trashed.Birthdate}})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{trashed.ID}, skipped)
+
+	credit := &domain.Credit{ID: uuid.New(), MovieID: movie.ID, PersonID: actor.ID, Role: domain.RoleActor, Character: "Cobb", BillingOrder: 1}
+	require.NoError(t, r.Import.ImportCredits(ctx, []*domain.Credit{credit}))
+
+	// Credits are matched by movie, person, role and character.
+	require.NoError(t, r.Import.ImportCredits(ctx, []*domain.Credit{
+		{ID: uuid.New(), MovieID: movie.ID, PersonID: actor.ID, Role: domain.RoleActor, Character: "Cobb", BillingOrder: 3},
+	}))
+
+	credits, err := r.Credits.GetMovieCredits(ctx, movie.ID)
+	require.NoError(t, err)
+	require.Len(t, credits, 1)
+	assert.Equal(t, credit.ID, credits[0].ID)
+	assert.Equal(t, 3, credits[0].BillingOrder)
+	assert.Equal(t, actor, credits[0].Person)
+
+	err = r.Import.ImportCredits(ctx, []*domain.Credit{
+		{ID: uuid.New(), MovieID: movie.ID, PersonID: actor.ID, Role: domain.RoleDirector},
+		{ID: uuid.New(), MovieID: uuid.New(), PersonID: actor.ID, Role: domain.RoleActor},
+	})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	credits, err = r.Credits.GetMovieCredits(ctx, movie.ID)
+	require.NoError(t, err)
+	assert.Len(t, credits, 1)
+}
+
+func testExport(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	actors := []*domain.Actor{
+		{ID: uuid.MustParse("30000000-0000-0000-0000-000000000000"), Name: "C", Birthdate: date(1970, 1, 1)},
+		{ID: uuid.MustParse("10000000-0000-0000-0000-000000000000"), Name: "A", Birthdate: date(1970, 1, 1)},
+		{ID: uuid.MustParse("20000000-0000-0000-0000-000000000000"), Name: "B", Birthdate: date(1970, 1, 1)},
+	}
+	_, err := r.Import.ImportActors(ctx, actors)
+	require.NoError(t, err)
+	movie := createMovie(t, r, "Heat")
+	for i, actor := range actors {
+		createCredit(t, r, &domain.Credit{MovieID: movie.ID, PersonID: actor.ID, Role: domain.RoleActor, BillingOrder: len(actors) - i})
+	}
+
+	var names []string
+	require.NoError(t, r.Export.ExportActors(ctx, func(actor *domain.Actor) error {
+		names = append(names, actor.Name)
+		return nil
+	}))
+	assert.Equal(t, []string{"A", "B", "C"}, names)
+
+	var billing []int
+	require.NoError(t, r.Export.ExportCredits(ctx, func(credit *domain.Credit) error {
+		billing = append(billing, credit.BillingOrder)
+		return nil
+	}))
+	assert.Equal(t, []int{1, 2, 3}, billing)
+
+	var exported []*domain.Movie
+	require.NoError(t, r.Export.ExportMovies(ctx, func(movie *domain.Movie) error {
+		exported = append(exported, movie)
+		return nil
+	}))
+	require.Len(t, exported, 1)
+	assert.Equal(t, movie.Title, exported[0].Title)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = r.Export.ExportActors(ctx, func(*domain.Actor) error {
+		calls++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
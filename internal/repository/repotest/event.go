@@ -0,0 +1,79 @@
+package repotest
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEventOutbox(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	appendEvent := func(ctx context.Context, eventType string) (*domain.Event, error) {
+		event := &domain.Event{
+			ID:            uuid.New(),
+			Type:          eventType,
+			EntityID:      uuid.New(),
+			Payload:       []byte(`{"Title": "Heat"}`),
+			RequestID:     "request-1",
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		}
+		return event, r.Events.AppendEvent(ctx, event)
+	}
+
+	first, err := appendEvent(ctx, domain.EventMovieCreated)
+	require.NoError(t, err)
+	second, err := appendEvent(ctx, domain.EventMovieUpdated)
+	require.NoError(t, err)
+	assert.Greater(t, second.Sequence, first.Sequence)
+
+	// Events appended in a transaction that rolls back are not stored.
+	err = r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := appendEvent(ctx, domain.EventMovieDeleted); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	// A claimed event is not due again until the lease ends.
+	lease := now.Add(time.Minute)
+	events, err := r.Events.ClaimPendingEvents(ctx, now, lease, 1)
+	require.NoError(t, err)
+	first.NextAttemptAt = lease
+	assert.Equal(t, []*domain.Event{first}, events)
+	events, err = r.Events.ClaimPendingEvents(ctx, now, lease, 10)
+	require.NoError(t, err)
+	second.NextAttemptAt = lease
+	assert.Equal(t, []*domain.Event{second}, events)
+	events, err = r.Events.ClaimPendingEvents(ctx, now, lease, 10)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	first.Attempts = 1
+	first.NextAttemptAt = now.Add(time.Minute)
+	first.LastError = "connection refused"
+	require.NoError(t, r.Events.MarkEventFailed(ctx, first))
+	require.NoError(t, r.Events.MarkEventPublished(ctx, second.ID, now))
+	assert.ErrorIs(t, r.Events.MarkEventPublished(ctx, uuid.New(), now), domain.ErrNotFound)
+
+	events, err = r.Events.ClaimPendingEvents(ctx, lease, lease, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Event{first}, events)
+
+	purged, err := r.Events.PurgePublishedEvents(ctx, now)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	purged, err = r.Events.PurgePublishedEvents(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	events, err = r.Events.ClaimPendingEvents(ctx, lease, lease, 10)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
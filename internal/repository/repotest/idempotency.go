@@ -0,0 +1,70 @@
+package repotest
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIdempotencyKeys(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := uuid.New()
+	bob := uuid.New()
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	key := &domain.IdempotencyKey{
+		UserID:      alice,
+		Key:         "create-heat",
+		Fingerprint: "POST /api/v1/movies 1",
+		CreatedAt:   createdAt,
+		ExpiresAt:   createdAt.Add(time.Hour),
+	}
+	require.NoError(t, r.Idempotency.CreateIdempotencyKey(ctx, key))
+	err := r.Idempotency.CreateIdempotencyKey(ctx, key)
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+	// Keys belong to a user.
+	require.NoError(t, r.Idempotency.CreateIdempotencyKey(ctx, &domain.IdempotencyKey{
+		UserID: bob, Key: key.Key, Fingerprint: "other", CreatedAt: createdAt, ExpiresAt: createdAt.Add(time.Minute),
+	}))
+
+	stored, err := r.Idempotency.GetIdempotencyKey(ctx, alice, key.Key)
+	require.NoError(t, err)
+	assert.Equal(t, key, stored)
+
+	key.StatusCode = 201
+	key.Header = map[string][]string{"Content-Type": {"application/json"}}
+	key.Body = []byte(`{"status":"ok"}`)
+	require.NoError(t, r.Idempotency.SaveIdempotencyResponse(ctx, key))
+	stored, err = r.Idempotency.GetIdempotencyKey(ctx, alice, key.Key)
+	require.NoError(t, err)
+	assert.Equal(t, key, stored)
+
+	// An expired key can be used for another request.
+	reused := &domain.IdempotencyKey{
+		UserID:      alice,
+		Key:         key.Key,
+		Fingerprint: "POST /api/v1/movies 2",
+		CreatedAt:   key.ExpiresAt,
+		ExpiresAt:   key.ExpiresAt.Add(time.Hour),
+	}
+	require.NoError(t, r.Idempotency.CreateIdempotencyKey(ctx, reused))
+	stored, err = r.Idempotency.GetIdempotencyKey(ctx, alice, key.Key)
+	require.NoError(t, err)
+	assert.Equal(t, reused, stored)
+
+	purged, err := r.Idempotency.PurgeIdempotencyKeys(ctx, createdAt.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	_, err = r.Idempotency.GetIdempotencyKey(ctx, bob, key.Key)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, r.Idempotency.DeleteIdempotencyKey(ctx, alice, key.Key))
+	_, err = r.Idempotency.GetIdempotencyKey(ctx, alice, key.Key)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	err = r.Idempotency.SaveIdempotencyResponse(ctx, key)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,129 @@
+// Package repotest is the conformance suite for storage backends. Every
+// implementation of the usecase repository interfaces must pass it, so the
+// services behave the same whichever backend they run on.
+package repotest
+
+import (
+	"cinema_service/internal/domain"
+	"cinema_service/internal/usecase"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Repositories are the repositories of one backend. They usually share a
+// single storage.
+type Repositories struct {
+	Actors       usecase.ActorsRepo
+	Movies       usecase.MovieRepo
+	Users        usecase.UserRepo
+	Payments     usecase.PaymentRepo
+	Ratings      usecase.RatingRepo
+	Watchlist    usecase.WatchlistRepo
+	Genres       usecase.GenreRepo
+	Credits      usecase.CreditRepo
+	Translations usecase.TranslationRepo
+	Import       usecase.ImportRepo
+	Export       usecase.ExportRepo
+	SigningKeys  usecase.SigningKeyRepo
+	Trash        usecase.TrashRepo
+	Audit        usecase.AuditRepo
+	Versions     usecase.VersionRepo
+	Idempotency  usecase.IdempotencyRepo
+	Events       usecase.EventRepo
+	Webhooks     usecase.WebhookRepo
+	Transactor   usecase.Transactor
+}
+
+// Run runs the suite. newRepositories is called at the start of every test
+// and must return empty storage.
+func Run(t *testing.T, newRepositories func(t *testing.T) Repositories) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, r Repositories)
+	}{
+		{name: "Movies", test: testMovies},
+		{name: "CreateWithID", test: testCreateWithID},
+		{name: "MovieExternalIDs", test: testMovieExternalIDs},
+		{name: "MoviesBySnippet", test: testMoviesBySnippet},
+		{name: "MovieGenres", test: testMovieGenres},
+		{name: "DeleteMovie", test: testDeleteMovie},
+		{name: "PurgeMovieCascades", test: testPurgeMovieCascades},
+		{name: "Actors", test: testActors},
+		{name: "UpdateFields", test: testUpdateFields},
+		{name: "DeleteActor", test: testDeleteActor},
+		{name: "Trash", test: testTrash},
+		{name: "Genres", test: testGenres},
+		{name: "Credits", test: testCredits},
+		{name: "Translations", test: testTranslations},
+		{name: "ImportMovies", test: testImportMovies},
+		{name: "ImportCredits", test: testImportCredits},
+		{name: "Export", test: testExport},
+		{name: "Users", test: testUsers},
+		{name: "Payments", test: testPayments},
+		{name: "Ratings", test: testRatings},
+		{name: "Reviews", test: testReviews},
+		{name: "Watchlist", test: testWatchlist},
+		{name: "FavoriteActors", test: testFavoriteActors},
+		{name: "SigningKeys", test: testSigningKeys},
+		{name: "AuditLog", test: testAuditLog},
+		{name: "Versions", test: testVersions},
+		{name: "IdempotencyKeys", test: testIdempotencyKeys},
+		{name: "EventOutbox", test: testEventOutbox},
+		{name: "Webhooks", test: testWebhooks},
+		{name: "Transactions", test: testTransactions},
+		{name: "NestedTransactions", test: testNestedTransactions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepositories(t))
+		})
+	}
+}
+
+// date returns midnight UTC of a day, the way date columns are read back.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func createMovie(t *testing.T, r Repositories, title string) *domain.Movie {
+	t.Helper()
+	movie := &domain.Movie{Title: title, Description: title + " description", Date: date(2010, 7, 16)}
+	require.NoError(t, r.Movies.CreateMovie(context.Background(), movie))
+	return movie
+}
+
+func createActor(t *testing.T, r Repositories, name string, surname string) *domain.Actor {
+	t.Helper()
+	actor := &domain.Actor{Name: name, Surname: surname, Sex: "male", Birthdate: date(1974, 11, 11)}
+	require.NoError(t, r.Actors.CreateActor(context.Background(), actor))
+	return actor
+}
+
+func createGenre(t *testing.T, r Repositories, name string) *domain.Genre {
+	t.Helper()
+	genre := &domain.Genre{Name: name}
+	require.NoError(t, r.Genres.CreateGenre(context.Background(), genre))
+	return genre
+}
+
+func createCredit(t *testing.T, r Repositories, credit *domain.Credit) *domain.Credit {
+	t.Helper()
+	require.NoError(t, r.Credits.CreateCredit(context.Background(), credit))
+	return credit
+}
+
+// createUser stores a user whose password is the login. The hash uses the
+// minimum cost to keep the suite fast.
+func createUser(t *testing.T, r Repositories, login string) *domain.User {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(login), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &domain.User{Login: login, Password: hash, Role: domain.USER}
+	require.NoError(t, r.Users.CreateUser(context.Background(), user))
+	return user
+}
@@ -0,0 +1,102 @@
+package repotest
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errAbort = errors.New("abort")
+
+func movieTitles(t *testing.T, r Repositories) []string {
+	t.Helper()
+	movies, err := r.Movies.GetMovies(context.Background())
+	require.NoError(t, err)
+	titles := make([]string, 0, len(movies))
+	for _, movie := range movies {
+		titles = append(titles, movie.Title)
+	}
+	return titles
+}
+
+func testTransactions(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	genre := createGenre(t, r, "Crime")
+
+	err := r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		movie := &domain.Movie{Title: "Heat", Date: date(1995, 12, 15)}
+		if err := r.Movies.CreateMovie(ctx, movie); err != nil {
+			return err
+		}
+		return r.Movies.SetMovieGenres(ctx, movie.ID, []uuid.UUID{genre.ID})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Heat"}, movieTitles(t, r))
+
+	err = r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.Movies.CreateMovie(ctx, &domain.Movie{Title: "Ronin", Date: date(1998, 9, 25)}); err != nil {
+			return err
+		}
+		if err := r.Genres.CreateGenre(ctx, &domain.Genre{Name: "Thriller"}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+	assert.Equal(t, []string{"Heat"}, movieTitles(t, r))
+	genres, err := r.Genres.GetGenres(ctx)
+	require.NoError(t, err)
+	assert.Len(t, genres, 1)
+
+	// A constraint error inside the transaction rolls it back as a whole.
+	err = r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		movie := &domain.Movie{Title: "Collateral", Date: date(2004, 8, 6)}
+		if err := r.Movies.CreateMovie(ctx, movie); err != nil {
+			return err
+		}
+		return r.Movies.SetMovieGenres(ctx, movie.ID, []uuid.UUID{genre.ID, uuid.New()})
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"Heat"}, movieTitles(t, r))
+}
+
+func testNestedTransactions(t *testing.T, r Repositories) {
+	ctx := context.Background()
+
+	err := r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.Movies.CreateMovie(ctx, &domain.Movie{Title: "Heat", Date: date(1995, 12, 15)}); err != nil {
+			return err
+		}
+		err := r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := r.Movies.CreateMovie(ctx, &domain.Movie{Title: "Ronin", Date: date(1998, 9, 25)}); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		if !errors.Is(err, errAbort) {
+			return err
+		}
+		return r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return r.Movies.CreateMovie(ctx, &domain.Movie{Title: "Collateral", Date: date(2004, 8, 6)})
+		})
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Heat", "Collateral"}, movieTitles(t, r))
+
+	err = r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return r.Movies.CreateMovie(ctx, &domain.Movie{Title: "Thief", Date: date(1981, 3, 27)})
+		})
+		if err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+	assert.ElementsMatch(t, []string{"Heat", "Collateral"}, movieTitles(t, r))
+}
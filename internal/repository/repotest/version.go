@@ -0,0 +1,54 @@
+package repotest
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testVersions(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	alice := uuid.New()
+	movieID := uuid.New()
+	addVersion := func(entityID uuid.UUID, userID uuid.UUID, state string) *domain.EntityVersion {
+		t.Helper()
+		version := &domain.EntityVersion{
+			Entity:   domain.AuditEntityMovie,
+			EntityID: entityID,
+			State:    []byte(state),
+			UserID:   userID,
+		}
+		require.NoError(t, r.Versions.AddVersion(ctx, version))
+		assert.False(t, version.CreatedAt.IsZero())
+		return version
+	}
+
+	first := addVersion(movieID, uuid.Nil, `{"Title": "Heat"}`)
+	second := addVersion(movieID, alice, `{"Title": "Ronin"}`)
+	other := addVersion(uuid.New(), alice, `{"Title": "Alien"}`)
+	assert.Equal(t, 1, first.Number)
+	assert.Equal(t, 2, second.Number)
+	assert.Equal(t, 1, other.Number)
+
+	versions, err := r.Versions.GetVersions(ctx, domain.AuditEntityMovie, movieID)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, 2, versions[0].Number)
+	assert.Equal(t, alice, versions[0].UserID)
+	assert.JSONEq(t, `{"Title": "Ronin"}`, string(versions[0].State))
+	assert.Equal(t, uuid.Nil, versions[1].UserID)
+
+	version, err := r.Versions.GetVersion(ctx, domain.AuditEntityMovie, movieID, 1)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Title": "Heat"}`, string(version.State))
+	assert.Equal(t, first.CreatedAt, version.CreatedAt)
+
+	_, err = r.Versions.GetVersion(ctx, domain.AuditEntityMovie, movieID, 3)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = r.Versions.GetVersion(ctx, domain.AuditEntityActor, movieID, 1)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
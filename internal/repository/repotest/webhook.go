@@ -0,0 +1,117 @@
+package repotest
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWebhooks(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	subscription := &domain.WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{domain.EventMovieCreated, domain.EventMovieDeleted},
+		Secret:     "secret",
+		CreatedAt:  now,
+	}
+	require.NoError(t, r.Webhooks.CreateWebhookSubscription(ctx, subscription))
+	other := &domain.WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        "http://localhost:9000",
+		EventTypes: []string{domain.EventCastChanged},
+		Secret:     "other",
+		CreatedAt:  now.Add(time.Second),
+	}
+	require.NoError(t, r.Webhooks.CreateWebhookSubscription(ctx, other))
+
+	stored, err := r.Webhooks.GetWebhookSubscription(ctx, subscription.ID)
+	require.NoError(t, err)
+	assert.Equal(t, subscription, stored)
+	_, err = r.Webhooks.GetWebhookSubscription(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	subscriptions, err := r.Webhooks.GetWebhookSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.WebhookSubscription{subscription, other}, subscriptions)
+
+	newDelivery := func(subscriptionID uuid.UUID, createdAt time.Time) *domain.WebhookDelivery {
+		return &domain.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscriptionID,
+			EventID:        uuid.New(),
+			EventType:      domain.EventMovieCreated,
+			Body:           []byte(`{"type":"movie.created"}`),
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  createdAt,
+			CreatedAt:      createdAt,
+		}
+	}
+	first := newDelivery(subscription.ID, now)
+	require.NoError(t, r.Webhooks.CreateWebhookDelivery(ctx, first))
+	second := newDelivery(subscription.ID, now.Add(time.Second))
+	require.NoError(t, r.Webhooks.CreateWebhookDelivery(ctx, second))
+	require.NoError(t, r.Webhooks.CreateWebhookDelivery(ctx, newDelivery(other.ID, now)))
+
+	// An event is delivered once per subscription.
+	duplicate := newDelivery(subscription.ID, now)
+	duplicate.EventID = first.EventID
+	assert.ErrorIs(t, r.Webhooks.CreateWebhookDelivery(ctx, duplicate), domain.ErrAlreadyExists)
+	assert.ErrorIs(t, r.Webhooks.CreateWebhookDelivery(ctx, newDelivery(uuid.New(), now)), domain.ErrNotFound)
+
+	delivery, err := r.Webhooks.GetWebhookDelivery(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first, delivery)
+	_, err = r.Webhooks.GetWebhookDelivery(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	deliveries, err := r.Webhooks.GetWebhookDeliveries(ctx, subscription.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.WebhookDelivery{second, first}, deliveries)
+	deliveries, err = r.Webhooks.GetWebhookDeliveries(ctx, subscription.ID, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.WebhookDelivery{first}, deliveries)
+
+	due, err := r.Webhooks.GetDueWebhookDeliveries(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Len(t, due, 2)
+	assert.NotContains(t, due, second)
+
+	first.Status = domain.DeliveryDelivered
+	first.Attempts = 1
+	first.ResponseStatus = 204
+	first.DeliveredAt = now
+	require.NoError(t, r.Webhooks.UpdateWebhookDelivery(ctx, first))
+	second.Attempts = 1
+	second.ResponseStatus = 503
+	second.LastError = "status 503"
+	second.NextAttemptAt = now.Add(time.Minute)
+	require.NoError(t, r.Webhooks.UpdateWebhookDelivery(ctx, second))
+	assert.ErrorIs(t, r.Webhooks.UpdateWebhookDelivery(ctx, newDelivery(subscription.ID, now)), domain.ErrNotFound)
+
+	delivery, err = r.Webhooks.GetWebhookDelivery(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first, delivery)
+	due, err = r.Webhooks.GetDueWebhookDeliveries(ctx, now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Contains(t, due, second)
+	due, err = r.Webhooks.GetDueWebhookDeliveries(ctx, now.Add(time.Minute), 1)
+	require.NoError(t, err)
+	assert.Len(t, due, 1)
+
+	// Deleting a subscription deletes its deliveries.
+	require.NoError(t, r.Webhooks.DeleteWebhookSubscription(ctx, subscription.ID))
+	assert.ErrorIs(t, r.Webhooks.DeleteWebhookSubscription(ctx, subscription.ID), domain.ErrNotFound)
+	_, err = r.Webhooks.GetWebhookDelivery(ctx, second.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	subscriptions, err = r.Webhooks.GetWebhookSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.WebhookSubscription{other}, subscriptions)
+}
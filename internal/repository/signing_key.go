@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageSigningKey struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageSigningKey(dbPool *pgxpool.Pool) StorageSigningKey {
+	StorageSigningKey := StorageSigningKey{
+		db: dbPool,
+	}
+	return StorageSigningKey
+}
+
+// GetSigningKeys returns the current key and the keys retired after
+// retiredAfter, newest first.
+func (s *StorageSigningKey) GetSigningKeys(ctx context.Context, retiredAfter time.Time) ([]*domain.SigningKey, error) {
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT id, secret, created_at, COALESCE(retired_at, '0001-01-01')
+		FROM "signing_keys"
+		WHERE retired_at IS NULL OR retired_at > $1
+		ORDER BY created_at DESC`,
+		retiredAfter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.SigningKey
+	for rows.Next() {
+		key := &domain.SigningKey{}
+		if err = rows.Scan(&key.ID, &key.Secret, &key.CreatedAt, &key.RetiredAt); err != nil {
+			return nil, fmt.Errorf("get signing keys: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get signing keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RotateSigningKey retires the current key, stores key as the new current
+// one and deletes keys retired before pruneBefore.
+func (s *StorageSigningKey) RotateSigningKey(ctx context.Context, key *domain.SigningKey, pruneBefore time.Time) error {
+	tx, err := conn(ctx, s.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("rotate signing key: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx,
+		`UPDATE "signing_keys" SET retired_at = now() WHERE retired_at IS NULL`,
+	); err != nil {
+		return fmt.Errorf("rotate signing key: %w", err)
+	}
+	if err = tx.QueryRow(ctx,
+		`INSERT INTO "signing_keys" (id, secret) VALUES ($1, $2) RETURNING created_at`,
+		key.ID, key.Secret,
+	).Scan(&key.CreatedAt); err != nil {
+		return fmt.Errorf("rotate signing key: %w", err)
+	}
+	if _, err = tx.Exec(ctx,
+		`DELETE FROM "signing_keys" WHERE retired_at < $1`,
+		pruneBefore,
+	); err != nil {
+		return fmt.Errorf("rotate signing key: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("rotate signing key: %w", err)
+	}
+	return nil
+}
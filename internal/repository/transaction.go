@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// retryBaseDelay is the wait before the first retry of a transaction. It
+// doubles on every further retry.
+const retryBaseDelay = 10 * time.Millisecond
+
+// querier is what storages run queries on: the pool, or the transaction
+// of the context.
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+type txKey struct{}
+
+// conn returns the transaction started by Transactor for ctx, or db outside
+// of transactions. Storage methods run every statement on it, so they join
+// the transaction of their caller.
+func conn(ctx context.Context, db *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// Transactor runs units of work spanning several storages in one
+// transaction.
+type Transactor struct {
+	db         *pgxpool.Pool
+	maxRetries int
+}
+
+func NewTransactor(dbPool *pgxpool.Pool, maxRetries int) Transactor {
+	Transactor := Transactor{
+		db:         dbPool,
+		maxRetries: maxRetries,
+	}
+	return Transactor
+}
+
+// WithinTransaction runs fn in a serializable transaction that storage
+// methods called with the context passed to fn join. The transaction is
+// committed if fn returns nil and rolled back otherwise.
+//
+// A nested call runs fn under a savepoint, so its failure only undoes its
+// own work. The outermost call runs fn again, up to maxRetries times, when
+// the transaction fails with a serialization failure or a deadlock; fn must
+// therefore not have side effects outside the database.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return runInTx(ctx, tx.Begin, fn)
+	}
+
+	begin := func(ctx context.Context) (pgx.Tx, error) {
+		return t.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	}
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		err := runInTx(ctx, begin, fn)
+		if err == nil || attempt >= t.maxRetries || !isRetryable(err) {
+			return err
+		}
+
+		// Jitter keeps conflicting transactions from retrying in lockstep.
+		timer := time.NewTimer(delay/2 + rand.N(delay))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (retry canceled: %v)", err, ctx.Err())
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+func runInTx(ctx context.Context, begin func(context.Context) (pgx.Tx, error),
+	fn func(ctx context.Context) error) error {
+	tx, err := begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// isRetryable reports whether err means the transaction lost a conflict
+// with a concurrent one and may succeed when run again.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected)
+}
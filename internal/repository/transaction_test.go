@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"cinema_service/internal/domain"
+	"cinema_service/internal/repository"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactorRetriesSerializationFailures(t *testing.T) {
+	dbPool := openTestDB(t)
+	transactor := repository.NewTransactor(dbPool, 2)
+	storageMovie := repository.NewStorageMovie(dbPool)
+	ctx := context.Background()
+
+	attempts := 0
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		attempts++
+		if err := storageMovie.CreateMovie(ctx, &domain.Movie{Title: "Heat"}); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	// The movie of the failed attempt was rolled back.
+	movies, err := storageMovie.GetMovies(ctx)
+	require.NoError(t, err)
+	assert.Len(t, movies, 1)
+
+	attempts = 0
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		attempts++
+		return &pgconn.PgError{Code: "40P01"}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		attempts++
+		return &pgconn.PgError{Code: "23505"}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+// TestTransactorRetriesOnlyOutermost checks that a nested call leaves the
+// retry to the transaction it joined.
+func TestTransactorRetriesOnlyOutermost(t *testing.T) {
+	dbPool := openTestDB(t)
+	transactor := repository.NewTransactor(dbPool, 2)
+	ctx := context.Background()
+
+	outer, inner := 0, 0
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		outer++
+		return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			inner++
+			if outer == 1 {
+				return &pgconn.PgError{Code: "40001"}
+			}
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, outer)
+	assert.Equal(t, 2, inner)
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageTranslation struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageTranslation(dbPool *pgxpool.Pool) StorageTranslation {
+	StorageTranslation := StorageTranslation{
+		db: dbPool,
+	}
+	return StorageTranslation
+}
+
+func (s *StorageTranslation) UpsertMovieTranslation(ctx context.Context, translation *domain.MovieTranslation) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO "movie_translations" (movie_id, locale, title, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (movie_id, locale) DO UPDATE SET title = EXCLUDED.title, description = EXCLUDED.description`,
+		translation.MovieID, translation.Locale, translation.Title, translation.Description,
+	); err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("upsert movie translation: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("upsert movie translation: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageTranslation) DeleteMovieTranslation(ctx context.Context, movieID uuid.UUID, locale string) error {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM "movie_translations" WHERE movie_id = $1 AND locale = $2`,
+		movieID, locale,
+	)
+	if err != nil {
+		return fmt.Errorf("delete movie translation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("delete movie translation: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageTranslation) ListMovieTranslations(ctx context.Context, movieID uuid.UUID) ([]*domain.MovieTranslation, error) {
+	translations, err := getMovieTranslations(ctx, conn(ctx, s.db), []uuid.UUID{movieID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list movie translations: %w", err)
+	}
+	return translations, nil
+}
+
+func (s *StorageTranslation) UpsertActorTranslation(ctx context.Context, translation *domain.ActorTranslation) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO "actor_translations" (actor_id, locale, name, surname)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (actor_id, locale) DO UPDATE SET name = EXCLUDED.name, surname = EXCLUDED.surname`,
+		translation.ActorID, translation.Locale, translation.Name, translation.Surname,
+	); err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("upsert actor translation: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("upsert actor translation: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageTranslation) DeleteActorTranslation(ctx context.Context, actorID uuid.UUID, locale string) error {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM "actor_translations" WHERE actor_id = $1 AND locale = $2`,
+		actorID, locale,
+	)
+	if err != nil {
+		return fmt.Errorf("delete actor translation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("delete actor translation: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageTranslation) ListActorTranslations(ctx context.Context, actorID uuid.UUID) ([]*domain.ActorTranslation, error) {
+	translations, err := getActorTranslations(ctx, conn(ctx, s.db), []uuid.UUID{actorID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list actor translations: %w", err)
+	}
+	return translations, nil
+}
+
+// getMovieTranslations loads the translations of the given movies. A nil
+// locales slice loads every locale.
+func getMovieTranslations(ctx context.Context, db querier, movieIDs []uuid.UUID, locales []string) ([]*domain.MovieTranslation, error) {
+	rows, err := db.Query(ctx,
+		`SELECT movie_id, locale, title, description
+		FROM "movie_translations"
+		WHERE movie_id = ANY($1) AND ($2::varchar[] IS NULL OR locale = ANY($2))
+		ORDER BY locale`,
+		movieIDs, locales,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var translations []*domain.MovieTranslation
+	for rows.Next() {
+		translation := &domain.MovieTranslation{}
+		if err = rows.Scan(&translation.MovieID, &translation.Locale, &translation.Title, &translation.Description); err != nil {
+			return nil, err
+		}
+		translations = append(translations, translation)
+	}
+	return translations, rows.Err()
+}
+
+// getActorTranslations loads the translations of the given actors. A nil
+// locales slice loads every locale.
+func getActorTranslations(ctx context.Context, db querier, actorIDs []uuid.UUID, locales []string) ([]*domain.ActorTranslation, error) {
+	rows, err := db.Query(ctx,
+		`SELECT actor_id, locale, name, surname
+		FROM "actor_translations"
+		WHERE actor_id = ANY($1) AND ($2::varchar[] IS NULL OR locale = ANY($2))
+		ORDER BY locale`,
+		actorIDs, locales,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var translations []*domain.ActorTranslation
+	for rows.Next() {
+		translation := &domain.ActorTranslation{}
+		if err = rows.Scan(&translation.ActorID, &translation.Locale, &translation.Name, &translation.Surname); err != nil {
+			return nil, err
+		}
+		translations = append(translations, translation)
+	}
+	return translations, rows.Err()
+}
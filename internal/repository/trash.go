@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageTrash struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageTrash(dbPool *pgxpool.Pool) StorageTrash {
+	StorageTrash := StorageTrash{
+		db: dbPool,
+	}
+	return StorageTrash
+}
+
+func (s *StorageTrash) GetDeletedMovies(ctx context.Context) ([]*domain.TrashedMovie, error) {
+	var movies []*domain.TrashedMovie
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT `+movieColumns+`, m.deleted_at FROM movies m
+		WHERE m.deleted_at IS NOT NULL
+		ORDER BY m.deleted_at DESC, m.id`)
+	if err != nil {
+		return nil, fmt.Errorf("get deleted movies: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		trashed := &domain.TrashedMovie{Movie: &domain.Movie{}}
+		movie := trashed.Movie
+		if err = rows.Scan(
+			&movie.ID, &movie.Title, &movie.Description, &movie.Rating, &movie.Date,
+			&movie.DurationMinutes, &movie.AgeRating, &movie.Countries,
+			&movie.OriginalLanguage, &movie.IMDbID, &movie.TMDBID, &movie.Version,
+			&trashed.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("get deleted movies: %w", err)
+		}
+		movies = append(movies, trashed)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get deleted movies: %w", err)
+	}
+
+	return movies, nil
+}
+
+func (s *StorageTrash) GetDeletedActors(ctx context.Context) ([]*domain.TrashedActor, error) {
+	var actors []*domain.TrashedActor
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT id, COALESCE(name, ''), COALESCE(surname, ''), COALESCE(sex, ''),
+			COALESCE(birthdate, '0001-01-01'), version, deleted_at
+		FROM actors
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id`)
+	if err != nil {
+		return nil, fmt.Errorf("get deleted actors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		trashed := &domain.TrashedActor{Actor: &domain.Actor{}}
+		actor := trashed.Actor
+		if err = rows.Scan(
+			&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.Birthdate, &actor.Version, &trashed.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("get deleted actors: %w", err)
+		}
+		actors = append(actors, trashed)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get deleted actors: %w", err)
+	}
+
+	return actors, nil
+}
+
+func (s *StorageTrash) RestoreMovie(ctx context.Context, movieID uuid.UUID) error {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE "movies" SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`,
+		movieID,
+	)
+	if err != nil {
+		return fmt.Errorf("restore movie: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("restore movie: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageTrash) RestoreActor(ctx context.Context, actorID uuid.UUID) error {
+	result, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE "actors" SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`,
+		actorID,
+	)
+	if err != nil {
+		return fmt.Errorf("restore actor: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("restore actor: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// GetMovieByID returns a movie that is not in the trash, so restores can
+// read the restored movie in their transaction.
+func (s *StorageTrash) GetMovieByID(ctx context.Context, movieID uuid.UUID) (*domain.Movie, error) {
+	storage := NewStorageMovie(s.db)
+	return storage.GetMovieByID(ctx, movieID)
+}
+
+// GetActorByID returns an actor that is not in the trash.
+func (s *StorageTrash) GetActorByID(ctx context.Context, actorID uuid.UUID) (*domain.Actor, error) {
+	storage := NewStorageActor(s.db)
+	return storage.GetActorByID(ctx, actorID)
+}
+
+// PurgeDeleted hard-deletes movies and actors deleted before deletedBefore.
+// The foreign keys cascade the delete to their credits, genres,
+// translations, ratings and list entries; their versions are deleted here.
+func (s *StorageTrash) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged int
+	if err := conn(ctx, s.db).QueryRow(ctx,
+		`WITH movies_purged AS (
+			DELETE FROM "movies" WHERE deleted_at < $1 RETURNING id
+		), actors_purged AS (
+			DELETE FROM "actors" WHERE deleted_at < $1 RETURNING id
+		), versions_purged AS (
+			DELETE FROM entity_versions
+			WHERE (entity = 'movie' AND entity_id IN (SELECT id FROM movies_purged))
+				OR (entity = 'actor' AND entity_id IN (SELECT id FROM actors_purged))
+		)
+		SELECT (SELECT count(*) FROM movies_purged) + (SELECT count(*) FROM actors_purged)`,
+		deletedBefore,
+	).Scan(&purged); err != nil {
+		return 0, fmt.Errorf("purge deleted: %w", err)
+	}
+	return purged, nil
+}
@@ -3,7 +3,10 @@ package repository
 import (
 	"cinema_service/internal/domain"
 	"context"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -20,7 +23,7 @@ func NewUserStorage(dbPool *pgxpool.Pool) StorageUser {
 
 func (s *StorageUser) GetUser(ctx context.Context, login string, password string) (*domain.User, error) {
 	user := &domain.User{}
-	if err := s.db.QueryRow(
+	if err := conn(ctx, s.db).QueryRow(
 		ctx,
 		`SELECT id, login, password, role, created_at FROM "users" u WHERE u.login = $1`, login,
 	).Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.CreatedAt); err != nil {
@@ -34,3 +37,23 @@ func (s *StorageUser) GetUser(ctx context.Context, login string, password string
 
 	return user, nil
 }
+
+// CreateUser stores a user whose password is already hashed. Logins are
+// unique.
+func (s *StorageUser) CreateUser(ctx context.Context, user *domain.User) error {
+	user.ID = uuid.New()
+	err := conn(ctx, s.db).QueryRow(ctx,
+		`INSERT INTO "users" (id, login, password, role, created_at)
+		SELECT $1, $2, $3, $4, now()
+		WHERE NOT EXISTS (SELECT 1 FROM "users" WHERE login = $2)
+		RETURNING created_at`,
+		user.ID, user.Login, user.Password, user.Role,
+	).Scan(&user.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("create user: %w", domain.ErrAlreadyExists)
+	}
+	if err != nil {
+		return fmt.Errorf("create user: %w", err)
+	}
+	return nil
+}
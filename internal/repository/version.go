@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageVersion struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageVersion(dbPool *pgxpool.Pool) StorageVersion {
+	StorageVersion := StorageVersion{
+		db: dbPool,
+	}
+	return StorageVersion
+}
+
+// AddVersion stores a version with the next number of its entity. The
+// number and creation time are set by the storage.
+func (s *StorageVersion) AddVersion(ctx context.Context, version *domain.EntityVersion) error {
+	err := conn(ctx, s.db).QueryRow(ctx,
+		`INSERT INTO entity_versions (entity, entity_id, number, state, user_id)
+		SELECT $1, $2, COALESCE(MAX(number), 0) + 1, $3,
+			NULLIF($4, '00000000-0000-0000-0000-000000000000'::uuid)
+		FROM entity_versions WHERE entity = $1 AND entity_id = $2
+		RETURNING number, created_at`,
+		version.Entity, version.EntityID, []byte(version.State), version.UserID,
+	).Scan(&version.Number, &version.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("add version: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageVersion) GetVersions(ctx context.Context, entity string, entityID uuid.UUID) ([]*domain.EntityVersion, error) {
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT `+versionColumns+` FROM entity_versions
+		WHERE entity = $1 AND entity_id = $2
+		ORDER BY number DESC`,
+		entity, entityID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*domain.EntityVersion
+	for rows.Next() {
+		version := &domain.EntityVersion{}
+		if err = scanVersion(rows, version); err != nil {
+			return nil, fmt.Errorf("get versions: %w", err)
+		}
+		versions = append(versions, version)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+func (s *StorageVersion) GetVersion(ctx context.Context, entity string, entityID uuid.UUID, number int) (*domain.EntityVersion, error) {
+	version := &domain.EntityVersion{}
+	err := scanVersion(conn(ctx, s.db).QueryRow(ctx,
+		`SELECT `+versionColumns+` FROM entity_versions
+		WHERE entity = $1 AND entity_id = $2 AND number = $3`,
+		entity, entityID, number,
+	), version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get version: %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get version: %w", err)
+	}
+	return version, nil
+}
+
+// versionColumns is the column list scanned by scanVersion.
+const versionColumns = `entity, entity_id, number, state,
+	COALESCE(user_id, '00000000-0000-0000-0000-000000000000'::uuid), created_at`
+
+func scanVersion(row pgx.Row, version *domain.EntityVersion) error {
+	var state []byte
+	if err := row.Scan(
+		&version.Entity, &version.EntityID, &version.Number, &state, &version.UserID, &version.CreatedAt,
+	); err != nil {
+		return err
+	}
+	version.State = state
+	return nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageWatchlist struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageWatchlist(dbPool *pgxpool.Pool) StorageWatchlist {
+	StorageWatchlist := StorageWatchlist{
+		db: dbPool,
+	}
+	return StorageWatchlist
+}
+
+func (s *StorageWatchlist) AddToWatchlist(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error {
+	// Trashed movies are not added. The no-op update makes an entry that is
+	// already there count as a row, so no row means a missing movie.
+	tag, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO "watchlist" (user_id, movie_id)
+		SELECT $1, id FROM "movies" WHERE id = $2 AND deleted_at IS NULL
+		ON CONFLICT (user_id, movie_id) DO UPDATE SET added_at = "watchlist".added_at`,
+		userID, movieID,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("add to watchlist: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("add to watchlist: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("add to watchlist: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageWatchlist) RemoveFromWatchlist(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM "watchlist" WHERE user_id = $1 AND movie_id = $2`,
+		userID, movieID,
+	); err != nil {
+		return fmt.Errorf("remove from watchlist: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageWatchlist) GetWatchlist(ctx context.Context, userID uuid.UUID) ([]*domain.WatchlistEntry, error) {
+	var entries []*domain.WatchlistEntry
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT m.id, m.title, m.description, m.rating, COALESCE(m.release_date, '0001-01-01'), w.added_at
+		FROM watchlist w
+		INNER JOIN movies m ON w.movie_id = m.id
+		WHERE w.user_id = $1 AND m.deleted_at IS NULL
+		ORDER BY w.added_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get watchlist: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry := &domain.WatchlistEntry{Movie: &domain.Movie{InWatchlist: true}}
+		if err = rows.Scan(
+			&entry.Movie.ID, &entry.Movie.Title, &entry.Movie.Description, &entry.Movie.Rating, &entry.Movie.Date,
+			&entry.AddedAt,
+		); err != nil {
+			return nil, fmt.Errorf("get watchlist: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get watchlist: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (s *StorageWatchlist) AddFavoriteActor(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error {
+	// Trashed actors are not added. The no-op update makes an entry that is
+	// already there count as a row, so no row means a missing actor.
+	tag, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO "favorite_actors" (user_id, actor_id)
+		SELECT $1, id FROM "actors" WHERE id = $2 AND deleted_at IS NULL
+		ON CONFLICT (user_id, actor_id) DO UPDATE SET added_at = "favorite_actors".added_at`,
+		userID, actorID,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("add favorite actor: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("add favorite actor: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("add favorite actor: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageWatchlist) RemoveFavoriteActor(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`DELETE FROM "favorite_actors" WHERE user_id = $1 AND actor_id = $2`,
+		userID, actorID,
+	); err != nil {
+		return fmt.Errorf("remove favorite actor: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageWatchlist) GetFavoriteActors(ctx context.Context, userID uuid.UUID) ([]*domain.FavoriteActor, error) {
+	var favorites []*domain.FavoriteActor
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT a.id, a.name, a.surname, a.sex, a.birthdate, a.version, f.added_at
+		FROM favorite_actors f
+		INNER JOIN actors a ON f.actor_id = a.id
+		WHERE f.user_id = $1 AND a.deleted_at IS NULL
+		ORDER BY f.added_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get favorite actors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		favorite := &domain.FavoriteActor{Actor: &domain.Actor{}}
+		if err = rows.Scan(
+			&favorite.Actor.ID, &favorite.Actor.Name, &favorite.Actor.Surname, &favorite.Actor.Sex, &favorite.Actor.Birthdate,
+			&favorite.Actor.Version, &favorite.AddedAt,
+		); err != nil {
+			return nil, fmt.Errorf("get favorite actors: %w", err)
+		}
+		favorites = append(favorites, favorite)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get favorite actors: %w", err)
+	}
+
+	return favorites, nil
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StorageWebhook struct {
+	db *pgxpool.Pool
+}
+
+func NewStorageWebhook(dbPool *pgxpool.Pool) StorageWebhook {
+	StorageWebhook := StorageWebhook{
+		db: dbPool,
+	}
+	return StorageWebhook
+}
+
+func (s *StorageWebhook) CreateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO webhook_subscriptions (id, url, event_types, secret, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		subscription.ID, subscription.URL, subscription.EventTypes, subscription.Secret, subscription.CreatedAt,
+	); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("create webhook subscription: %w", domain.ErrAlreadyExists)
+		}
+		return fmt.Errorf("create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageWebhook) GetWebhookSubscription(ctx context.Context, subscriptionID uuid.UUID) (*domain.WebhookSubscription, error) {
+	subscription := &domain.WebhookSubscription{}
+	err := conn(ctx, s.db).QueryRow(ctx,
+		`SELECT id, url, event_types, secret, created_at FROM webhook_subscriptions WHERE id = $1`,
+		subscriptionID,
+	).Scan(&subscription.ID, &subscription.URL, &subscription.EventTypes, &subscription.Secret, &subscription.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get webhook subscription: %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get webhook subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+// GetWebhookSubscriptions returns all subscriptions, oldest first.
+func (s *StorageWebhook) GetWebhookSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	rows, err := conn(ctx, s.db).Query(ctx,
+		`SELECT id, url, event_types, secret, created_at FROM webhook_subscriptions ORDER BY created_at, id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*domain.WebhookSubscription
+	for rows.Next() {
+		subscription := &domain.WebhookSubscription{}
+		if err = rows.Scan(&subscription.ID, &subscription.URL, &subscription.EventTypes, &subscription.Secret,
+			&subscription.CreatedAt); err != nil {
+			return nil, fmt.Errorf("get webhook subscriptions: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteWebhookSubscription removes a subscription. Its deliveries are
+// removed by the foreign key.
+func (s *StorageWebhook) DeleteWebhookSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	tag, err := conn(ctx, s.db).Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, subscriptionID)
+	if err != nil {
+		return fmt.Errorf("delete webhook subscription: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("delete webhook subscription: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageWebhook) CreateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if _, err := conn(ctx, s.db).Exec(ctx,
+		`INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_type, body, status, attempts,
+			next_attempt_at, response_status, last_error, created_at, delivered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, '0001-01-01'::timestamp))`,
+		delivery.ID, delivery.SubscriptionID, delivery.EventID, delivery.EventType, []byte(delivery.Body),
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.ResponseStatus, delivery.LastError,
+		delivery.CreatedAt, delivery.DeliveredAt,
+	); err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return fmt.Errorf("create webhook delivery: %w", domain.ErrNotFound)
+		case isUniqueViolation(err):
+			return fmt.Errorf("create webhook delivery: %w", domain.ErrAlreadyExists)
+		}
+		return fmt.Errorf("create webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageWebhook) GetWebhookDelivery(ctx context.Context, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	delivery := &domain.WebhookDelivery{}
+	err := scanWebhookDelivery(conn(ctx, s.db).QueryRow(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = $1`,
+		deliveryID,
+	), delivery)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get webhook delivery: %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+func (s *StorageWebhook) GetWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int, offset int) ([]*domain.WebhookDelivery, error) {
+	deliveries, err := s.queryWebhookDeliveries(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3`,
+		subscriptionID, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (s *StorageWebhook) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	deliveries, err := s.queryWebhookDeliveries(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at, id
+		LIMIT $3`,
+		domain.DeliveryPending, now, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get due webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (s *StorageWebhook) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	tag, err := conn(ctx, s.db).Exec(ctx,
+		`UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, response_status = $5, last_error = $6,
+			delivered_at = NULLIF($7, '0001-01-01'::timestamp)
+		WHERE id = $1`,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.ResponseStatus,
+		delivery.LastError, delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update webhook delivery: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("update webhook delivery: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (s *StorageWebhook) queryWebhookDeliveries(ctx context.Context, query string, args ...any) ([]*domain.WebhookDelivery, error) {
+	rows, err := conn(ctx, s.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		delivery := &domain.WebhookDelivery{}
+		if err = scanWebhookDelivery(rows, delivery); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// webhookDeliveryColumns is the column list scanned by scanWebhookDelivery.
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, body, status, attempts,
+	next_attempt_at, response_status, last_error, created_at, COALESCE(delivered_at, '0001-01-01')`
+
+func scanWebhookDelivery(row pgx.Row, delivery *domain.WebhookDelivery) error {
+	var body []byte
+	if err := row.Scan(
+		&delivery.ID, &delivery.SubscriptionID, &delivery.EventID, &delivery.EventType, &body, &delivery.Status,
+		&delivery.Attempts, &delivery.NextAttemptAt, &delivery.ResponseStatus, &delivery.LastError,
+		&delivery.CreatedAt, &delivery.DeliveredAt,
+	); err != nil {
+		return err
+	}
+	delivery.Body = body
+	return nil
+}
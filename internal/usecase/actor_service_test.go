@@ -19,7 +19,7 @@ func TestCreateActor(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock_repo.NewMockActorsRepo(ctrl) 
-	actorService := NewActorsService(mockRepo)
+	actorService := NewActorsService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
 
 	testCases := []struct {
 		name     string
@@ -32,6 +32,7 @@ func TestCreateActor(t *testing.T) {
 			actor: &domain.Actor{},
 			mockFunc: func() {
 				mockRepo.EXPECT().CreateActor(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetActorByID(gomock.Any(), gomock.Any()).Return(&domain.Actor{}, nil)
 			},
 			wantErr: false,
 		},
@@ -65,7 +66,7 @@ func TestUpdateActor(t *testing.T) {
 
 	mockRepo := mock_repo.NewMockActorsRepo(ctrl)
 
-	actorService := NewActorsService(mockRepo)
+	actorService := NewActorsService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
 
 	testCases := []struct {
 		name     string
@@ -77,6 +78,7 @@ func TestUpdateActor(t *testing.T) {
 			name:  "Update actor successfully",
 			actor: &domain.Actor{},
 			mockFunc: func() {
+				mockRepo.EXPECT().GetActorByID(gomock.Any(), gomock.Any()).Return(&domain.Actor{}, nil).Times(2)
 				mockRepo.EXPECT().UpdateActor(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
@@ -85,6 +87,7 @@ func TestUpdateActor(t *testing.T) {
 			name:  "Update actor fails",
 			actor: &domain.Actor{},
 			mockFunc: func() {
+				mockRepo.EXPECT().GetActorByID(gomock.Any(), gomock.Any()).Return(&domain.Actor{}, nil)
 				mockRepo.EXPECT().UpdateActor(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			wantErr: true,
@@ -112,7 +115,7 @@ func TestDeleteActor(t *testing.T) {
 
 	mockRepo := mock_repo.NewMockActorsRepo(ctrl)
 
-	actorService := NewActorsService(mockRepo)
+	actorService := NewActorsService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
 
 	testCases := []struct {
 		name     string
@@ -124,6 +127,7 @@ func TestDeleteActor(t *testing.T) {
 			name:    "Delete actor successfully",
 			actorID: uuid.UUID{},
 			mockFunc: func() {
+				mockRepo.EXPECT().GetActorByID(gomock.Any(), gomock.Any()).Return(&domain.Actor{}, nil)
 				mockRepo.EXPECT().DeleteActor(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
@@ -132,6 +136,7 @@ func TestDeleteActor(t *testing.T) {
 			name:    "Delete actor fails",
 			actorID: uuid.UUID{},
 			mockFunc: func() {
+				mockRepo.EXPECT().GetActorByID(gomock.Any(), gomock.Any()).Return(&domain.Actor{}, nil)
 				mockRepo.EXPECT().DeleteActor(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			wantErr: true,
@@ -142,7 +147,7 @@ func TestDeleteActor(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			err := actorService.DeleteActor(context.Background(), tc.actorID)
+			err := actorService.DeleteActor(context.Background(), tc.actorID, 0)
 
 			if tc.wantErr {
 				assert.Error(t, err)
@@ -153,13 +158,52 @@ func TestDeleteActor(t *testing.T) {
 	}
 }
 
+func TestActorVersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockActorsRepo(ctrl)
+	actorService := NewActorsService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+	actorID := uuid.New()
+
+	mockRepo.EXPECT().GetActorByID(gomock.Any(), actorID).Return(&domain.Actor{ID: actorID, Version: 2}, nil).Times(2)
+	err := actorService.UpdateActor(context.Background(), &domain.Actor{ID: actorID, Name: "Al", Version: 1})
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	err = actorService.DeleteActor(context.Background(), actorID, 1)
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+}
+
+func TestPatchActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockActorsRepo(ctrl)
+	actorService := NewActorsService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+	actorID := uuid.New()
+	stored := &domain.Actor{ID: actorID, Name: "Robert", Surname: "De Niro", Version: 1}
+
+	mockRepo.EXPECT().GetActorByID(gomock.Any(), actorID).Return(stored, nil)
+	mockRepo.EXPECT().UpdateActorFields(gomock.Any(), &domain.Actor{ID: actorID, Name: "Bob", Surname: "De Niro", Version: 1}, []string{"Name"}).Return(nil)
+	mockRepo.EXPECT().GetActorByID(gomock.Any(), actorID).Return(&domain.Actor{ID: actorID, Name: "Bob", Surname: "De Niro", Version: 2}, nil)
+	actor, err := actorService.PatchActor(context.Background(), actorID, 1, func(act *domain.Actor) error {
+		act.Name = "Bob"
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, actor.Version)
+
+	mockRepo.EXPECT().GetActorByID(gomock.Any(), actorID).Return(nil, domain.ErrNotFound)
+	_, err = actorService.PatchActor(context.Background(), actorID, 0, func(*domain.Actor) error { return nil })
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
 func TestGetActors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mock_repo.NewMockActorsRepo(ctrl)
 
-	actorService := NewActorsService(mockRepo)
+	actorService := NewActorsService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
 
 	testCases := []struct {
 		name     string
@@ -3,6 +3,7 @@ package usecase
 import (
 	"cinema_service/internal/domain"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
 )
@@ -10,48 +11,307 @@ import (
 //go:generate mockgen -source=actors.go -destination=mocks/actorsMock.go
 
 type ActorsRepo interface {
+	// GetActorByID returns an actor or domain.ErrNotFound.
+	GetActorByID(ctx context.Context, actorID uuid.UUID) (*domain.Actor, error)
 	CreateActor(ctx context.Context, act *domain.Actor) error
 	UpdateActor(ctx context.Context, act *domain.Actor) error
+	// UpdateActorFields writes only the given fields of an actor, named as
+	// in domain.Actor.
+	UpdateActorFields(ctx context.Context, act *domain.Actor, fields []string) error
 	GetActors(ctx context.Context) (map[*domain.Actor][]*domain.Movie, error)
 	DeleteActor(ctx context.Context, actorID uuid.UUID) error
+	GetActorTranslations(ctx context.Context, actorIDs []uuid.UUID, locales []string) ([]*domain.ActorTranslation, error)
+	GetMovieTranslations(ctx context.Context, movieIDs []uuid.UUID, locales []string) ([]*domain.MovieTranslation, error)
 }
 
 type ActorsService struct {
-	repo ActorsRepo
+	repo     ActorsRepo
+	tx       Transactor
+	audit    Auditor
+	versions Versioner
+	// cache holds localized actors by ID and the actor listing.
+	cache  Cache
+	events Publisher
 }
 
-func NewActorsService(repo ActorsRepo) *ActorsService {
-	return &ActorsService{repo: repo}
+func NewActorsService(repo ActorsRepo, tx Transactor, audit Auditor, versions Versioner, cache Cache,
+	events Publisher) *ActorsService {
+	return &ActorsService{repo: repo, tx: tx, audit: audit, versions: versions, cache: cache, events: events}
 }
 
+// CreateActor stores the actor and sets act to the stored actor. A new ID is
+// generated unless act has one; domain.ErrAlreadyExists is returned if it is
+// taken.
 func (s *ActorsService) CreateActor(ctx context.Context, act *domain.Actor) error {
-	err := s.repo.CreateActor(ctx, act)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateActor(ctx, act); err != nil {
+			return err
+		}
+		created, err := s.repo.GetActorByID(ctx, act.ID)
+		if err != nil {
+			return err
+		}
+		if err = s.audit.Record(ctx, domain.AuditCreate, domain.AuditEntityActor, act.ID, nil, created); err != nil {
+			return err
+		}
+		if err = s.events.Publish(ctx, domain.EventActorCreated, act.ID, created); err != nil {
+			return err
+		}
+		*act = *created
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("create actor: %w", err)
 	}
+	invalidate(ctx, s.cache, cacheActors)
 	return nil
 }
 
+// GetActor returns an actor or domain.ErrNotFound.
+func (s *ActorsService) GetActor(ctx context.Context, actorID uuid.UUID) (*domain.Actor, error) {
+	key := cacheActors + "id:" + actorID.String() + ":" + localesKey(ctx)
+	act, err := cached(ctx, s.cache, key, func() (*domain.Actor, error) {
+		act, err := s.repo.GetActorByID(ctx, actorID)
+		if err != nil {
+			return nil, err
+		}
+		return act, s.localize(ctx, map[*domain.Actor][]*domain.Movie{act: nil})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get actor: %w", err)
+	}
+	return act, nil
+}
+
+// UpdateActor changes an actor and sets act.Version to the new version. A
+// non-zero act.Version must match the stored version or
+// domain.ErrVersionConflict is returned. It returns domain.ErrNotFound if
+// there is no such actor.
 func (s *ActorsService) UpdateActor(ctx context.Context, act *domain.Actor) error {
-	err := s.repo.UpdateActor(ctx, act)
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetActorByID(ctx, act.ID)
+		if err != nil {
+			return err
+		}
+		if act.Version != 0 && act.Version != before.Version {
+			return domain.ErrVersionConflict
+		}
+		return s.update(ctx, before, act)
+	})
 	if err != nil {
 		return fmt.Errorf("update actor: %w", err)
 	}
+	invalidate(ctx, s.cache, cacheActors)
+	return nil
+}
+
+// update changes an actor stored as before, then audits, versions and
+// publishes the change. The caller runs it in a transaction.
+func (s *ActorsService) update(ctx context.Context, before *domain.Actor, act *domain.Actor) error {
+	if err := s.repo.UpdateActor(ctx, act); err != nil {
+		return err
+	}
+	after, err := s.repo.GetActorByID(ctx, act.ID)
+	if err != nil {
+		return err
+	}
+	act.Version = after.Version
+	if err = s.audit.Record(ctx, domain.AuditUpdate, domain.AuditEntityActor, act.ID, before, after); err != nil {
+		return err
+	}
+	if err = s.events.Publish(ctx, domain.EventActorUpdated, act.ID, after); err != nil {
+		return err
+	}
+	return s.versions.Snapshot(ctx, domain.AuditEntityActor, act.ID, before, after)
+}
+
+// PatchActor changes an actor by calling apply with a copy of them and
+// stores the fields apply changed. A non-zero version must match the stored
+// version or domain.ErrVersionConflict is returned. It returns the updated
+// actor, or domain.ErrNotFound if there is no such actor.
+func (s *ActorsService) PatchActor(ctx context.Context, actorID uuid.UUID, version int,
+	apply func(act *domain.Actor) error) (*domain.Actor, error) {
+	var result *domain.Actor
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetActorByID(ctx, actorID)
+		if err != nil {
+			return err
+		}
+		if version != 0 && version != before.Version {
+			return domain.ErrVersionConflict
+		}
+
+		act := *before
+		if err = apply(&act); err != nil {
+			return err
+		}
+		act.ID = actorID
+
+		fields, err := changedFields(before, &act)
+		if err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			result = before
+			return nil
+		}
+		if err = s.repo.UpdateActorFields(ctx, &act, fields); err != nil {
+			return err
+		}
+
+		if result, err = s.repo.GetActorByID(ctx, actorID); err != nil {
+			return err
+		}
+		if err = s.audit.Record(ctx, domain.AuditUpdate, domain.AuditEntityActor, actorID, before, result); err != nil {
+			return err
+		}
+		if err = s.events.Publish(ctx, domain.EventActorUpdated, actorID, result); err != nil {
+			return err
+		}
+		return s.versions.Snapshot(ctx, domain.AuditEntityActor, actorID, before, result)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("patch actor: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheActors)
+	return result, nil
+}
+
+func (s *ActorsService) GetActorVersions(ctx context.Context, actorID uuid.UUID) ([]*domain.EntityVersion, error) {
+	versions, err := s.versions.GetVersions(ctx, domain.AuditEntityActor, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("get actor versions: %w", err)
+	}
+	return versions, nil
+}
+
+func (s *ActorsService) DiffActorVersions(ctx context.Context, actorID uuid.UUID, from int, to int) (map[string]domain.AuditChange, error) {
+	changes, err := s.versions.DiffVersions(ctx, domain.AuditEntityActor, actorID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("diff actor versions: %w", err)
+	}
+	return changes, nil
+}
+
+// RestoreActorVersion updates an actor to the state of one of their
+// versions, which adds a new version. It returns domain.ErrNotFound if there
+// is no such actor or version.
+func (s *ActorsService) RestoreActorVersion(ctx context.Context, actorID uuid.UUID, number int) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		version, err := s.versions.GetVersion(ctx, domain.AuditEntityActor, actorID, number)
+		if err != nil {
+			return err
+		}
+		before, err := s.repo.GetActorByID(ctx, actorID)
+		if err != nil {
+			return err
+		}
+
+		var act domain.Actor
+		if err = json.Unmarshal(version.State, &act); err != nil {
+			return err
+		}
+		act.ID = actorID
+		act.Version = 0
+		return s.update(ctx, before, &act)
+	})
+	if err != nil {
+		return fmt.Errorf("restore actor version: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheActors)
 	return nil
 }
 
-func (s *ActorsService) DeleteActor(ctx context.Context, actorID uuid.UUID) error {
-	err := s.repo.DeleteActor(ctx, actorID)
+// DeleteActor moves an actor to the trash. It returns domain.ErrNotFound if
+// there is no such actor and domain.ErrVersionConflict if a non-zero version
+// does not match the stored version.
+func (s *ActorsService) DeleteActor(ctx context.Context, actorID uuid.UUID, version int) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetActorByID(ctx, actorID)
+		if err != nil {
+			return err
+		}
+		if version != 0 && version != before.Version {
+			return domain.ErrVersionConflict
+		}
+		if err = s.repo.DeleteActor(ctx, actorID); err != nil {
+			return err
+		}
+		if err = s.audit.Record(ctx, domain.AuditDelete, domain.AuditEntityActor, actorID, before, nil); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, domain.EventActorDeleted, actorID, before)
+	})
 	if err != nil {
 		return fmt.Errorf("delete actor: %w", err)
 	}
+	invalidate(ctx, s.cache, cacheActors)
 	return nil
 }
 
 func (s *ActorsService) GetActors(ctx context.Context) (map[*domain.Actor][]*domain.Movie, error) {
-	actors, err := s.repo.GetActors(ctx)
+	// The listing is cached as a slice, as JSON objects cannot have
+	// pointer keys.
+	type actorFilms struct {
+		Actor  *domain.Actor
+		Movies []*domain.Movie
+	}
+	key := cacheActors + "list:" + localesKey(ctx)
+	list, err := cached(ctx, s.cache, key, func() ([]actorFilms, error) {
+		actors, err := s.repo.GetActors(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err = s.localize(ctx, actors); err != nil {
+			return nil, err
+		}
+		list := make([]actorFilms, 0, len(actors))
+		for act, movies := range actors {
+			list = append(list, actorFilms{Actor: act, Movies: movies})
+		}
+		return list, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("get actors: %w", err)
 	}
+
+	actors := make(map[*domain.Actor][]*domain.Movie, len(list))
+	for _, entry := range list {
+		actors[entry.Actor] = entry.Movies
+	}
 	return actors, nil
 }
+
+// localize translates the actor names and movie titles into the locales
+// preferred by the client.
+func (s *ActorsService) localize(ctx context.Context, actorFilms map[*domain.Actor][]*domain.Movie) error {
+	locales := LocalesFromContext(ctx)
+	if len(locales) == 0 || len(actorFilms) == 0 {
+		return nil
+	}
+
+	actors := make([]*domain.Actor, 0, len(actorFilms))
+	actorIDs := make([]uuid.UUID, 0, len(actorFilms))
+	var movies []*domain.Movie
+	for actor, films := range actorFilms {
+		actors = append(actors, actor)
+		actorIDs = append(actorIDs, actor.ID)
+		movies = append(movies, films...)
+	}
+
+	actorTranslations, err := s.repo.GetActorTranslations(ctx, actorIDs, locales)
+	if err != nil {
+		return err
+	}
+	localizeActors(actors, actorTranslations, locales)
+
+	if len(movies) == 0 {
+		return nil
+	}
+	movieTranslations, err := s.repo.GetMovieTranslations(ctx, movieIDs(movies), locales)
+	if err != nil {
+		return err
+	}
+	localizeMovies(movies, movieTranslations, locales)
+	return nil
+}
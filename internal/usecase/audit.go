@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=audit.go -destination=mocks/auditMock.go
+
+const RequestIDCtx ContextKey = "request_id"
+
+// WithRequestID stores the ID of the request being served.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDCtx, requestID)
+}
+
+// RequestIDFromContext returns the ID stored by WithRequestID or "".
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDCtx).(string)
+	return requestID
+}
+
+type AuditRepo interface {
+	AppendAuditRecord(ctx context.Context, record *domain.AuditRecord) error
+	GetAuditRecords(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditRecord, error)
+}
+
+// Auditor records changes of entities. Services call it in the transaction
+// of the change, so a change is never stored without its record.
+type Auditor interface {
+	// Record stores the change of an entity from before to after. Either
+	// state may be nil: before on creation and after on deletion.
+	Record(ctx context.Context, action string, entity string, entityID uuid.UUID, before any, after any) error
+}
+
+type AuditService struct {
+	repo AuditRepo
+}
+
+func NewAuditService(repo AuditRepo) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// Record stores an audit record attributed to the user and the request of
+// ctx. Updates that change nothing are not recorded.
+func (s *AuditService) Record(ctx context.Context, action string, entity string, entityID uuid.UUID, before any, after any) error {
+	changes, err := diffStates(before, after)
+	if err != nil {
+		return fmt.Errorf("record audit: %w", err)
+	}
+	if action == domain.AuditUpdate && len(changes) == 0 {
+		return nil
+	}
+
+	record := &domain.AuditRecord{
+		Action:    action,
+		Entity:    entity,
+		EntityID:  entityID,
+		Changes:   changes,
+		RequestID: RequestIDFromContext(ctx),
+	}
+	if user, ok := UserFromContext(ctx); ok {
+		record.UserID = user.UserID
+	}
+	if err = s.repo.AppendAuditRecord(ctx, record); err != nil {
+		return fmt.Errorf("record audit: %w", err)
+	}
+	return nil
+}
+
+func (s *AuditService) GetAuditRecords(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditRecord, error) {
+	records, err := s.repo.GetAuditRecords(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get audit records: %w", err)
+	}
+	return records, nil
+}
+
+// diffStates compares the JSON encodings of two states field by field and
+// returns the fields that differ.
+func diffStates(before any, after any) (map[string]domain.AuditChange, error) {
+	beforeFields, err := stateFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := stateFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]domain.AuditChange)
+	for field, value := range beforeFields {
+		if afterValue, ok := afterFields[field]; !ok || !reflect.DeepEqual(value, afterValue) {
+			changes[field] = domain.AuditChange{Before: value, After: afterFields[field]}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = domain.AuditChange{After: value}
+		}
+	}
+	return changes, nil
+}
+
+// changedFields returns the names of the fields that differ between two
+// states of an entity, sorted.
+func changedFields(before any, after any) ([]string, error) {
+	changes, err := diffStates(before, after)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+func stateFields(state any) (map[string]any, error) {
+	if state == nil || reflect.ValueOf(state).Kind() == reflect.Pointer && reflect.ValueOf(state).IsNil() {
+		return nil, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	// Every update increments the version, which is not a change of its own.
+	delete(fields, "Version")
+	return fields, nil
+}
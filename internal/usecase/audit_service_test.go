@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// noAudit discards audit records.
+type noAudit struct{}
+
+func (noAudit) Record(context.Context, string, string, uuid.UUID, any, any) error {
+	return nil
+}
+
+func TestAuditRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockAuditRepo(ctrl)
+	service := NewAuditService(repo)
+
+	userID := uuid.New()
+	actorID := uuid.New()
+	ctx := context.WithValue(context.Background(), UserCtx, &UserInfo{UserID: userID, Role: domain.ADMIN})
+	ctx = WithRequestID(ctx, "req-1")
+
+	repo.EXPECT().AppendAuditRecord(gomock.Any(), &domain.AuditRecord{
+		UserID:   userID,
+		Action:   domain.AuditUpdate,
+		Entity:   domain.AuditEntityActor,
+		EntityID: actorID,
+		Changes: map[string]domain.AuditChange{
+			"Surname": {Before: "Pacino", After: "Pacino Jr."},
+		},
+		RequestID: "req-1",
+	}).Return(nil)
+
+	before := &domain.Actor{ID: actorID, Name: "Al", Surname: "Pacino"}
+	after := &domain.Actor{ID: actorID, Name: "Al", Surname: "Pacino Jr."}
+	require.NoError(t, service.Record(ctx, domain.AuditUpdate, domain.AuditEntityActor, actorID, before, after))
+
+	// An update that changes nothing is not recorded.
+	require.NoError(t, service.Record(ctx, domain.AuditUpdate, domain.AuditEntityActor, actorID, before, before))
+}
+
+func TestAuditRecordCreateAndDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockAuditRepo(ctrl)
+	service := NewAuditService(repo)
+	actorID := uuid.New()
+	actor := &domain.Actor{ID: actorID, Name: "Al"}
+
+	var records []*domain.AuditRecord
+	repo.EXPECT().AppendAuditRecord(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, record *domain.AuditRecord) error {
+			records = append(records, record)
+			return nil
+		}).Times(2)
+
+	require.NoError(t, service.Record(context.Background(), domain.AuditCreate, domain.AuditEntityActor, actorID, nil, actor))
+	require.NoError(t, service.Record(context.Background(), domain.AuditDelete, domain.AuditEntityActor, actorID, actor, (*domain.Actor)(nil)))
+
+	require.Len(t, records, 2)
+	assert.Equal(t, uuid.Nil, records[0].UserID)
+	assert.Equal(t, domain.AuditChange{After: "Al"}, records[0].Changes["Name"])
+	assert.Equal(t, domain.AuditChange{Before: "Al"}, records[1].Changes["Name"])
+
+	repo.EXPECT().AppendAuditRecord(gomock.Any(), gomock.Any()).Return(errors.New("repository error"))
+	assert.Error(t, service.Record(context.Background(), domain.AuditCreate, domain.AuditEntityActor, actorID, nil, actor))
+}
+
+func TestCreateUserAuditsWithoutPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mock_repo.NewMockUserRepo(ctrl)
+	auditRepo := mock_repo.NewMockAuditRepo(ctrl)
+	service := NewUserService(userRepo, nil, directTx{}, NewAuditService(auditRepo))
+
+	userRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil)
+	auditRepo.EXPECT().AppendAuditRecord(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, record *domain.AuditRecord) error {
+			assert.Equal(t, domain.AuditEntityUser, record.Entity)
+			assert.Equal(t, domain.AuditChange{After: "admin"}, record.Changes["Login"])
+			assert.NotContains(t, record.Changes, "Password")
+			return nil
+		})
+
+	_, err := service.CreateUser(context.Background(), "admin", "secret", domain.ADMIN)
+	require.NoError(t, err)
+}
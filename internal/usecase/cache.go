@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"log/slog"
+	"strings"
+)
+
+// Cache stores encoded results of catalogue reads. Values are opaque bytes
+// and keys are grouped by prefixes, so a cache shared between processes,
+// like Redis, can implement it with GET, SET with an expiry and a SCAN of
+// the prefix. Implementations choose how long entries are kept and must be
+// safe for concurrent use.
+type Cache interface {
+	// Get returns the value of key and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key. The cache keeps value, so the caller must
+	// not modify it afterwards.
+	Set(ctx context.Context, key string, value []byte) error
+	// DeletePrefix removes the entries whose keys start with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// Prefixes of cache keys. Writes to movies invalidate the cached actors as
+// well, as actor listings include the movies of each actor.
+const (
+	cacheMovies = "movies:"
+	cacheActors = "actors:"
+)
+
+// cacheHits and cacheMisses count cache lookups by key prefix. They are
+// published by expvar.
+var (
+	cacheHits   = expvar.NewMap("cache_hits")
+	cacheMisses = expvar.NewMap("cache_misses")
+)
+
+// cached returns the value cached under key, or loads it and caches it.
+// Cache failures are logged and fall back to load, so a broken cache only
+// makes reads slower.
+func cached[T any](ctx context.Context, cache Cache, key string, load func() (T, error)) (T, error) {
+	name, _, _ := strings.Cut(key, ":")
+	data, ok, err := cache.Get(ctx, key)
+	if err != nil {
+		slog.Error("Failed to read cache", "key", key, "err", err)
+	}
+	if ok {
+		var value T
+		if err = json.Unmarshal(data, &value); err == nil {
+			cacheHits.Add(name, 1)
+			return value, nil
+		}
+		slog.Error("Failed to decode cached value", "key", key, "err", err)
+	}
+	cacheMisses.Add(name, 1)
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	if data, err = json.Marshal(value); err != nil {
+		slog.Error("Failed to encode cached value", "key", key, "err", err)
+		return value, nil
+	}
+	if err = cache.Set(ctx, key, data); err != nil {
+		slog.Error("Failed to write cache", "key", key, "err", err)
+	}
+	return value, nil
+}
+
+// invalidate removes the cached values of the prefixes. Failures are
+// logged; the entries then expire on their own.
+func invalidate(ctx context.Context, cache Cache, prefixes ...string) {
+	for _, prefix := range prefixes {
+		if err := cache.DeletePrefix(ctx, prefix); err != nil {
+			slog.Error("Failed to invalidate cache", "prefix", prefix, "err", err)
+		}
+	}
+}
+
+// localesKey is the part of a cache key for the locales of a request, as
+// results are localized.
+func localesKey(ctx context.Context) string {
+	return strings.Join(LocalesFromContext(ctx), ",")
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// noCache caches nothing.
+type noCache struct{}
+
+func (noCache) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (noCache) Set(context.Context, string, []byte) error {
+	return nil
+}
+
+func (noCache) DeletePrefix(context.Context, string) error {
+	return nil
+}
+
+// mapCache keeps entries until they are deleted.
+type mapCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{entries: map[string][]byte{}}
+}
+
+func (c *mapCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.entries[key]
+	return value, ok, nil
+}
+
+func (c *mapCache) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = value
+	return nil
+}
+
+func (c *mapCache) DeletePrefix(_ context.Context, prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+	return nil
+}
+
+func TestGetMovieCachedUntilWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	cache := newMapCache()
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, cache, noEvents{})
+
+	movie := &domain.Movie{ID: uuid.New(), Title: "Heat", Version: 1}
+	mockRepo.EXPECT().GetMovieByID(gomock.Any(), movie.ID).Return(movie, nil).Times(3)
+	mockRepo.EXPECT().DeleteMovie(gomock.Any(), movie.ID).Return(nil)
+
+	ctx := context.Background()
+	for range 2 {
+		result, err := movieService.GetMovie(ctx, movie.ID)
+		require.NoError(t, err)
+		assert.Equal(t, movie, result)
+	}
+
+	require.NoError(t, movieService.DeleteMovie(ctx, movie.ID, 0))
+	assert.Empty(t, cache.entries)
+
+	_, err := movieService.GetMovie(ctx, movie.ID)
+	require.NoError(t, err)
+}
+
+func TestGetMoviesFilterCachesWithoutWatchlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, newMapCache(), noEvents{})
+
+	first := &UserInfo{UserID: uuid.New(), Role: domain.USER}
+	second := &UserInfo{UserID: uuid.New(), Role: domain.USER}
+	movie := &domain.Movie{ID: uuid.New(), Title: "Heat"}
+
+	mockRepo.EXPECT().GetMovies(gomock.Any()).Return([]*domain.Movie{movie}, nil)
+	mockRepo.EXPECT().GetWatchlistMovieIDs(gomock.Any(), first.UserID).Return(map[uuid.UUID]struct{}{movie.ID: {}}, nil)
+	mockRepo.EXPECT().GetWatchlistMovieIDs(gomock.Any(), second.UserID).Return(map[uuid.UUID]struct{}{}, nil)
+
+	movies, err := movieService.GetMoviesFilter(context.WithValue(context.Background(), UserCtx, first), domain.MovieFilter{})
+	require.NoError(t, err)
+	assert.True(t, movies[0].InWatchlist)
+
+	movies, err = movieService.GetMoviesFilter(context.WithValue(context.Background(), UserCtx, second), domain.MovieFilter{})
+	require.NoError(t, err)
+	assert.False(t, movies[0].InWatchlist)
+}
+
+func TestGetActorsCachedPerLocale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockActorsRepo(ctrl)
+	actorService := NewActorsService(mockRepo, directTx{}, noAudit{}, noVersions{}, newMapCache(), noEvents{})
+
+	act := &domain.Actor{ID: uuid.New(), Name: "Robert", Surname: "De Niro"}
+	movie := &domain.Movie{ID: uuid.New(), Title: "Heat"}
+	mockRepo.EXPECT().GetActors(gomock.Any()).DoAndReturn(func(context.Context) (map[*domain.Actor][]*domain.Movie, error) {
+		copied := *act
+		return map[*domain.Actor][]*domain.Movie{&copied: {movie}}, nil
+	}).Times(2)
+	mockRepo.EXPECT().GetActorTranslations(gomock.Any(), gomock.Any(), []string{"de"}).Return(nil, nil)
+	mockRepo.EXPECT().GetMovieTranslations(gomock.Any(), gomock.Any(), []string{"de"}).Return(nil, nil)
+
+	for _, ctx := range []context.Context{
+		context.Background(),
+		context.Background(),
+		WithLocales(context.Background(), []string{"de"}),
+	} {
+		actors, err := actorService.GetActors(ctx)
+		require.NoError(t, err)
+		require.Len(t, actors, 1)
+		for cachedActor, movies := range actors {
+			assert.Equal(t, act, cachedActor)
+			assert.Equal(t, []*domain.Movie{movie}, movies)
+		}
+	}
+}
+
+func TestCatalogueWritesInvalidateCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	movieID := uuid.New()
+	writes := map[string]func(cache Cache) error{
+		"Credit": func(cache Cache) error {
+			repo := mock_repo.NewMockCreditRepo(ctrl)
+			repo.EXPECT().CreateCredit(gomock.Any(), gomock.Any()).Return(nil)
+			return NewCreditService(repo, directTx{}, cache, noEvents{}).
+				CreateCredit(ctx, &domain.Credit{MovieID: movieID, Role: domain.RoleActor})
+		},
+		"Genre": func(cache Cache) error {
+			repo := mock_repo.NewMockGenreRepo(ctrl)
+			repo.EXPECT().UpdateGenre(gomock.Any(), gomock.Any()).Return(nil)
+			return NewGenreService(repo, cache).UpdateGenre(ctx, &domain.Genre{ID: uuid.New(), Name: "Crime"})
+		},
+		"Restore": func(cache Cache) error {
+			repo := mock_repo.NewMockTrashRepo(ctrl)
+			repo.EXPECT().RestoreMovie(gomock.Any(), movieID).Return(nil)
+			repo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(&domain.Movie{ID: movieID}, nil)
+			return NewTrashService(repo, directTx{}, noAudit{}, cache, noEvents{}, time.Hour).RestoreMovie(ctx, movieID)
+		},
+		"Rating": func(cache Cache) error {
+			repo := mock_repo.NewMockRatingRepo(ctrl)
+			repo.EXPECT().UpsertRating(gomock.Any(), gomock.Any()).Return(nil)
+			repo.EXPECT().GetRatingStats(gomock.Any(), movieID).Return(8.0, 1, nil)
+			repo.EXPECT().UpdateMovieRating(gomock.Any(), gomock.Any()).Return(nil)
+			_, err := NewRatingService(repo, directTx{}, cache, 0).
+				RateMovie(ctx, &domain.Rating{UserID: uuid.New(), MovieID: movieID, Score: 8})
+			return err
+		},
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			cache := newMapCache()
+			require.NoError(t, cache.Set(ctx, cacheMovies+"id:"+movieID.String()+":", []byte("{}")))
+			require.NoError(t, cache.Set(ctx, cacheActors+"list:", []byte("[]")))
+
+			require.NoError(t, write(cache))
+			assert.Empty(t, cache.entries)
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"cinema_service/internal/domain"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// This file holds the file formats shared by ImportService and
+// ExportService.
+
+const (
+	catalogueDateLayout = "2006-01-02"
+	// listSeparator separates the values of list columns in CSV files.
+	listSeparator = "|"
+)
+
+// rowError is a malformed row that does not stop the import.
+type rowError struct {
+	message string
+}
+
+func (e *rowError) Error() string {
+	return e.message
+}
+
+// rowReader yields the rows of an import file as column values keyed by
+// column name, together with the line each row starts on.
+type rowReader interface {
+	next() (line int, fields map[string]string, err error)
+}
+
+func newRowReader(format string, r io.Reader) (rowReader, error) {
+	switch format {
+	case domain.FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("%w: read CSV header: %v", domain.ErrInvalidImport, err)
+		}
+		for i := range header {
+			header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+		}
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+		return &csvRowReader{reader: reader, header: header}, nil
+	case domain.FormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		return &ndjsonRowReader{scanner: scanner}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown format %q", domain.ErrInvalidImport, format)
+}
+
+type csvRowReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func (c *csvRowReader) next() (int, map[string]string, error) {
+	record, err := c.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return parseErr.StartLine, nil, &rowError{message: parseErr.Err.Error()}
+		}
+		return 0, nil, err
+	}
+	// FieldPos panics unless the last read succeeded.
+	line, _ := c.reader.FieldPos(0)
+	if len(record) != len(c.header) {
+		return line, nil, &rowError{
+			message: fmt.Sprintf("expected %d columns, got %d", len(c.header), len(record)),
+		}
+	}
+
+	fields := make(map[string]string, len(record))
+	for i, value := range record {
+		fields[c.header[i]] = strings.TrimSpace(value)
+	}
+	return line, fields, nil
+}
+
+type ndjsonRowReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// next decodes one JSON object per line. Scalars are converted to their
+// text form and arrays joined like CSV list columns, so both formats share
+// the row parsers.
+func (n *ndjsonRowReader) next() (int, map[string]string, error) {
+	for n.scanner.Scan() {
+		n.line++
+		data := bytes.TrimSpace(n.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var object map[string]any
+		if err := decoder.Decode(&object); err != nil {
+			return n.line, nil, &rowError{message: "invalid JSON object"}
+		}
+
+		fields := make(map[string]string, len(object))
+		for key, value := range object {
+			text, ok := jsonText(value)
+			if !ok {
+				return n.line, nil, &rowError{message: fmt.Sprintf("unsupported value of %s", key)}
+			}
+			fields[strings.ToLower(key)] = text
+		}
+		return n.line, fields, nil
+	}
+	if err := n.scanner.Err(); err != nil {
+		return 0, nil, err
+	}
+	return 0, nil, io.EOF
+}
+
+func jsonText(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", true
+	case string:
+		return strings.TrimSpace(v), true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return "", false
+			}
+			items = append(items, text)
+		}
+		return strings.Join(items, listSeparator), true
+	}
+	return "", false
+}
+
+// exportRecord is a record as written to export files. Its JSON fields
+// follow exportColumns and csvRow returns them in the same order.
+type exportRecord interface {
+	csvRow() []string
+}
+
+type movieRecord struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	ReleaseDate      string   `json:"release_date,omitempty"`
+	Rating           float32  `json:"rating"`
+	DurationMinutes  int      `json:"duration_minutes,omitempty"`
+	AgeRating        string   `json:"age_rating,omitempty"`
+	Countries        []string `json:"countries"`
+	OriginalLanguage string   `json:"original_language,omitempty"`
+	IMDbID           string   `json:"imdb_id,omitempty"`
+	TMDBID           int      `json:"tmdb_id,omitempty"`
+}
+
+func newMovieRecord(movie *domain.Movie) *movieRecord {
+	countries := movie.Countries
+	if countries == nil {
+		countries = []string{}
+	}
+	return &movieRecord{
+		ID:               movie.ID.String(),
+		Title:            movie.Title,
+		Description:      movie.Description,
+		ReleaseDate:      formatDate(movie.Date),
+		Rating:           movie.Rating,
+		DurationMinutes:  movie.DurationMinutes,
+		AgeRating:        movie.AgeRating,
+		Countries:        countries,
+		OriginalLanguage: movie.OriginalLanguage,
+		IMDbID:           movie.IMDbID,
+		TMDBID:           movie.TMDBID,
+	}
+}
+
+func (r *movieRecord) csvRow() []string {
+	return []string{
+		r.ID, r.Title, r.Description, r.ReleaseDate, strconv.FormatFloat(float64(r.Rating), 'f', -1, 32),
+		formatInt(r.DurationMinutes), r.AgeRating, strings.Join(r.Countries, listSeparator),
+		r.OriginalLanguage, r.IMDbID, formatInt(r.TMDBID),
+	}
+}
+
+type actorRecord struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Surname   string `json:"surname"`
+	Sex       string `json:"sex,omitempty"`
+	Birthdate string `json:"birthdate,omitempty"`
+}
+
+func newActorRecord(actor *domain.Actor) *actorRecord {
+	return &actorRecord{
+		ID:        actor.ID.String(),
+		Name:      actor.Name,
+		Surname:   actor.Surname,
+		Sex:       actor.Sex,
+		Birthdate: formatDate(actor.Birthdate),
+	}
+}
+
+func (r *actorRecord) csvRow() []string {
+	return []string{r.ID, r.Name, r.Surname, r.Sex, r.Birthdate}
+}
+
+type creditRecord struct {
+	MovieID      string `json:"movie_id"`
+	PersonID     string `json:"person_id"`
+	Role         string `json:"role"`
+	Character    string `json:"character,omitempty"`
+	BillingOrder int    `json:"billing_order"`
+}
+
+func newCreditRecord(credit *domain.Credit) *creditRecord {
+	return &creditRecord{
+		MovieID:      credit.MovieID.String(),
+		PersonID:     credit.PersonID.String(),
+		Role:         credit.Role,
+		Character:    credit.Character,
+		BillingOrder: credit.BillingOrder,
+	}
+}
+
+func (r *creditRecord) csvRow() []string {
+	return []string{r.MovieID, r.PersonID, r.Role, r.Character, strconv.Itoa(r.BillingOrder)}
+}
+
+// formatDate formats dates like the import expects them; unknown dates,
+// stored as the zero year, are left empty.
+func formatDate(date time.Time) string {
+	if date.IsZero() || date.Year() <= 1 {
+		return ""
+	}
+	return date.Format(catalogueDateLayout)
+}
+
+func formatInt(value int) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+type recordEncoder interface {
+	begin(columns []string) error
+	encode(record exportRecord) error
+	end() error
+}
+
+func newRecordEncoder(format string, w io.Writer) (recordEncoder, error) {
+	switch format {
+	case domain.FormatCSV:
+		return &csvEncoder{writer: csv.NewWriter(w)}, nil
+	case domain.FormatNDJSON:
+		return &ndjsonEncoder{encoder: json.NewEncoder(w)}, nil
+	case domain.FormatJSON:
+		return &jsonArrayEncoder{w: w}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown format %q", domain.ErrInvalidExport, format)
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvEncoder) begin(columns []string) error {
+	return e.writer.Write(columns)
+}
+
+func (e *csvEncoder) encode(record exportRecord) error {
+	return e.writer.Write(record.csvRow())
+}
+
+func (e *csvEncoder) end() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type ndjsonEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonEncoder) begin([]string) error {
+	return nil
+}
+
+func (e *ndjsonEncoder) encode(record exportRecord) error {
+	return e.encoder.Encode(record)
+}
+
+func (e *ndjsonEncoder) end() error {
+	return nil
+}
+
+// jsonArrayEncoder writes the records as one JSON array, element by
+// element. The opening bracket is held back until the first record or the
+// end, so an export that fails before any record has written nothing and
+// can still be answered with an error status.
+type jsonArrayEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonArrayEncoder) begin([]string) error {
+	return nil
+}
+
+func (e *jsonArrayEncoder) encode(record exportRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if e.count == 0 {
+		separator = "["
+	}
+	if _, err = io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+	e.count++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonArrayEncoder) end() error {
+	closing := "]\n"
+	if e.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(e.w, closing)
+	return err
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=credit.go -destination=mocks/creditMock.go
+
+var ErrInvalidRole = errors.New("invalid credit role")
+
+type CreditRepo interface {
+	CreateCredit(ctx context.Context, credit *domain.Credit) error
+	DeleteCredit(ctx context.Context, creditID uuid.UUID) error
+	// GetCredit returns a credit without the person and the movie or
+	// domain.ErrNotFound.
+	GetCredit(ctx context.Context, creditID uuid.UUID) (*domain.Credit, error)
+	GetMovieCredits(ctx context.Context, movieID uuid.UUID) ([]*domain.Credit, error)
+	GetPersonCredits(ctx context.Context, personID uuid.UUID) ([]*domain.Credit, error)
+}
+
+type CreditService struct {
+	repo   CreditRepo
+	tx     Transactor
+	cache  Cache
+	events Publisher
+}
+
+func NewCreditService(repo CreditRepo, tx Transactor, cache Cache, events Publisher) *CreditService {
+	return &CreditService{repo: repo, tx: tx, cache: cache, events: events}
+}
+
+func (s *CreditService) CreateCredit(ctx context.Context, credit *domain.Credit) error {
+	if !domain.IsRole(credit.Role) {
+		return ErrInvalidRole
+	}
+	if credit.Role != domain.RoleActor {
+		credit.Character = ""
+		credit.BillingOrder = 0
+	}
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateCredit(ctx, credit); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, domain.EventCastChanged, credit.MovieID, castChange{Credit: credit})
+	})
+	if err != nil {
+		return fmt.Errorf("create credit: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return nil
+}
+
+func (s *CreditService) DeleteCredit(ctx context.Context, creditID uuid.UUID) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		credit, err := s.repo.GetCredit(ctx, creditID)
+		if err != nil {
+			return err
+		}
+		if err = s.repo.DeleteCredit(ctx, creditID); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, domain.EventCastChanged, credit.MovieID, castChange{Credit: credit, Removed: true})
+	})
+	if err != nil {
+		return fmt.Errorf("delete credit: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return nil
+}
+
+// castChange is the payload of a domain.EventCastChanged event.
+type castChange struct {
+	Credit  *domain.Credit
+	Removed bool
+}
+
+// GetMovieCredits returns the full credits of a movie grouped by role.
+func (s *CreditService) GetMovieCredits(ctx context.Context, movieID uuid.UUID) (map[string][]*domain.Credit, error) {
+	credits, err := s.repo.GetMovieCredits(ctx, movieID)
+	if err != nil {
+		return nil, fmt.Errorf("get movie credits: %w", err)
+	}
+	return groupByRole(credits), nil
+}
+
+// GetCredit returns a credit without the person and the movie, or
+// domain.ErrNotFound.
+func (s *CreditService) GetCredit(ctx context.Context, creditID uuid.UUID) (*domain.Credit, error) {
+	credit, err := s.repo.GetCredit(ctx, creditID)
+	if err != nil {
+		return nil, fmt.Errorf("get credit: %w", err)
+	}
+	return credit, nil
+}
+
+// GetFilmography returns the movies of a person grouped by role.
+func (s *CreditService) GetFilmography(ctx context.Context, personID uuid.UUID) (map[string][]*domain.Credit, error) {
+	credits, err := s.repo.GetPersonCredits(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("get filmography: %w", err)
+	}
+	return groupByRole(credits), nil
+}
+
+func groupByRole(credits []*domain.Credit) map[string][]*domain.Credit {
+	grouped := make(map[string][]*domain.Credit)
+	for _, credit := range credits {
+		grouped[credit.Role] = append(grouped[credit.Role], credit)
+	}
+	return grouped
+}
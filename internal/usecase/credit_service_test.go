@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCredit(t *testing.T) {
+	movieID := uuid.New()
+	personID := uuid.New()
+	type mockBehavior func(r *mock_repo.MockCreditRepo)
+
+	testCases := []struct {
+		name         string
+		credit       *domain.Credit
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name:   "Acting credit keeps character",
+			credit: &domain.Credit{MovieID: movieID, PersonID: personID, Role: domain.RoleActor, Character: "Neo", BillingOrder: 1},
+			mockBehavior: func(r *mock_repo.MockCreditRepo) {
+				r.EXPECT().CreateCredit(gomock.Any(), &domain.Credit{
+					MovieID: movieID, PersonID: personID, Role: domain.RoleActor, Character: "Neo", BillingOrder: 1,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Crew credit drops character",
+			credit: &domain.Credit{MovieID: movieID, PersonID: personID, Role: domain.RoleDirector, Character: "Neo", BillingOrder: 1},
+			mockBehavior: func(r *mock_repo.MockCreditRepo) {
+				r.EXPECT().CreateCredit(gomock.Any(), &domain.Credit{
+					MovieID: movieID, PersonID: personID, Role: domain.RoleDirector,
+				}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:         "Invalid role",
+			credit:       &domain.Credit{MovieID: movieID, PersonID: personID, Role: "GAFFER"},
+			mockBehavior: func(r *mock_repo.MockCreditRepo) {},
+			wantErr:      ErrInvalidRole,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockCreditRepo(ctrl)
+			tc.mockBehavior(repo)
+
+			service := NewCreditService(repo, directTx{}, noCache{}, noEvents{})
+			err := service.CreateCredit(context.Background(), tc.credit)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetFilmography(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	personID := uuid.New()
+	acting := &domain.Credit{ID: uuid.New(), Role: domain.RoleActor, Character: "Neo"}
+	directing := &domain.Credit{ID: uuid.New(), Role: domain.RoleDirector}
+	acting2 := &domain.Credit{ID: uuid.New(), Role: domain.RoleActor, Character: "John"}
+
+	repo := mock_repo.NewMockCreditRepo(ctrl)
+	repo.EXPECT().GetPersonCredits(gomock.Any(), personID).Return([]*domain.Credit{acting, directing, acting2}, nil)
+
+	service := NewCreditService(repo, directTx{}, noCache{}, noEvents{})
+	grouped, err := service.GetFilmography(context.Background(), personID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]*domain.Credit{
+		domain.RoleActor:    {acting, acting2},
+		domain.RoleDirector: {directing},
+	}, grouped)
+}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=event.go -destination=mocks/eventMock.go
+
+// Delays between the deliveries of an event that failed. The delay doubles
+// with every attempt up to eventRetryMaxDelay.
+const (
+	eventRetryDelay    = time.Second
+	eventRetryMaxDelay = time.Hour
+)
+
+// eventClaimLease is how long a dispatcher has to deliver the events it
+// claimed. Events of a dispatcher that stopped are delivered again after it.
+const eventClaimLease = 5 * time.Minute
+
+type EventRepo interface {
+	// AppendEvent stores a new event in the outbox and sets its Sequence.
+	AppendEvent(ctx context.Context, event *domain.Event) error
+	// ClaimPendingEvents returns up to limit events that are not published
+	// and due at now, by Sequence, and postpones them to until, so that
+	// concurrent dispatchers do not get them as well.
+	ClaimPendingEvents(ctx context.Context, now, until time.Time, limit int) ([]*domain.Event, error)
+	MarkEventPublished(ctx context.Context, eventID uuid.UUID, publishedAt time.Time) error
+	// MarkEventFailed stores the Attempts, NextAttemptAt and LastError of
+	// an event.
+	MarkEventFailed(ctx context.Context, event *domain.Event) error
+	// PurgePublishedEvents removes the events published before
+	// publishedBefore and returns how many it removed.
+	PurgePublishedEvents(ctx context.Context, publishedBefore time.Time) (int, error)
+}
+
+// Publisher records domain events. Services call it in the transaction of
+// the change, so an event is stored if and only if the change is.
+type Publisher interface {
+	// Publish records an event of an entity whose state is payload.
+	Publish(ctx context.Context, eventType string, entityID uuid.UUID, payload any) error
+}
+
+// Sink delivers events to their consumers, like a message broker or a
+// webhook. A sink may receive an event more than once.
+type Sink interface {
+	Deliver(ctx context.Context, event *domain.Event) error
+}
+
+// EventService keeps domain events in a transactional outbox and
+// dispatches them to the sinks. Imports do not publish events.
+type EventService struct {
+	repo  EventRepo
+	sinks []Sink
+	// batchSize is the number of events read from the outbox at once.
+	batchSize int
+	// retention is how long published events are kept.
+	retention time.Duration
+}
+
+func NewEventService(repo EventRepo, sinks []Sink, batchSize int, retention time.Duration) *EventService {
+	return &EventService{repo: repo, sinks: sinks, batchSize: batchSize, retention: retention}
+}
+
+// Publish stores an event in the outbox, attributed to the request of ctx.
+func (s *EventService) Publish(ctx context.Context, eventType string, entityID uuid.UUID, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("publish event: %w", err)
+	}
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	event := &domain.Event{
+		ID:            uuid.New(),
+		Type:          eventType,
+		EntityID:      entityID,
+		Payload:       data,
+		RequestID:     RequestIDFromContext(ctx),
+		CreatedAt:     createdAt,
+		NextAttemptAt: createdAt,
+	}
+	if err = s.repo.AppendEvent(ctx, event); err != nil {
+		return fmt.Errorf("publish event: %w", err)
+	}
+	return nil
+}
+
+// Dispatch delivers a batch of due events to every sink and returns how
+// many were delivered. An event is published once all sinks accept it;
+// otherwise it is delivered again to all of them after a delay. Each
+// event of the batch is claimed, so concurrent dispatchers deliver
+// different events.
+func (s *EventService) Dispatch(ctx context.Context) (int, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	events, err := s.repo.ClaimPendingEvents(ctx, now, now.Add(eventClaimLease), s.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("dispatch events: %w", err)
+	}
+
+	delivered := 0
+	for _, event := range events {
+		if err = s.deliver(ctx, event); err != nil {
+			event.Attempts++
+			event.NextAttemptAt = now.Add(retryDelay(event.Attempts))
+			event.LastError = err.Error()
+			slog.Warn("Failed to deliver event", "id", event.ID, "type", event.Type,
+				"attempts", event.Attempts, "err", err)
+			if err = s.repo.MarkEventFailed(ctx, event); err != nil {
+				return delivered, fmt.Errorf("dispatch events: %w", err)
+			}
+			continue
+		}
+		if err = s.repo.MarkEventPublished(ctx, event.ID, now); err != nil {
+			return delivered, fmt.Errorf("dispatch events: %w", err)
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+func (s *EventService) deliver(ctx context.Context, event *domain.Event) error {
+	var errs []error
+	for _, sink := range s.sinks {
+		if err := sink.Deliver(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// retryDelay returns the delay before the next delivery of an event that
+// failed attempts times.
+func retryDelay(attempts int) time.Duration {
+	delay := eventRetryDelay
+	for i := 1; i < attempts && delay < eventRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, eventRetryMaxDelay)
+}
+
+// RunDispatch dispatches events every interval until ctx is canceled.
+// Failures are logged and retried on the next tick.
+func (s *EventService) RunDispatch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// A full batch means more events may be due.
+		for {
+			delivered, err := s.Dispatch(ctx)
+			if err != nil {
+				slog.Error("Failed to dispatch events", "err", err)
+				break
+			}
+			if delivered < s.batchSize {
+				break
+			}
+		}
+	}
+}
+
+// Purge removes the events published longer than the retention ago and
+// returns how many it removed.
+func (s *EventService) Purge(ctx context.Context) (int, error) {
+	purged, err := s.repo.PurgePublishedEvents(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return 0, fmt.Errorf("purge events: %w", err)
+	}
+	return purged, nil
+}
+
+// RunPurge purges published events every interval until ctx is canceled.
+// Failures are logged and retried on the next tick.
+func (s *EventService) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		purged, err := s.Purge(ctx)
+		if err != nil {
+			slog.Error("Failed to purge events", "err", err)
+			continue
+		}
+		if purged > 0 {
+			slog.Info("Purged events", "events", purged)
+		}
+	}
+}
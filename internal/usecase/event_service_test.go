@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// noEvents publishes nothing.
+type noEvents struct{}
+
+func (noEvents) Publish(context.Context, string, uuid.UUID, any) error {
+	return nil
+}
+
+func TestPublishEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockEventRepo(ctrl)
+	service := NewEventService(repo, nil, 10, time.Hour)
+	movieID := uuid.New()
+
+	repo.EXPECT().AppendEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *domain.Event) error {
+		assert.NotEqual(t, uuid.Nil, event.ID)
+		assert.Equal(t, domain.EventMovieCreated, event.Type)
+		assert.Equal(t, movieID, event.EntityID)
+		assert.JSONEq(t, `{"ID":"`+movieID.String()+`","Title":"Heat"}`, string(event.Payload))
+		assert.Equal(t, "request-1", event.RequestID)
+		assert.Equal(t, event.CreatedAt, event.NextAttemptAt)
+		return nil
+	})
+
+	ctx := WithRequestID(context.Background(), "request-1")
+	payload := struct {
+		ID    uuid.UUID
+		Title string
+	}{ID: movieID, Title: "Heat"}
+	require.NoError(t, service.Publish(ctx, domain.EventMovieCreated, movieID, payload))
+}
+
+func TestDispatchEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockEventRepo(ctrl)
+	log := mock_repo.NewMockSink(ctrl)
+	webhook := mock_repo.NewMockSink(ctrl)
+	service := NewEventService(repo, []Sink{log, webhook}, 10, time.Hour)
+
+	delivered := &domain.Event{ID: uuid.New(), Type: domain.EventMovieCreated}
+	failed := &domain.Event{ID: uuid.New(), Type: domain.EventActorDeleted, Attempts: 2}
+	repo.EXPECT().ClaimPendingEvents(gomock.Any(), gomock.Any(), gomock.Any(), 10).DoAndReturn(
+		func(_ context.Context, now, until time.Time, _ int) ([]*domain.Event, error) {
+			assert.Equal(t, now.Add(eventClaimLease), until)
+			return []*domain.Event{delivered, failed}, nil
+		})
+	log.EXPECT().Deliver(gomock.Any(), delivered).Return(nil)
+	webhook.EXPECT().Deliver(gomock.Any(), delivered).Return(nil)
+	repo.EXPECT().MarkEventPublished(gomock.Any(), delivered.ID, gomock.Any()).Return(nil)
+
+	// A failed event is delivered to every sink again, as it is not known
+	// which sinks saw it.
+	log.EXPECT().Deliver(gomock.Any(), failed).Return(nil)
+	webhook.EXPECT().Deliver(gomock.Any(), failed).Return(errors.New("status 503"))
+	repo.EXPECT().MarkEventFailed(gomock.Any(), failed).DoAndReturn(func(_ context.Context, event *domain.Event) error {
+		assert.Equal(t, 3, event.Attempts)
+		assert.Equal(t, "status 503", event.LastError)
+		assert.WithinDuration(t, time.Now().Add(4*time.Second), event.NextAttemptAt, time.Second)
+		return nil
+	})
+
+	count, err := service.Dispatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Second, retryDelay(1))
+	assert.Equal(t, 2*time.Second, retryDelay(2))
+	assert.Equal(t, 8*time.Second, retryDelay(4))
+	assert.Equal(t, time.Hour, retryDelay(20))
+	assert.Equal(t, time.Hour, retryDelay(1000))
+}
+
+func TestDeleteCreditPublishesCastChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockCreditRepo(ctrl)
+	events := mock_repo.NewMockPublisher(ctrl)
+	service := NewCreditService(repo, directTx{}, noCache{}, events)
+	credit := &domain.Credit{ID: uuid.New(), MovieID: uuid.New(), PersonID: uuid.New(), Role: domain.RoleDirector}
+
+	repo.EXPECT().GetCredit(gomock.Any(), credit.ID).Return(credit, nil)
+	repo.EXPECT().DeleteCredit(gomock.Any(), credit.ID).Return(nil)
+	events.EXPECT().Publish(gomock.Any(), domain.EventCastChanged, credit.MovieID, castChange{Credit: credit, Removed: true}).Return(nil)
+	require.NoError(t, service.DeleteCredit(context.Background(), credit.ID))
+
+	repo.EXPECT().GetCredit(gomock.Any(), credit.ID).Return(nil, domain.ErrNotFound)
+	assert.ErrorIs(t, service.DeleteCredit(context.Background(), credit.ID), domain.ErrNotFound)
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"io"
+)
+
+//go:generate mockgen -source=export.go -destination=mocks/exportMock.go
+
+type ExportRepo interface {
+	ExportMovies(ctx context.Context, fn func(*domain.Movie) error) error
+	ExportActors(ctx context.Context, fn func(*domain.Actor) error) error
+	ExportCredits(ctx context.Context, fn func(*domain.Credit) error) error
+}
+
+type ExportService struct {
+	repo ExportRepo
+}
+
+func NewExportService(repo ExportRepo) *ExportService {
+	return &ExportService{repo: repo}
+}
+
+// Export streams every record of the given kind to w. The columns match
+// the ones accepted by ImportService.Import, so an export can be imported
+// again. Nothing is written when kind or format is invalid.
+func (s *ExportService) Export(ctx context.Context, kind string, format string, w io.Writer) error {
+	columns, ok := exportColumns[kind]
+	if !ok {
+		return fmt.Errorf("%w: unknown kind %q", domain.ErrInvalidExport, kind)
+	}
+	encoder, err := newRecordEncoder(format, w)
+	if err != nil {
+		return err
+	}
+
+	if err = encoder.begin(columns); err != nil {
+		return fmt.Errorf("export %s: %w", kind, err)
+	}
+	switch kind {
+	case domain.ImportMovies:
+		err = s.repo.ExportMovies(ctx, func(movie *domain.Movie) error {
+			return encoder.encode(newMovieRecord(movie))
+		})
+	case domain.ImportActors:
+		err = s.repo.ExportActors(ctx, func(actor *domain.Actor) error {
+			return encoder.encode(newActorRecord(actor))
+		})
+	case domain.ImportCredits:
+		err = s.repo.ExportCredits(ctx, func(credit *domain.Credit) error {
+			return encoder.encode(newCreditRecord(credit))
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("export %s: %w", kind, err)
+	}
+	if err = encoder.end(); err != nil {
+		return fmt.Errorf("export %s: %w", kind, err)
+	}
+	return nil
+}
+
+var exportColumns = map[string][]string{
+	domain.ImportMovies: {
+		"id", "title", "description", "release_date", "rating", "duration_minutes", "age_rating",
+		"countries", "original_language", "imdb_id", "tmdb_id",
+	},
+	domain.ImportActors:  {"id", "name", "surname", "sex", "birthdate"},
+	domain.ImportCredits: {"movie_id", "person_id", "role", "character", "billing_order"},
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"bytes"
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportMovies(t *testing.T) {
+	movieID := uuid.MustParse("4f6b3c1e-8d2a-4b7e-9f10-2a3b4c5d6e7f")
+	movie := &domain.Movie{
+		ID:          movieID,
+		Title:       "Brother, part one",
+		Description: "St. Petersburg",
+		Date:        time.Date(1997, 5, 17, 0, 0, 0, 0, time.UTC),
+		Rating:      8.1,
+		AgeRating:   "18+",
+		Countries:   []string{"RU", "US"},
+	}
+	unknownDate := &domain.Movie{
+		ID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		Title: "Untitled",
+		Date:  time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:   "CSV",
+			format: domain.FormatCSV,
+			expected: "id,title,description,release_date,rating,duration_minutes,age_rating,countries,original_language,imdb_id,tmdb_id\n" +
+				movieID.String() + `,"Brother, part one",St. Petersburg,1997-05-17,8.1,,18+,RU|US,,,` + "\n" +
+				"00000000-0000-0000-0000-000000000001,Untitled,,,0,,,,,,\n",
+		},
+		{
+			name:   "NDJSON",
+			format: domain.FormatNDJSON,
+			expected: `{"id":"` + movieID.String() + `","title":"Brother, part one","description":"St. Petersburg","release_date":"1997-05-17","rating":8.1,"age_rating":"18+","countries":["RU","US"]}` + "\n" +
+				`{"id":"00000000-0000-0000-0000-000000000001","title":"Untitled","description":"","rating":0,"countries":[]}` + "\n",
+		},
+		{
+			name:   "JSON",
+			format: domain.FormatJSON,
+			expected: `[{"id":"` + movieID.String() + `","title":"Brother, part one","description":"St. Petersburg","release_date":"1997-05-17","rating":8.1,"age_rating":"18+","countries":["RU","US"]},` + "\n" +
+				`{"id":"00000000-0000-0000-0000-000000000001","title":"Untitled","description":"","rating":0,"countries":[]}]` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockExportRepo(ctrl)
+			repo.EXPECT().ExportMovies(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, fn func(*domain.Movie) error) error {
+					for _, m := range []*domain.Movie{movie, unknownDate} {
+						if err := fn(m); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+
+			var out bytes.Buffer
+			service := NewExportService(repo)
+			err := service.Export(context.Background(), domain.ImportMovies, tc.format, &out)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out.String())
+
+			if tc.format == domain.FormatJSON {
+				return
+			}
+			report, err := NewImportService(nil, noCache{}, 0).Import(context.Background(), domain.ImportMovies, tc.format, &out, true)
+			require.NoError(t, err)
+			assert.Equal(t, 2, report.Imported)
+			assert.Empty(t, report.Errors)
+		})
+	}
+}
+
+func TestExportEmptyJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockExportRepo(ctrl)
+	repo.EXPECT().ExportCredits(gomock.Any(), gomock.Any()).Return(nil)
+
+	var out bytes.Buffer
+	err := NewExportService(repo).Export(context.Background(), domain.ImportCredits, domain.FormatJSON, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", out.String())
+}
+
+func TestExportErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockExportRepo(ctrl)
+	service := NewExportService(repo)
+
+	var out bytes.Buffer
+	err := service.Export(context.Background(), "genres", domain.FormatCSV, &out)
+	assert.ErrorIs(t, err, domain.ErrInvalidExport)
+	err = service.Export(context.Background(), domain.ImportActors, "xml", &out)
+	assert.ErrorIs(t, err, domain.ErrInvalidExport)
+	assert.Empty(t, out.String())
+
+	repo.EXPECT().ExportActors(gomock.Any(), gomock.Any()).Return(errors.New("repository error"))
+	err = service.Export(context.Background(), domain.ImportActors, domain.FormatNDJSON, &out)
+	assert.EqualError(t, err, "export actors: repository error")
+}
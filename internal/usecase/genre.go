@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=genre.go -destination=mocks/genreMock.go
+
+type GenreRepo interface {
+	CreateGenre(ctx context.Context, genre *domain.Genre) error
+	UpdateGenre(ctx context.Context, genre *domain.Genre) error
+	DeleteGenre(ctx context.Context, genreID uuid.UUID) error
+	// GetGenre returns a genre or domain.ErrNotFound.
+	GetGenre(ctx context.Context, genreID uuid.UUID) (*domain.Genre, error)
+	GetGenres(ctx context.Context) ([]*domain.Genre, error)
+}
+
+type GenreService struct {
+	repo  GenreRepo
+	cache Cache
+}
+
+func NewGenreService(repo GenreRepo, cache Cache) *GenreService {
+	return &GenreService{repo: repo, cache: cache}
+}
+
+func (s *GenreService) CreateGenre(ctx context.Context, genre *domain.Genre) error {
+	err := s.repo.CreateGenre(ctx, genre)
+	if err != nil {
+		return fmt.Errorf("create genre: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return nil
+}
+
+func (s *GenreService) UpdateGenre(ctx context.Context, genre *domain.Genre) error {
+	err := s.repo.UpdateGenre(ctx, genre)
+	if err != nil {
+		return fmt.Errorf("update genre: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return nil
+}
+
+func (s *GenreService) DeleteGenre(ctx context.Context, genreID uuid.UUID) error {
+	err := s.repo.DeleteGenre(ctx, genreID)
+	if err != nil {
+		return fmt.Errorf("delete genre: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return nil
+}
+
+func (s *GenreService) GetGenre(ctx context.Context, genreID uuid.UUID) (*domain.Genre, error) {
+	genre, err := s.repo.GetGenre(ctx, genreID)
+	if err != nil {
+		return nil, fmt.Errorf("get genre: %w", err)
+	}
+	return genre, nil
+}
+
+func (s *GenreService) GetGenres(ctx context.Context) ([]*domain.Genre, error) {
+	genres, err := s.repo.GetGenres(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get genres: %w", err)
+	}
+	return genres, nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateGenre(t *testing.T) {
+	testCases := []struct {
+		name    string
+		repoErr error
+		wantErr error
+	}{
+		{
+			name:    "Create genre successfully",
+			repoErr: nil,
+			wantErr: nil,
+		},
+		{
+			name:    "Duplicate name",
+			repoErr: domain.ErrAlreadyExists,
+			wantErr: domain.ErrAlreadyExists,
+		},
+		{
+			name:    "Repository error",
+			repoErr: errors.New("repository error"),
+			wantErr: errors.New("create genre: repository error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockGenreRepo(ctrl)
+			repo.EXPECT().CreateGenre(gomock.Any(), &domain.Genre{Name: "Drama"}).Return(tc.repoErr)
+
+			service := NewGenreService(repo, noCache{})
+			err := service.CreateGenre(context.Background(), &domain.Genre{Name: "Drama"})
+
+			switch {
+			case tc.wantErr == nil:
+				assert.NoError(t, err)
+			case errors.Is(tc.wantErr, domain.ErrAlreadyExists):
+				assert.ErrorIs(t, err, tc.wantErr)
+			default:
+				assert.EqualError(t, err, tc.wantErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=idempotency.go -destination=mocks/idempotencyMock.go
+
+type IdempotencyRepo interface {
+	// CreateIdempotencyKey stores a new key. It replaces an expired key of
+	// the same user and returns domain.ErrAlreadyExists if one has not
+	// expired at key.CreatedAt.
+	CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error
+	GetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) (*domain.IdempotencyKey, error)
+	// SaveIdempotencyResponse stores the status code, header and body of
+	// the response to the request of a key.
+	SaveIdempotencyResponse(ctx context.Context, key *domain.IdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) error
+	// PurgeIdempotencyKeys removes the keys that expired before
+	// expiredBefore and returns how many it removed.
+	PurgeIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (int, error)
+}
+
+// IdempotencyService remembers the responses to requests sent with an
+// Idempotency-Key header, so that retries do not repeat their effects.
+type IdempotencyService struct {
+	repo IdempotencyRepo
+	// ttl is how long a key and its response are kept.
+	ttl time.Duration
+}
+
+func NewIdempotencyService(repo IdempotencyRepo, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{repo: repo, ttl: ttl}
+}
+
+// Begin reserves key of a user for the request with fingerprint. It
+// returns nil if the request is new and must be handled, or the stored key
+// with its response if the request is a retry. It returns
+// domain.ErrIdempotencyKeyReused if the key was sent with another request
+// and domain.ErrRequestInProgress if the first request is still handled.
+func (s *IdempotencyService) Begin(ctx context.Context, userID uuid.UUID, key string, fingerprint string) (*domain.IdempotencyKey, error) {
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	err := s.repo.CreateIdempotencyKey(ctx, &domain.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   createdAt,
+		ExpiresAt:   createdAt.Add(s.ttl),
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, domain.ErrAlreadyExists) {
+		return nil, fmt.Errorf("begin idempotent request: %w", err)
+	}
+
+	stored, err := s.repo.GetIdempotencyKey(ctx, userID, key)
+	if err != nil {
+		return nil, fmt.Errorf("begin idempotent request: %w", err)
+	}
+	if stored.Fingerprint != fingerprint {
+		return nil, fmt.Errorf("begin idempotent request: %w", domain.ErrIdempotencyKeyReused)
+	}
+	if stored.StatusCode == 0 {
+		return nil, fmt.Errorf("begin idempotent request: %w", domain.ErrRequestInProgress)
+	}
+	return stored, nil
+}
+
+// Complete stores the response to the request of a key reserved by Begin.
+func (s *IdempotencyService) Complete(ctx context.Context, key *domain.IdempotencyKey) error {
+	if err := s.repo.SaveIdempotencyResponse(ctx, key); err != nil {
+		return fmt.Errorf("complete idempotent request: %w", err)
+	}
+	return nil
+}
+
+// Release drops a key reserved by Begin without a response, so that the
+// request can be retried.
+func (s *IdempotencyService) Release(ctx context.Context, userID uuid.UUID, key string) error {
+	if err := s.repo.DeleteIdempotencyKey(ctx, userID, key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Purge removes the expired keys.
+func (s *IdempotencyService) Purge(ctx context.Context) (int, error) {
+	purged, err := s.repo.PurgeIdempotencyKeys(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("purge idempotency keys: %w", err)
+	}
+	return purged, nil
+}
+
+// RunPurge purges expired keys every interval until ctx is canceled.
+// Failures are logged and retried on the next tick.
+func (s *IdempotencyService) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		purged, err := s.Purge(ctx)
+		if err != nil {
+			slog.Error("Failed to purge idempotency keys", "err", err)
+			continue
+		}
+		if purged > 0 {
+			slog.Info("Purged idempotency keys", "keys", purged)
+		}
+	}
+}
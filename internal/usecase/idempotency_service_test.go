@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotencyBegin(t *testing.T) {
+	userID := uuid.New()
+	conflict := fmt.Errorf("create idempotency key: %w", domain.ErrAlreadyExists)
+
+	testCases := []struct {
+		name         string
+		mockBehavior func(r *mock_repo.MockIdempotencyRepo)
+		expected     *domain.IdempotencyKey
+		err          error
+	}{
+		{
+			name: "New key",
+			mockBehavior: func(r *mock_repo.MockIdempotencyRepo) {
+				r.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, key *domain.IdempotencyKey) error {
+						assert.Equal(t, "abc", key.Fingerprint)
+						assert.Equal(t, time.Hour, key.ExpiresAt.Sub(key.CreatedAt))
+						return nil
+					})
+			},
+		},
+		{
+			name: "Retry",
+			mockBehavior: func(r *mock_repo.MockIdempotencyRepo) {
+				r.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(conflict)
+				r.EXPECT().GetIdempotencyKey(gomock.Any(), userID, "key").
+					Return(&domain.IdempotencyKey{Fingerprint: "abc", StatusCode: 201}, nil)
+			},
+			expected: &domain.IdempotencyKey{Fingerprint: "abc", StatusCode: 201},
+		},
+		{
+			name: "Different request",
+			mockBehavior: func(r *mock_repo.MockIdempotencyRepo) {
+				r.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(conflict)
+				r.EXPECT().GetIdempotencyKey(gomock.Any(), userID, "key").
+					Return(&domain.IdempotencyKey{Fingerprint: "def", StatusCode: 201}, nil)
+			},
+			err: domain.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "In progress",
+			mockBehavior: func(r *mock_repo.MockIdempotencyRepo) {
+				r.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(conflict)
+				r.EXPECT().GetIdempotencyKey(gomock.Any(), userID, "key").
+					Return(&domain.IdempotencyKey{Fingerprint: "abc"}, nil)
+			},
+			err: domain.ErrRequestInProgress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := mock_repo.NewMockIdempotencyRepo(ctrl)
+			tc.mockBehavior(repo)
+
+			stored, err := NewIdempotencyService(repo, time.Hour).Begin(context.Background(), userID, "key", "abc")
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, stored)
+		})
+	}
+}
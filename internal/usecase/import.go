@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=import.go -destination=mocks/importMock.go
+
+const (
+	defaultImportBatchSize = 1000
+	maxDescriptionLength   = 1000
+)
+
+// ImportRepo upserts batches of records. ImportMovies and ImportActors
+// leave records in the trash unchanged and return their IDs.
+type ImportRepo interface {
+	ImportMovies(ctx context.Context, movies []*domain.Movie) ([]uuid.UUID, error)
+	ImportActors(ctx context.Context, actors []*domain.Actor) ([]uuid.UUID, error)
+	ImportCredits(ctx context.Context, credits []*domain.Credit) error
+}
+
+type ImportService struct {
+	repo      ImportRepo
+	cache     Cache
+	batchSize int
+}
+
+func NewImportService(repo ImportRepo, cache Cache, batchSize int) *ImportService {
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+	return &ImportService{repo: repo, cache: cache, batchSize: batchSize}
+}
+
+// Import reads records of the given kind from r, validates them row by row
+// and upserts the valid ones in batches. Rejected rows are listed in the
+// report. A batch the database refuses is reported against each of its
+// rows, and rows of records in the trash are reported as well. On dry runs
+// the rows are only validated, so references to movies and actors are not
+// checked.
+//
+// Imports are bulk loads: they write no audit entries, versions or outbox
+// events. Ratings of existing movies are kept. The cached catalogue is
+// invalidated after each written batch.
+func (s *ImportService) Import(ctx context.Context, kind string, format string, r io.Reader, dryRun bool) (*domain.ImportReport, error) {
+	parse, flush, err := s.importer(kind)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := newRowReader(format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{Kind: kind, DryRun: dryRun}
+	seen := make(map[string]int)
+	var lines []int
+	var batch []any
+
+	write := func() {
+		if len(batch) == 0 {
+			return
+		}
+		written := len(batch)
+		if !dryRun {
+			skipped, err := flush(ctx, batch)
+			if err != nil {
+				for _, line := range lines {
+					report.Errors = append(report.Errors, domain.ImportRowError{Line: line, Message: err.Error()})
+				}
+				batch, lines = batch[:0], lines[:0]
+				return
+			}
+			invalidate(ctx, s.cache, cacheMovies, cacheActors)
+			for _, i := range skipped {
+				report.Errors = append(report.Errors, domain.ImportRowError{Line: lines[i], Message: errInTrash.Error()})
+			}
+			written -= len(skipped)
+		}
+		report.Imported += written
+		batch, lines = batch[:0], lines[:0]
+	}
+
+	for {
+		line, fields, err := rows.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var rowErr *rowError
+			if !errors.As(err, &rowErr) {
+				return nil, fmt.Errorf("import %s: %w", kind, err)
+			}
+			report.Rows++
+			report.Errors = append(report.Errors, domain.ImportRowError{Line: line, Message: rowErr.Error()})
+			continue
+		}
+		report.Rows++
+
+		record, key, err := parse(fields)
+		if err == nil {
+			if first, ok := seen[key]; ok {
+				err = fmt.Errorf("duplicate of line %d", first)
+			} else {
+				seen[key] = line
+			}
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, domain.ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+
+		batch = append(batch, record)
+		lines = append(lines, line)
+		if len(batch) >= s.batchSize {
+			write()
+		}
+	}
+	write()
+
+	return report, nil
+}
+
+// errInTrash is reported for rows of records in the trash, which imports
+// do not change.
+var errInTrash = errors.New("record is in the trash; restore it first")
+
+// flushFunc writes a batch and returns the positions of the records it
+// skipped.
+type (
+	parseFunc func(fields map[string]string) (record any, key string, err error)
+	flushFunc func(ctx context.Context, batch []any) (skipped []int, err error)
+)
+
+func (s *ImportService) importer(kind string) (parseFunc, flushFunc, error) {
+	switch kind {
+	case domain.ImportMovies:
+		return parseMovieRow, func(ctx context.Context, batch []any) ([]int, error) {
+			movies := batchOf[*domain.Movie](batch)
+			trashed, err := s.repo.ImportMovies(ctx, movies)
+			return positionsOf(movies, trashed, func(movie *domain.Movie) uuid.UUID { return movie.ID }), err
+		}, nil
+	case domain.ImportActors:
+		return parseActorRow, func(ctx context.Context, batch []any) ([]int, error) {
+			actors := batchOf[*domain.Actor](batch)
+			trashed, err := s.repo.ImportActors(ctx, actors)
+			return positionsOf(actors, trashed, func(actor *domain.Actor) uuid.UUID { return actor.ID }), err
+		}, nil
+	case domain.ImportCredits:
+		return parseCreditRow, func(ctx context.Context, batch []any) ([]int, error) {
+			return nil, s.repo.ImportCredits(ctx, batchOf[*domain.Credit](batch))
+		}, nil
+	}
+	return nil, nil, fmt.Errorf("%w: unknown kind %q", domain.ErrInvalidImport, kind)
+}
+
+func batchOf[T any](batch []any) []T {
+	result := make([]T, len(batch))
+	for i, record := range batch {
+		result[i] = record.(T)
+	}
+	return result
+}
+
+// positionsOf returns the positions of the records whose IDs are in ids.
+func positionsOf[T any](records []T, ids []uuid.UUID, id func(T) uuid.UUID) []int {
+	if len(ids) == 0 {
+		return nil
+	}
+	wanted := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		wanted[id] = struct{}{}
+	}
+	var positions []int
+	for i, record := range records {
+		if _, ok := wanted[id(record)]; ok {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+func parseMovieRow(fields map[string]string) (any, string, error) {
+	movie := &domain.Movie{
+		Title:            strings.TrimSpace(fields["title"]),
+		Description:      fields["description"],
+		AgeRating:        fields["age_rating"],
+		OriginalLanguage: fields["original_language"],
+		IMDbID:           fields["imdb_id"],
+	}
+	if movie.Title == "" || utf8.RuneCountInString(movie.Title) > domain.MaxTitleLength {
+		return nil, "", fmt.Errorf("title must be 1 to %d characters", domain.MaxTitleLength)
+	}
+	if utf8.RuneCountInString(movie.Description) > maxDescriptionLength {
+		return nil, "", fmt.Errorf("description must be at most %d characters", maxDescriptionLength)
+	}
+
+	var err error
+	if movie.ID, err = parseID(fields, "id", false); err != nil {
+		return nil, "", err
+	}
+	if movie.Date, err = parseDate(fields, "release_date"); err != nil {
+		return nil, "", err
+	}
+	if value := fields["rating"]; value != "" {
+		rating, err := strconv.ParseFloat(value, 32)
+		if err != nil || rating < 0 || rating > 10 {
+			return nil, "", errors.New("rating must be a number from 0 to 10")
+		}
+		movie.Rating = float32(rating)
+	}
+	if movie.DurationMinutes, err = parseInt(fields, "duration_minutes"); err != nil {
+		return nil, "", err
+	}
+	if movie.TMDBID, err = parseInt(fields, "tmdb_id"); err != nil {
+		return nil, "", err
+	}
+	if value := fields["countries"]; value != "" {
+		movie.Countries = strings.Split(value, listSeparator)
+	}
+	if err = movie.Validate(); err != nil {
+		return nil, "", err
+	}
+	return movie, movie.ID.String(), nil
+}
+
+func parseActorRow(fields map[string]string) (any, string, error) {
+	actor := &domain.Actor{
+		Name:    strings.TrimSpace(fields["name"]),
+		Surname: strings.TrimSpace(fields["surname"]),
+		Sex:     fields["sex"],
+	}
+	if actor.Name == "" {
+		return nil, "", errors.New("name is required")
+	}
+
+	var err error
+	if actor.ID, err = parseID(fields, "id", false); err != nil {
+		return nil, "", err
+	}
+	if actor.Birthdate, err = parseDate(fields, "birthdate"); err != nil {
+		return nil, "", err
+	}
+	return actor, actor.ID.String(), nil
+}
+
+func parseCreditRow(fields map[string]string) (any, string, error) {
+	credit := &domain.Credit{
+		ID:        uuid.New(),
+		Role:      strings.ToUpper(fields["role"]),
+		Character: fields["character"],
+	}
+	if credit.Role == "" {
+		credit.Role = domain.RoleActor
+	}
+	if !domain.IsRole(credit.Role) {
+		return nil, "", fmt.Errorf("unknown role %q", credit.Role)
+	}
+
+	var err error
+	if credit.MovieID, err = parseID(fields, "movie_id", true); err != nil {
+		return nil, "", err
+	}
+	if credit.PersonID, err = parseID(fields, "person_id", true); err != nil {
+		return nil, "", err
+	}
+	if credit.BillingOrder, err = parseInt(fields, "billing_order"); err != nil {
+		return nil, "", err
+	}
+	key := strings.Join([]string{
+		credit.MovieID.String(), credit.PersonID.String(), credit.Role, credit.Character,
+	}, "/")
+	return credit, key, nil
+}
+
+// parseID reads a UUID column. Optional IDs default to a new UUID.
+func parseID(fields map[string]string, column string, required bool) (uuid.UUID, error) {
+	value := fields[column]
+	if value == "" {
+		if required {
+			return uuid.Nil, fmt.Errorf("%s is required", column)
+		}
+		return uuid.New(), nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid %s", column)
+	}
+	return id, nil
+}
+
+func parseDate(fields map[string]string, column string) (time.Time, error) {
+	value := fields[column]
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(catalogueDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date in YYYY-MM-DD format", column)
+	}
+	return date, nil
+}
+
+func parseInt(fields map[string]string, column string) (int, error) {
+	value := fields[column]
+	if value == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer", column)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportMoviesCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieID := uuid.New()
+	input := "id,title,release_date,rating,countries,age_rating\n" +
+		movieID.String() + ",Brother,1997-05-17,8.1,RU|US,18+\n" +
+		",,1997-05-17,8,RU,18+\n" +
+		",Stalker,not a date,8,SU,12+\n" +
+		",Solaris,1972-03-20,11,,\n" +
+		",Mirror,1975-03-07,8,,0+,extra\n" +
+		movieID.String() + ",Brother 2,2000-05-11,7.5,RU,16+\n" +
+		",Kin-dza-dza!,1986-12-01,8,SU,12+\n"
+
+	repo := mock_repo.NewMockImportRepo(ctrl)
+	var imported []*domain.Movie
+	repo.EXPECT().ImportMovies(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, movies []*domain.Movie) ([]uuid.UUID, error) {
+			imported = append(imported, movies...)
+			return nil, nil
+		}).Times(2)
+
+	service := NewImportService(repo, noCache{}, 1)
+	report, err := service.Import(context.Background(), domain.ImportMovies, domain.FormatCSV, strings.NewReader(input), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, report.Rows)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, []domain.ImportRowError{
+		{Line: 3, Message: "title must be 1 to 150 characters"},
+		{Line: 4, Message: "release_date must be a date in YYYY-MM-DD format"},
+		{Line: 5, Message: "rating must be a number from 0 to 10"},
+		{Line: 6, Message: "expected 6 columns, got 7"},
+		{Line: 7, Message: "duplicate of line 2"},
+	}, report.Errors)
+
+	require.Len(t, imported, 2)
+	assert.Equal(t, movieID, imported[0].ID)
+	assert.Equal(t, "Brother", imported[0].Title)
+	assert.Equal(t, time.Date(1997, 5, 17, 0, 0, 0, 0, time.UTC), imported[0].Date)
+	assert.Equal(t, float32(8.1), imported[0].Rating)
+	assert.Equal(t, []string{"RU", "US"}, imported[0].Countries)
+	assert.NotEqual(t, uuid.Nil, imported[1].ID)
+}
+
+func TestImportMalformedCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockImportRepo(ctrl)
+	service := NewImportService(repo, noCache{}, 10)
+	input := "title,description\n\"Heat\"x,desc\nRonin,desc\n"
+
+	report, err := service.Import(context.Background(), domain.ImportMovies, domain.FormatCSV, strings.NewReader(input), true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Rows)
+	assert.Equal(t, 1, report.Imported)
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, 2, report.Errors[0].Line)
+	assert.Contains(t, report.Errors[0].Message, "quote")
+}
+
+func TestImportCreditsNDJSONDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieID, personID := uuid.New(), uuid.New()
+	input := `{"movie_id":"` + movieID.String() + `","person_id":"` + personID.String() + `","character":"Danila","billing_order":1}` + "\n" +
+		"\n" +
+		`{"movie_id":"` + movieID.String() + `","person_id":"` + personID.String() + `","role":"director"}` + "\n" +
+		`{"movie_id":"` + movieID.String() + `","role":"composer"}` + "\n" +
+		`{"movie_id":"` + movieID.String() + `","person_id":"` + personID.String() + `","billing_order":-1}` + "\n" +
+		`not json` + "\n"
+
+	repo := mock_repo.NewMockImportRepo(ctrl)
+
+	service := NewImportService(repo, noCache{}, 0)
+	report, err := service.Import(context.Background(), domain.ImportCredits, domain.FormatNDJSON, strings.NewReader(input), true)
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 5, report.Rows)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, []domain.ImportRowError{
+		{Line: 4, Message: `unknown role "COMPOSER"`},
+		{Line: 5, Message: "billing_order must be a non-negative integer"},
+		{Line: 6, Message: "invalid JSON object"},
+	}, report.Errors)
+}
+
+func TestImportBatchFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	input := "name,surname,birthdate\nSergei,Bodrov,1971-12-27\nViktor,Sukhorukov,1951-11-10\n"
+
+	repo := mock_repo.NewMockImportRepo(ctrl)
+	repo.EXPECT().ImportActors(gomock.Any(), gomock.Len(2)).Return(nil, errors.New("import actors: connection reset"))
+
+	service := NewImportService(repo, noCache{}, 10)
+	report, err := service.Import(context.Background(), domain.ImportActors, domain.FormatCSV, strings.NewReader(input), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, report.Imported)
+	assert.Equal(t, []domain.ImportRowError{
+		{Line: 2, Message: "import actors: connection reset"},
+		{Line: 3, Message: "import actors: connection reset"},
+	}, report.Errors)
+}
+
+func TestImportTrashedRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trashedID := uuid.New()
+	input := "id,name\n" + uuid.New().String() + ",Sergei\n" + trashedID.String() + ",Viktor\n"
+
+	repo := mock_repo.NewMockImportRepo(ctrl)
+	repo.EXPECT().ImportActors(gomock.Any(), gomock.Len(2)).Return([]uuid.UUID{trashedID}, nil)
+
+	service := NewImportService(repo, noCache{}, 10)
+	report, err := service.Import(context.Background(), domain.ImportActors, domain.FormatCSV, strings.NewReader(input), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, []domain.ImportRowError{
+		{Line: 3, Message: "record is in the trash; restore it first"},
+	}, report.Errors)
+}
+
+func TestImportInvalidRequest(t *testing.T) {
+	service := NewImportService(nil, noCache{}, 0)
+
+	_, err := service.Import(context.Background(), "genres", domain.FormatCSV, strings.NewReader("name\n"), false)
+	assert.ErrorIs(t, err, domain.ErrInvalidImport)
+
+	_, err = service.Import(context.Background(), domain.ImportMovies, "xml", strings.NewReader(""), false)
+	assert.ErrorIs(t, err, domain.ErrInvalidImport)
+
+	_, err = service.Import(context.Background(), domain.ImportMovies, domain.FormatCSV, strings.NewReader(""), false)
+	assert.ErrorIs(t, err, domain.ErrInvalidImport)
+}
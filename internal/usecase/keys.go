@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+//go:generate mockgen -source=keys.go -destination=mocks/keysMock.go
+
+const (
+	signingKeySize = 32
+	// keyRefreshInterval bounds how long an instance keeps signing with a
+	// rotated key.
+	keyRefreshInterval = time.Minute
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// legacyKey signs tokens until the first rotation stores a key in the
+// database.
+var legacyKey = &domain.SigningKey{Secret: []byte(signingKey)}
+
+type SigningKeyRepo interface {
+	GetSigningKeys(ctx context.Context, retiredAfter time.Time) ([]*domain.SigningKey, error)
+	RotateSigningKey(ctx context.Context, key *domain.SigningKey, pruneBefore time.Time) error
+}
+
+// KeyService caches the signing keys and reloads them every
+// keyRefreshInterval, or sooner when a token names a key it does not know.
+type KeyService struct {
+	repo SigningKeyRepo
+
+	mu       sync.Mutex
+	keys     []*domain.SigningKey
+	loadedAt time.Time
+}
+
+func NewKeyService(repo SigningKeyRepo) *KeyService {
+	return &KeyService{repo: repo}
+}
+
+// Rotate makes a new random key current. Retired keys keep verifying
+// tokens for tokenTTL, after which they are deleted.
+func (s *KeyService) Rotate(ctx context.Context) (*domain.SigningKey, error) {
+	id := make([]byte, 8)
+	secret := make([]byte, signingKeySize)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("rotate signing key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("rotate signing key: %w", err)
+	}
+
+	key := &domain.SigningKey{ID: hex.EncodeToString(id), Secret: secret}
+	if err := s.repo.RotateSigningKey(ctx, key, time.Now().Add(-tokenTTL)); err != nil {
+		return nil, fmt.Errorf("rotate signing key: %w", err)
+	}
+
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+	return key, nil
+}
+
+// SigningKey returns the key new tokens are signed with.
+func (s *KeyService) SigningKey(ctx context.Context) (*domain.SigningKey, error) {
+	keys, err := s.load(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("get signing key: %w", err)
+	}
+	for _, key := range keys {
+		if key.RetiredAt.IsZero() {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("get signing key: %w", ErrUnknownKey)
+}
+
+// VerificationKey returns the key with the given ID if tokens signed with
+// it are still accepted.
+func (s *KeyService) VerificationKey(ctx context.Context, id string) (*domain.SigningKey, error) {
+	for _, force := range []bool{false, true} {
+		keys, err := s.load(ctx, force)
+		if err != nil {
+			return nil, fmt.Errorf("get verification key: %w", err)
+		}
+		for _, key := range keys {
+			if key.ID == id {
+				return key, nil
+			}
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+func (s *KeyService) load(ctx context.Context, force bool) ([]*domain.SigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Forced reloads are still rate limited, so tokens with made up key IDs
+	// cannot turn every request into a query.
+	stale := time.Since(s.loadedAt) > keyRefreshInterval
+	if force {
+		stale = time.Since(s.loadedAt) > time.Second
+	}
+	if !stale {
+		return s.keys, nil
+	}
+
+	keys, err := s.repo.GetSigningKeys(ctx, time.Now().Add(-tokenTTL))
+	if err != nil {
+		if s.keys != nil {
+			slog.Error("Failed to reload signing keys", "err", err)
+			return s.keys, nil
+		}
+		return nil, err
+	}
+	if len(keys) == 0 {
+		keys = []*domain.SigningKey{legacyKey}
+	}
+	s.keys, s.loadedAt = keys, time.Now()
+	return keys, nil
+}
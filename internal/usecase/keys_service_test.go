@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTokenSurvivesKeyRotation(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	keyRepo := mock_repo.NewMockSigningKeyRepo(c)
+	userRepo := mock_repo.NewMockUserRepo(c)
+	keys := NewKeyService(keyRepo)
+	service := NewUserService(userRepo, keys, directTx{}, noAudit{})
+
+	user := &domain.User{ID: uuid.New(), Role: domain.ADMIN}
+	userRepo.EXPECT().GetUser(gomock.Any(), "admin", "secret").Return(user, nil).Times(2)
+
+	var stored []*domain.SigningKey
+	keyRepo.EXPECT().RotateSigningKey(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, key *domain.SigningKey, _ time.Time) error {
+			for _, old := range stored {
+				if old.RetiredAt.IsZero() {
+					old.RetiredAt = time.Now()
+				}
+			}
+			stored = append([]*domain.SigningKey{key}, stored...)
+			return nil
+		}).Times(2)
+	keyRepo.EXPECT().GetSigningKeys(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, time.Time) ([]*domain.SigningKey, error) {
+			return stored, nil
+		}).AnyTimes()
+
+	first, err := keys.Rotate(context.Background())
+	require.NoError(t, err)
+	oldToken, err := service.GenerateToken(context.Background(), "admin", "secret")
+	require.NoError(t, err)
+
+	second, err := keys.Rotate(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID)
+	newToken, err := service.GenerateToken(context.Background(), "admin", "secret")
+	require.NoError(t, err)
+
+	for _, token := range []string{oldToken, newToken} {
+		info, err := service.ParseToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, info.UserID)
+	}
+
+	// Once the old key is pruned its tokens are rejected.
+	stored = stored[:1]
+	keys.loadedAt = time.Time{}
+	_, err = service.ParseToken(oldToken)
+	assert.Error(t, err)
+}
+
+func TestLegacyKeyBeforeFirstRotation(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	keyRepo := mock_repo.NewMockSigningKeyRepo(c)
+	keyRepo.EXPECT().GetSigningKeys(gomock.Any(), gomock.Any()).Return(nil, nil)
+	keys := NewKeyService(keyRepo)
+
+	key, err := keys.SigningKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, legacyKey, key)
+
+	key, err = keys.VerificationKey(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, legacyKey, key)
+}
@@ -69,6 +69,36 @@ func (mr *MockActorsRepoMockRecorder) DeleteActor(ctx, actorID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockActorsRepo)(nil).DeleteActor), ctx, actorID)
 }
 
+// GetActorByID mocks base method.
+func (m *MockActorsRepo) GetActorByID(ctx context.Context, actorID uuid.UUID) (*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorByID", ctx, actorID)
+	ret0, _ := ret[0].(*domain.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorByID indicates an expected call of GetActorByID.
+func (mr *MockActorsRepoMockRecorder) GetActorByID(ctx, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorByID", reflect.TypeOf((*MockActorsRepo)(nil).GetActorByID), ctx, actorID)
+}
+
+// GetActorTranslations mocks base method.
+func (m *MockActorsRepo) GetActorTranslations(ctx context.Context, actorIDs []uuid.UUID, locales []string) ([]*domain.ActorTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorTranslations", ctx, actorIDs, locales)
+	ret0, _ := ret[0].([]*domain.ActorTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorTranslations indicates an expected call of GetActorTranslations.
+func (mr *MockActorsRepoMockRecorder) GetActorTranslations(ctx, actorIDs, locales any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorTranslations", reflect.TypeOf((*MockActorsRepo)(nil).GetActorTranslations), ctx, actorIDs, locales)
+}
+
 // GetActors mocks base method.
 func (m *MockActorsRepo) GetActors(ctx context.Context) (map[*domain.Actor][]*domain.Movie, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +114,21 @@ func (mr *MockActorsRepoMockRecorder) GetActors(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActors", reflect.TypeOf((*MockActorsRepo)(nil).GetActors), ctx)
 }
 
+// GetMovieTranslations mocks base method.
+func (m *MockActorsRepo) GetMovieTranslations(ctx context.Context, movieIDs []uuid.UUID, locales []string) ([]*domain.MovieTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieTranslations", ctx, movieIDs, locales)
+	ret0, _ := ret[0].([]*domain.MovieTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieTranslations indicates an expected call of GetMovieTranslations.
+func (mr *MockActorsRepoMockRecorder) GetMovieTranslations(ctx, movieIDs, locales any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieTranslations", reflect.TypeOf((*MockActorsRepo)(nil).GetMovieTranslations), ctx, movieIDs, locales)
+}
+
 // UpdateActor mocks base method.
 func (m *MockActorsRepo) UpdateActor(ctx context.Context, act *domain.Actor) error {
 	m.ctrl.T.Helper()
@@ -97,3 +142,17 @@ func (mr *MockActorsRepoMockRecorder) UpdateActor(ctx, act any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockActorsRepo)(nil).UpdateActor), ctx, act)
 }
+
+// UpdateActorFields mocks base method.
+func (m *MockActorsRepo) UpdateActorFields(ctx context.Context, act *domain.Actor, fields []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActorFields", ctx, act, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateActorFields indicates an expected call of UpdateActorFields.
+func (mr *MockActorsRepoMockRecorder) UpdateActorFields(ctx, act, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorFields", reflect.TypeOf((*MockActorsRepo)(nil).UpdateActorFields), ctx, act, fields)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: credit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=credit.go -destination=mocks/creditMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCreditRepo is a mock of CreditRepo interface.
+type MockCreditRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditRepoMockRecorder
+}
+
+// MockCreditRepoMockRecorder is the mock recorder for MockCreditRepo.
+type MockCreditRepoMockRecorder struct {
+	mock *MockCreditRepo
+}
+
+// NewMockCreditRepo creates a new mock instance.
+func NewMockCreditRepo(ctrl *gomock.Controller) *MockCreditRepo {
+	mock := &MockCreditRepo{ctrl: ctrl}
+	mock.recorder = &MockCreditRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreditRepo) EXPECT() *MockCreditRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateCredit mocks base method.
+func (m *MockCreditRepo) CreateCredit(ctx context.Context, credit *domain.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredit", ctx, credit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCredit indicates an expected call of CreateCredit.
+func (mr *MockCreditRepoMockRecorder) CreateCredit(ctx, credit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredit", reflect.TypeOf((*MockCreditRepo)(nil).CreateCredit), ctx, credit)
+}
+
+// DeleteCredit mocks base method.
+func (m *MockCreditRepo) DeleteCredit(ctx context.Context, creditID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCredit", ctx, creditID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCredit indicates an expected call of DeleteCredit.
+func (mr *MockCreditRepoMockRecorder) DeleteCredit(ctx, creditID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCredit", reflect.TypeOf((*MockCreditRepo)(nil).DeleteCredit), ctx, creditID)
+}
+
+// GetCredit mocks base method.
+func (m *MockCreditRepo) GetCredit(ctx context.Context, creditID uuid.UUID) (*domain.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredit", ctx, creditID)
+	ret0, _ := ret[0].(*domain.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredit indicates an expected call of GetCredit.
+func (mr *MockCreditRepoMockRecorder) GetCredit(ctx, creditID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredit", reflect.TypeOf((*MockCreditRepo)(nil).GetCredit), ctx, creditID)
+}
+
+// GetMovieCredits mocks base method.
+func (m *MockCreditRepo) GetMovieCredits(ctx context.Context, movieID uuid.UUID) ([]*domain.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieCredits", ctx, movieID)
+	ret0, _ := ret[0].([]*domain.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieCredits indicates an expected call of GetMovieCredits.
+func (mr *MockCreditRepoMockRecorder) GetMovieCredits(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieCredits", reflect.TypeOf((*MockCreditRepo)(nil).GetMovieCredits), ctx, movieID)
+}
+
+// GetPersonCredits mocks base method.
+func (m *MockCreditRepo) GetPersonCredits(ctx context.Context, personID uuid.UUID) ([]*domain.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersonCredits", ctx, personID)
+	ret0, _ := ret[0].([]*domain.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPersonCredits indicates an expected call of GetPersonCredits.
+func (mr *MockCreditRepoMockRecorder) GetPersonCredits(ctx, personID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersonCredits", reflect.TypeOf((*MockCreditRepo)(nil).GetPersonCredits), ctx, personID)
+}
@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=event.go -destination=mocks/eventMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventRepo is a mock of EventRepo interface.
+type MockEventRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRepoMockRecorder
+}
+
+// MockEventRepoMockRecorder is the mock recorder for MockEventRepo.
+type MockEventRepoMockRecorder struct {
+	mock *MockEventRepo
+}
+
+// NewMockEventRepo creates a new mock instance.
+func NewMockEventRepo(ctrl *gomock.Controller) *MockEventRepo {
+	mock := &MockEventRepo{ctrl: ctrl}
+	mock.recorder = &MockEventRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRepo) EXPECT() *MockEventRepoMockRecorder {
+	return m.recorder
+}
+
+// AppendEvent mocks base method.
+func (m *MockEventRepo) AppendEvent(ctx context.Context, event *domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendEvent indicates an expected call of AppendEvent.
+func (mr *MockEventRepoMockRecorder) AppendEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEvent", reflect.TypeOf((*MockEventRepo)(nil).AppendEvent), ctx, event)
+}
+
+// ClaimPendingEvents mocks base method.
+func (m *MockEventRepo) ClaimPendingEvents(ctx context.Context, now, until time.Time, limit int) ([]*domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingEvents", ctx, now, until, limit)
+	ret0, _ := ret[0].([]*domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingEvents indicates an expected call of ClaimPendingEvents.
+func (mr *MockEventRepoMockRecorder) ClaimPendingEvents(ctx, now, until, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingEvents", reflect.TypeOf((*MockEventRepo)(nil).ClaimPendingEvents), ctx, now, until, limit)
+}
+
+// MarkEventFailed mocks base method.
+func (m *MockEventRepo) MarkEventFailed(ctx context.Context, event *domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventFailed", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventFailed indicates an expected call of MarkEventFailed.
+func (mr *MockEventRepoMockRecorder) MarkEventFailed(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventFailed", reflect.TypeOf((*MockEventRepo)(nil).MarkEventFailed), ctx, event)
+}
+
+// MarkEventPublished mocks base method.
+func (m *MockEventRepo) MarkEventPublished(ctx context.Context, eventID uuid.UUID, publishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventPublished", ctx, eventID, publishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventPublished indicates an expected call of MarkEventPublished.
+func (mr *MockEventRepoMockRecorder) MarkEventPublished(ctx, eventID, publishedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventPublished", reflect.TypeOf((*MockEventRepo)(nil).MarkEventPublished), ctx, eventID, publishedAt)
+}
+
+// PurgePublishedEvents mocks base method.
+func (m *MockEventRepo) PurgePublishedEvents(ctx context.Context, publishedBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePublishedEvents", ctx, publishedBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgePublishedEvents indicates an expected call of PurgePublishedEvents.
+func (mr *MockEventRepoMockRecorder) PurgePublishedEvents(ctx, publishedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePublishedEvents", reflect.TypeOf((*MockEventRepo)(nil).PurgePublishedEvents), ctx, publishedBefore)
+}
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisher) Publish(ctx context.Context, eventType string, entityID uuid.UUID, payload any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, eventType, entityID, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(ctx, eventType, entityID, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), ctx, eventType, entityID, payload)
+}
+
+// MockSink is a mock of Sink interface.
+type MockSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockSinkMockRecorder
+}
+
+// MockSinkMockRecorder is the mock recorder for MockSink.
+type MockSinkMockRecorder struct {
+	mock *MockSink
+}
+
+// NewMockSink creates a new mock instance.
+func NewMockSink(ctrl *gomock.Controller) *MockSink {
+	mock := &MockSink{ctrl: ctrl}
+	mock.recorder = &MockSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSink) EXPECT() *MockSinkMockRecorder {
+	return m.recorder
+}
+
+// Deliver mocks base method.
+func (m *MockSink) Deliver(ctx context.Context, event *domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockSinkMockRecorder) Deliver(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockSink)(nil).Deliver), ctx, event)
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: export.go
+//
+// Generated by this command:
+//
+//	mockgen -source=export.go -destination=mocks/exportMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExportRepo is a mock of ExportRepo interface.
+type MockExportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportRepoMockRecorder
+}
+
+// MockExportRepoMockRecorder is the mock recorder for MockExportRepo.
+type MockExportRepoMockRecorder struct {
+	mock *MockExportRepo
+}
+
+// NewMockExportRepo creates a new mock instance.
+func NewMockExportRepo(ctrl *gomock.Controller) *MockExportRepo {
+	mock := &MockExportRepo{ctrl: ctrl}
+	mock.recorder = &MockExportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportRepo) EXPECT() *MockExportRepoMockRecorder {
+	return m.recorder
+}
+
+// ExportActors mocks base method.
+func (m *MockExportRepo) ExportActors(ctx context.Context, fn func(*domain.Actor) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportActors", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportActors indicates an expected call of ExportActors.
+func (mr *MockExportRepoMockRecorder) ExportActors(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportActors", reflect.TypeOf((*MockExportRepo)(nil).ExportActors), ctx, fn)
+}
+
+// ExportCredits mocks base method.
+func (m *MockExportRepo) ExportCredits(ctx context.Context, fn func(*domain.Credit) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCredits", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportCredits indicates an expected call of ExportCredits.
+func (mr *MockExportRepoMockRecorder) ExportCredits(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCredits", reflect.TypeOf((*MockExportRepo)(nil).ExportCredits), ctx, fn)
+}
+
+// ExportMovies mocks base method.
+func (m *MockExportRepo) ExportMovies(ctx context.Context, fn func(*domain.Movie) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMovies", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportMovies indicates an expected call of ExportMovies.
+func (mr *MockExportRepoMockRecorder) ExportMovies(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMovies", reflect.TypeOf((*MockExportRepo)(nil).ExportMovies), ctx, fn)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: genre.go
+//
+// Generated by this command:
+//
+//	mockgen -source=genre.go -destination=mocks/genreMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGenreRepo is a mock of GenreRepo interface.
+type MockGenreRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreRepoMockRecorder
+}
+
+// MockGenreRepoMockRecorder is the mock recorder for MockGenreRepo.
+type MockGenreRepoMockRecorder struct {
+	mock *MockGenreRepo
+}
+
+// NewMockGenreRepo creates a new mock instance.
+func NewMockGenreRepo(ctrl *gomock.Controller) *MockGenreRepo {
+	mock := &MockGenreRepo{ctrl: ctrl}
+	mock.recorder = &MockGenreRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreRepo) EXPECT() *MockGenreRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateGenre mocks base method.
+func (m *MockGenreRepo) CreateGenre(ctx context.Context, genre *domain.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockGenreRepoMockRecorder) CreateGenre(ctx, genre any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockGenreRepo)(nil).CreateGenre), ctx, genre)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockGenreRepo) DeleteGenre(ctx context.Context, genreID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, genreID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockGenreRepoMockRecorder) DeleteGenre(ctx, genreID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockGenreRepo)(nil).DeleteGenre), ctx, genreID)
+}
+
+// GetGenre mocks base method.
+func (m *MockGenreRepo) GetGenre(ctx context.Context, genreID uuid.UUID) (*domain.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenre", ctx, genreID)
+	ret0, _ := ret[0].(*domain.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenre indicates an expected call of GetGenre.
+func (mr *MockGenreRepoMockRecorder) GetGenre(ctx, genreID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenre", reflect.TypeOf((*MockGenreRepo)(nil).GetGenre), ctx, genreID)
+}
+
+// GetGenres mocks base method.
+func (m *MockGenreRepo) GetGenres(ctx context.Context) ([]*domain.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenres", ctx)
+	ret0, _ := ret[0].([]*domain.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenres indicates an expected call of GetGenres.
+func (mr *MockGenreRepoMockRecorder) GetGenres(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockGenreRepo)(nil).GetGenres), ctx)
+}
+
+// UpdateGenre mocks base method.
+func (m *MockGenreRepo) UpdateGenre(ctx context.Context, genre *domain.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGenre", ctx, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGenre indicates an expected call of UpdateGenre.
+func (mr *MockGenreRepoMockRecorder) UpdateGenre(ctx, genre any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockGenreRepo)(nil).UpdateGenre), ctx, genre)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: idempotency.go
+//
+// Generated by this command:
+//
+//	mockgen -source=idempotency.go -destination=mocks/idempotencyMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdempotencyRepo is a mock of IdempotencyRepo interface.
+type MockIdempotencyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepoMockRecorder
+}
+
+// MockIdempotencyRepoMockRecorder is the mock recorder for MockIdempotencyRepo.
+type MockIdempotencyRepoMockRecorder struct {
+	mock *MockIdempotencyRepo
+}
+
+// NewMockIdempotencyRepo creates a new mock instance.
+func NewMockIdempotencyRepo(ctrl *gomock.Controller) *MockIdempotencyRepo {
+	mock := &MockIdempotencyRepo{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepo) EXPECT() *MockIdempotencyRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateIdempotencyKey mocks base method.
+func (m *MockIdempotencyRepo) CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdempotencyKey", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdempotencyKey indicates an expected call of CreateIdempotencyKey.
+func (mr *MockIdempotencyRepoMockRecorder) CreateIdempotencyKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyKey", reflect.TypeOf((*MockIdempotencyRepo)(nil).CreateIdempotencyKey), ctx, key)
+}
+
+// DeleteIdempotencyKey mocks base method.
+func (m *MockIdempotencyRepo) DeleteIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdempotencyKey", ctx, userID, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdempotencyKey indicates an expected call of DeleteIdempotencyKey.
+func (mr *MockIdempotencyRepoMockRecorder) DeleteIdempotencyKey(ctx, userID, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdempotencyKey", reflect.TypeOf((*MockIdempotencyRepo)(nil).DeleteIdempotencyKey), ctx, userID, key)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockIdempotencyRepo) GetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) (*domain.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, userID, key)
+	ret0, _ := ret[0].(*domain.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockIdempotencyRepoMockRecorder) GetIdempotencyKey(ctx, userID, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockIdempotencyRepo)(nil).GetIdempotencyKey), ctx, userID, key)
+}
+
+// PurgeIdempotencyKeys mocks base method.
+func (m *MockIdempotencyRepo) PurgeIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeIdempotencyKeys", ctx, expiredBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeIdempotencyKeys indicates an expected call of PurgeIdempotencyKeys.
+func (mr *MockIdempotencyRepoMockRecorder) PurgeIdempotencyKeys(ctx, expiredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeIdempotencyKeys", reflect.TypeOf((*MockIdempotencyRepo)(nil).PurgeIdempotencyKeys), ctx, expiredBefore)
+}
+
+// SaveIdempotencyResponse mocks base method.
+func (m *MockIdempotencyRepo) SaveIdempotencyResponse(ctx context.Context, key *domain.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotencyResponse", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIdempotencyResponse indicates an expected call of SaveIdempotencyResponse.
+func (mr *MockIdempotencyRepoMockRecorder) SaveIdempotencyResponse(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotencyResponse", reflect.TypeOf((*MockIdempotencyRepo)(nil).SaveIdempotencyResponse), ctx, key)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: import.go
+//
+// Generated by this command:
+//
+//	mockgen -source=import.go -destination=mocks/importMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportRepo is a mock of ImportRepo interface.
+type MockImportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepoMockRecorder
+}
+
+// MockImportRepoMockRecorder is the mock recorder for MockImportRepo.
+type MockImportRepoMockRecorder struct {
+	mock *MockImportRepo
+}
+
+// NewMockImportRepo creates a new mock instance.
+func NewMockImportRepo(ctrl *gomock.Controller) *MockImportRepo {
+	mock := &MockImportRepo{ctrl: ctrl}
+	mock.recorder = &MockImportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepo) EXPECT() *MockImportRepoMockRecorder {
+	return m.recorder
+}
+
+// ImportActors mocks base method.
+func (m *MockImportRepo) ImportActors(ctx context.Context, actors []*domain.Actor) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportActors", ctx, actors)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportActors indicates an expected call of ImportActors.
+func (mr *MockImportRepoMockRecorder) ImportActors(ctx, actors any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportActors", reflect.TypeOf((*MockImportRepo)(nil).ImportActors), ctx, actors)
+}
+
+// ImportCredits mocks base method.
+func (m *MockImportRepo) ImportCredits(ctx context.Context, credits []*domain.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCredits", ctx, credits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCredits indicates an expected call of ImportCredits.
+func (mr *MockImportRepoMockRecorder) ImportCredits(ctx, credits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCredits", reflect.TypeOf((*MockImportRepo)(nil).ImportCredits), ctx, credits)
+}
+
+// ImportMovies mocks base method.
+func (m *MockImportRepo) ImportMovies(ctx context.Context, movies []*domain.Movie) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportMovies", ctx, movies)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportMovies indicates an expected call of ImportMovies.
+func (mr *MockImportRepoMockRecorder) ImportMovies(ctx, movies any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportMovies", reflect.TypeOf((*MockImportRepo)(nil).ImportMovies), ctx, movies)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: keys.go
+//
+// Generated by this command:
+//
+//	mockgen -source=keys.go -destination=mocks/keysMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSigningKeyRepo is a mock of SigningKeyRepo interface.
+type MockSigningKeyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeyRepoMockRecorder
+}
+
+// MockSigningKeyRepoMockRecorder is the mock recorder for MockSigningKeyRepo.
+type MockSigningKeyRepoMockRecorder struct {
+	mock *MockSigningKeyRepo
+}
+
+// NewMockSigningKeyRepo creates a new mock instance.
+func NewMockSigningKeyRepo(ctrl *gomock.Controller) *MockSigningKeyRepo {
+	mock := &MockSigningKeyRepo{ctrl: ctrl}
+	mock.recorder = &MockSigningKeyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeyRepo) EXPECT() *MockSigningKeyRepoMockRecorder {
+	return m.recorder
+}
+
+// GetSigningKeys mocks base method.
+func (m *MockSigningKeyRepo) GetSigningKeys(ctx context.Context, retiredAfter time.Time) ([]*domain.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSigningKeys", ctx, retiredAfter)
+	ret0, _ := ret[0].([]*domain.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSigningKeys indicates an expected call of GetSigningKeys.
+func (mr *MockSigningKeyRepoMockRecorder) GetSigningKeys(ctx, retiredAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSigningKeys", reflect.TypeOf((*MockSigningKeyRepo)(nil).GetSigningKeys), ctx, retiredAfter)
+}
+
+// RotateSigningKey mocks base method.
+func (m *MockSigningKeyRepo) RotateSigningKey(ctx context.Context, key *domain.SigningKey, pruneBefore time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSigningKey", ctx, key, pruneBefore)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateSigningKey indicates an expected call of RotateSigningKey.
+func (mr *MockSigningKeyRepoMockRecorder) RotateSigningKey(ctx, key, pruneBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSigningKey", reflect.TypeOf((*MockSigningKeyRepo)(nil).RotateSigningKey), ctx, key, pruneBefore)
+}
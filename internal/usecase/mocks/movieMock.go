@@ -69,6 +69,36 @@ func (mr *MockMovieRepoMockRecorder) DeleteMovie(ctx, movieID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieRepo)(nil).DeleteMovie), ctx, movieID)
 }
 
+// GetMovieByID mocks base method.
+func (m *MockMovieRepo) GetMovieByID(ctx context.Context, movieID uuid.UUID) (*domain.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieByID", ctx, movieID)
+	ret0, _ := ret[0].(*domain.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieByID indicates an expected call of GetMovieByID.
+func (mr *MockMovieRepoMockRecorder) GetMovieByID(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieByID", reflect.TypeOf((*MockMovieRepo)(nil).GetMovieByID), ctx, movieID)
+}
+
+// GetMovieTranslations mocks base method.
+func (m *MockMovieRepo) GetMovieTranslations(ctx context.Context, movieIDs []uuid.UUID, locales []string) ([]*domain.MovieTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieTranslations", ctx, movieIDs, locales)
+	ret0, _ := ret[0].([]*domain.MovieTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieTranslations indicates an expected call of GetMovieTranslations.
+func (mr *MockMovieRepoMockRecorder) GetMovieTranslations(ctx, movieIDs, locales any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieTranslations", reflect.TypeOf((*MockMovieRepo)(nil).GetMovieTranslations), ctx, movieIDs, locales)
+}
+
 // GetMovies mocks base method.
 func (m *MockMovieRepo) GetMovies(ctx context.Context) ([]*domain.Movie, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +129,35 @@ func (mr *MockMovieRepoMockRecorder) GetMoviesBySnippet(ctx, snippet any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviesBySnippet", reflect.TypeOf((*MockMovieRepo)(nil).GetMoviesBySnippet), ctx, snippet)
 }
 
+// GetWatchlistMovieIDs mocks base method.
+func (m *MockMovieRepo) GetWatchlistMovieIDs(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlistMovieIDs", ctx, userID)
+	ret0, _ := ret[0].(map[uuid.UUID]struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlistMovieIDs indicates an expected call of GetWatchlistMovieIDs.
+func (mr *MockMovieRepoMockRecorder) GetWatchlistMovieIDs(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlistMovieIDs", reflect.TypeOf((*MockMovieRepo)(nil).GetWatchlistMovieIDs), ctx, userID)
+}
+
+// SetMovieGenres mocks base method.
+func (m *MockMovieRepo) SetMovieGenres(ctx context.Context, movieID uuid.UUID, genreIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMovieGenres", ctx, movieID, genreIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMovieGenres indicates an expected call of SetMovieGenres.
+func (mr *MockMovieRepoMockRecorder) SetMovieGenres(ctx, movieID, genreIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMovieGenres", reflect.TypeOf((*MockMovieRepo)(nil).SetMovieGenres), ctx, movieID, genreIDs)
+}
+
 // UpdateMovie mocks base method.
 func (m *MockMovieRepo) UpdateMovie(ctx context.Context, movie *domain.Movie) error {
 	m.ctrl.T.Helper()
@@ -112,3 +171,17 @@ func (mr *MockMovieRepoMockRecorder) UpdateMovie(ctx, movie any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockMovieRepo)(nil).UpdateMovie), ctx, movie)
 }
+
+// UpdateMovieFields mocks base method.
+func (m *MockMovieRepo) UpdateMovieFields(ctx context.Context, movie *domain.Movie, fields []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovieFields", ctx, movie, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMovieFields indicates an expected call of UpdateMovieFields.
+func (mr *MockMovieRepoMockRecorder) UpdateMovieFields(ctx, movie, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieFields", reflect.TypeOf((*MockMovieRepo)(nil).UpdateMovieFields), ctx, movie, fields)
+}
@@ -0,0 +1,210 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: payment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=payment.go -destination=mocks/paymentMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentRepo is a mock of PaymentRepo interface.
+type MockPaymentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepoMockRecorder
+}
+
+// MockPaymentRepoMockRecorder is the mock recorder for MockPaymentRepo.
+type MockPaymentRepoMockRecorder struct {
+	mock *MockPaymentRepo
+}
+
+// NewMockPaymentRepo creates a new mock instance.
+func NewMockPaymentRepo(ctrl *gomock.Controller) *MockPaymentRepo {
+	mock := &MockPaymentRepo{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepo) EXPECT() *MockPaymentRepoMockRecorder {
+	return m.recorder
+}
+
+// CreatePayment mocks base method.
+func (m *MockPaymentRepo) CreatePayment(ctx context.Context, payment *domain.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockPaymentRepoMockRecorder) CreatePayment(ctx, payment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPaymentRepo)(nil).CreatePayment), ctx, payment)
+}
+
+// GetPaymentByBookingID mocks base method.
+func (m *MockPaymentRepo) GetPaymentByBookingID(ctx context.Context, bookingID uuid.UUID) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByBookingID", ctx, bookingID)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByBookingID indicates an expected call of GetPaymentByBookingID.
+func (mr *MockPaymentRepoMockRecorder) GetPaymentByBookingID(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByBookingID", reflect.TypeOf((*MockPaymentRepo)(nil).GetPaymentByBookingID), ctx, bookingID)
+}
+
+// GetPaymentByIntentID mocks base method.
+func (m *MockPaymentRepo) GetPaymentByIntentID(ctx context.Context, intentID string) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByIntentID", ctx, intentID)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByIntentID indicates an expected call of GetPaymentByIntentID.
+func (mr *MockPaymentRepoMockRecorder) GetPaymentByIntentID(ctx, intentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByIntentID", reflect.TypeOf((*MockPaymentRepo)(nil).GetPaymentByIntentID), ctx, intentID)
+}
+
+// SaveWebhookEvent mocks base method.
+func (m *MockPaymentRepo) SaveWebhookEvent(ctx context.Context, eventID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWebhookEvent", ctx, eventID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveWebhookEvent indicates an expected call of SaveWebhookEvent.
+func (mr *MockPaymentRepoMockRecorder) SaveWebhookEvent(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebhookEvent", reflect.TypeOf((*MockPaymentRepo)(nil).SaveWebhookEvent), ctx, eventID)
+}
+
+// UpdatePaymentStatus mocks base method.
+func (m *MockPaymentRepo) UpdatePaymentStatus(ctx context.Context, paymentID uuid.UUID, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", ctx, paymentID, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockPaymentRepoMockRecorder) UpdatePaymentStatus(ctx, paymentID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockPaymentRepo)(nil).UpdatePaymentStatus), ctx, paymentID, from, to)
+}
+
+// MockPaymentProvider is a mock of PaymentProvider interface.
+type MockPaymentProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentProviderMockRecorder
+}
+
+// MockPaymentProviderMockRecorder is the mock recorder for MockPaymentProvider.
+type MockPaymentProviderMockRecorder struct {
+	mock *MockPaymentProvider
+}
+
+// NewMockPaymentProvider creates a new mock instance.
+func NewMockPaymentProvider(ctrl *gomock.Controller) *MockPaymentProvider {
+	mock := &MockPaymentProvider{ctrl: ctrl}
+	mock.recorder = &MockPaymentProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentProvider) EXPECT() *MockPaymentProviderMockRecorder {
+	return m.recorder
+}
+
+// CancelIntent mocks base method.
+func (m *MockPaymentProvider) CancelIntent(ctx context.Context, intentID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelIntent", ctx, intentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelIntent indicates an expected call of CancelIntent.
+func (mr *MockPaymentProviderMockRecorder) CancelIntent(ctx, intentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelIntent", reflect.TypeOf((*MockPaymentProvider)(nil).CancelIntent), ctx, intentID)
+}
+
+// Capture mocks base method.
+func (m *MockPaymentProvider) Capture(ctx context.Context, intentID, idempotencyKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", ctx, intentID, idempotencyKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockPaymentProviderMockRecorder) Capture(ctx, intentID, idempotencyKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockPaymentProvider)(nil).Capture), ctx, intentID, idempotencyKey)
+}
+
+// CreateIntent mocks base method.
+func (m *MockPaymentProvider) CreateIntent(ctx context.Context, amount int64, currency string, bookingID uuid.UUID) (*domain.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIntent", ctx, amount, currency, bookingID)
+	ret0, _ := ret[0].(*domain.PaymentIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIntent indicates an expected call of CreateIntent.
+func (mr *MockPaymentProviderMockRecorder) CreateIntent(ctx, amount, currency, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIntent", reflect.TypeOf((*MockPaymentProvider)(nil).CreateIntent), ctx, amount, currency, bookingID)
+}
+
+// Refund mocks base method.
+func (m *MockPaymentProvider) Refund(ctx context.Context, intentID string, amount int64, idempotencyKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, intentID, amount, idempotencyKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentProviderMockRecorder) Refund(ctx, intentID, amount, idempotencyKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentProvider)(nil).Refund), ctx, intentID, amount, idempotencyKey)
+}
+
+// VerifyWebhook mocks base method.
+func (m *MockPaymentProvider) VerifyWebhook(payload []byte, signature string) (*domain.PaymentEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWebhook", payload, signature)
+	ret0, _ := ret[0].(*domain.PaymentEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyWebhook indicates an expected call of VerifyWebhook.
+func (mr *MockPaymentProviderMockRecorder) VerifyWebhook(payload, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWebhook", reflect.TypeOf((*MockPaymentProvider)(nil).VerifyWebhook), payload, signature)
+}
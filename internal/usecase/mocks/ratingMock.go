@@ -0,0 +1,188 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rating.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rating.go -destination=mocks/ratingMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRatingRepo is a mock of RatingRepo interface.
+type MockRatingRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingRepoMockRecorder
+}
+
+// MockRatingRepoMockRecorder is the mock recorder for MockRatingRepo.
+type MockRatingRepoMockRecorder struct {
+	mock *MockRatingRepo
+}
+
+// NewMockRatingRepo creates a new mock instance.
+func NewMockRatingRepo(ctrl *gomock.Controller) *MockRatingRepo {
+	mock := &MockRatingRepo{ctrl: ctrl}
+	mock.recorder = &MockRatingRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingRepo) EXPECT() *MockRatingRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteRating mocks base method.
+func (m *MockRatingRepo) DeleteRating(ctx context.Context, userID, movieID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRating", ctx, userID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRating indicates an expected call of DeleteRating.
+func (mr *MockRatingRepoMockRecorder) DeleteRating(ctx, userID, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRating", reflect.TypeOf((*MockRatingRepo)(nil).DeleteRating), ctx, userID, movieID)
+}
+
+// GetGlobalRatingMean mocks base method.
+func (m *MockRatingRepo) GetGlobalRatingMean(ctx context.Context) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGlobalRatingMean", ctx)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGlobalRatingMean indicates an expected call of GetGlobalRatingMean.
+func (mr *MockRatingRepoMockRecorder) GetGlobalRatingMean(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGlobalRatingMean", reflect.TypeOf((*MockRatingRepo)(nil).GetGlobalRatingMean), ctx)
+}
+
+// GetRatedMovieIDs mocks base method.
+func (m *MockRatingRepo) GetRatedMovieIDs(ctx context.Context) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatedMovieIDs", ctx)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatedMovieIDs indicates an expected call of GetRatedMovieIDs.
+func (mr *MockRatingRepoMockRecorder) GetRatedMovieIDs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatedMovieIDs", reflect.TypeOf((*MockRatingRepo)(nil).GetRatedMovieIDs), ctx)
+}
+
+// GetRatingStats mocks base method.
+func (m *MockRatingRepo) GetRatingStats(ctx context.Context, movieID uuid.UUID) (float64, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingStats", ctx, movieID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRatingStats indicates an expected call of GetRatingStats.
+func (mr *MockRatingRepoMockRecorder) GetRatingStats(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingStats", reflect.TypeOf((*MockRatingRepo)(nil).GetRatingStats), ctx, movieID)
+}
+
+// GetRatingSummary mocks base method.
+func (m *MockRatingRepo) GetRatingSummary(ctx context.Context, movieID uuid.UUID) (*domain.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingSummary", ctx, movieID)
+	ret0, _ := ret[0].(*domain.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingSummary indicates an expected call of GetRatingSummary.
+func (mr *MockRatingRepoMockRecorder) GetRatingSummary(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingSummary", reflect.TypeOf((*MockRatingRepo)(nil).GetRatingSummary), ctx, movieID)
+}
+
+// GetReviews mocks base method.
+func (m *MockRatingRepo) GetReviews(ctx context.Context, movieID uuid.UUID, status string, limit, offset int) ([]*domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviews", ctx, movieID, status, limit, offset)
+	ret0, _ := ret[0].([]*domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviews indicates an expected call of GetReviews.
+func (mr *MockRatingRepoMockRecorder) GetReviews(ctx, movieID, status, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviews", reflect.TypeOf((*MockRatingRepo)(nil).GetReviews), ctx, movieID, status, limit, offset)
+}
+
+// UpdateMovieRating mocks base method.
+func (m *MockRatingRepo) UpdateMovieRating(ctx context.Context, summary *domain.RatingSummary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovieRating", ctx, summary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMovieRating indicates an expected call of UpdateMovieRating.
+func (mr *MockRatingRepoMockRecorder) UpdateMovieRating(ctx, summary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieRating", reflect.TypeOf((*MockRatingRepo)(nil).UpdateMovieRating), ctx, summary)
+}
+
+// UpdateReviewStatus mocks base method.
+func (m *MockRatingRepo) UpdateReviewStatus(ctx context.Context, reviewID uuid.UUID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReviewStatus", ctx, reviewID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReviewStatus indicates an expected call of UpdateReviewStatus.
+func (mr *MockRatingRepoMockRecorder) UpdateReviewStatus(ctx, reviewID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReviewStatus", reflect.TypeOf((*MockRatingRepo)(nil).UpdateReviewStatus), ctx, reviewID, status)
+}
+
+// UpsertRating mocks base method.
+func (m *MockRatingRepo) UpsertRating(ctx context.Context, rating *domain.Rating) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRating", ctx, rating)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertRating indicates an expected call of UpsertRating.
+func (mr *MockRatingRepoMockRecorder) UpsertRating(ctx, rating any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRating", reflect.TypeOf((*MockRatingRepo)(nil).UpsertRating), ctx, rating)
+}
+
+// UpsertReview mocks base method.
+func (m *MockRatingRepo) UpsertReview(ctx context.Context, review *domain.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertReview", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertReview indicates an expected call of UpsertReview.
+func (mr *MockRatingRepoMockRecorder) UpsertReview(ctx, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertReview", reflect.TypeOf((*MockRatingRepo)(nil).UpsertReview), ctx, review)
+}
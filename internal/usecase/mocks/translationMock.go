@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: translation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=translation.go -destination=mocks/translationMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTranslationRepo is a mock of TranslationRepo interface.
+type MockTranslationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationRepoMockRecorder
+}
+
+// MockTranslationRepoMockRecorder is the mock recorder for MockTranslationRepo.
+type MockTranslationRepoMockRecorder struct {
+	mock *MockTranslationRepo
+}
+
+// NewMockTranslationRepo creates a new mock instance.
+func NewMockTranslationRepo(ctrl *gomock.Controller) *MockTranslationRepo {
+	mock := &MockTranslationRepo{ctrl: ctrl}
+	mock.recorder = &MockTranslationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationRepo) EXPECT() *MockTranslationRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteActorTranslation mocks base method.
+func (m *MockTranslationRepo) DeleteActorTranslation(ctx context.Context, actorID uuid.UUID, locale string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActorTranslation", ctx, actorID, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActorTranslation indicates an expected call of DeleteActorTranslation.
+func (mr *MockTranslationRepoMockRecorder) DeleteActorTranslation(ctx, actorID, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActorTranslation", reflect.TypeOf((*MockTranslationRepo)(nil).DeleteActorTranslation), ctx, actorID, locale)
+}
+
+// DeleteMovieTranslation mocks base method.
+func (m *MockTranslationRepo) DeleteMovieTranslation(ctx context.Context, movieID uuid.UUID, locale string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovieTranslation", ctx, movieID, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMovieTranslation indicates an expected call of DeleteMovieTranslation.
+func (mr *MockTranslationRepoMockRecorder) DeleteMovieTranslation(ctx, movieID, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovieTranslation", reflect.TypeOf((*MockTranslationRepo)(nil).DeleteMovieTranslation), ctx, movieID, locale)
+}
+
+// ListActorTranslations mocks base method.
+func (m *MockTranslationRepo) ListActorTranslations(ctx context.Context, actorID uuid.UUID) ([]*domain.ActorTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActorTranslations", ctx, actorID)
+	ret0, _ := ret[0].([]*domain.ActorTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActorTranslations indicates an expected call of ListActorTranslations.
+func (mr *MockTranslationRepoMockRecorder) ListActorTranslations(ctx, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActorTranslations", reflect.TypeOf((*MockTranslationRepo)(nil).ListActorTranslations), ctx, actorID)
+}
+
+// ListMovieTranslations mocks base method.
+func (m *MockTranslationRepo) ListMovieTranslations(ctx context.Context, movieID uuid.UUID) ([]*domain.MovieTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovieTranslations", ctx, movieID)
+	ret0, _ := ret[0].([]*domain.MovieTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovieTranslations indicates an expected call of ListMovieTranslations.
+func (mr *MockTranslationRepoMockRecorder) ListMovieTranslations(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovieTranslations", reflect.TypeOf((*MockTranslationRepo)(nil).ListMovieTranslations), ctx, movieID)
+}
+
+// UpsertActorTranslation mocks base method.
+func (m *MockTranslationRepo) UpsertActorTranslation(ctx context.Context, translation *domain.ActorTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertActorTranslation", ctx, translation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertActorTranslation indicates an expected call of UpsertActorTranslation.
+func (mr *MockTranslationRepoMockRecorder) UpsertActorTranslation(ctx, translation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertActorTranslation", reflect.TypeOf((*MockTranslationRepo)(nil).UpsertActorTranslation), ctx, translation)
+}
+
+// UpsertMovieTranslation mocks base method.
+func (m *MockTranslationRepo) UpsertMovieTranslation(ctx context.Context, translation *domain.MovieTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMovieTranslation", ctx, translation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertMovieTranslation indicates an expected call of UpsertMovieTranslation.
+func (mr *MockTranslationRepoMockRecorder) UpsertMovieTranslation(ctx, translation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMovieTranslation", reflect.TypeOf((*MockTranslationRepo)(nil).UpsertMovieTranslation), ctx, translation)
+}
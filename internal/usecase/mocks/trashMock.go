@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: trash.go
+//
+// Generated by this command:
+//
+//	mockgen -source=trash.go -destination=mocks/trashMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrashRepo is a mock of TrashRepo interface.
+type MockTrashRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashRepoMockRecorder
+}
+
+// MockTrashRepoMockRecorder is the mock recorder for MockTrashRepo.
+type MockTrashRepoMockRecorder struct {
+	mock *MockTrashRepo
+}
+
+// NewMockTrashRepo creates a new mock instance.
+func NewMockTrashRepo(ctrl *gomock.Controller) *MockTrashRepo {
+	mock := &MockTrashRepo{ctrl: ctrl}
+	mock.recorder = &MockTrashRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashRepo) EXPECT() *MockTrashRepoMockRecorder {
+	return m.recorder
+}
+
+// GetActorByID mocks base method.
+func (m *MockTrashRepo) GetActorByID(ctx context.Context, actorID uuid.UUID) (*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorByID", ctx, actorID)
+	ret0, _ := ret[0].(*domain.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorByID indicates an expected call of GetActorByID.
+func (mr *MockTrashRepoMockRecorder) GetActorByID(ctx, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorByID", reflect.TypeOf((*MockTrashRepo)(nil).GetActorByID), ctx, actorID)
+}
+
+// GetDeletedActors mocks base method.
+func (m *MockTrashRepo) GetDeletedActors(ctx context.Context) ([]*domain.TrashedActor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedActors", ctx)
+	ret0, _ := ret[0].([]*domain.TrashedActor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedActors indicates an expected call of GetDeletedActors.
+func (mr *MockTrashRepoMockRecorder) GetDeletedActors(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedActors", reflect.TypeOf((*MockTrashRepo)(nil).GetDeletedActors), ctx)
+}
+
+// GetDeletedMovies mocks base method.
+func (m *MockTrashRepo) GetDeletedMovies(ctx context.Context) ([]*domain.TrashedMovie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedMovies", ctx)
+	ret0, _ := ret[0].([]*domain.TrashedMovie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedMovies indicates an expected call of GetDeletedMovies.
+func (mr *MockTrashRepoMockRecorder) GetDeletedMovies(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedMovies", reflect.TypeOf((*MockTrashRepo)(nil).GetDeletedMovies), ctx)
+}
+
+// GetMovieByID mocks base method.
+func (m *MockTrashRepo) GetMovieByID(ctx context.Context, movieID uuid.UUID) (*domain.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovieByID", ctx, movieID)
+	ret0, _ := ret[0].(*domain.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovieByID indicates an expected call of GetMovieByID.
+func (mr *MockTrashRepoMockRecorder) GetMovieByID(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieByID", reflect.TypeOf((*MockTrashRepo)(nil).GetMovieByID), ctx, movieID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockTrashRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockTrashRepoMockRecorder) PurgeDeleted(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockTrashRepo)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
+// RestoreActor mocks base method.
+func (m *MockTrashRepo) RestoreActor(ctx context.Context, actorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreActor", ctx, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreActor indicates an expected call of RestoreActor.
+func (mr *MockTrashRepoMockRecorder) RestoreActor(ctx, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreActor", reflect.TypeOf((*MockTrashRepo)(nil).RestoreActor), ctx, actorID)
+}
+
+// RestoreMovie mocks base method.
+func (m *MockTrashRepo) RestoreMovie(ctx context.Context, movieID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMovie", ctx, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMovie indicates an expected call of RestoreMovie.
+func (mr *MockTrashRepoMockRecorder) RestoreMovie(ctx, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMovie", reflect.TypeOf((*MockTrashRepo)(nil).RestoreMovie), ctx, movieID)
+}
@@ -40,6 +40,20 @@ func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
 	return m.recorder
 }
 
+// CreateUser mocks base method.
+func (m *MockUserRepo) CreateUser(ctx context.Context, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserRepoMockRecorder) CreateUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepo)(nil).CreateUser), ctx, user)
+}
+
 // GetUser mocks base method.
 func (m *MockUserRepo) GetUser(ctx context.Context, login, password string) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -54,3 +68,56 @@ func (mr *MockUserRepoMockRecorder) GetUser(ctx, login, password any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserRepo)(nil).GetUser), ctx, login, password)
 }
+
+// MockTokenKeys is a mock of TokenKeys interface.
+type MockTokenKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenKeysMockRecorder
+}
+
+// MockTokenKeysMockRecorder is the mock recorder for MockTokenKeys.
+type MockTokenKeysMockRecorder struct {
+	mock *MockTokenKeys
+}
+
+// NewMockTokenKeys creates a new mock instance.
+func NewMockTokenKeys(ctrl *gomock.Controller) *MockTokenKeys {
+	mock := &MockTokenKeys{ctrl: ctrl}
+	mock.recorder = &MockTokenKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenKeys) EXPECT() *MockTokenKeysMockRecorder {
+	return m.recorder
+}
+
+// SigningKey mocks base method.
+func (m *MockTokenKeys) SigningKey(ctx context.Context) (*domain.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SigningKey", ctx)
+	ret0, _ := ret[0].(*domain.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SigningKey indicates an expected call of SigningKey.
+func (mr *MockTokenKeysMockRecorder) SigningKey(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SigningKey", reflect.TypeOf((*MockTokenKeys)(nil).SigningKey), ctx)
+}
+
+// VerificationKey mocks base method.
+func (m *MockTokenKeys) VerificationKey(ctx context.Context, id string) (*domain.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerificationKey", ctx, id)
+	ret0, _ := ret[0].(*domain.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerificationKey indicates an expected call of VerificationKey.
+func (mr *MockTokenKeysMockRecorder) VerificationKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerificationKey", reflect.TypeOf((*MockTokenKeys)(nil).VerificationKey), ctx, id)
+}
@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: version.go
+//
+// Generated by this command:
+//
+//	mockgen -source=version.go -destination=mocks/versionMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVersionRepo is a mock of VersionRepo interface.
+type MockVersionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersionRepoMockRecorder
+}
+
+// MockVersionRepoMockRecorder is the mock recorder for MockVersionRepo.
+type MockVersionRepoMockRecorder struct {
+	mock *MockVersionRepo
+}
+
+// NewMockVersionRepo creates a new mock instance.
+func NewMockVersionRepo(ctrl *gomock.Controller) *MockVersionRepo {
+	mock := &MockVersionRepo{ctrl: ctrl}
+	mock.recorder = &MockVersionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersionRepo) EXPECT() *MockVersionRepoMockRecorder {
+	return m.recorder
+}
+
+// AddVersion mocks base method.
+func (m *MockVersionRepo) AddVersion(ctx context.Context, version *domain.EntityVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVersion", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVersion indicates an expected call of AddVersion.
+func (mr *MockVersionRepoMockRecorder) AddVersion(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVersion", reflect.TypeOf((*MockVersionRepo)(nil).AddVersion), ctx, version)
+}
+
+// GetVersion mocks base method.
+func (m *MockVersionRepo) GetVersion(ctx context.Context, entity string, entityID uuid.UUID, number int) (*domain.EntityVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", ctx, entity, entityID, number)
+	ret0, _ := ret[0].(*domain.EntityVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockVersionRepoMockRecorder) GetVersion(ctx, entity, entityID, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockVersionRepo)(nil).GetVersion), ctx, entity, entityID, number)
+}
+
+// GetVersions mocks base method.
+func (m *MockVersionRepo) GetVersions(ctx context.Context, entity string, entityID uuid.UUID) ([]*domain.EntityVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", ctx, entity, entityID)
+	ret0, _ := ret[0].([]*domain.EntityVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockVersionRepoMockRecorder) GetVersions(ctx, entity, entityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockVersionRepo)(nil).GetVersions), ctx, entity, entityID)
+}
+
+// MockVersioner is a mock of Versioner interface.
+type MockVersioner struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersionerMockRecorder
+}
+
+// MockVersionerMockRecorder is the mock recorder for MockVersioner.
+type MockVersionerMockRecorder struct {
+	mock *MockVersioner
+}
+
+// NewMockVersioner creates a new mock instance.
+func NewMockVersioner(ctrl *gomock.Controller) *MockVersioner {
+	mock := &MockVersioner{ctrl: ctrl}
+	mock.recorder = &MockVersionerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersioner) EXPECT() *MockVersionerMockRecorder {
+	return m.recorder
+}
+
+// DiffVersions mocks base method.
+func (m *MockVersioner) DiffVersions(ctx context.Context, entity string, entityID uuid.UUID, from, to int) (map[string]domain.AuditChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffVersions", ctx, entity, entityID, from, to)
+	ret0, _ := ret[0].(map[string]domain.AuditChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffVersions indicates an expected call of DiffVersions.
+func (mr *MockVersionerMockRecorder) DiffVersions(ctx, entity, entityID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffVersions", reflect.TypeOf((*MockVersioner)(nil).DiffVersions), ctx, entity, entityID, from, to)
+}
+
+// GetVersion mocks base method.
+func (m *MockVersioner) GetVersion(ctx context.Context, entity string, entityID uuid.UUID, number int) (*domain.EntityVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", ctx, entity, entityID, number)
+	ret0, _ := ret[0].(*domain.EntityVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockVersionerMockRecorder) GetVersion(ctx, entity, entityID, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockVersioner)(nil).GetVersion), ctx, entity, entityID, number)
+}
+
+// GetVersions mocks base method.
+func (m *MockVersioner) GetVersions(ctx context.Context, entity string, entityID uuid.UUID) ([]*domain.EntityVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", ctx, entity, entityID)
+	ret0, _ := ret[0].([]*domain.EntityVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockVersionerMockRecorder) GetVersions(ctx, entity, entityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockVersioner)(nil).GetVersions), ctx, entity, entityID)
+}
+
+// Snapshot mocks base method.
+func (m *MockVersioner) Snapshot(ctx context.Context, entity string, entityID uuid.UUID, before, after any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, entity, entityID, before, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockVersionerMockRecorder) Snapshot(ctx, entity, entityID, before, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockVersioner)(nil).Snapshot), ctx, entity, entityID, before, after)
+}
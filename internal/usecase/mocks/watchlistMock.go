@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: watchlist.go
+//
+// Generated by this command:
+//
+//	mockgen -source=watchlist.go -destination=mocks/watchlistMock.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	domain "cinema_service/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWatchlistRepo is a mock of WatchlistRepo interface.
+type MockWatchlistRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatchlistRepoMockRecorder
+}
+
+// MockWatchlistRepoMockRecorder is the mock recorder for MockWatchlistRepo.
+type MockWatchlistRepoMockRecorder struct {
+	mock *MockWatchlistRepo
+}
+
+// NewMockWatchlistRepo creates a new mock instance.
+func NewMockWatchlistRepo(ctrl *gomock.Controller) *MockWatchlistRepo {
+	mock := &MockWatchlistRepo{ctrl: ctrl}
+	mock.recorder = &MockWatchlistRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatchlistRepo) EXPECT() *MockWatchlistRepoMockRecorder {
+	return m.recorder
+}
+
+// AddFavoriteActor mocks base method.
+func (m *MockWatchlistRepo) AddFavoriteActor(ctx context.Context, userID, actorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavoriteActor", ctx, userID, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFavoriteActor indicates an expected call of AddFavoriteActor.
+func (mr *MockWatchlistRepoMockRecorder) AddFavoriteActor(ctx, userID, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavoriteActor", reflect.TypeOf((*MockWatchlistRepo)(nil).AddFavoriteActor), ctx, userID, actorID)
+}
+
+// AddToWatchlist mocks base method.
+func (m *MockWatchlistRepo) AddToWatchlist(ctx context.Context, userID, movieID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWatchlist", ctx, userID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToWatchlist indicates an expected call of AddToWatchlist.
+func (mr *MockWatchlistRepoMockRecorder) AddToWatchlist(ctx, userID, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWatchlist", reflect.TypeOf((*MockWatchlistRepo)(nil).AddToWatchlist), ctx, userID, movieID)
+}
+
+// GetFavoriteActors mocks base method.
+func (m *MockWatchlistRepo) GetFavoriteActors(ctx context.Context, userID uuid.UUID) ([]*domain.FavoriteActor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavoriteActors", ctx, userID)
+	ret0, _ := ret[0].([]*domain.FavoriteActor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavoriteActors indicates an expected call of GetFavoriteActors.
+func (mr *MockWatchlistRepoMockRecorder) GetFavoriteActors(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavoriteActors", reflect.TypeOf((*MockWatchlistRepo)(nil).GetFavoriteActors), ctx, userID)
+}
+
+// GetWatchlist mocks base method.
+func (m *MockWatchlistRepo) GetWatchlist(ctx context.Context, userID uuid.UUID) ([]*domain.WatchlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlist", ctx, userID)
+	ret0, _ := ret[0].([]*domain.WatchlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlist indicates an expected call of GetWatchlist.
+func (mr *MockWatchlistRepoMockRecorder) GetWatchlist(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlist", reflect.TypeOf((*MockWatchlistRepo)(nil).GetWatchlist), ctx, userID)
+}
+
+// RemoveFavoriteActor mocks base method.
+func (m *MockWatchlistRepo) RemoveFavoriteActor(ctx context.Context, userID, actorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFavoriteActor", ctx, userID, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFavoriteActor indicates an expected call of RemoveFavoriteActor.
+func (mr *MockWatchlistRepoMockRecorder) RemoveFavoriteActor(ctx, userID, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavoriteActor", reflect.TypeOf((*MockWatchlistRepo)(nil).RemoveFavoriteActor), ctx, userID, actorID)
+}
+
+// RemoveFromWatchlist mocks base method.
+func (m *MockWatchlistRepo) RemoveFromWatchlist(ctx context.Context, userID, movieID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWatchlist", ctx, userID, movieID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromWatchlist indicates an expected call of RemoveFromWatchlist.
+func (mr *MockWatchlistRepoMockRecorder) RemoveFromWatchlist(ctx, userID, movieID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWatchlist", reflect.TypeOf((*MockWatchlistRepo)(nil).RemoveFromWatchlist), ctx, userID, movieID)
+}
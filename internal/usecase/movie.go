@@ -3,7 +3,10 @@ package usecase
 import (
 	"cinema_service/internal/domain"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"sort"
 
 	"github.com/google/uuid"
@@ -11,43 +14,325 @@ import (
 
 //go:generate mockgen -source=movie.go -destination=mocks/movieMock.go
 
+// ErrUnknownGenre is returned when a movie is given a genre that does not
+// exist.
+var ErrUnknownGenre = errors.New("unknown genre")
+
 type MovieRepo interface {
+	// GetMovieByID returns a movie with its genres or domain.ErrNotFound.
+	GetMovieByID(ctx context.Context, movieID uuid.UUID) (*domain.Movie, error)
 	CreateMovie(ctx context.Context, movie *domain.Movie) error
 	GetMovies(ctx context.Context) ([]*domain.Movie, error)
 	GetMoviesBySnippet(ctx context.Context, snippet string) ([]*domain.Movie, error)
 	UpdateMovie(ctx context.Context, movie *domain.Movie) error
+	// UpdateMovieFields writes only the given fields of a movie, named as
+	// in domain.Movie. Fields that are not columns, like Genres, are
+	// ignored.
+	UpdateMovieFields(ctx context.Context, movie *domain.Movie, fields []string) error
 	DeleteMovie(ctx context.Context, movieID uuid.UUID) error
+	GetWatchlistMovieIDs(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]struct{}, error)
+	SetMovieGenres(ctx context.Context, movieID uuid.UUID, genreIDs []uuid.UUID) error
+	GetMovieTranslations(ctx context.Context, movieIDs []uuid.UUID, locales []string) ([]*domain.MovieTranslation, error)
 }
 
 type MovieService struct {
-	repo MovieRepo
+	repo     MovieRepo
+	tx       Transactor
+	audit    Auditor
+	versions Versioner
+	// cache holds movies by ID and filtered listings, localized but
+	// without the watchlist flags of a user.
+	cache  Cache
+	events Publisher
 }
 
-func NewMovieService(repo MovieRepo) *MovieService {
-	return &MovieService{repo: repo}
+func NewMovieService(repo MovieRepo, tx Transactor, audit Auditor, versions Versioner, cache Cache,
+	events Publisher) *MovieService {
+	return &MovieService{repo: repo, tx: tx, audit: audit, versions: versions, cache: cache, events: events}
 }
 
+// CreateMovie stores the movie and its genres and sets movie to the stored
+// movie. A new ID is generated unless movie has one; domain.ErrAlreadyExists
+// is returned if it is taken. Nothing is stored and ErrUnknownGenre is
+// returned if a genre does not exist.
 func (s *MovieService) CreateMovie(ctx context.Context, movie *domain.Movie) error {
-	err := s.repo.CreateMovie(ctx, movie)
+	if err := movie.Validate(); err != nil {
+		return err
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateMovie(ctx, movie); err != nil {
+			return err
+		}
+		if err := s.setGenres(ctx, movie); err != nil {
+			return err
+		}
+		created, err := s.repo.GetMovieByID(ctx, movie.ID)
+		if err != nil {
+			return err
+		}
+		if err = s.audit.Record(ctx, domain.AuditCreate, domain.AuditEntityMovie, movie.ID, nil, created); err != nil {
+			return err
+		}
+		if err = s.events.Publish(ctx, domain.EventMovieCreated, movie.ID, created); err != nil {
+			return err
+		}
+		*movie = *created
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("create movie: %w", err)
 	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
 	return nil
 }
 
+// GetMovie returns a movie with its genres or domain.ErrNotFound.
+func (s *MovieService) GetMovie(ctx context.Context, movieID uuid.UUID) (*domain.Movie, error) {
+	key := cacheMovies + "id:" + movieID.String() + ":" + localesKey(ctx)
+	movie, err := cached(ctx, s.cache, key, func() (*domain.Movie, error) {
+		movie, err := s.repo.GetMovieByID(ctx, movieID)
+		if err != nil {
+			return nil, err
+		}
+		return movie, s.localize(ctx, []*domain.Movie{movie})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get movie: %w", err)
+	}
+	return movie, nil
+}
+
+// UpdateMovie changes a movie and its genres and sets movie.Version to the
+// new version. A non-zero movie.Version must match the stored version or
+// domain.ErrVersionConflict is returned. It returns domain.ErrNotFound if
+// there is no such movie and ErrUnknownGenre if a genre does not exist.
 func (s *MovieService) UpdateMovie(ctx context.Context, movie *domain.Movie) error {
-	err := s.repo.UpdateMovie(ctx, movie)
+	if err := movie.Validate(); err != nil {
+		return err
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetMovieByID(ctx, movie.ID)
+		if err != nil {
+			return err
+		}
+		if movie.Version != 0 && movie.Version != before.Version {
+			return domain.ErrVersionConflict
+		}
+		return s.update(ctx, before, movie)
+	})
 	if err != nil {
 		return fmt.Errorf("update movie: %w", err)
 	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
 	return nil
 }
 
-func (s *MovieService) DeleteMovie(ctx context.Context, movieID uuid.UUID) error {
-	err := s.repo.DeleteMovie(ctx, movieID)
+// update changes a movie stored as before, then audits, versions and
+// publishes the change. The rating is kept: only RatingService writes it.
+// The caller runs it in a transaction.
+func (s *MovieService) update(ctx context.Context, before *domain.Movie, movie *domain.Movie) error {
+	movie.Rating = before.Rating
+	if err := s.repo.UpdateMovie(ctx, movie); err != nil {
+		return err
+	}
+	if err := s.setGenres(ctx, movie); err != nil {
+		return err
+	}
+	after, err := s.repo.GetMovieByID(ctx, movie.ID)
+	if err != nil {
+		return err
+	}
+	movie.Version = after.Version
+	if err = s.audit.Record(ctx, domain.AuditUpdate, domain.AuditEntityMovie, movie.ID, before, after); err != nil {
+		return err
+	}
+	if err = s.events.Publish(ctx, domain.EventMovieUpdated, movie.ID, after); err != nil {
+		return err
+	}
+	return s.versions.Snapshot(ctx, domain.AuditEntityMovie, movie.ID, before, after)
+}
+
+// PatchMovie changes a movie by calling apply with a copy of it and stores
+// the fields apply changed. A non-zero version must match the stored version
+// or domain.ErrVersionConflict is returned. It returns the updated movie, or
+// domain.ErrNotFound if there is no such movie.
+func (s *MovieService) PatchMovie(ctx context.Context, movieID uuid.UUID, version int,
+	apply func(movie *domain.Movie) error) (*domain.Movie, error) {
+	var result *domain.Movie
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetMovieByID(ctx, movieID)
+		if err != nil {
+			return err
+		}
+		if version != 0 && version != before.Version {
+			return domain.ErrVersionConflict
+		}
+
+		movie := *before
+		movie.Countries = slices.Clone(before.Countries)
+		movie.Genres = slices.Clone(before.Genres)
+		if err = apply(&movie); err != nil {
+			return err
+		}
+		movie.ID = movieID
+		if err = movie.Validate(); err != nil {
+			return err
+		}
+		// A null and an empty list are the same, so neither is a change.
+		if sameGenres(before.Genres, movie.Genres) {
+			movie.Genres = before.Genres
+		}
+		if len(before.Countries) == 0 && len(movie.Countries) == 0 {
+			movie.Countries = before.Countries
+		}
+
+		fields, err := changedFields(before, &movie)
+		if err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			result = before
+			return nil
+		}
+		if err = s.repo.UpdateMovieFields(ctx, &movie, fields); err != nil {
+			return err
+		}
+		if slices.Contains(fields, "Genres") {
+			err = s.setGenres(ctx, &movie)
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("%w: unknown genre", domain.ErrInvalidMovie)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if result, err = s.repo.GetMovieByID(ctx, movieID); err != nil {
+			return err
+		}
+		if err = s.audit.Record(ctx, domain.AuditUpdate, domain.AuditEntityMovie, movieID, before, result); err != nil {
+			return err
+		}
+		if err = s.events.Publish(ctx, domain.EventMovieUpdated, movieID, result); err != nil {
+			return err
+		}
+		return s.versions.Snapshot(ctx, domain.AuditEntityMovie, movieID, before, result)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("patch movie: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return result, nil
+}
+
+// sameGenres reports whether two lists hold the same genres in any order.
+// Nil and empty lists are the same.
+func sameGenres(a []*domain.Genre, b []*domain.Genre) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := make(map[uuid.UUID]struct{}, len(a))
+	for _, genre := range a {
+		ids[genre.ID] = struct{}{}
+	}
+	for _, genre := range b {
+		if _, ok := ids[genre.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *MovieService) GetMovieVersions(ctx context.Context, movieID uuid.UUID) ([]*domain.EntityVersion, error) {
+	versions, err := s.versions.GetVersions(ctx, domain.AuditEntityMovie, movieID)
+	if err != nil {
+		return nil, fmt.Errorf("get movie versions: %w", err)
+	}
+	return versions, nil
+}
+
+func (s *MovieService) DiffMovieVersions(ctx context.Context, movieID uuid.UUID, from int, to int) (map[string]domain.AuditChange, error) {
+	changes, err := s.versions.DiffVersions(ctx, domain.AuditEntityMovie, movieID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("diff movie versions: %w", err)
+	}
+	return changes, nil
+}
+
+// RestoreMovieVersion updates a movie to the state of one of its versions,
+// which adds a new version. The rating is left alone as it comes from the
+// votes of users. It returns domain.ErrNotFound if there is no such movie
+// or version.
+func (s *MovieService) RestoreMovieVersion(ctx context.Context, movieID uuid.UUID, number int) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		version, err := s.versions.GetVersion(ctx, domain.AuditEntityMovie, movieID, number)
+		if err != nil {
+			return err
+		}
+		before, err := s.repo.GetMovieByID(ctx, movieID)
+		if err != nil {
+			return err
+		}
+
+		var movie domain.Movie
+		if err = json.Unmarshal(version.State, &movie); err != nil {
+			return err
+		}
+		movie.ID = movieID
+		movie.Version = 0
+		if movie.Genres == nil {
+			movie.Genres = []*domain.Genre{}
+		}
+		return s.update(ctx, before, &movie)
+	})
+	if err != nil {
+		return fmt.Errorf("restore movie version: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return nil
+}
+
+func (s *MovieService) setGenres(ctx context.Context, movie *domain.Movie) error {
+	if movie.Genres == nil {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(movie.Genres))
+	for _, genre := range movie.Genres {
+		ids = append(ids, genre.ID)
+	}
+	err := s.repo.SetMovieGenres(ctx, movie.ID, ids)
+	if errors.Is(err, domain.ErrNotFound) {
+		// The movie exists by now, so a genre is missing.
+		return ErrUnknownGenre
+	}
+	return err
+}
+
+// DeleteMovie moves a movie to the trash. It returns domain.ErrNotFound if
+// there is no such movie and domain.ErrVersionConflict if a non-zero version
+// does not match the stored version.
+func (s *MovieService) DeleteMovie(ctx context.Context, movieID uuid.UUID, version int) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetMovieByID(ctx, movieID)
+		if err != nil {
+			return err
+		}
+		if version != 0 && version != before.Version {
+			return domain.ErrVersionConflict
+		}
+		if err = s.repo.DeleteMovie(ctx, movieID); err != nil {
+			return err
+		}
+		if err = s.audit.Record(ctx, domain.AuditDelete, domain.AuditEntityMovie, movieID, before, nil); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, domain.EventMovieDeleted, movieID, before)
+	})
 	if err != nil {
 		return fmt.Errorf("delete movie: %w", err)
 	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
 	return nil
 }
 
@@ -56,16 +341,44 @@ func (s *MovieService) GetMovies(ctx context.Context) ([]*domain.Movie, error) {
 	if err != nil {
 		return nil, fmt.Errorf("get movies: %w", err)
 	}
+	if err = s.localize(ctx, movies); err != nil {
+		return nil, fmt.Errorf("get movies: %w", err)
+	}
 	return movies, nil
 }
 
-func (s *MovieService) GetMoviesFilter(ctx context.Context, filter string) ([]*domain.Movie, error) {
-	movies, err := s.repo.GetMovies(ctx)
+func (s *MovieService) GetMoviesFilter(ctx context.Context, filter domain.MovieFilter) ([]*domain.Movie, error) {
+	params, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("get movies: %w", err)
+	}
+	key := cacheMovies + "filter:" + string(params) + ":" + localesKey(ctx)
+	movies, err := cached(ctx, s.cache, key, func() ([]*domain.Movie, error) {
+		return s.filterMovies(ctx, filter)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("get movies: %w", err)
 	}
 
-	switch filter {
+	if err = s.markWatchlist(ctx, movies); err != nil {
+		return nil, fmt.Errorf("get movies: %w", err)
+	}
+	return movies, nil
+}
+
+// filterMovies returns the localized movies matching filter in the order
+// it asks for.
+func (s *MovieService) filterMovies(ctx context.Context, filter domain.MovieFilter) ([]*domain.Movie, error) {
+	movies, err := s.repo.GetMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	movies = applyFilter(movies, filter)
+	if err = s.localize(ctx, movies); err != nil {
+		return nil, err
+	}
+
+	switch filter.Sort {
 	case "title":
 		sort.Slice(movies, func(i, j int) bool {
 			return movies[i].Title < movies[j].Title
@@ -85,7 +398,6 @@ func (s *MovieService) GetMoviesFilter(ctx context.Context, filter string) ([]*d
 	default:
 		return nil, fmt.Errorf("invalid filter")
 	}
-
 	return movies, nil
 }
 
@@ -94,5 +406,126 @@ func (s *MovieService) GetMoviesBySnippet(ctx context.Context, snippet string) (
 	if err != nil {
 		return nil, fmt.Errorf("get movies by snippet: %w", err)
 	}
+	if err = s.localize(ctx, movies); err != nil {
+		return nil, fmt.Errorf("get movies by snippet: %w", err)
+	}
+	if err = s.markWatchlist(ctx, movies); err != nil {
+		return nil, fmt.Errorf("get movies by snippet: %w", err)
+	}
 	return movies, nil
 }
+
+// GetGenreFacets counts the movies of each genre among the movies matching
+// filter, most frequent genres first.
+func (s *MovieService) GetGenreFacets(ctx context.Context, filter domain.MovieFilter) ([]*domain.GenreFacet, error) {
+	movies, err := s.repo.GetMovies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get genre facets: %w", err)
+	}
+
+	facets := make(map[uuid.UUID]*domain.GenreFacet)
+	for _, movie := range applyFilter(movies, filter) {
+		for _, genre := range movie.Genres {
+			facet, ok := facets[genre.ID]
+			if !ok {
+				facet = &domain.GenreFacet{Genre: genre}
+				facets[genre.ID] = facet
+			}
+			facet.Count++
+		}
+	}
+
+	result := make([]*domain.GenreFacet, 0, len(facets))
+	for _, facet := range facets {
+		result = append(result, facet)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Genre.Name < result[j].Genre.Name
+	})
+	return result, nil
+}
+
+func applyFilter(movies []*domain.Movie, filter domain.MovieFilter) []*domain.Movie {
+	filtered := make([]*domain.Movie, 0, len(movies))
+	for _, movie := range movies {
+		if matchesFilter(movie, filter) {
+			filtered = append(filtered, movie)
+		}
+	}
+	return filtered
+}
+
+func matchesFilter(movie *domain.Movie, filter domain.MovieFilter) bool {
+	if filter.Country != "" && !slices.Contains(movie.Countries, filter.Country) {
+		return false
+	}
+	if filter.Language != "" && movie.OriginalLanguage != filter.Language {
+		return false
+	}
+	if filter.MaxAgeRating != "" {
+		rank := domain.AgeRatingRank(movie.AgeRating)
+		if rank < 0 || rank > domain.AgeRatingRank(filter.MaxAgeRating) {
+			return false
+		}
+	}
+	if filter.MinDuration > 0 && movie.DurationMinutes < filter.MinDuration {
+		return false
+	}
+	if filter.MaxDuration > 0 && (movie.DurationMinutes == 0 || movie.DurationMinutes > filter.MaxDuration) {
+		return false
+	}
+	return hasGenres(movie, filter.GenreIDs)
+}
+
+func hasGenres(movie *domain.Movie, genreIDs []uuid.UUID) bool {
+	for _, id := range genreIDs {
+		found := false
+		for _, genre := range movie.Genres {
+			if genre.ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// markWatchlist flags the movies the requesting user has on their watchlist.
+// Requests without a user in the context are left untouched.
+func (s *MovieService) markWatchlist(ctx context.Context, movies []*domain.Movie) error {
+	user, ok := UserFromContext(ctx)
+	if !ok || len(movies) == 0 {
+		return nil
+	}
+
+	ids, err := s.repo.GetWatchlistMovieIDs(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+	for _, movie := range movies {
+		_, movie.InWatchlist = ids[movie.ID]
+	}
+	return nil
+}
+
+// localize translates the movies into the locales preferred by the client.
+// Requests without preferred locales get the original text.
+func (s *MovieService) localize(ctx context.Context, movies []*domain.Movie) error {
+	locales := LocalesFromContext(ctx)
+	if len(locales) == 0 || len(movies) == 0 {
+		return nil
+	}
+
+	translations, err := s.repo.GetMovieTranslations(ctx, movieIDs(movies), locales)
+	if err != nil {
+		return err
+	}
+	localizeMovies(movies, translations, locales)
+	return nil
+}
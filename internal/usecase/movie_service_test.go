@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -21,7 +22,7 @@ func TestCreateMovie(t *testing.T) {
 
 	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
 
-	movieService := NewMovieService(mockRepo)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
 
 	testCases := []struct {
 		name     string
@@ -34,6 +35,7 @@ func TestCreateMovie(t *testing.T) {
 			movie: &domain.Movie{},
 			mockFunc: func() {
 				mockRepo.EXPECT().CreateMovie(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetMovieByID(gomock.Any(), gomock.Any()).Return(&domain.Movie{}, nil)
 			},
 			wantErr: false,
 		},
@@ -68,7 +70,7 @@ func TestUpdateMovie(t *testing.T) {
 
 	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
 
-	movieService := NewMovieService(mockRepo)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
 
 	testCases := []struct {
 		name     string
@@ -80,6 +82,7 @@ func TestUpdateMovie(t *testing.T) {
 			name:  "Update movie successfully",
 			movie: &domain.Movie{},
 			mockFunc: func() {
+				mockRepo.EXPECT().GetMovieByID(gomock.Any(), gomock.Any()).Return(&domain.Movie{}, nil).Times(2)
 				mockRepo.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
@@ -88,10 +91,19 @@ func TestUpdateMovie(t *testing.T) {
 			name:  "Update movie fails",
 			movie: &domain.Movie{},
 			mockFunc: func() {
+				mockRepo.EXPECT().GetMovieByID(gomock.Any(), gomock.Any()).Return(&domain.Movie{}, nil)
 				mockRepo.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			wantErr: true,
 		},
+		{
+			name:  "Update missing movie",
+			movie: &domain.Movie{},
+			mockFunc: func() {
+				mockRepo.EXPECT().GetMovieByID(gomock.Any(), gomock.Any()).Return(nil, domain.ErrNotFound)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -109,13 +121,43 @@ func TestUpdateMovie(t *testing.T) {
 	}
 }
 
+// directTx runs units of work without a transaction.
+type directTx struct{}
+
+func (directTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestCreateMovieWithGenresInTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	mockTx := mock_repo.NewMockTransactor(ctrl)
+	movieService := NewMovieService(mockRepo, mockTx, noAudit{}, noVersions{}, noCache{}, noEvents{})
+
+	genreID := uuid.New()
+	movie := &domain.Movie{Genres: []*domain.Genre{{ID: genreID}}}
+	txCtx := context.WithValue(context.Background(), directTx{}, "tx")
+
+	mockTx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(txCtx)
+		})
+	mockRepo.EXPECT().CreateMovie(txCtx, movie).Return(nil)
+	mockRepo.EXPECT().SetMovieGenres(txCtx, movie.ID, []uuid.UUID{genreID}).Return(domain.ErrNotFound)
+
+	err := movieService.CreateMovie(context.Background(), movie)
+	assert.ErrorIs(t, err, ErrUnknownGenre)
+}
+
 func TestDeleteMovie(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
 
-	movieService := NewMovieService(mockRepo)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
 
 	testCases := []struct {
 		name     string
@@ -127,6 +169,7 @@ func TestDeleteMovie(t *testing.T) {
 			name:    "Delete movie successfully",
 			movieID: uuid.New(),
 			mockFunc: func() {
+				mockRepo.EXPECT().GetMovieByID(gomock.Any(), gomock.Any()).Return(&domain.Movie{}, nil)
 				mockRepo.EXPECT().DeleteMovie(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
@@ -135,17 +178,26 @@ func TestDeleteMovie(t *testing.T) {
 			name:    "Delete movie fails",
 			movieID: uuid.New(),
 			mockFunc: func() {
+				mockRepo.EXPECT().GetMovieByID(gomock.Any(), gomock.Any()).Return(&domain.Movie{}, nil)
 				mockRepo.EXPECT().DeleteMovie(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			wantErr: true,
 		},
+		{
+			name:    "Delete missing movie",
+			movieID: uuid.New(),
+			mockFunc: func() {
+				mockRepo.EXPECT().GetMovieByID(gomock.Any(), gomock.Any()).Return(nil, domain.ErrNotFound)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			err := movieService.DeleteMovie(context.Background(), tc.movieID)
+			err := movieService.DeleteMovie(context.Background(), tc.movieID, 0)
 
 			if tc.wantErr {
 				assert.Error(t, err)
@@ -156,13 +208,98 @@ func TestDeleteMovie(t *testing.T) {
 	}
 }
 
+func TestMovieVersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+	movieID := uuid.New()
+	stored := &domain.Movie{ID: movieID, Title: "Heat", Version: 3}
+
+	mockRepo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(stored, nil).Times(2)
+	err := movieService.UpdateMovie(context.Background(), &domain.Movie{ID: movieID, Title: "Ronin", Version: 2})
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	err = movieService.DeleteMovie(context.Background(), movieID, 2)
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+
+	// A matching version updates the movie and reports the new version.
+	movie := &domain.Movie{ID: movieID, Title: "Ronin", Version: 3}
+	mockRepo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(stored, nil)
+	mockRepo.EXPECT().UpdateMovie(gomock.Any(), movie).Return(nil)
+	mockRepo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(&domain.Movie{ID: movieID, Title: "Ronin", Version: 4}, nil)
+	require.NoError(t, movieService.UpdateMovie(context.Background(), movie))
+	assert.Equal(t, 4, movie.Version)
+}
+
+func TestPatchMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+	movieID := uuid.New()
+	genre := &domain.Genre{ID: uuid.New(), Name: "Crime"}
+	stored := &domain.Movie{ID: movieID, Title: "Heat", Description: "Heist", Rating: 8,
+		Date: time.Date(1995, 12, 15, 0, 0, 0, 0, time.UTC), Genres: []*domain.Genre{genre}, Version: 3}
+
+	// Only the changed fields are written.
+	mockRepo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(stored, nil)
+	mockRepo.EXPECT().UpdateMovieFields(gomock.Any(), gomock.Any(), []string{"Description", "Rating"}).
+		DoAndReturn(func(_ context.Context, movie *domain.Movie, _ []string) error {
+			assert.Equal(t, "Heat", movie.Title)
+			assert.Equal(t, "Bank heist", movie.Description)
+			return nil
+		})
+	mockRepo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(&domain.Movie{ID: movieID, Title: "Heat", Version: 4}, nil)
+	movie, err := movieService.PatchMovie(context.Background(), movieID, 3, func(movie *domain.Movie) error {
+		movie.Description = "Bank heist"
+		movie.Rating = 9
+		// Genres with the same IDs are not a change.
+		movie.Genres = []*domain.Genre{{ID: genre.ID}}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, movie.Version)
+	assert.Equal(t, "Heat", stored.Title, "the stored movie must not be changed")
+
+	// A patch that changes nothing writes nothing.
+	mockRepo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(stored, nil)
+	movie, err = movieService.PatchMovie(context.Background(), movieID, 0, func(*domain.Movie) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, stored, movie)
+
+	// The patched movie is validated.
+	mockRepo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(stored, nil)
+	_, err = movieService.PatchMovie(context.Background(), movieID, 0, func(movie *domain.Movie) error {
+		movie.AgeRating = "21+"
+		return nil
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidMovie)
+
+	mockRepo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(stored, nil)
+	_, err = movieService.PatchMovie(context.Background(), movieID, 2, func(*domain.Movie) error { return nil })
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+
+	// Clearing the empty genres and countries of a movie is not a change.
+	bare := &domain.Movie{ID: movieID, Title: "Heat", Genres: []*domain.Genre{}, Countries: []string{}, Version: 3}
+	mockRepo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(bare, nil)
+	movie, err = movieService.PatchMovie(context.Background(), movieID, 3, func(movie *domain.Movie) error {
+		movie.Genres = nil
+		movie.Countries = nil
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, bare, movie)
+}
+
 func TestGetMovies(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
 
-	movieService := NewMovieService(mockRepo)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
 
 	testCases := []struct {
 		name     string
@@ -216,7 +353,7 @@ func TestGetMoviesFilter(t *testing.T) {
 
 	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
 
-	movieService := NewMovieService(mockRepo)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
 
 	movies := []*domain.Movie{
 		&domain.Movie{ID: func() uuid.UUID { id, _ := uuid.Parse("6ec91a6d-12ce-4bd1-b7f1-e70b94eeef0b"); return id }(), Title: "Movie B", Rating: 8.5, Date: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
@@ -321,7 +458,7 @@ func TestGetMoviesFilter(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockFunc()
 
-			movies, err := movieService.GetMoviesFilter(context.Background(), tc.filter)
+			movies, err := movieService.GetMoviesFilter(context.Background(), domain.MovieFilter{Sort: tc.filter})
 
 			if tc.wantErr {
 				assert.Error(t, err)
@@ -378,7 +515,7 @@ func TestGetMoviesBySnippet(t *testing.T) {
 			mockRepo := mock_repo.NewMockMovieRepo(ctrl)
 			tc.mockBehavior(mockRepo, tc.snippet)
 
-			service := NewMovieService(mockRepo)
+			service := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
 
 			movies, err := service.GetMoviesBySnippet(context.Background(), tc.snippet)
 
@@ -390,4 +527,133 @@ func TestGetMoviesBySnippet(t *testing.T) {
 			assert.Equal(t, tc.expectedMovies, movies)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestGetMoviesFilterMarksWatchlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+
+	user := &UserInfo{UserID: uuid.New(), Role: domain.USER}
+	watched := &domain.Movie{ID: uuid.New(), Title: "Movie A"}
+	other := &domain.Movie{ID: uuid.New(), Title: "Movie B"}
+
+	mockRepo.EXPECT().GetMovies(gomock.Any()).Return([]*domain.Movie{other, watched}, nil)
+	mockRepo.EXPECT().GetWatchlistMovieIDs(gomock.Any(), user.UserID).Return(map[uuid.UUID]struct{}{watched.ID: {}}, nil)
+
+	ctx := context.WithValue(context.Background(), UserCtx, user)
+	movies, err := movieService.GetMoviesFilter(ctx, domain.MovieFilter{Sort: "title"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Movie{
+		{ID: watched.ID, Title: "Movie A", InWatchlist: true},
+		{ID: other.ID, Title: "Movie B", InWatchlist: false},
+	}, movies)
+}
+
+func TestGenreFilterAndFacets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+
+	drama := &domain.Genre{ID: uuid.New(), Name: "Drama"}
+	comedy := &domain.Genre{ID: uuid.New(), Name: "Comedy"}
+	crime := &domain.Genre{ID: uuid.New(), Name: "Crime"}
+	movies := []*domain.Movie{
+		{ID: uuid.New(), Title: "A", Rating: 5, Genres: []*domain.Genre{drama, comedy}},
+		{ID: uuid.New(), Title: "B", Rating: 7, Genres: []*domain.Genre{drama, crime}},
+		{ID: uuid.New(), Title: "C", Rating: 9, Genres: []*domain.Genre{comedy}},
+	}
+	mockRepo.EXPECT().GetMovies(gomock.Any()).Return(movies, nil).Times(2)
+
+	filtered, err := movieService.GetMoviesFilter(context.Background(), domain.MovieFilter{
+		Sort:     "rating",
+		GenreIDs: []uuid.UUID{drama.ID},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Movie{movies[1], movies[0]}, filtered)
+
+	facets, err := movieService.GetGenreFacets(context.Background(), domain.MovieFilter{
+		GenreIDs: []uuid.UUID{drama.ID},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.GenreFacet{
+		{Genre: drama, Count: 2},
+		{Genre: comedy, Count: 1},
+		{Genre: crime, Count: 1},
+	}, facets)
+}
+
+func TestCreateMovieSetsGenres(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+
+	movieID := uuid.New()
+	genreID := uuid.New()
+	movie := &domain.Movie{Title: "Movie", Genres: []*domain.Genre{{ID: genreID}}}
+
+	mockRepo.EXPECT().CreateMovie(gomock.Any(), movie).DoAndReturn(func(_ context.Context, m *domain.Movie) error {
+		m.ID = movieID
+		return nil
+	})
+	mockRepo.EXPECT().SetMovieGenres(gomock.Any(), movieID, []uuid.UUID{genreID}).Return(domain.ErrNotFound)
+
+	err := movieService.CreateMovie(context.Background(), movie)
+	assert.ErrorIs(t, err, ErrUnknownGenre)
+}
+
+func TestCreateMovieValidatesMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+
+	for _, movie := range []*domain.Movie{
+		{Title: "A", DurationMinutes: -1},
+		{Title: "A", AgeRating: "21+"},
+		{Title: "A", Countries: []string{"usa"}},
+		{Title: "A", OriginalLanguage: "English"},
+		{Title: "A", IMDbID: "0133093"},
+	} {
+		err := movieService.CreateMovie(context.Background(), movie)
+		assert.ErrorIs(t, err, domain.ErrInvalidMovie)
+	}
+}
+
+func TestMetadataFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+
+	movies := []*domain.Movie{
+		{ID: uuid.New(), Title: "A", Rating: 5, DurationMinutes: 90, AgeRating: "12+", Countries: []string{"US"}, OriginalLanguage: "en"},
+		{ID: uuid.New(), Title: "B", Rating: 7, DurationMinutes: 150, AgeRating: "18+", Countries: []string{"US", "GB"}, OriginalLanguage: "en"},
+		{ID: uuid.New(), Title: "C", Rating: 9, DurationMinutes: 120, AgeRating: "6+", Countries: []string{"RU"}, OriginalLanguage: "ru"},
+	}
+	mockRepo.EXPECT().GetMovies(gomock.Any()).Return(movies, nil).Times(2)
+
+	filtered, err := movieService.GetMoviesFilter(context.Background(), domain.MovieFilter{
+		Sort:         "rating",
+		Country:      "US",
+		MaxAgeRating: "16+",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Movie{movies[0]}, filtered)
+
+	filtered, err = movieService.GetMoviesFilter(context.Background(), domain.MovieFilter{
+		Sort:        "rating",
+		Language:    "en",
+		MinDuration: 100,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Movie{movies[1]}, filtered)
+}
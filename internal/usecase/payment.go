@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=payment.go -destination=mocks/paymentMock.go
+
+type PaymentRepo interface {
+	CreatePayment(ctx context.Context, payment *domain.Payment) error
+	GetPaymentByBookingID(ctx context.Context, bookingID uuid.UUID) (*domain.Payment, error)
+	GetPaymentByIntentID(ctx context.Context, intentID string) (*domain.Payment, error)
+	// UpdatePaymentStatus moves a payment from status from to status to. It
+	// returns domain.ErrPaymentState if the payment is no longer in status from.
+	UpdatePaymentStatus(ctx context.Context, paymentID uuid.UUID, from string, to string) error
+	SaveWebhookEvent(ctx context.Context, eventID string) (bool, error)
+}
+
+type PaymentProvider interface {
+	CreateIntent(ctx context.Context, amount int64, currency string, bookingID uuid.UUID) (*domain.PaymentIntent, error)
+	// CancelIntent cancels an intent that was not captured. Cancelling it
+	// again has no effect.
+	CancelIntent(ctx context.Context, intentID string) error
+	// Capture and Refund are idempotent per idempotencyKey: repeating a call
+	// with the same key returns the first result without charging or
+	// refunding again.
+	Capture(ctx context.Context, intentID string, idempotencyKey string) error
+	Refund(ctx context.Context, intentID string, amount int64, idempotencyKey string) error
+	VerifyWebhook(payload []byte, signature string) (*domain.PaymentEvent, error)
+}
+
+type PaymentService struct {
+	repo     PaymentRepo
+	tx       Transactor
+	provider PaymentProvider
+}
+
+func NewPaymentService(repo PaymentRepo, tx Transactor, provider PaymentProvider) *PaymentService {
+	return &PaymentService{repo: repo, tx: tx, provider: provider}
+}
+
+// CreatePayment starts a payment for a booking on behalf of the current user,
+// who becomes its owner. A booking has a single payment: a second one fails
+// with domain.ErrAlreadyExists, and the intent created for it is cancelled.
+func (s *PaymentService) CreatePayment(ctx context.Context, bookingID uuid.UUID, amount int64, currency string) (*domain.Payment, *domain.PaymentIntent, error) {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return nil, nil, fmt.Errorf("create payment: %w", domain.ErrForbidden)
+	}
+
+	intent, err := s.provider.CreateIntent(ctx, amount, currency, bookingID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create payment intent: %w", err)
+	}
+
+	payment := &domain.Payment{
+		BookingID: bookingID,
+		UserID:    user.UserID,
+		IntentID:  intent.ID,
+		Amount:    intent.Amount,
+		Currency:  intent.Currency,
+		Status:    domain.PaymentPending,
+	}
+	if err = s.repo.CreatePayment(ctx, payment); err != nil {
+		if cancelErr := s.provider.CancelIntent(ctx, intent.ID); cancelErr != nil {
+			slog.Error("Failed to cancel unused payment intent", "intent", intent.ID, "err", cancelErr)
+		}
+		return nil, nil, fmt.Errorf("create payment: %w", err)
+	}
+	return payment, intent, nil
+}
+
+// CapturePayment captures a pending payment. The provider call is keyed by
+// the payment, so a capture retried after a failed status update does not
+// charge twice.
+func (s *PaymentService) CapturePayment(ctx context.Context, bookingID uuid.UUID) error {
+	payment, err := s.repo.GetPaymentByBookingID(ctx, bookingID)
+	if err != nil {
+		return fmt.Errorf("capture payment: %w", err)
+	}
+	if payment.Status != domain.PaymentPending {
+		return fmt.Errorf("capture payment: %w", domain.ErrPaymentState)
+	}
+
+	if err = s.provider.Capture(ctx, payment.IntentID, "capture-"+payment.ID.String()); err != nil {
+		return fmt.Errorf("capture payment: %w", err)
+	}
+	if err = s.repo.UpdatePaymentStatus(ctx, payment.ID, domain.PaymentPending, domain.PaymentCaptured); err != nil {
+		return fmt.Errorf("capture payment: %w", err)
+	}
+	return nil
+}
+
+// CancelPayment is called when a booking is cancelled. A captured payment is
+// refunded in full, a pending one is just marked as cancelled. Only the owner
+// of the payment or an admin may cancel it, and the refund is keyed by the
+// payment so it is never issued twice.
+func (s *PaymentService) CancelPayment(ctx context.Context, bookingID uuid.UUID) error {
+	payment, err := s.repo.GetPaymentByBookingID(ctx, bookingID)
+	if err != nil {
+		return fmt.Errorf("cancel payment: %w", err)
+	}
+	user, ok := UserFromContext(ctx)
+	if !ok || (user.Role != domain.ADMIN && user.UserID != payment.UserID) {
+		return fmt.Errorf("cancel payment: %w", domain.ErrForbidden)
+	}
+
+	switch payment.Status {
+	case domain.PaymentCaptured:
+		if err = s.provider.Refund(ctx, payment.IntentID, payment.Amount, "refund-"+payment.ID.String()); err != nil {
+			return fmt.Errorf("refund payment: %w", err)
+		}
+		err = s.repo.UpdatePaymentStatus(ctx, payment.ID, domain.PaymentCaptured, domain.PaymentRefunded)
+	case domain.PaymentPending:
+		err = s.repo.UpdatePaymentStatus(ctx, payment.ID, domain.PaymentPending, domain.PaymentCanceled)
+	default:
+		return fmt.Errorf("cancel payment: %w", domain.ErrPaymentState)
+	}
+	if err != nil {
+		return fmt.Errorf("cancel payment: %w", err)
+	}
+	return nil
+}
+
+// webhookStatuses maps provider notifications to the payment status they
+// report.
+var webhookStatuses = map[string]string{
+	domain.PaymentEventSucceeded: domain.PaymentCaptured,
+	domain.PaymentEventFailed:    domain.PaymentFailed,
+	domain.PaymentEventRefunded:  domain.PaymentRefunded,
+}
+
+// HandleWebhook applies a provider notification. Events are deduplicated by
+// their provider ID, so redelivered webhooks are acknowledged without effect.
+// The event is recorded in the same transaction as the status change: if the
+// update fails the provider's retry is applied again. Notifications arriving
+// out of order, such as a late payment.failed for a captured payment, are
+// acknowledged without changing the payment.
+func (s *PaymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := s.provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return fmt.Errorf("verify webhook: %w", err)
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		isNew, err := s.repo.SaveWebhookEvent(ctx, event.ID)
+		if err != nil || !isNew {
+			return err
+		}
+
+		status, ok := webhookStatuses[event.Type]
+		if !ok {
+			return nil
+		}
+		payment, err := s.repo.GetPaymentByIntentID(ctx, event.IntentID)
+		if err != nil {
+			return err
+		}
+		if payment.Status == status {
+			return nil
+		}
+		if !domain.CanTransitionPayment(payment.Status, status) {
+			slog.Warn("Ignoring payment webhook", "event", event.ID, "type", event.Type,
+				"payment", payment.ID, "status", payment.Status)
+			return nil
+		}
+		return s.repo.UpdatePaymentStatus(ctx, payment.ID, payment.Status, status)
+	})
+	if err != nil {
+		return fmt.Errorf("handle webhook: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreatePayment(t *testing.T) {
+	bookingID := uuid.New()
+	userID := uuid.New()
+	type mockBehavior func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider)
+
+	testCases := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "Create payment successfully",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				p.EXPECT().CreateIntent(gomock.Any(), int64(500), "RUB", bookingID).
+					Return(&domain.PaymentIntent{ID: "pi_1", Amount: 500, Currency: "RUB"}, nil)
+				r.EXPECT().CreatePayment(gomock.Any(), &domain.Payment{
+					BookingID: bookingID,
+					UserID:    userID,
+					IntentID:  "pi_1",
+					Amount:    500,
+					Currency:  "RUB",
+					Status:    domain.PaymentPending,
+				}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Provider error",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				p.EXPECT().CreateIntent(gomock.Any(), int64(500), "RUB", bookingID).Return(nil, errors.New("provider error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Repository error",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				p.EXPECT().CreateIntent(gomock.Any(), int64(500), "RUB", bookingID).
+					Return(&domain.PaymentIntent{ID: "pi_1", Amount: 500, Currency: "RUB"}, nil)
+				r.EXPECT().CreatePayment(gomock.Any(), gomock.Any()).Return(errors.New("repository error"))
+				p.EXPECT().CancelIntent(gomock.Any(), "pi_1").Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Booking already paid",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				p.EXPECT().CreateIntent(gomock.Any(), int64(500), "RUB", bookingID).
+					Return(&domain.PaymentIntent{ID: "pi_2", Amount: 500, Currency: "RUB"}, nil)
+				r.EXPECT().CreatePayment(gomock.Any(), gomock.Any()).Return(domain.ErrAlreadyExists)
+				p.EXPECT().CancelIntent(gomock.Any(), "pi_2").Return(errors.New("provider error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockPaymentRepo(ctrl)
+			provider := mock_repo.NewMockPaymentProvider(ctrl)
+			tc.mockBehavior(repo, provider)
+
+			service := NewPaymentService(repo, directTx{}, provider)
+			ctx := context.WithValue(context.Background(), UserCtx, &UserInfo{UserID: userID, Role: domain.USER})
+			payment, intent, err := service.CreatePayment(ctx, bookingID, 500, "RUB")
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, payment)
+				assert.Nil(t, intent)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "pi_1", payment.IntentID)
+				assert.Equal(t, "pi_1", intent.ID)
+			}
+		})
+	}
+}
+
+func TestCapturePayment(t *testing.T) {
+	bookingID := uuid.New()
+	paymentID := uuid.New()
+	type mockBehavior func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider)
+
+	testCases := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name: "Capture pending payment",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				r.EXPECT().GetPaymentByBookingID(gomock.Any(), bookingID).Return(&domain.Payment{
+					ID: paymentID, IntentID: "pi_1", Amount: 500, Status: domain.PaymentPending,
+				}, nil)
+				p.EXPECT().Capture(gomock.Any(), "pi_1", "capture-"+paymentID.String()).Return(nil)
+				r.EXPECT().UpdatePaymentStatus(gomock.Any(), paymentID, domain.PaymentPending, domain.PaymentCaptured).Return(nil)
+			},
+		},
+		{
+			name: "Captured concurrently",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				r.EXPECT().GetPaymentByBookingID(gomock.Any(), bookingID).Return(&domain.Payment{
+					ID: paymentID, IntentID: "pi_1", Amount: 500, Status: domain.PaymentPending,
+				}, nil)
+				p.EXPECT().Capture(gomock.Any(), "pi_1", "capture-"+paymentID.String()).Return(nil)
+				r.EXPECT().UpdatePaymentStatus(gomock.Any(), paymentID, domain.PaymentPending, domain.PaymentCaptured).
+					Return(domain.ErrPaymentState)
+			},
+			wantErr: domain.ErrPaymentState,
+		},
+		{
+			name: "Already captured",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				r.EXPECT().GetPaymentByBookingID(gomock.Any(), bookingID).Return(&domain.Payment{
+					ID: paymentID, IntentID: "pi_1", Amount: 500, Status: domain.PaymentCaptured,
+				}, nil)
+			},
+			wantErr: domain.ErrPaymentState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockPaymentRepo(ctrl)
+			provider := mock_repo.NewMockPaymentProvider(ctrl)
+			tc.mockBehavior(repo, provider)
+
+			service := NewPaymentService(repo, directTx{}, provider)
+			err := service.CapturePayment(context.Background(), bookingID)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCancelPayment(t *testing.T) {
+	errProvider := errors.New("provider error")
+	bookingID := uuid.New()
+	paymentID := uuid.New()
+	owner := &UserInfo{UserID: uuid.New(), Role: domain.USER}
+	type mockBehavior func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider)
+
+	testCases := []struct {
+		name         string
+		user         *UserInfo
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name: "Refund captured payment",
+			user: owner,
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				r.EXPECT().GetPaymentByBookingID(gomock.Any(), bookingID).Return(&domain.Payment{
+					ID: paymentID, UserID: owner.UserID, IntentID: "pi_1", Amount: 500, Status: domain.PaymentCaptured,
+				}, nil)
+				p.EXPECT().Refund(gomock.Any(), "pi_1", int64(500), "refund-"+paymentID.String()).Return(nil)
+				r.EXPECT().UpdatePaymentStatus(gomock.Any(), paymentID, domain.PaymentCaptured, domain.PaymentRefunded).Return(nil)
+			},
+		},
+		{
+			name: "Cancel pending payment",
+			user: owner,
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				r.EXPECT().GetPaymentByBookingID(gomock.Any(), bookingID).Return(&domain.Payment{
+					ID: paymentID, UserID: owner.UserID, IntentID: "pi_1", Amount: 500, Status: domain.PaymentPending,
+				}, nil)
+				r.EXPECT().UpdatePaymentStatus(gomock.Any(), paymentID, domain.PaymentPending, domain.PaymentCanceled).Return(nil)
+			},
+		},
+		{
+			name: "Admin cancels payment of another user",
+			user: &UserInfo{UserID: uuid.New(), Role: domain.ADMIN},
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				r.EXPECT().GetPaymentByBookingID(gomock.Any(), bookingID).Return(&domain.Payment{
+					ID: paymentID, UserID: owner.UserID, IntentID: "pi_1", Amount: 500, Status: domain.PaymentPending,
+				}, nil)
+				r.EXPECT().UpdatePaymentStatus(gomock.Any(), paymentID, domain.PaymentPending, domain.PaymentCanceled).Return(nil)
+			},
+		},
+		{
+			name: "Payment of another user",
+			user: &UserInfo{UserID: uuid.New(), Role: domain.USER},
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				r.EXPECT().GetPaymentByBookingID(gomock.Any(), bookingID).Return(&domain.Payment{
+					ID: paymentID, UserID: owner.UserID, IntentID: "pi_1", Amount: 500, Status: domain.PaymentCaptured,
+				}, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "Already refunded",
+			user: owner,
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				r.EXPECT().GetPaymentByBookingID(gomock.Any(), bookingID).Return(&domain.Payment{
+					ID: paymentID, UserID: owner.UserID, IntentID: "pi_1", Amount: 500, Status: domain.PaymentRefunded,
+				}, nil)
+			},
+			wantErr: domain.ErrPaymentState,
+		},
+		{
+			name: "Refund fails",
+			user: owner,
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				r.EXPECT().GetPaymentByBookingID(gomock.Any(), bookingID).Return(&domain.Payment{
+					ID: paymentID, UserID: owner.UserID, IntentID: "pi_1", Amount: 500, Status: domain.PaymentCaptured,
+				}, nil)
+				p.EXPECT().Refund(gomock.Any(), "pi_1", int64(500), "refund-"+paymentID.String()).Return(errProvider)
+			},
+			wantErr: errProvider,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockPaymentRepo(ctrl)
+			provider := mock_repo.NewMockPaymentProvider(ctrl)
+			tc.mockBehavior(repo, provider)
+
+			service := NewPaymentService(repo, directTx{}, provider)
+			ctx := context.WithValue(context.Background(), UserCtx, tc.user)
+			err := service.CancelPayment(ctx, bookingID)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHandleWebhook(t *testing.T) {
+	paymentID := uuid.New()
+	payload := []byte(`{"id":"evt_1","type":"payment.succeeded","intent_id":"pi_1"}`)
+	event := &domain.PaymentEvent{ID: "evt_1", Type: domain.PaymentEventSucceeded, IntentID: "pi_1"}
+	type mockBehavior func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider)
+
+	testCases := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "First delivery updates payment",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				p.EXPECT().VerifyWebhook(payload, "sig").Return(event, nil)
+				r.EXPECT().SaveWebhookEvent(gomock.Any(), "evt_1").Return(true, nil)
+				r.EXPECT().GetPaymentByIntentID(gomock.Any(), "pi_1").Return(&domain.Payment{ID: paymentID, Status: domain.PaymentPending}, nil)
+				r.EXPECT().UpdatePaymentStatus(gomock.Any(), paymentID, domain.PaymentPending, domain.PaymentCaptured).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Late failure does not overwrite capture",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				p.EXPECT().VerifyWebhook(payload, "sig").
+					Return(&domain.PaymentEvent{ID: "evt_2", Type: domain.PaymentEventFailed, IntentID: "pi_1"}, nil)
+				r.EXPECT().SaveWebhookEvent(gomock.Any(), "evt_2").Return(true, nil)
+				r.EXPECT().GetPaymentByIntentID(gomock.Any(), "pi_1").
+					Return(&domain.Payment{ID: paymentID, Status: domain.PaymentCaptured}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed update is returned for a retry",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				p.EXPECT().VerifyWebhook(payload, "sig").Return(event, nil)
+				r.EXPECT().SaveWebhookEvent(gomock.Any(), "evt_1").Return(true, nil)
+				r.EXPECT().GetPaymentByIntentID(gomock.Any(), "pi_1").
+					Return(&domain.Payment{ID: paymentID, Status: domain.PaymentPending}, nil)
+				r.EXPECT().UpdatePaymentStatus(gomock.Any(), paymentID, domain.PaymentPending, domain.PaymentCaptured).
+					Return(errors.New("repository error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Redelivery is ignored",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				p.EXPECT().VerifyWebhook(payload, "sig").Return(event, nil)
+				r.EXPECT().SaveWebhookEvent(gomock.Any(), "evt_1").Return(false, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid signature",
+			mockBehavior: func(r *mock_repo.MockPaymentRepo, p *mock_repo.MockPaymentProvider) {
+				p.EXPECT().VerifyWebhook(payload, "sig").Return(nil, errors.New("invalid signature"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockPaymentRepo(ctrl)
+			provider := mock_repo.NewMockPaymentProvider(ctrl)
+			tc.mockBehavior(repo, provider)
+
+			service := NewPaymentService(repo, directTx{}, provider)
+			err := service.HandleWebhook(context.Background(), payload, "sig")
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
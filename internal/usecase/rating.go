@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=rating.go -destination=mocks/ratingMock.go
+
+var (
+	ErrInvalidScore        = errors.New("score must be between 0 and 10")
+	ErrInvalidReviewStatus = errors.New("invalid review status")
+)
+
+type RatingRepo interface {
+	UpsertRating(ctx context.Context, rating *domain.Rating) error
+	DeleteRating(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error
+	GetRatingStats(ctx context.Context, movieID uuid.UUID) (float64, int, error)
+	GetGlobalRatingMean(ctx context.Context) (float64, error)
+	GetRatedMovieIDs(ctx context.Context) ([]uuid.UUID, error)
+	// UpdateMovieRating stores the rating and vote count of a movie. Votes
+	// are not edits of the movie, so its version is left as it is.
+	UpdateMovieRating(ctx context.Context, summary *domain.RatingSummary) error
+	GetRatingSummary(ctx context.Context, movieID uuid.UUID) (*domain.RatingSummary, error)
+	UpsertReview(ctx context.Context, review *domain.Review) error
+	GetReviews(ctx context.Context, movieID uuid.UUID, status string, limit int, offset int) ([]*domain.Review, error)
+	UpdateReviewStatus(ctx context.Context, reviewID uuid.UUID, status string) error
+}
+
+type RatingService struct {
+	repo  RatingRepo
+	tx    Transactor
+	cache Cache
+	// minVotes is the prior weight of the Bayesian average; zero means the
+	// plain average is used.
+	minVotes int
+}
+
+func NewRatingService(repo RatingRepo, tx Transactor, cache Cache, minVotes int) *RatingService {
+	return &RatingService{repo: repo, tx: tx, cache: cache, minVotes: minVotes}
+}
+
+func (s *RatingService) RateMovie(ctx context.Context, rating *domain.Rating) (*domain.RatingSummary, error) {
+	if rating.Score < domain.MinRatingScore || rating.Score > domain.MaxRatingScore {
+		return nil, ErrInvalidScore
+	}
+
+	mean, err := s.globalMean(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var summary *domain.RatingSummary
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpsertRating(ctx, rating); err != nil {
+			return fmt.Errorf("rate movie: %w", err)
+		}
+		var err error
+		summary, err = s.store(ctx, rating.MovieID, mean)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return summary, nil
+}
+
+func (s *RatingService) DeleteRating(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) (*domain.RatingSummary, error) {
+	mean, err := s.globalMean(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var summary *domain.RatingSummary
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteRating(ctx, userID, movieID); err != nil {
+			return fmt.Errorf("delete rating: %w", err)
+		}
+		var err error
+		summary, err = s.store(ctx, movieID, mean)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return summary, nil
+}
+
+func (s *RatingService) GetRatingSummary(ctx context.Context, movieID uuid.UUID) (*domain.RatingSummary, error) {
+	summary, err := s.repo.GetRatingSummary(ctx, movieID)
+	if err != nil {
+		return nil, fmt.Errorf("get rating summary: %w", err)
+	}
+	return summary, nil
+}
+
+// globalMean returns the mean of all votes that the Bayesian average pulls
+// ratings towards, or zero if the plain average is used. It is read before
+// the transaction of a vote, which then only touches the rated movie, so
+// votes for different movies do not conflict.
+func (s *RatingService) globalMean(ctx context.Context) (float64, error) {
+	if s.minVotes == 0 {
+		return 0, nil
+	}
+	mean, err := s.repo.GetGlobalRatingMean(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get global rating mean: %w", err)
+	}
+	return mean, nil
+}
+
+// RecomputeRatings stores the Bayesian average of every rated movie. A vote
+// only recomputes its own movie, so as the mean of all votes moves the
+// ratings of the others drift; this brings them back in line. Each movie is
+// written on its own, without a transaction spanning the catalogue. It
+// returns the number of movies recomputed.
+func (s *RatingService) RecomputeRatings(ctx context.Context) (int, error) {
+	if s.minVotes == 0 {
+		return 0, nil
+	}
+	mean, err := s.globalMean(ctx)
+	if err != nil {
+		return 0, err
+	}
+	movieIDs, err := s.repo.GetRatedMovieIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("recompute ratings: %w", err)
+	}
+	recomputed := 0
+	for _, movieID := range movieIDs {
+		if _, err = s.store(ctx, movieID, mean); err != nil {
+			break
+		}
+		recomputed++
+	}
+	if recomputed > 0 {
+		invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	}
+	return recomputed, err
+}
+
+// RunRecompute recomputes the ratings every interval until ctx is canceled.
+// Failures are logged and retried on the next tick.
+func (s *RatingService) RunRecompute(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := s.RecomputeRatings(ctx); err != nil {
+			slog.Error("Failed to recompute ratings", "err", err)
+		}
+	}
+}
+
+// store stores the rating of a movie computed from its votes, given the
+// mean of all votes. It runs in the transaction that changed the votes, so
+// concurrent votes for the movie cannot leave a stale rating behind.
+func (s *RatingService) store(ctx context.Context, movieID uuid.UUID, mean float64) (*domain.RatingSummary, error) {
+	avg, votes, err := s.repo.GetRatingStats(ctx, movieID)
+	if err != nil {
+		return nil, fmt.Errorf("recompute rating: %w", err)
+	}
+
+	rating := avg
+	if s.minVotes > 0 && votes > 0 {
+		rating = bayesianAverage(avg, votes, mean, s.minVotes)
+	}
+
+	summary := &domain.RatingSummary{
+		MovieID: movieID,
+		Rating:  float32(rating),
+		Votes:   votes,
+	}
+	if err = s.repo.UpdateMovieRating(ctx, summary); err != nil {
+		return nil, fmt.Errorf("recompute rating: %w", err)
+	}
+	return summary, nil
+}
+
+// bayesianAverage pulls the average of movies with few votes towards the
+// mean of all ratings, so a single 10 does not top the charts.
+func bayesianAverage(avg float64, votes int, mean float64, minVotes int) float64 {
+	v := float64(votes)
+	m := float64(minVotes)
+	return (v*avg + m*mean) / (v + m)
+}
+
+func (s *RatingService) CreateReview(ctx context.Context, review *domain.Review) error {
+	review.Status = domain.ReviewPending
+	err := s.repo.UpsertReview(ctx, review)
+	if err != nil {
+		return fmt.Errorf("create review: %w", err)
+	}
+	return nil
+}
+
+func (s *RatingService) GetMovieReviews(ctx context.Context, movieID uuid.UUID, limit int, offset int) ([]*domain.Review, error) {
+	reviews, err := s.repo.GetReviews(ctx, movieID, domain.ReviewApproved, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("get movie reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+func (s *RatingService) GetReviewsByStatus(ctx context.Context, status string, limit int, offset int) ([]*domain.Review, error) {
+	if !isReviewStatus(status) {
+		return nil, ErrInvalidReviewStatus
+	}
+	reviews, err := s.repo.GetReviews(ctx, uuid.Nil, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("get reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+func (s *RatingService) ModerateReview(ctx context.Context, reviewID uuid.UUID, status string) error {
+	if !isReviewStatus(status) {
+		return ErrInvalidReviewStatus
+	}
+	err := s.repo.UpdateReviewStatus(ctx, reviewID, status)
+	if err != nil {
+		return fmt.Errorf("moderate review: %w", err)
+	}
+	return nil
+}
+
+func isReviewStatus(status string) bool {
+	switch status {
+	case domain.ReviewPending, domain.ReviewApproved, domain.ReviewRejected:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRateMovie(t *testing.T) {
+	movieID := uuid.New()
+	userID := uuid.New()
+	type mockBehavior func(r *mock_repo.MockRatingRepo)
+
+	testCases := []struct {
+		name         string
+		score        int
+		minVotes     int
+		mockBehavior mockBehavior
+		want         *domain.RatingSummary
+		wantErr      bool
+	}{
+		{
+			name:     "Plain average",
+			score:    8,
+			minVotes: 0,
+			mockBehavior: func(r *mock_repo.MockRatingRepo) {
+				r.EXPECT().UpsertRating(gomock.Any(), &domain.Rating{UserID: userID, MovieID: movieID, Score: 8}).Return(nil)
+				r.EXPECT().GetRatingStats(gomock.Any(), movieID).Return(7.5, 2, nil)
+				r.EXPECT().UpdateMovieRating(gomock.Any(), &domain.RatingSummary{MovieID: movieID, Rating: 7.5, Votes: 2}).Return(nil)
+			},
+			want:    &domain.RatingSummary{MovieID: movieID, Rating: 7.5, Votes: 2},
+			wantErr: false,
+		},
+		{
+			name:     "Bayesian average",
+			score:    10,
+			minVotes: 3,
+			mockBehavior: func(r *mock_repo.MockRatingRepo) {
+				r.EXPECT().UpsertRating(gomock.Any(), gomock.Any()).Return(nil)
+				// Only the rated movie is recomputed.
+				r.EXPECT().GetGlobalRatingMean(gomock.Any()).Return(6.0, nil)
+				r.EXPECT().GetRatingStats(gomock.Any(), movieID).Return(10.0, 1, nil)
+				r.EXPECT().UpdateMovieRating(gomock.Any(), &domain.RatingSummary{MovieID: movieID, Rating: 7, Votes: 1}).Return(nil)
+			},
+			want:    &domain.RatingSummary{MovieID: movieID, Rating: 7, Votes: 1},
+			wantErr: false,
+		},
+		{
+			name:         "Score out of range",
+			score:        11,
+			mockBehavior: func(r *mock_repo.MockRatingRepo) {},
+			want:         nil,
+			wantErr:      true,
+		},
+		{
+			name:  "Repository error",
+			score: 5,
+			mockBehavior: func(r *mock_repo.MockRatingRepo) {
+				r.EXPECT().UpsertRating(gomock.Any(), gomock.Any()).Return(errors.New("repository error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockRatingRepo(ctrl)
+			tc.mockBehavior(repo)
+
+			service := NewRatingService(repo, directTx{}, noCache{}, tc.minVotes)
+			summary, err := service.RateMovie(context.Background(), &domain.Rating{
+				UserID:  userID,
+				MovieID: movieID,
+				Score:   tc.score,
+			})
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, summary)
+		})
+	}
+}
+
+func TestRecomputeRatings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	movieID, otherID := uuid.New(), uuid.New()
+	repo := mock_repo.NewMockRatingRepo(ctrl)
+	repo.EXPECT().GetGlobalRatingMean(gomock.Any()).Return(6.0, nil)
+	repo.EXPECT().GetRatedMovieIDs(gomock.Any()).Return([]uuid.UUID{movieID, otherID}, nil)
+	repo.EXPECT().GetRatingStats(gomock.Any(), movieID).Return(10.0, 1, nil)
+	repo.EXPECT().UpdateMovieRating(gomock.Any(), &domain.RatingSummary{MovieID: movieID, Rating: 7, Votes: 1}).Return(nil)
+	repo.EXPECT().GetRatingStats(gomock.Any(), otherID).Return(8.0, 1, nil)
+	repo.EXPECT().UpdateMovieRating(gomock.Any(), &domain.RatingSummary{MovieID: otherID, Rating: 6.5, Votes: 1}).Return(nil)
+
+	recomputed, err := NewRatingService(repo, directTx{}, noCache{}, 3).RecomputeRatings(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, recomputed)
+
+	// Without weighting a vote already stores the final rating.
+	recomputed, err = NewRatingService(repo, directTx{}, noCache{}, 0).RecomputeRatings(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, recomputed)
+}
+
+func TestModerateReview(t *testing.T) {
+	reviewID := uuid.New()
+	type mockBehavior func(r *mock_repo.MockRatingRepo)
+
+	testCases := []struct {
+		name         string
+		status       string
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name:   "Approve review",
+			status: domain.ReviewApproved,
+			mockBehavior: func(r *mock_repo.MockRatingRepo) {
+				r.EXPECT().UpdateReviewStatus(gomock.Any(), reviewID, domain.ReviewApproved).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:         "Invalid status",
+			status:       "DELETED",
+			mockBehavior: func(r *mock_repo.MockRatingRepo) {},
+			wantErr:      ErrInvalidReviewStatus,
+		},
+		{
+			name:   "Review not found",
+			status: domain.ReviewRejected,
+			mockBehavior: func(r *mock_repo.MockRatingRepo) {
+				r.EXPECT().UpdateReviewStatus(gomock.Any(), reviewID, domain.ReviewRejected).Return(domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockRatingRepo(ctrl)
+			tc.mockBehavior(repo)
+
+			service := NewRatingService(repo, directTx{}, noCache{}, 0)
+			err := service.ModerateReview(context.Background(), reviewID, tc.status)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateReviewIsPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockRatingRepo(ctrl)
+	repo.EXPECT().UpsertReview(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, review *domain.Review) error {
+			assert.Equal(t, domain.ReviewPending, review.Status)
+			return nil
+		})
+
+	service := NewRatingService(repo, directTx{}, noCache{}, 0)
+	err := service.CreateReview(context.Background(), &domain.Review{Text: "Great", Status: domain.ReviewApproved})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import "context"
+
+//go:generate mockgen -source=transaction.go -destination=mocks/transactionMock.go
+
+// Transactor runs units of work that must not be left half done. Repository
+// calls made with the context passed to fn take part in the transaction;
+// nested calls roll back only their own work when they fail. fn may be run
+// more than once if the transaction conflicts with a concurrent one.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=translation.go -destination=mocks/translationMock.go
+
+const LocalesCtx ContextKey = "locales"
+
+// WithLocales stores the locales preferred by the client, most preferred
+// first.
+func WithLocales(ctx context.Context, locales []string) context.Context {
+	return context.WithValue(ctx, LocalesCtx, locales)
+}
+
+// LocalesFromContext returns the locales stored by WithLocales.
+func LocalesFromContext(ctx context.Context) []string {
+	locales, _ := ctx.Value(LocalesCtx).([]string)
+	return locales
+}
+
+type TranslationRepo interface {
+	UpsertMovieTranslation(ctx context.Context, translation *domain.MovieTranslation) error
+	DeleteMovieTranslation(ctx context.Context, movieID uuid.UUID, locale string) error
+	ListMovieTranslations(ctx context.Context, movieID uuid.UUID) ([]*domain.MovieTranslation, error)
+	UpsertActorTranslation(ctx context.Context, translation *domain.ActorTranslation) error
+	DeleteActorTranslation(ctx context.Context, actorID uuid.UUID, locale string) error
+	ListActorTranslations(ctx context.Context, actorID uuid.UUID) ([]*domain.ActorTranslation, error)
+}
+
+type TranslationService struct {
+	repo  TranslationRepo
+	cache Cache
+}
+
+func NewTranslationService(repo TranslationRepo, cache Cache) *TranslationService {
+	return &TranslationService{repo: repo, cache: cache}
+}
+
+func (s *TranslationService) SetMovieTranslation(ctx context.Context, translation *domain.MovieTranslation) error {
+	if err := translation.Validate(); err != nil {
+		return err
+	}
+	err := s.repo.UpsertMovieTranslation(ctx, translation)
+	if err != nil {
+		return fmt.Errorf("set movie translation: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return nil
+}
+
+func (s *TranslationService) DeleteMovieTranslation(ctx context.Context, movieID uuid.UUID, locale string) error {
+	err := s.repo.DeleteMovieTranslation(ctx, movieID, locale)
+	if err != nil {
+		return fmt.Errorf("delete movie translation: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return nil
+}
+
+func (s *TranslationService) GetMovieTranslations(ctx context.Context, movieID uuid.UUID) ([]*domain.MovieTranslation, error) {
+	translations, err := s.repo.ListMovieTranslations(ctx, movieID)
+	if err != nil {
+		return nil, fmt.Errorf("get movie translations: %w", err)
+	}
+	return translations, nil
+}
+
+func (s *TranslationService) SetActorTranslation(ctx context.Context, translation *domain.ActorTranslation) error {
+	if err := translation.Validate(); err != nil {
+		return err
+	}
+	err := s.repo.UpsertActorTranslation(ctx, translation)
+	if err != nil {
+		return fmt.Errorf("set actor translation: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheActors)
+	return nil
+}
+
+func (s *TranslationService) DeleteActorTranslation(ctx context.Context, actorID uuid.UUID, locale string) error {
+	err := s.repo.DeleteActorTranslation(ctx, actorID, locale)
+	if err != nil {
+		return fmt.Errorf("delete actor translation: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheActors)
+	return nil
+}
+
+func (s *TranslationService) GetActorTranslations(ctx context.Context, actorID uuid.UUID) ([]*domain.ActorTranslation, error) {
+	translations, err := s.repo.ListActorTranslations(ctx, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("get actor translations: %w", err)
+	}
+	return translations, nil
+}
+
+// localizeMovies replaces the title and description of each movie with its
+// translation into the most preferred locale that has one. Movies without a
+// translation keep the original text.
+func localizeMovies(movies []*domain.Movie, translations []*domain.MovieTranslation, locales []string) {
+	best := make(map[uuid.UUID]*domain.MovieTranslation, len(translations))
+	for _, translation := range translations {
+		current, ok := best[translation.MovieID]
+		if !ok || slices.Index(locales, translation.Locale) < slices.Index(locales, current.Locale) {
+			best[translation.MovieID] = translation
+		}
+	}
+
+	for _, movie := range movies {
+		translation, ok := best[movie.ID]
+		if !ok {
+			continue
+		}
+		movie.Title = translation.Title
+		if translation.Description != "" {
+			movie.Description = translation.Description
+		}
+	}
+}
+
+// localizeActors is localizeMovies for actor names.
+func localizeActors(actors []*domain.Actor, translations []*domain.ActorTranslation, locales []string) {
+	best := make(map[uuid.UUID]*domain.ActorTranslation, len(translations))
+	for _, translation := range translations {
+		current, ok := best[translation.ActorID]
+		if !ok || slices.Index(locales, translation.Locale) < slices.Index(locales, current.Locale) {
+			best[translation.ActorID] = translation
+		}
+	}
+
+	for _, actor := range actors {
+		translation, ok := best[actor.ID]
+		if !ok {
+			continue
+		}
+		actor.Name = translation.Name
+		if translation.Surname != "" {
+			actor.Surname = translation.Surname
+		}
+	}
+}
+
+func movieIDs(movies []*domain.Movie) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(movies))
+	for _, movie := range movies {
+		ids = append(ids, movie.ID)
+	}
+	return ids
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetMovieTranslation(t *testing.T) {
+	movieID := uuid.New()
+	testCases := []struct {
+		name        string
+		translation *domain.MovieTranslation
+		callsRepo   bool
+		repoErr     error
+		wantErr     error
+	}{
+		{
+			name:        "Set translation successfully",
+			translation: &domain.MovieTranslation{MovieID: movieID, Locale: "en", Title: "The Irony of Fate"},
+			callsRepo:   true,
+		},
+		{
+			name:        "Invalid locale",
+			translation: &domain.MovieTranslation{MovieID: movieID, Locale: "eng", Title: "The Irony of Fate"},
+			wantErr:     domain.ErrInvalidTranslation,
+		},
+		{
+			name:        "Empty title",
+			translation: &domain.MovieTranslation{MovieID: movieID, Locale: "en"},
+			wantErr:     domain.ErrInvalidTranslation,
+		},
+		{
+			name:        "Unknown movie",
+			translation: &domain.MovieTranslation{MovieID: movieID, Locale: "en", Title: "The Irony of Fate"},
+			callsRepo:   true,
+			repoErr:     domain.ErrNotFound,
+			wantErr:     domain.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockTranslationRepo(ctrl)
+			if tc.callsRepo {
+				repo.EXPECT().UpsertMovieTranslation(gomock.Any(), tc.translation).Return(tc.repoErr)
+			}
+
+			service := NewTranslationService(repo, noCache{})
+			err := service.SetMovieTranslation(context.Background(), tc.translation)
+
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetMoviesLocalized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockMovieRepo(ctrl)
+	movieService := NewMovieService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+
+	movies := []*domain.Movie{
+		{ID: uuid.New(), Title: "Ирония судьбы", Description: "Оригинал"},
+		{ID: uuid.New(), Title: "Брат", Description: "Оригинал"},
+		{ID: uuid.New(), Title: "Сталкер", Description: "Оригинал"},
+	}
+	locales := []string{"en", "de"}
+	mockRepo.EXPECT().GetMovies(gomock.Any()).Return(movies, nil)
+	mockRepo.EXPECT().GetMovieTranslations(gomock.Any(), []uuid.UUID{movies[0].ID, movies[1].ID, movies[2].ID}, locales).
+		Return([]*domain.MovieTranslation{
+			{MovieID: movies[0].ID, Locale: "de", Title: "Ironie des Schicksals", Description: "Deutsch"},
+			{MovieID: movies[0].ID, Locale: "en", Title: "The Irony of Fate"},
+			{MovieID: movies[1].ID, Locale: "de", Title: "Der Bruder", Description: "Deutsch"},
+		}, nil)
+
+	result, err := movieService.GetMovies(WithLocales(context.Background(), locales))
+	assert.NoError(t, err)
+	assert.Equal(t, "The Irony of Fate", result[0].Title)
+	assert.Equal(t, "Оригинал", result[0].Description)
+	assert.Equal(t, "Der Bruder", result[1].Title)
+	assert.Equal(t, "Deutsch", result[1].Description)
+	assert.Equal(t, "Сталкер", result[2].Title)
+}
+
+func TestGetActorsLocalized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repo.NewMockActorsRepo(ctrl)
+	actorService := NewActorsService(mockRepo, directTx{}, noAudit{}, noVersions{}, noCache{}, noEvents{})
+
+	actor := &domain.Actor{ID: uuid.New(), Name: "Андрей", Surname: "Мягков"}
+	movie := &domain.Movie{ID: uuid.New(), Title: "Ирония судьбы"}
+	locales := []string{"en"}
+	mockRepo.EXPECT().GetActors(gomock.Any()).Return(map[*domain.Actor][]*domain.Movie{actor: {movie}}, nil)
+	mockRepo.EXPECT().GetActorTranslations(gomock.Any(), []uuid.UUID{actor.ID}, locales).
+		Return([]*domain.ActorTranslation{{ActorID: actor.ID, Locale: "en", Name: "Andrey", Surname: "Myagkov"}}, nil)
+	mockRepo.EXPECT().GetMovieTranslations(gomock.Any(), []uuid.UUID{movie.ID}, locales).
+		Return(nil, errors.New("repository error"))
+
+	_, err := actorService.GetActors(WithLocales(context.Background(), locales))
+	assert.EqualError(t, err, "get actors: repository error")
+	assert.Equal(t, "Andrey", actor.Name)
+	assert.Equal(t, "Myagkov", actor.Surname)
+}
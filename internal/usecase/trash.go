@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=trash.go -destination=mocks/trashMock.go
+
+type TrashRepo interface {
+	GetDeletedMovies(ctx context.Context) ([]*domain.TrashedMovie, error)
+	GetDeletedActors(ctx context.Context) ([]*domain.TrashedActor, error)
+	// RestoreMovie and RestoreActor return domain.ErrNotFound when the
+	// record is not in the trash.
+	RestoreMovie(ctx context.Context, movieID uuid.UUID) error
+	RestoreActor(ctx context.Context, actorID uuid.UUID) error
+	// GetMovieByID and GetActorByID return records that are not in the
+	// trash or domain.ErrNotFound.
+	GetMovieByID(ctx context.Context, movieID uuid.UUID) (*domain.Movie, error)
+	GetActorByID(ctx context.Context, actorID uuid.UUID) (*domain.Actor, error)
+	// PurgeDeleted removes movies and actors deleted before deletedBefore
+	// for good, together with everything linked to them, and returns how
+	// many it removed.
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+type TrashService struct {
+	repo   TrashRepo
+	tx     Transactor
+	audit  Auditor
+	cache  Cache
+	events Publisher
+	// retention is how long deleted records stay restorable.
+	retention time.Duration
+}
+
+func NewTrashService(repo TrashRepo, tx Transactor, audit Auditor, cache Cache, events Publisher,
+	retention time.Duration) *TrashService {
+	return &TrashService{repo: repo, tx: tx, audit: audit, cache: cache, events: events, retention: retention}
+}
+
+func (s *TrashService) GetTrash(ctx context.Context) (*domain.Trash, error) {
+	movies, err := s.repo.GetDeletedMovies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get trash: %w", err)
+	}
+	actors, err := s.repo.GetDeletedActors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get trash: %w", err)
+	}
+	return &domain.Trash{Movies: movies, Actors: actors}, nil
+}
+
+// RestoreMovie takes a movie out of the trash. It returns
+// domain.ErrNotFound if the movie is not in the trash.
+func (s *TrashService) RestoreMovie(ctx context.Context, movieID uuid.UUID) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.RestoreMovie(ctx, movieID); err != nil {
+			return err
+		}
+		restored, err := s.repo.GetMovieByID(ctx, movieID)
+		if err != nil {
+			return err
+		}
+		if err = s.audit.Record(ctx, domain.AuditRestore, domain.AuditEntityMovie, movieID, nil, restored); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, domain.EventMovieRestored, movieID, restored)
+	})
+	if err != nil {
+		return fmt.Errorf("restore movie: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return nil
+}
+
+// RestoreActor takes an actor out of the trash. It returns
+// domain.ErrNotFound if the actor is not in the trash.
+func (s *TrashService) RestoreActor(ctx context.Context, actorID uuid.UUID) error {
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.RestoreActor(ctx, actorID); err != nil {
+			return err
+		}
+		restored, err := s.repo.GetActorByID(ctx, actorID)
+		if err != nil {
+			return err
+		}
+		if err = s.audit.Record(ctx, domain.AuditRestore, domain.AuditEntityActor, actorID, nil, restored); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, domain.EventActorRestored, actorID, restored)
+	})
+	if err != nil {
+		return fmt.Errorf("restore actor: %w", err)
+	}
+	invalidate(ctx, s.cache, cacheMovies, cacheActors)
+	return nil
+}
+
+// Purge removes the records that have been in the trash longer than the
+// retention period.
+func (s *TrashService) Purge(ctx context.Context) (int, error) {
+	purged, err := s.repo.PurgeDeleted(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return 0, fmt.Errorf("purge trash: %w", err)
+	}
+	return purged, nil
+}
+
+// RunPurge purges the trash every interval until ctx is canceled. Failures
+// are logged and retried on the next tick.
+func (s *TrashService) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		purged, err := s.Purge(ctx)
+		if err != nil {
+			slog.Error("Failed to purge trash", "err", err)
+			continue
+		}
+		if purged > 0 {
+			slog.Info("Purged trash", "records", purged)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockTrashRepo(ctrl)
+	service := NewTrashService(repo, directTx{}, noAudit{}, noCache{}, noEvents{}, time.Hour)
+
+	movies := []*domain.TrashedMovie{{Movie: &domain.Movie{ID: uuid.New()}, DeletedAt: time.Now()}}
+	actors := []*domain.TrashedActor{{Actor: &domain.Actor{ID: uuid.New()}, DeletedAt: time.Now()}}
+	repo.EXPECT().GetDeletedMovies(gomock.Any()).Return(movies, nil)
+	repo.EXPECT().GetDeletedActors(gomock.Any()).Return(actors, nil)
+
+	trash, err := service.GetTrash(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &domain.Trash{Movies: movies, Actors: actors}, trash)
+
+	repo.EXPECT().GetDeletedMovies(gomock.Any()).Return(nil, errors.New("repository error"))
+	_, err = service.GetTrash(context.Background())
+	assert.Error(t, err)
+}
+
+func TestRestoreMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockTrashRepo(ctrl)
+	service := NewTrashService(repo, directTx{}, noAudit{}, noCache{}, noEvents{}, time.Hour)
+	movieID := uuid.New()
+
+	repo.EXPECT().RestoreMovie(gomock.Any(), movieID).Return(domain.ErrNotFound)
+	err := service.RestoreMovie(context.Background(), movieID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestRestorePublishesAndAudits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockTrashRepo(ctrl)
+	audit := mock_repo.NewMockAuditor(ctrl)
+	events := mock_repo.NewMockPublisher(ctrl)
+	service := NewTrashService(repo, directTx{}, audit, noCache{}, events, time.Hour)
+	movie := &domain.Movie{ID: uuid.New(), Title: "Heat"}
+	actor := &domain.Actor{ID: uuid.New(), Name: "Al", Surname: "Pacino"}
+
+	gomock.InOrder(
+		repo.EXPECT().RestoreMovie(gomock.Any(), movie.ID).Return(nil),
+		repo.EXPECT().GetMovieByID(gomock.Any(), movie.ID).Return(movie, nil),
+		audit.EXPECT().Record(gomock.Any(), domain.AuditRestore, domain.AuditEntityMovie, movie.ID, nil, movie).Return(nil),
+		events.EXPECT().Publish(gomock.Any(), domain.EventMovieRestored, movie.ID, movie).Return(nil),
+	)
+	require.NoError(t, service.RestoreMovie(context.Background(), movie.ID))
+
+	gomock.InOrder(
+		repo.EXPECT().RestoreActor(gomock.Any(), actor.ID).Return(nil),
+		repo.EXPECT().GetActorByID(gomock.Any(), actor.ID).Return(actor, nil),
+		audit.EXPECT().Record(gomock.Any(), domain.AuditRestore, domain.AuditEntityActor, actor.ID, nil, actor).Return(nil),
+		events.EXPECT().Publish(gomock.Any(), domain.EventActorRestored, actor.ID, actor).Return(nil),
+	)
+	require.NoError(t, service.RestoreActor(context.Background(), actor.ID))
+
+	// A failed publish fails the restore, so its transaction is rolled
+	// back.
+	repo.EXPECT().RestoreActor(gomock.Any(), actor.ID).Return(nil)
+	repo.EXPECT().GetActorByID(gomock.Any(), actor.ID).Return(actor, nil)
+	audit.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	events.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("outbox error"))
+	assert.Error(t, service.RestoreActor(context.Background(), actor.ID))
+}
+
+func TestPurgeTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockTrashRepo(ctrl)
+	service := NewTrashService(repo, directTx{}, noAudit{}, noCache{}, noEvents{}, 30*24*time.Hour)
+
+	repo.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, deletedBefore time.Time) (int, error) {
+			assert.WithinDuration(t, time.Now().Add(-30*24*time.Hour), deletedBefore, time.Minute)
+			return 3, nil
+		})
+
+	purged, err := service.Purge(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, purged)
+}
+
+func TestRunPurgeStopsWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockTrashRepo(ctrl)
+	service := NewTrashService(repo, directTx{}, noAudit{}, noCache{}, noEvents{}, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	purged := make(chan struct{})
+	repo.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, time.Time) (int, error) {
+			close(purged)
+			cancel()
+			return 0, nil
+		})
+
+	done := make(chan struct{})
+	go func() {
+		service.RunPurge(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	<-purged
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunPurge did not return after the context was canceled")
+	}
+}
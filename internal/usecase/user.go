@@ -22,6 +22,21 @@ type UserInfo struct {
 	Role   string    `json:"role"`
 }
 
+type ContextKey string
+
+const (
+	UserCtx ContextKey = "user_info"
+)
+
+// UserFromContext returns the user stored in ctx by the authentication middleware.
+func UserFromContext(ctx context.Context) (*UserInfo, bool) {
+	user, ok := ctx.Value(UserCtx).(*UserInfo)
+	if !ok || user == nil {
+		return nil, false
+	}
+	return user, true
+}
+
 type tokenClaims struct {
 	jwt.RegisteredClaims
 	UserClaims UserInfo `json:"userClaims"`
@@ -31,14 +46,56 @@ type tokenClaims struct {
 
 type UserRepo interface {
 	GetUser(ctx context.Context, login string, password string) (*domain.User, error)
+	CreateUser(ctx context.Context, user *domain.User) error
+}
+
+// TokenKeys provides the keys access tokens are signed and verified with.
+type TokenKeys interface {
+	SigningKey(ctx context.Context) (*domain.SigningKey, error)
+	VerificationKey(ctx context.Context, id string) (*domain.SigningKey, error)
 }
 
 type UserService struct {
-	repo UserRepo
+	repo  UserRepo
+	keys  TokenKeys
+	tx    Transactor
+	audit Auditor
+}
+
+func NewUserService(repo UserRepo, keys TokenKeys, tx Transactor, audit Auditor) *UserService {
+	return &UserService{repo: repo, keys: keys, tx: tx, audit: audit}
+}
+
+// CreateUser stores a user with a bcrypt hash of password.
+func (s *UserService) CreateUser(ctx context.Context, login string, password string, role string) (*domain.User, error) {
+	if role != domain.ADMIN && role != domain.USER {
+		return nil, fmt.Errorf("create user: unknown role %q", role)
+	}
+	user := &domain.User{Login: login, Role: role}
+	if err := user.Set(password); err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, domain.AuditCreate, domain.AuditEntityUser, user.ID, nil, auditedUser(user))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	return user, nil
 }
 
-func NewUserService(repo UserRepo) *UserService {
-	return &UserService{repo: repo}
+// auditedUser is the state of a user kept in the audit log. The password
+// hash is never logged.
+func auditedUser(user *domain.User) map[string]any {
+	return map[string]any{
+		"ID":        user.ID,
+		"Login":     user.Login,
+		"Role":      user.Role,
+		"CreatedAt": user.CreatedAt,
+	}
 }
 
 func (s *UserService) GetUser(ctx context.Context, login string, password string) (*domain.User, error) {
@@ -55,6 +112,11 @@ func (s *UserService) GenerateToken(ctx context.Context, login string, password
 		return "", fmt.Errorf("get user: %w", err)
 	}
 
+	key, err := s.keys.SigningKey(ctx)
+	if err != nil {
+		return "", fmt.Errorf("sign token: %w", err)
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &tokenClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenTTL)),
@@ -66,7 +128,11 @@ func (s *UserService) GenerateToken(ctx context.Context, login string, password
 		},
 	})
 
-	signedToken, err := token.SignedString([]byte(signingKey))
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+
+	signedToken, err := token.SignedString(key.Secret)
 	if err != nil {
 		return "", fmt.Errorf("sign token: %w", err)
 	}
@@ -80,7 +146,12 @@ func (s *UserService) ParseToken(accessToken string) (*UserInfo, error) {
 			return nil, errors.New("invalid signing method")
 		}
 
-		return []byte(signingKey), nil
+		kid, _ := token.Header["kid"].(string)
+		key, err := s.keys.VerificationKey(context.Background(), kid)
+		if err != nil {
+			return nil, err
+		}
+		return key.Secret, nil
 	})
 	if err != nil {
 		return nil, err
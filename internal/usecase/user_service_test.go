@@ -46,7 +46,7 @@ func TestGetUser(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			c := gomock.NewController(t)
 			mockUserRepo := mock_repo.NewMockUserRepo(c)
-			service := NewUserService(mockUserRepo)
+			service := NewUserService(mockUserRepo, nil, directTx{}, noAudit{})
 
 			mockUserRepo.EXPECT().GetUser(gomock.Any(), test.login, test.password).Return(test.mockUser, test.mockError)
 
@@ -61,3 +61,25 @@ func TestGetUser(t *testing.T) {
 	}
 }
 
+
+func TestCreateUser(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	mockUserRepo := mock_repo.NewMockUserRepo(c)
+	service := NewUserService(mockUserRepo, nil, directTx{}, noAudit{})
+
+	mockUserRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil)
+	user, err := service.CreateUser(context.Background(), "admin", "secret", domain.ADMIN)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", user.Login)
+	assert.Equal(t, domain.ADMIN, user.Role)
+	assert.NotEqual(t, []byte("secret"), user.Password)
+
+	_, err = service.CreateUser(context.Background(), "admin", "secret", "ROOT")
+	assert.Error(t, err)
+
+	mockUserRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(domain.ErrAlreadyExists)
+	_, err = service.CreateUser(context.Background(), "admin", "secret", domain.ADMIN)
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+}
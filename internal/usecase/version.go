@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=version.go -destination=mocks/versionMock.go
+
+type VersionRepo interface {
+	// AddVersion stores a version with the next number of its entity.
+	AddVersion(ctx context.Context, version *domain.EntityVersion) error
+	// GetVersions returns the versions of an entity, newest first.
+	GetVersions(ctx context.Context, entity string, entityID uuid.UUID) ([]*domain.EntityVersion, error)
+	// GetVersion returns a version or domain.ErrNotFound.
+	GetVersion(ctx context.Context, entity string, entityID uuid.UUID, number int) (*domain.EntityVersion, error)
+}
+
+// Versioner keeps the version history of entities. Services call Snapshot
+// in the transaction of an update.
+type Versioner interface {
+	// Snapshot stores after as the next version of an entity. before is
+	// stored first if the entity has no history yet, so the state it was
+	// created with can be restored.
+	Snapshot(ctx context.Context, entity string, entityID uuid.UUID, before any, after any) error
+	GetVersions(ctx context.Context, entity string, entityID uuid.UUID) ([]*domain.EntityVersion, error)
+	GetVersion(ctx context.Context, entity string, entityID uuid.UUID, number int) (*domain.EntityVersion, error)
+	// DiffVersions returns the fields that differ between two versions.
+	DiffVersions(ctx context.Context, entity string, entityID uuid.UUID, from int, to int) (map[string]domain.AuditChange, error)
+}
+
+type VersionService struct {
+	repo VersionRepo
+}
+
+func NewVersionService(repo VersionRepo) *VersionService {
+	return &VersionService{repo: repo}
+}
+
+// Snapshot stores a version attributed to the user of ctx. Updates that
+// change nothing are not versioned.
+func (s *VersionService) Snapshot(ctx context.Context, entity string, entityID uuid.UUID, before any, after any) error {
+	changes, err := diffStates(before, after)
+	if err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	history, err := s.repo.GetVersions(ctx, entity, entityID)
+	if err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+	if len(history) == 0 {
+		if err = s.add(ctx, entity, entityID, uuid.Nil, before); err != nil {
+			return fmt.Errorf("snapshot: %w", err)
+		}
+	}
+
+	var userID uuid.UUID
+	if user, ok := UserFromContext(ctx); ok {
+		userID = user.UserID
+	}
+	if err = s.add(ctx, entity, entityID, userID, after); err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+	return nil
+}
+
+func (s *VersionService) add(ctx context.Context, entity string, entityID uuid.UUID, userID uuid.UUID, state any) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.repo.AddVersion(ctx, &domain.EntityVersion{
+		Entity:   entity,
+		EntityID: entityID,
+		State:    data,
+		UserID:   userID,
+	})
+}
+
+func (s *VersionService) GetVersions(ctx context.Context, entity string, entityID uuid.UUID) ([]*domain.EntityVersion, error) {
+	versions, err := s.repo.GetVersions(ctx, entity, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("get versions: %w", err)
+	}
+	return versions, nil
+}
+
+func (s *VersionService) GetVersion(ctx context.Context, entity string, entityID uuid.UUID, number int) (*domain.EntityVersion, error) {
+	version, err := s.repo.GetVersion(ctx, entity, entityID, number)
+	if err != nil {
+		return nil, fmt.Errorf("get version: %w", err)
+	}
+	return version, nil
+}
+
+func (s *VersionService) DiffVersions(ctx context.Context, entity string, entityID uuid.UUID, from int, to int) (map[string]domain.AuditChange, error) {
+	fromVersion, err := s.repo.GetVersion(ctx, entity, entityID, from)
+	if err != nil {
+		return nil, fmt.Errorf("diff versions: %w", err)
+	}
+	toVersion, err := s.repo.GetVersion(ctx, entity, entityID, to)
+	if err != nil {
+		return nil, fmt.Errorf("diff versions: %w", err)
+	}
+	changes, err := diffStates(fromVersion.State, toVersion.State)
+	if err != nil {
+		return nil, fmt.Errorf("diff versions: %w", err)
+	}
+	return changes, nil
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// noVersions keeps no version history.
+type noVersions struct{}
+
+func (noVersions) Snapshot(context.Context, string, uuid.UUID, any, any) error {
+	return nil
+}
+
+func (noVersions) GetVersions(context.Context, string, uuid.UUID) ([]*domain.EntityVersion, error) {
+	return nil, nil
+}
+
+func (noVersions) GetVersion(context.Context, string, uuid.UUID, int) (*domain.EntityVersion, error) {
+	return nil, domain.ErrNotFound
+}
+
+func (noVersions) DiffVersions(context.Context, string, uuid.UUID, int, int) (map[string]domain.AuditChange, error) {
+	return nil, domain.ErrNotFound
+}
+
+func TestSnapshotStoresInitialState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockVersionRepo(ctrl)
+	service := NewVersionService(repo)
+	userID := uuid.New()
+	actorID := uuid.New()
+	ctx := context.WithValue(context.Background(), UserCtx, &UserInfo{UserID: userID})
+	before := &domain.Actor{ID: actorID, Name: "Al"}
+	after := &domain.Actor{ID: actorID, Name: "Alfredo"}
+
+	var stored []*domain.EntityVersion
+	repo.EXPECT().GetVersions(gomock.Any(), domain.AuditEntityActor, actorID).Return(nil, nil)
+	repo.EXPECT().AddVersion(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, version *domain.EntityVersion) error {
+			stored = append(stored, version)
+			return nil
+		}).Times(2)
+
+	require.NoError(t, service.Snapshot(ctx, domain.AuditEntityActor, actorID, before, after))
+	require.Len(t, stored, 2)
+	assert.Equal(t, uuid.Nil, stored[0].UserID)
+	assert.JSONEq(t, `{"ID":"`+actorID.String()+`","Name":"Al","Surname":"","Sex":"","Birthdate":"0001-01-01T00:00:00Z","Version":0}`,
+		string(stored[0].State))
+	assert.Equal(t, userID, stored[1].UserID)
+
+	// Later snapshots only add the new state, and no-op updates nothing.
+	repo.EXPECT().GetVersions(gomock.Any(), domain.AuditEntityActor, actorID).Return(stored, nil)
+	repo.EXPECT().AddVersion(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, service.Snapshot(ctx, domain.AuditEntityActor, actorID, after, before))
+	require.NoError(t, service.Snapshot(ctx, domain.AuditEntityActor, actorID, after, after))
+}
+
+func TestDiffVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockVersionRepo(ctrl)
+	service := NewVersionService(repo)
+	movieID := uuid.New()
+
+	repo.EXPECT().GetVersion(gomock.Any(), domain.AuditEntityMovie, movieID, 1).
+		Return(&domain.EntityVersion{Number: 1, State: json.RawMessage(`{"Title":"Heat","Rating":7}`)}, nil)
+	repo.EXPECT().GetVersion(gomock.Any(), domain.AuditEntityMovie, movieID, 3).
+		Return(&domain.EntityVersion{Number: 3, State: json.RawMessage(`{"Title":"Ronin","Rating":7}`)}, nil)
+
+	changes, err := service.DiffVersions(context.Background(), domain.AuditEntityMovie, movieID, 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.AuditChange{"Title": {Before: "Heat", After: "Ronin"}}, changes)
+
+	repo.EXPECT().GetVersion(gomock.Any(), domain.AuditEntityMovie, movieID, 1).Return(nil, domain.ErrNotFound)
+	_, err = service.DiffVersions(context.Background(), domain.AuditEntityMovie, movieID, 1, 3)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestRestoreMovieVersionKeepsRating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockMovieRepo(ctrl)
+	versions := mock_repo.NewMockVersioner(ctrl)
+	service := NewMovieService(repo, directTx{}, noAudit{}, versions, noCache{}, noEvents{})
+	movieID := uuid.New()
+	current := &domain.Movie{ID: movieID, Title: "Ronin", Rating: 8, Genres: []*domain.Genre{}}
+	genreID := uuid.New()
+
+	versions.EXPECT().GetVersion(gomock.Any(), domain.AuditEntityMovie, movieID, 1).Return(&domain.EntityVersion{
+		Number: 1,
+		State:  json.RawMessage(`{"Title":"Heat","Rating":6,"Genres":[{"ID":"` + genreID.String() + `","Name":"Crime"}]}`),
+	}, nil)
+	repo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(current, nil)
+	repo.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movie *domain.Movie) error {
+		assert.Equal(t, movieID, movie.ID)
+		assert.Equal(t, "Heat", movie.Title)
+		assert.Equal(t, float32(8), movie.Rating)
+		return nil
+	})
+	repo.EXPECT().SetMovieGenres(gomock.Any(), movieID, []uuid.UUID{genreID}).Return(nil)
+	repo.EXPECT().GetMovieByID(gomock.Any(), movieID).Return(&domain.Movie{ID: movieID, Title: "Heat"}, nil)
+	versions.EXPECT().Snapshot(gomock.Any(), domain.AuditEntityMovie, movieID, current, gomock.Any()).Return(nil)
+
+	require.NoError(t, service.RestoreMovieVersion(context.Background(), movieID, 1))
+
+	versions.EXPECT().GetVersion(gomock.Any(), domain.AuditEntityMovie, movieID, 7).Return(nil, domain.ErrNotFound)
+	err := service.RestoreMovieVersion(context.Background(), movieID, 7)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=watchlist.go -destination=mocks/watchlistMock.go
+
+type WatchlistRepo interface {
+	AddToWatchlist(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error
+	RemoveFromWatchlist(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error
+	GetWatchlist(ctx context.Context, userID uuid.UUID) ([]*domain.WatchlistEntry, error)
+	AddFavoriteActor(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error
+	RemoveFavoriteActor(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error
+	GetFavoriteActors(ctx context.Context, userID uuid.UUID) ([]*domain.FavoriteActor, error)
+}
+
+type WatchlistService struct {
+	repo WatchlistRepo
+}
+
+func NewWatchlistService(repo WatchlistRepo) *WatchlistService {
+	return &WatchlistService{repo: repo}
+}
+
+func (s *WatchlistService) AddToWatchlist(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error {
+	err := s.repo.AddToWatchlist(ctx, userID, movieID)
+	if err != nil {
+		return fmt.Errorf("add to watchlist: %w", err)
+	}
+	return nil
+}
+
+func (s *WatchlistService) RemoveFromWatchlist(ctx context.Context, userID uuid.UUID, movieID uuid.UUID) error {
+	err := s.repo.RemoveFromWatchlist(ctx, userID, movieID)
+	if err != nil {
+		return fmt.Errorf("remove from watchlist: %w", err)
+	}
+	return nil
+}
+
+func (s *WatchlistService) GetWatchlist(ctx context.Context, userID uuid.UUID) ([]*domain.WatchlistEntry, error) {
+	entries, err := s.repo.GetWatchlist(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get watchlist: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *WatchlistService) AddFavoriteActor(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error {
+	err := s.repo.AddFavoriteActor(ctx, userID, actorID)
+	if err != nil {
+		return fmt.Errorf("add favorite actor: %w", err)
+	}
+	return nil
+}
+
+func (s *WatchlistService) RemoveFavoriteActor(ctx context.Context, userID uuid.UUID, actorID uuid.UUID) error {
+	err := s.repo.RemoveFavoriteActor(ctx, userID, actorID)
+	if err != nil {
+		return fmt.Errorf("remove favorite actor: %w", err)
+	}
+	return nil
+}
+
+func (s *WatchlistService) GetFavoriteActors(ctx context.Context, userID uuid.UUID) ([]*domain.FavoriteActor, error) {
+	favorites, err := s.repo.GetFavoriteActors(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get favorite actors: %w", err)
+	}
+	return favorites, nil
+}
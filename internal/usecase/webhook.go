@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=webhook.go -destination=mocks/webhookMock.go
+
+// Headers of webhook deliveries. The signature is "sha256=" followed by
+// the hex HMAC-SHA256 of the timestamp, a dot and the body, keyed with the
+// secret of the subscription.
+const (
+	WebhookEventIDHeader     = "X-Event-ID"
+	WebhookEventTypeHeader   = "X-Event-Type"
+	WebhookDeliveryHeader    = "X-Webhook-Delivery"
+	WebhookTimestampHeader   = "X-Webhook-Timestamp"
+	WebhookSignatureHeader   = "X-Webhook-Signature"
+	webhookSecretLength      = 32
+	webhookDeliveryBatchSize = 100
+	// webhookDispatchWorkers is the number of subscriptions a batch is sent
+	// to at once.
+	webhookDispatchWorkers = 8
+)
+
+type WebhookRepo interface {
+	CreateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+	GetWebhookSubscription(ctx context.Context, subscriptionID uuid.UUID) (*domain.WebhookSubscription, error)
+	GetWebhookSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error)
+	// DeleteWebhookSubscription removes a subscription with its deliveries.
+	DeleteWebhookSubscription(ctx context.Context, subscriptionID uuid.UUID) error
+	// CreateWebhookDelivery stores a new delivery. It returns
+	// domain.ErrAlreadyExists if the subscription has a delivery of the
+	// event.
+	CreateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetWebhookDelivery(ctx context.Context, deliveryID uuid.UUID) (*domain.WebhookDelivery, error)
+	// GetWebhookDeliveries returns the deliveries of a subscription, newest
+	// first.
+	GetWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int, offset int) ([]*domain.WebhookDelivery, error)
+	// GetDueWebhookDeliveries returns up to limit pending deliveries due at
+	// now, oldest first.
+	GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+	// UpdateWebhookDelivery stores the state of a delivery: its Status,
+	// Attempts, NextAttemptAt, ResponseStatus, LastError and DeliveredAt.
+	UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
+
+// WebhookSender posts deliveries to subscribers.
+type WebhookSender interface {
+	// Send posts body with the headers to url and returns the status code
+	// of the response.
+	Send(ctx context.Context, url string, header map[string]string, body []byte) (int, error)
+}
+
+// WebhookService manages webhook subscriptions and delivers catalogue
+// events to them. It is a Sink of the EventService, which turns every
+// event into a delivery per subscription; deliveries are then sent on
+// their own, so a slow subscriber does not hold up the others.
+type WebhookService struct {
+	repo   WebhookRepo
+	sender WebhookSender
+	// maxAttempts is the number of attempts before a delivery fails.
+	maxAttempts int
+}
+
+func NewWebhookService(repo WebhookRepo, sender WebhookSender, maxAttempts int) *WebhookService {
+	return &WebhookService{repo: repo, sender: sender, maxAttempts: maxAttempts}
+}
+
+// CreateSubscription stores a subscription and sets its ID. A random
+// secret is generated unless the subscription has one.
+func (s *WebhookService) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	if subscription.Secret == "" {
+		secret := make([]byte, webhookSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return fmt.Errorf("create webhook subscription: %w", err)
+		}
+		subscription.Secret = hex.EncodeToString(secret)
+	}
+	if err := subscription.Validate(); err != nil {
+		return fmt.Errorf("create webhook subscription: %w", err)
+	}
+	subscription.ID = uuid.New()
+	subscription.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	if err := s.repo.CreateWebhookSubscription(ctx, subscription); err != nil {
+		return fmt.Errorf("create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookService) GetSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	subscriptions, err := s.repo.GetWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// GetSubscription returns a subscription or domain.ErrNotFound.
+func (s *WebhookService) GetSubscription(ctx context.Context, subscriptionID uuid.UUID) (*domain.WebhookSubscription, error) {
+	subscription, err := s.repo.GetWebhookSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	if err := s.repo.DeleteWebhookSubscription(ctx, subscriptionID); err != nil {
+		return fmt.Errorf("delete webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// GetDeliveries returns the delivery log of a subscription, newest first,
+// or domain.ErrNotFound if there is no such subscription.
+func (s *WebhookService) GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int, offset int) ([]*domain.WebhookDelivery, error) {
+	if _, err := s.repo.GetWebhookSubscription(ctx, subscriptionID); err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w", err)
+	}
+	deliveries, err := s.repo.GetWebhookDeliveries(ctx, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Redeliver sends a delivery again with a fresh set of attempts, whatever
+// its state. It returns the delivery, which is sent by the next dispatch.
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	delivery, err := s.repo.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("redeliver webhook: %w", err)
+	}
+	delivery.Status = domain.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now().UTC().Truncate(time.Microsecond)
+	if err = s.repo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("redeliver webhook: %w", err)
+	}
+	return delivery, nil
+}
+
+// Deliver creates a delivery of event for every subscription to its type.
+// Events delivered again do not create deliveries twice.
+func (s *WebhookService) Deliver(ctx context.Context, event *domain.Event) error {
+	subscriptions, err := s.repo.GetWebhookSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("deliver event to webhooks: %w", err)
+	}
+	body, err := json.Marshal(event.Message())
+	if err != nil {
+		return fmt.Errorf("deliver event to webhooks: %w", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	for _, subscription := range subscriptions {
+		if !slices.Contains(subscription.EventTypes, event.Type) {
+			continue
+		}
+		err = s.repo.CreateWebhookDelivery(ctx, &domain.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Body:           body,
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+		if err != nil && !errors.Is(err, domain.ErrAlreadyExists) {
+			return fmt.Errorf("deliver event to webhooks: %w", err)
+		}
+	}
+	return nil
+}
+
+// Dispatch sends a batch of due deliveries and returns how many it
+// attempted. Failed deliveries are retried with a growing delay until they
+// run out of attempts. The deliveries of a subscription are sent in order,
+// while up to webhookDispatchWorkers subscriptions are sent to at once, so
+// a slow subscriber holds up only its own deliveries.
+func (s *WebhookService) Dispatch(ctx context.Context) (int, error) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	deliveries, err := s.repo.GetDueWebhookDeliveries(ctx, now, webhookDeliveryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("dispatch webhooks: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+	subscriptions, err := s.repo.GetWebhookSubscriptions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("dispatch webhooks: %w", err)
+	}
+
+	bySubscription := make(map[uuid.UUID][]*domain.WebhookDelivery)
+	for _, delivery := range deliveries {
+		bySubscription[delivery.SubscriptionID] = append(bySubscription[delivery.SubscriptionID], delivery)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errs    []error
+		workers = make(chan struct{}, webhookDispatchWorkers)
+	)
+	// Deliveries of subscriptions that are not listed were deleted with
+	// their subscription.
+	for _, subscription := range subscriptions {
+		pending := bySubscription[subscription.ID]
+		if len(pending) == 0 {
+			continue
+		}
+		wg.Add(1)
+		workers <- struct{}{}
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			for _, delivery := range pending {
+				s.send(ctx, subscription, delivery, now)
+				if err := s.repo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err = errors.Join(errs...); err != nil {
+		return 0, fmt.Errorf("dispatch webhooks: %w", err)
+	}
+	return len(deliveries), nil
+}
+
+// send makes an attempt to deliver and updates the state of delivery.
+func (s *WebhookService) send(ctx context.Context, subscription *domain.WebhookSubscription,
+	delivery *domain.WebhookDelivery, now time.Time) {
+	timestamp := now.Unix()
+	header := map[string]string{
+		"Content-Type":         "application/json",
+		WebhookEventIDHeader:   delivery.EventID.String(),
+		WebhookEventTypeHeader: delivery.EventType,
+		WebhookDeliveryHeader:  delivery.ID.String(),
+		WebhookTimestampHeader: strconv.FormatInt(timestamp, 10),
+		WebhookSignatureHeader: SignWebhook(subscription.Secret, timestamp, delivery.Body),
+	}
+
+	delivery.Attempts++
+	status, err := s.sender.Send(ctx, subscription.URL, header, delivery.Body)
+	delivery.ResponseStatus = status
+	if err == nil && (status < 200 || status > 299) {
+		err = fmt.Errorf("status %d", status)
+	}
+	if err == nil {
+		delivery.Status = domain.DeliveryDelivered
+		delivery.DeliveredAt = now
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= s.maxAttempts {
+		delivery.Status = domain.DeliveryFailed
+	} else {
+		delivery.NextAttemptAt = now.Add(retryDelay(delivery.Attempts))
+	}
+	slog.Warn("Failed to deliver webhook", "delivery", delivery.ID, "subscription", subscription.ID,
+		"attempts", delivery.Attempts, "err", err)
+}
+
+// SignWebhook returns the signature header value of a delivery.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RunDispatch sends due deliveries every interval until ctx is canceled.
+// Failures are logged and retried on the next tick.
+func (s *WebhookService) RunDispatch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// A full batch means more deliveries may be due.
+		for {
+			attempted, err := s.Dispatch(ctx)
+			if err != nil {
+				slog.Error("Failed to dispatch webhooks", "err", err)
+				break
+			}
+			if attempted < webhookDeliveryBatchSize {
+				break
+			}
+		}
+	}
+}
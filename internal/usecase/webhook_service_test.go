@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"cinema_service/internal/domain"
+	mock_repo "cinema_service/internal/usecase/mocks"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhookSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockWebhookRepo(ctrl)
+	service := NewWebhookService(repo, nil, 3)
+
+	repo.EXPECT().CreateWebhookSubscription(gomock.Any(), gomock.Any()).Return(nil)
+	subscription := &domain.WebhookSubscription{URL: "https://partner.example.com", EventTypes: []string{domain.EventMovieCreated}}
+	require.NoError(t, service.CreateSubscription(context.Background(), subscription))
+	assert.NotEqual(t, uuid.Nil, subscription.ID)
+	assert.Len(t, subscription.Secret, 2*webhookSecretLength)
+
+	invalid := &domain.WebhookSubscription{URL: "ftp://partner.example.com", EventTypes: []string{domain.EventMovieCreated}}
+	assert.ErrorIs(t, service.CreateSubscription(context.Background(), invalid), domain.ErrInvalidSubscription)
+	invalid = &domain.WebhookSubscription{URL: "https://partner.example.com", EventTypes: []string{"movie.watched"}}
+	assert.ErrorIs(t, service.CreateSubscription(context.Background(), invalid), domain.ErrInvalidSubscription)
+}
+
+func TestDeliverEventToWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockWebhookRepo(ctrl)
+	service := NewWebhookService(repo, nil, 3)
+	movies := &domain.WebhookSubscription{ID: uuid.New(), EventTypes: []string{domain.EventMovieCreated}}
+	actors := &domain.WebhookSubscription{ID: uuid.New(), EventTypes: []string{domain.EventActorCreated}}
+	event := &domain.Event{ID: uuid.New(), Sequence: 3, Type: domain.EventMovieCreated, EntityID: uuid.New(),
+		Payload: []byte(`{"Title":"Heat"}`)}
+
+	repo.EXPECT().GetWebhookSubscriptions(gomock.Any()).Return([]*domain.WebhookSubscription{movies, actors}, nil).Times(2)
+	repo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, delivery *domain.WebhookDelivery) error {
+		assert.Equal(t, movies.ID, delivery.SubscriptionID)
+		assert.Equal(t, event.ID, delivery.EventID)
+		assert.Equal(t, domain.DeliveryPending, delivery.Status)
+		assert.JSONEq(t, `{"id":"`+event.ID.String()+`","sequence":3,"type":"movie.created",`+
+			`"entity_id":"`+event.EntityID.String()+`","payload":{"Title":"Heat"},"created_at":"0001-01-01T00:00:00Z"}`,
+			string(delivery.Body))
+		return nil
+	})
+	require.NoError(t, service.Deliver(context.Background(), event))
+
+	// An event delivered again by the outbox is not delivered twice.
+	repo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Return(domain.ErrAlreadyExists)
+	require.NoError(t, service.Deliver(context.Background(), event))
+}
+
+func TestDispatchWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockWebhookRepo(ctrl)
+	sender := mock_repo.NewMockWebhookSender(ctrl)
+	service := NewWebhookService(repo, sender, 3)
+	subscription := &domain.WebhookSubscription{ID: uuid.New(), URL: "https://partner.example.com", Secret: "secret"}
+	newDelivery := func(attempts int) *domain.WebhookDelivery {
+		return &domain.WebhookDelivery{ID: uuid.New(), SubscriptionID: subscription.ID, EventID: uuid.New(),
+			EventType: domain.EventMovieCreated, Body: []byte(`{}`), Status: domain.DeliveryPending, Attempts: attempts}
+	}
+	delivered, retried, failed := newDelivery(0), newDelivery(1), newDelivery(2)
+
+	repo.EXPECT().GetDueWebhookDeliveries(gomock.Any(), gomock.Any(), webhookDeliveryBatchSize).
+		Return([]*domain.WebhookDelivery{delivered, retried, failed}, nil)
+	repo.EXPECT().GetWebhookSubscriptions(gomock.Any()).Return([]*domain.WebhookSubscription{subscription}, nil)
+	sender.EXPECT().Send(gomock.Any(), subscription.URL, gomock.Any(), delivered.Body).DoAndReturn(
+		func(_ context.Context, _ string, header map[string]string, body []byte) (int, error) {
+			assert.Equal(t, delivered.ID.String(), header[WebhookDeliveryHeader])
+			assert.Equal(t, delivered.EventID.String(), header[WebhookEventIDHeader])
+			timestamp, err := strconv.ParseInt(header[WebhookTimestampHeader], 10, 64)
+			assert.NoError(t, err)
+			assert.Equal(t, SignWebhook("secret", timestamp, body), header[WebhookSignatureHeader])
+			return 204, nil
+		})
+	sender.EXPECT().Send(gomock.Any(), subscription.URL, gomock.Any(), gomock.Any()).Return(503, nil)
+	sender.EXPECT().Send(gomock.Any(), subscription.URL, gomock.Any(), gomock.Any()).Return(0, errors.New("connection refused"))
+	repo.EXPECT().UpdateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+	attempted, err := service.Dispatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempted)
+
+	assert.Equal(t, domain.DeliveryDelivered, delivered.Status)
+	assert.Equal(t, 204, delivered.ResponseStatus)
+	assert.False(t, delivered.DeliveredAt.IsZero())
+
+	assert.Equal(t, domain.DeliveryPending, retried.Status)
+	assert.Equal(t, 2, retried.Attempts)
+	assert.Equal(t, 503, retried.ResponseStatus)
+	assert.Equal(t, "status 503", retried.LastError)
+	assert.WithinDuration(t, time.Now().Add(2*time.Second), retried.NextAttemptAt, time.Second)
+
+	// The last attempt fails the delivery.
+	assert.Equal(t, domain.DeliveryFailed, failed.Status)
+	assert.Equal(t, 3, failed.Attempts)
+	assert.Contains(t, failed.LastError, "connection refused")
+}
+
+func TestDispatchWebhooksConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockWebhookRepo(ctrl)
+	sender := mock_repo.NewMockWebhookSender(ctrl)
+	service := NewWebhookService(repo, sender, 3)
+	slow := &domain.WebhookSubscription{ID: uuid.New(), URL: "https://slow.example.com"}
+	fast := &domain.WebhookSubscription{ID: uuid.New(), URL: "https://fast.example.com"}
+	deleted := uuid.New()
+	newDelivery := func(subscriptionID uuid.UUID) *domain.WebhookDelivery {
+		return &domain.WebhookDelivery{ID: uuid.New(), SubscriptionID: subscriptionID, EventID: uuid.New(),
+			Status: domain.DeliveryPending}
+	}
+	deliveries := []*domain.WebhookDelivery{newDelivery(slow.ID), newDelivery(deleted), newDelivery(fast.ID)}
+
+	repo.EXPECT().GetDueWebhookDeliveries(gomock.Any(), gomock.Any(), webhookDeliveryBatchSize).Return(deliveries, nil)
+	repo.EXPECT().GetWebhookSubscriptions(gomock.Any()).Return([]*domain.WebhookSubscription{slow, fast}, nil)
+	// The slow subscriber answers only after the fast one was sent to.
+	fastSent := make(chan struct{})
+	sender.EXPECT().Send(gomock.Any(), slow.URL, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, string, map[string]string, []byte) (int, error) {
+			select {
+			case <-fastSent:
+			case <-time.After(time.Second):
+				t.Error("the fast subscriber waited for the slow one")
+			}
+			return 204, nil
+		})
+	sender.EXPECT().Send(gomock.Any(), fast.URL, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, string, map[string]string, []byte) (int, error) {
+			close(fastSent)
+			return 204, nil
+		})
+	repo.EXPECT().UpdateWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	attempted, err := service.Dispatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempted)
+	assert.Equal(t, domain.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, domain.DeliveryPending, deliveries[1].Status)
+	assert.Equal(t, domain.DeliveryDelivered, deliveries[2].Status)
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockWebhookRepo(ctrl)
+	service := NewWebhookService(repo, nil, 3)
+	delivery := &domain.WebhookDelivery{ID: uuid.New(), Status: domain.DeliveryFailed, Attempts: 3,
+		NextAttemptAt: time.Now().Add(-time.Hour)}
+
+	repo.EXPECT().GetWebhookDelivery(gomock.Any(), delivery.ID).Return(delivery, nil)
+	repo.EXPECT().UpdateWebhookDelivery(gomock.Any(), delivery).Return(nil)
+	redelivered, err := service.Redeliver(context.Background(), delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeliveryPending, redelivered.Status)
+	assert.Zero(t, redelivered.Attempts)
+	assert.WithinDuration(t, time.Now(), redelivered.NextAttemptAt, time.Second)
+
+	repo.EXPECT().GetWebhookDelivery(gomock.Any(), gomock.Any()).Return(nil, domain.ErrNotFound)
+	_, err = service.Redeliver(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestSignWebhook(t *testing.T) {
+	// echo -n '1714644000.{"type":"movie.created"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=a42324b570458dc87a19261a4cd52086146ee9bc1e77209f42a3650a4f553f99",
+		SignWebhook("secret", 1714644000, []byte(`{"type":"movie.created"}`)))
+}